@@ -0,0 +1,265 @@
+// Command extract-eval scores the resume extractors against a labelled corpus: a directory of
+// resumes (.txt, .md, .pdf, .docx), each with gold labels in a JSON file of the same name.
+// It prints precision, recall and F1 per field and per skill category for every method, and
+// can save the run to diff it against a later one.
+//
+//	go run ./cmd/extract-eval -dir testdata/resumes -out before.json
+//	go run ./cmd/extract-eval -dir testdata/resumes -methods ner -baseline before.json
+//	go run ./cmd/extract-eval -diff before.json after.json
+//
+// testdata/resumes holds a small corpus of made-up resumes to start from; add real, anonymised
+// ones beside them. The dictionary extractor reads the skill catalog from the database; the
+// huggingface and ensemble methods need HUGGINGFACE_API_KEY.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"stafind-backend/internal/database"
+	"stafind-backend/internal/extracteval"
+	"stafind-backend/internal/huggingface"
+	"stafind-backend/internal/logger"
+	"stafind-backend/internal/repositories"
+	"stafind-backend/internal/services"
+
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	// Initialize structured logging
+	if err := logger.Init(nil); err != nil {
+		panic("Failed to initialize logger: " + err.Error())
+	}
+	log := logger.Get()
+
+	// Load environment variables
+	// Try .env first (standard), then fall back to config.env (legacy)
+	if err := godotenv.Load(); err != nil {
+		if err := godotenv.Load("config.env"); err != nil {
+			log.Info("No .env or config.env file found, using environment variables")
+		}
+	}
+
+	dir := flag.String("dir", "", "Directory of resumes and their JSON labels")
+	methodList := flag.String("methods", "", "Comma-separated methods: ner, huggingface, ensemble (default: ner, plus the others when Hugging Face is configured)")
+	out := flag.String("out", "", "Save the run as JSON to this file")
+	baseline := flag.String("baseline", "", "Diff the run against a run saved with -out")
+	diffOnly := flag.Bool("diff", false, "Diff two saved runs given as arguments instead of running")
+	verbose := flag.Bool("v", false, "Print the missed and extra skills of every resume")
+	flag.Parse()
+
+	if *diffOnly {
+		if flag.NArg() != 2 {
+			log.Fatal("Usage: extract-eval -diff before.json after.json")
+		}
+		before, err := extracteval.LoadRun(flag.Arg(0))
+		if err != nil {
+			log.Fatal("Failed to load run", "error", err)
+		}
+		after, err := extracteval.LoadRun(flag.Arg(1))
+		if err != nil {
+			log.Fatal("Failed to load run", "error", err)
+		}
+		printDiff(extracteval.Diff(before, after))
+		return
+	}
+
+	if *dir == "" {
+		log.Fatal("No corpus; use -dir")
+	}
+	documents, err := extracteval.LoadCorpus(*dir)
+	if err != nil {
+		log.Fatal("Failed to load corpus", "error", err)
+	}
+	if len(documents) == 0 {
+		log.Fatal("No labelled resumes in corpus", "directory", *dir)
+	}
+
+	// Initialize database; the dictionary extractor reads the skill catalog
+	db, err := database.NewConnection()
+	if err != nil {
+		log.Fatal("Failed to connect to database", "error", err)
+	}
+	defer db.Close()
+
+	skillRepo, err := repositories.NewSkillRepository(db.DB)
+	if err != nil {
+		log.Fatal("Failed to initialize skill repository", "error", err)
+	}
+	categoryRepo, err := repositories.NewCategoryRepository(db.DB)
+	if err != nil {
+		log.Fatal("Failed to initialize category repository", "error", err)
+	}
+
+	nerService := services.NewNERService(skillRepo, categoryRepo)
+	huggingFaceClient := huggingface.NewClientFromEnv(nil)
+	huggingFaceService := services.NewHuggingFaceSkillService(huggingFaceClient, nil)
+	available := methods(nerService, huggingFaceService)
+
+	names := strings.Split(*methodList, ",")
+	if *methodList == "" {
+		names = []string{"ner"}
+		if huggingFaceClient.Configured() {
+			names = append(names, "huggingface", "ensemble")
+			huggingFaceService.WarmUp(context.Background())
+		}
+	}
+
+	run := &extracteval.Run{Corpus: *dir, CreatedAt: time.Now()}
+	for _, name := range names {
+		method, exists := available[strings.TrimSpace(name)]
+		if !exists {
+			log.Fatal("Unknown method", "method", name)
+		}
+
+		start := time.Now()
+		report := extracteval.Evaluate(method, documents)
+		log.Info("Evaluated method", "method", method.Name, "documents", report.Documents, "failed", report.Failed, "duration", time.Since(start).String())
+		run.Reports = append(run.Reports, report)
+	}
+
+	for _, report := range run.Reports {
+		printReport(report, *verbose)
+	}
+
+	if *out != "" {
+		if err := run.Save(*out); err != nil {
+			log.Fatal("Failed to save run", "error", err)
+		}
+		log.Info("Saved run", "file", *out)
+	}
+
+	if *baseline != "" {
+		before, err := extracteval.LoadRun(*baseline)
+		if err != nil {
+			log.Fatal("Failed to load baseline", "error", err)
+		}
+		printDiff(extracteval.Diff(before, run))
+	}
+}
+
+// methods returns the extraction methods that can be evaluated, by name
+func methods(nerService *services.NERService, huggingFaceService *services.HuggingFaceSkillService) map[string]extracteval.Method {
+	extractionService := services.NewCandidateExtractionService(nerService)
+	skillEnsemble := services.NewSkillEnsemble(nerService)
+
+	return map[string]extracteval.Method{
+		"ner": {
+			Name:   "ner",
+			Fields: extracteval.ResumeFields,
+			Extract: func(text string) (extracteval.Prediction, error) {
+				resume, err := extractionService.ExtractResume(text)
+				return extracteval.FromResume(resume), err
+			},
+		},
+		"huggingface": {
+			Name:   "huggingface",
+			Fields: extracteval.HuggingFaceFields,
+			Extract: func(text string) (extracteval.Prediction, error) {
+				result, err := huggingFaceService.ExtractSkillsFromText(context.Background(), text)
+				return extracteval.FromHuggingFace(result), err
+			},
+		},
+		"ensemble": {
+			Name:   "ensemble",
+			Fields: extracteval.ResumeFields,
+			Extract: func(text string) (extracteval.Prediction, error) {
+				resume, err := extractionService.ExtractResume(text)
+				if err != nil {
+					return extracteval.Prediction{}, err
+				}
+				// Like the combined endpoint, a failed model call leaves the dictionary skills
+				result, _ := huggingFaceService.ExtractSkillsFromText(context.Background(), text)
+				return extracteval.FromResume(skillEnsemble.Merge(resume, result)), nil
+			},
+		},
+	}
+}
+
+func printReport(report extracteval.Report, verbose bool) {
+	fmt.Printf("\n== %s: %d resumes, %d failed\n\n", report.Method, report.Documents, report.Failed)
+	printMetrics("field", report.Fields)
+	fmt.Println()
+	printMetrics("category", report.Categories)
+
+	if !verbose {
+		return
+	}
+	for _, result := range report.Results {
+		fmt.Printf("\n%s\n", result.Document)
+		if result.Error != "" {
+			fmt.Printf("  error:  %s\n", result.Error)
+		}
+		if len(result.Missed) > 0 {
+			fmt.Printf("  missed: %s\n", strings.Join(result.Missed, ", "))
+		}
+		if len(result.Extra) > 0 {
+			fmt.Printf("  extra:  %s\n", strings.Join(result.Extra, ", "))
+		}
+		for _, field := range sortedKeys(result.Mismatches) {
+			fmt.Printf("  %s: %s\n", field, result.Mismatches[field])
+		}
+	}
+}
+
+func printMetrics(label string, metrics map[string]extracteval.Metrics) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(w, "%s\tprecision\trecall\tF1\ttp\tfp\tfn\t\n", label)
+	for _, name := range sortedKeys(metrics) {
+		m := metrics[name]
+		fmt.Fprintf(w, "%s\t%.3f\t%.3f\t%.3f\t%d\t%d\t%d\t\n", name, m.Precision, m.Recall, m.F1, m.TruePositives, m.FalsePositives, m.FalseNegatives)
+	}
+	w.Flush()
+}
+
+func printDiff(diffs []extracteval.MethodDiff) {
+	for _, diff := range diffs {
+		fmt.Printf("\n== %s: changes against the baseline\n\n", diff.Method)
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+		fmt.Fprintf(w, "field\tprecision\trecall\tF1\tΔF1\t\n")
+		for _, name := range sortedKeys(diff.Fields) {
+			delta := diff.Fields[name]
+			fmt.Fprintf(w, "%s\t%+.3f\t%+.3f\t%.3f\t%+.3f\t\n", name, delta.Precision, delta.Recall, delta.After.F1, delta.F1)
+		}
+		for _, name := range sortedKeys(diff.Categories) {
+			delta := diff.Categories[name]
+			if delta.Precision != 0 || delta.Recall != 0 {
+				fmt.Fprintf(w, "category %s\t%+.3f\t%+.3f\t%.3f\t%+.3f\t\n", name, delta.Precision, delta.Recall, delta.After.F1, delta.F1)
+			}
+		}
+		w.Flush()
+
+		for _, document := range diff.Documents {
+			fmt.Printf("\n%s\n", document.Document)
+			printList("fixed", document.Fixed)
+			printList("regressed", document.Regressed)
+			printList("new extra", document.NewExtra)
+			printList("dropped extra", document.Dropped)
+			printList("fields fixed", document.FieldsFixed)
+			printList("fields broke", document.FieldsBroke)
+		}
+	}
+}
+
+func printList(label string, values []string) {
+	if len(values) > 0 {
+		fmt.Printf("  %-14s %s\n", label+":", strings.Join(values, ", "))
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
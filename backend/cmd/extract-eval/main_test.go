@@ -0,0 +1,116 @@
+package main
+
+import (
+	"sort"
+	"testing"
+
+	"stafind-backend/internal/extracteval"
+	"stafind-backend/internal/models"
+	"stafind-backend/internal/repositories"
+	"stafind-backend/internal/services"
+)
+
+const corpusDir = "../../testdata/resumes"
+
+// catalogRepo serves a skill catalog from memory instead of the database
+type catalogRepo struct {
+	repositories.SkillRepository
+	skills []models.Skill
+}
+
+func (r catalogRepo) GetSkillsWithCategories() ([]models.Skill, error) {
+	return r.skills, nil
+}
+
+func (r catalogRepo) GetSkillAliases() (map[int][]string, error) {
+	return map[int][]string{}, nil
+}
+
+type categoryRepo struct {
+	repositories.CategoryRepository
+	categories []models.Category
+}
+
+func (r categoryRepo) GetAll() ([]models.Category, error) {
+	return r.categories, nil
+}
+
+// corpusCatalog builds a skill catalog of every labelled skill of the corpus, plus some the
+// resumes do not mention, the way the seeded catalog holds far more skills than one resume
+func corpusCatalog(documents []extracteval.Document) (catalogRepo, categoryRepo) {
+	categoryIDs := make(map[string]int)
+	skillCategories := make(map[string]string)
+	for _, document := range documents {
+		for category, skills := range document.Labels.Skills {
+			if _, exists := categoryIDs[category]; !exists {
+				categoryIDs[category] = len(categoryIDs) + 1
+			}
+			for _, skill := range skills {
+				skillCategories[skill] = category
+			}
+		}
+	}
+	for _, skill := range []string{"Java", "Ruby", "Oracle", "Rust"} {
+		skillCategories[skill] = "programming_languages"
+	}
+
+	var categories categoryRepo
+	for name, id := range categoryIDs {
+		categories.categories = append(categories.categories, models.Category{ID: id, Name: name})
+	}
+
+	names := make([]string, 0, len(skillCategories))
+	for name := range skillCategories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var catalog catalogRepo
+	for i, name := range names {
+		category := skillCategories[name]
+		catalog.skills = append(catalog.skills, models.Skill{
+			ID:         i + 1,
+			Name:       name,
+			Categories: []models.Category{{ID: categoryIDs[category], Name: category}},
+		})
+	}
+	return catalog, categories
+}
+
+// The dictionary extractor, run over the committed corpus, keeps the scores it has today.
+// Raise the floors when the extractor improves; a drop below one is a regression.
+func TestNERMethodOnCorpus(t *testing.T) {
+	documents, err := extracteval.LoadCorpus(corpusDir)
+	if err != nil {
+		t.Fatalf("LoadCorpus() error = %v", err)
+	}
+
+	catalog, categories := corpusCatalog(documents)
+	nerService := services.NewNERService(catalog, categories)
+	report := extracteval.Evaluate(methods(nerService, nil)["ner"], documents)
+
+	if report.Failed != 0 {
+		t.Fatalf("%d resumes failed: %+v", report.Failed, report.Results)
+	}
+
+	// Today an email on the line above a heading runs into it, and a Markdown "# " stays in the name
+	floors := map[string]struct{ precision, recall float64 }{
+		extracteval.FieldSkills:          {1, 1},
+		extracteval.FieldSeniority:       {1, 1},
+		extracteval.FieldYearsExperience: {1, 0.8},
+		extracteval.FieldName:            {0.8, 0.8},
+		extracteval.FieldEmail:           {0.6, 0.6},
+	}
+	for field, floor := range floors {
+		metrics := report.Fields[field]
+		if metrics.Precision < floor.precision || metrics.Recall < floor.recall {
+			t.Errorf("%s = precision %.3f, recall %.3f; want at least %.2f and %.2f",
+				field, metrics.Precision, metrics.Recall, floor.precision, floor.recall)
+		}
+	}
+	if t.Failed() {
+		for _, result := range report.Results {
+			t.Logf("%s: missed %v, extra %v, mismatches %v", result.Document, result.Missed, result.Extra, result.Mismatches)
+		}
+	}
+}
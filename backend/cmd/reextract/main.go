@@ -0,0 +1,208 @@
+// Command reextract runs the stored resume text of employees through extraction again, so
+// extraction improvements reach existing employees. Employees are selected by extraction
+// source, extractor version and the date of their latest extraction. A dry run prints what
+// would change without storing anything.
+//
+//	go run ./cmd/reextract -outdated -dry-run
+//	go run ./cmd/reextract -source google_drive -after 2025-01-01 -concurrency 8
+//	go run ./cmd/reextract -resume reextract-1735689600000000000
+//
+// Progress is tracked as a CV extract under the printed request ID; after an interruption,
+// -resume continues with the employees the run has not processed.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"stafind-backend/internal/constants"
+	"stafind-backend/internal/database"
+	"stafind-backend/internal/logger"
+	"stafind-backend/internal/models"
+	"stafind-backend/internal/repositories"
+	"stafind-backend/internal/services"
+
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	// Initialize structured logging
+	if err := logger.Init(nil); err != nil {
+		panic("Failed to initialize logger: " + err.Error())
+	}
+	log := logger.Get()
+
+	// Load environment variables
+	// Try .env first (standard), then fall back to config.env (legacy)
+	if err := godotenv.Load(); err != nil {
+		if err := godotenv.Load("config.env"); err != nil {
+			log.Info("No .env or config.env file found, using environment variables")
+		}
+	}
+
+	source := flag.String("source", "", "Only employees from this extraction source, e.g. google_drive")
+	versionBelow := flag.Int("version-below", 0, "Only employees last extracted by an extractor version below this")
+	outdated := flag.Bool("outdated", false, fmt.Sprintf("Only employees last extracted by a version below the current one (%d)", constants.ExtractorVersion))
+	after := flag.String("after", "", "Only employees last extracted on or after this date (YYYY-MM-DD)")
+	before := flag.String("before", "", "Only employees last extracted before this date (YYYY-MM-DD)")
+	concurrency := flag.Int("concurrency", constants.DefaultReextractionConcurrency, "Employees processed at once")
+	dryRun := flag.Bool("dry-run", false, "Print what would change without storing anything")
+	resume := flag.String("resume", "", "Continue the interrupted run with this request ID")
+	verbose := flag.Bool("v", false, "Print unchanged employees too")
+	flag.Parse()
+
+	req := models.ReextractionRequest{
+		Filter: models.ReextractionFilter{
+			Source:                *source,
+			ExtractorVersionBelow: *versionBelow,
+		},
+		DryRun:      *dryRun,
+		Concurrency: *concurrency,
+	}
+	if *outdated {
+		req.Filter.ExtractorVersionBelow = constants.ExtractorVersion
+	}
+	var err error
+	if req.Filter.ExtractedAfter, err = parseDate(*after); err != nil {
+		log.Fatal("Invalid -after date", "error", err)
+	}
+	if req.Filter.ExtractedBefore, err = parseDate(*before); err != nil {
+		log.Fatal("Invalid -before date", "error", err)
+	}
+
+	// Initialize database; the schema is migrated by the server
+	db, err := database.NewConnection()
+	if err != nil {
+		log.Fatal("Failed to connect to database", "error", err)
+	}
+	defer db.Close()
+
+	// Initialize repositories
+	employeeRepo, err := repositories.NewEmployeeRepository(db.DB)
+	if err != nil {
+		log.Fatal("Failed to initialize employee repository", "error", err)
+	}
+	skillRepo, err := repositories.NewSkillRepository(db.DB)
+	if err != nil {
+		log.Fatal("Failed to initialize skill repository", "error", err)
+	}
+	categoryRepo, err := repositories.NewCategoryRepository(db.DB)
+	if err != nil {
+		log.Fatal("Failed to initialize category repository", "error", err)
+	}
+	cvExtractRepo, err := repositories.NewCVExtractRepository(db.DB)
+	if err != nil {
+		log.Fatal("Failed to initialize CV extract repository", "error", err)
+	}
+	duplicateRepo, err := repositories.NewEmployeeDuplicateRepository(db.DB)
+	if err != nil {
+		log.Fatal("Failed to initialize employee duplicate repository", "error", err)
+	}
+	extractionHistoryRepo, err := repositories.NewEmployeeExtractionRepository(db.DB)
+	if err != nil {
+		log.Fatal("Failed to initialize employee extraction repository", "error", err)
+	}
+	extractionReviewRepo, err := repositories.NewExtractionReviewRepository(db.DB)
+	if err != nil {
+		log.Fatal("Failed to initialize extraction review repository", "error", err)
+	}
+	skillDiscoveryRepo, err := repositories.NewSkillDiscoveryRepository(db.DB)
+	if err != nil {
+		log.Fatal("Failed to initialize skill discovery repository", "error", err)
+	}
+	reextractionRepo, err := repositories.NewReextractionRepository(db.DB)
+	if err != nil {
+		log.Fatal("Failed to initialize re-extraction repository", "error", err)
+	}
+	certificationRepo, err := repositories.NewCertificationRepository(db.DB)
+	if err != nil {
+		log.Fatal("Failed to initialize certification repository", "error", err)
+	}
+	backgroundRepo, err := repositories.NewEmployeeBackgroundRepository(db.DB)
+	if err != nil {
+		log.Fatal("Failed to initialize employee background repository", "error", err)
+	}
+
+	// Initialize services
+	nerService := services.NewNERService(skillRepo, categoryRepo)
+	extractionService := services.NewCandidateExtractionService(nerService)
+	duplicateService := services.NewDuplicateService(duplicateRepo, employeeRepo)
+	extractionHistoryService := services.NewExtractionHistoryService(extractionHistoryRepo, employeeRepo)
+	// Only the server announces certification expiries
+	certificationService := services.NewCertificationService(certificationRepo, employeeRepo, nil)
+	candidateStorageService := services.NewCandidateStorageService(employeeRepo, skillRepo, duplicateService, extractionHistoryService, extractionReviewRepo, skillDiscoveryRepo, certificationService, backgroundRepo)
+	cvExtractService := services.NewCVExtractService(cvExtractRepo)
+	importer := services.NewResumeImporter(extractionService, candidateStorageService)
+	reextractionService := services.NewReextractionService(reextractionRepo, employeeRepo, extractionHistoryService, cvExtractService, importer)
+
+	var run *models.ReextractionRun
+	var employeeIDs []int
+	if *resume != "" {
+		run, employeeIDs, err = reextractionService.Resume(*resume)
+	} else {
+		run, employeeIDs, err = reextractionService.Start(&req)
+	}
+	if err != nil {
+		log.Fatal("Failed to start re-extraction", "error", err)
+	}
+	fmt.Printf("Run %s: %d employees to re-extract", run.RequestID, len(employeeIDs))
+	if run.Request.DryRun {
+		fmt.Print(" (dry run)")
+	}
+	fmt.Println()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	counts := make(map[string]int)
+	done := 0
+	err = reextractionService.Run(ctx, run, employeeIDs, func(result models.ReextractionResult) {
+		counts[result.Status]++
+		done++
+		printResult(result, done, len(employeeIDs), *verbose)
+	})
+	fmt.Printf("%d changed, %d unchanged, %d failed\n",
+		counts[models.ReextractionStatusChanged], counts[models.ReextractionStatusUnchanged], counts[models.ReextractionStatusFailed])
+	if err == context.Canceled {
+		fmt.Printf("Interrupted; continue with -resume %s\n", run.RequestID)
+		os.Exit(1)
+	}
+	if err != nil {
+		log.Fatal("Re-extraction failed", "error", err)
+	}
+}
+
+// printResult prints an employee's outcome with the changes found, and unchanged employees only
+// when verbose
+func printResult(result models.ReextractionResult, done, total int, verbose bool) {
+	if result.Status == models.ReextractionStatusUnchanged && !verbose {
+		return
+	}
+
+	fmt.Printf("[%d/%d] Employee %d %s: %s\n", done, total, result.EmployeeID, result.EmployeeName, result.Status)
+	if result.ErrorMessage != "" {
+		fmt.Printf("    %s\n", result.ErrorMessage)
+	}
+	if result.Changes != nil && result.Changes.HasChanges() {
+		for _, line := range result.Changes.Summary {
+			fmt.Printf("    %s\n", line)
+		}
+	}
+}
+
+// parseDate parses a YYYY-MM-DD date, empty for none
+func parseDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, err
+	}
+	return &date, nil
+}
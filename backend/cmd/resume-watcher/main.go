@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/signal"
+	"stafind-backend/internal/constants"
+	"stafind-backend/internal/database"
+	"stafind-backend/internal/logger"
+	"stafind-backend/internal/repositories"
+	"stafind-backend/internal/resumewatcher"
+	"stafind-backend/internal/services"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	// Initialize structured logging
+	if err := logger.Init(nil); err != nil {
+		panic("Failed to initialize logger: " + err.Error())
+	}
+	log := logger.Get()
+
+	// Load environment variables
+	// Try .env first (standard), then fall back to config.env (legacy)
+	if err := godotenv.Load(); err != nil {
+		if err := godotenv.Load("config.env"); err != nil {
+			log.Info("No .env or config.env file found, using environment variables")
+		}
+	}
+
+	dirs := flag.String("dir", os.Getenv(constants.EnvResumeWatchDirs), "Comma-separated directories to watch")
+	interval := flag.Duration("interval", envSeconds(constants.EnvResumeWatchInterval, resumewatcher.DefaultInterval), "Time between polls")
+	stableFor := flag.Duration("stable-for", resumewatcher.DefaultStableFor, "Skip files modified more recently than this")
+	once := flag.Bool("once", false, "Poll once and exit")
+	flag.Parse()
+
+	var directories []string
+	for _, dir := range strings.Split(*dirs, ",") {
+		if dir = strings.TrimSpace(dir); dir != "" {
+			directories = append(directories, dir)
+		}
+	}
+	if len(directories) == 0 {
+		log.Fatal("No directories to watch; use -dir or " + constants.EnvResumeWatchDirs)
+	}
+	for _, dir := range directories {
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			log.Fatal("Watch directory is not accessible", "directory", dir, "error", err)
+		}
+	}
+
+	// Initialize database; the schema is migrated by the server
+	db, err := database.NewConnection()
+	if err != nil {
+		log.Fatal("Failed to connect to database", "error", err)
+	}
+	defer db.Close()
+
+	// Initialize repositories
+	employeeRepo, err := repositories.NewEmployeeRepository(db.DB)
+	if err != nil {
+		log.Fatal("Failed to initialize employee repository", "error", err)
+	}
+	skillRepo, err := repositories.NewSkillRepository(db.DB)
+	if err != nil {
+		log.Fatal("Failed to initialize skill repository", "error", err)
+	}
+	categoryRepo, err := repositories.NewCategoryRepository(db.DB)
+	if err != nil {
+		log.Fatal("Failed to initialize category repository", "error", err)
+	}
+	cvExtractRepo, err := repositories.NewCVExtractRepository(db.DB)
+	if err != nil {
+		log.Fatal("Failed to initialize CV extract repository", "error", err)
+	}
+	duplicateRepo, err := repositories.NewEmployeeDuplicateRepository(db.DB)
+	if err != nil {
+		log.Fatal("Failed to initialize employee duplicate repository", "error", err)
+	}
+	extractionHistoryRepo, err := repositories.NewEmployeeExtractionRepository(db.DB)
+	if err != nil {
+		log.Fatal("Failed to initialize employee extraction repository", "error", err)
+	}
+	extractionReviewRepo, err := repositories.NewExtractionReviewRepository(db.DB)
+	if err != nil {
+		log.Fatal("Failed to initialize extraction review repository", "error", err)
+	}
+	skillDiscoveryRepo, err := repositories.NewSkillDiscoveryRepository(db.DB)
+	if err != nil {
+		log.Fatal("Failed to initialize skill discovery repository", "error", err)
+	}
+	certificationRepo, err := repositories.NewCertificationRepository(db.DB)
+	if err != nil {
+		log.Fatal("Failed to initialize certification repository", "error", err)
+	}
+	backgroundRepo, err := repositories.NewEmployeeBackgroundRepository(db.DB)
+	if err != nil {
+		log.Fatal("Failed to initialize employee background repository", "error", err)
+	}
+
+	// Initialize services; skill catalog changes made by the server arrive through LISTEN/NOTIFY
+	skillCatalogEvents := services.NewSkillCatalogEvents()
+	nerService := services.NewNERService(skillRepo, categoryRepo)
+	nerService.WatchSkillCatalog(skillCatalogEvents)
+	extractionService := services.NewCandidateExtractionService(nerService)
+	duplicateService := services.NewDuplicateService(duplicateRepo, employeeRepo)
+	extractionHistoryService := services.NewExtractionHistoryService(extractionHistoryRepo, employeeRepo)
+	// Only the server announces certification expiries
+	certificationService := services.NewCertificationService(certificationRepo, employeeRepo, nil)
+	candidateStorageService := services.NewCandidateStorageService(employeeRepo, skillRepo, duplicateService, extractionHistoryService, extractionReviewRepo, skillDiscoveryRepo, certificationService, backgroundRepo)
+	cvExtractService := services.NewCVExtractService(cvExtractRepo)
+	importer := services.NewResumeImporter(extractionService, candidateStorageService)
+
+	watcher := resumewatcher.New(resumewatcher.Config{
+		Directories: directories,
+		Interval:    *interval,
+		StableFor:   *stableFor,
+	}, importer, cvExtractService)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		err := database.Listen(ctx, constants.SkillCatalogChannel, func(string) {
+			skillCatalogEvents.Publish(services.SkillCatalogEvent{Action: services.SkillCatalogRemoteChange})
+		})
+		if err != nil && err != context.Canceled {
+			log.Warn("Not listening for skill catalog changes; the skill cache refreshes on expiry", "error", err)
+		}
+	}()
+
+	if *once {
+		results := watcher.PollAll(ctx)
+		log.Info("Resume watcher finished", "files", len(results))
+		return
+	}
+
+	log.Info("Resume watcher started", "directories", directories, "interval", interval.String())
+	if err := watcher.Run(ctx); err != nil && err != context.Canceled {
+		log.Fatal("Resume watcher stopped", "error", err)
+	}
+	log.Info("Resume watcher stopped")
+}
+
+// envSeconds reads a duration in seconds from the environment
+func envSeconds(name string, fallback time.Duration) time.Duration {
+	if value := os.Getenv(name); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	return fallback
+}
@@ -1,14 +1,19 @@
 package main
 
 import (
+	"context"
 	"os"
 	"stafind-backend/cmd/server/routes"
 	"stafind-backend/internal/constants"
 	"stafind-backend/internal/database"
+	"stafind-backend/internal/googledrive"
 	"stafind-backend/internal/handlers"
+	"stafind-backend/internal/huggingface"
 	"stafind-backend/internal/logger"
+	"stafind-backend/internal/middleware"
 	"stafind-backend/internal/repositories"
 	"stafind-backend/internal/services"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -100,28 +105,99 @@ func main() {
 		log.Fatal("Failed to initialize CV extract repository", "error", err)
 	}
 
+	idempotencyRepo, err := repositories.NewIdempotencyRepository(db.DB)
+	if err != nil {
+		log.Fatal("Failed to initialize idempotency repository", "error", err)
+	}
+
+	driveSyncRepo, err := repositories.NewDriveSyncRepository(db.DB)
+	if err != nil {
+		log.Fatal("Failed to initialize drive sync repository", "error", err)
+	}
+	duplicateRepo, err := repositories.NewEmployeeDuplicateRepository(db.DB)
+	if err != nil {
+		log.Fatal("Failed to initialize employee duplicate repository", "error", err)
+	}
+	extractionHistoryRepo, err := repositories.NewEmployeeExtractionRepository(db.DB)
+	if err != nil {
+		log.Fatal("Failed to initialize employee extraction repository", "error", err)
+	}
+	extractionReviewRepo, err := repositories.NewExtractionReviewRepository(db.DB)
+	if err != nil {
+		log.Fatal("Failed to initialize extraction review repository", "error", err)
+	}
+	skillDiscoveryRepo, err := repositories.NewSkillDiscoveryRepository(db.DB)
+	if err != nil {
+		log.Fatal("Failed to initialize skill discovery repository", "error", err)
+	}
+	reextractionRepo, err := repositories.NewReextractionRepository(db.DB)
+	if err != nil {
+		log.Fatal("Failed to initialize re-extraction repository", "error", err)
+	}
+	certificationRepo, err := repositories.NewCertificationRepository(db.DB)
+	if err != nil {
+		log.Fatal("Failed to initialize certification repository", "error", err)
+	}
+	backgroundRepo, err := repositories.NewEmployeeBackgroundRepository(db.DB)
+	if err != nil {
+		log.Fatal("Failed to initialize employee background repository", "error", err)
+	}
+
+	// One skill extractor for every service; its cache reloads on skill catalog changes made
+	// here or, through LISTEN/NOTIFY, by other instances
+	skillCatalogEvents := services.NewSkillCatalogEvents()
+	nerService := services.NewNERService(skillRepo, categoryRepo)
+	nerService.WatchSkillCatalog(skillCatalogEvents)
+	go listenForSkillCatalogChanges(skillCatalogEvents)
+
 	// Initialize services
 	employeeService := services.NewEmployeeService(employeeRepo)
-	searchService := services.NewSearchService(employeeRepo)
-	skillService := services.NewSkillService(skillRepo, employeeRepo)
-	categoryService := services.NewCategoryService(categoryRepo)
+	searchService := services.NewSearchService(employeeRepo, backgroundRepo)
+	skillService := services.NewSkillService(skillRepo, employeeRepo, skillCatalogEvents)
+	categoryService := services.NewCategoryService(categoryRepo, skillCatalogEvents)
 	userService := services.NewUserService(userRepo, roleRepo)
 	roleService := services.NewRoleService(roleRepo)
 	dashboardService := services.NewDashboardService(employeeRepo, skillRepo, aiAgentRepo, matchRepo)
 	notificationService := services.NewNotificationService(aiAgentRepo)
-	aiAgentService := services.NewAIAgentService(aiAgentRepo, employeeRepo, skillRepo, categoryRepo, matchRepo, notificationService)
-	nerService := services.NewNERService(skillRepo, categoryRepo)
+	aiAgentService := services.NewAIAgentService(aiAgentRepo, employeeRepo, backgroundRepo, skillRepo, categoryRepo, matchRepo, notificationService, nerService)
 	apiKeyService := services.NewAPIKeyService(apiKeyRepo)
-	extractionService := services.NewCandidateExtractionService(skillRepo, categoryRepo)
-	candidateStorageService := services.NewCandidateStorageService(employeeRepo, skillRepo)
+	extractionService := services.NewCandidateExtractionService(nerService)
+	duplicateService := services.NewDuplicateService(duplicateRepo, employeeRepo)
+	extractionHistoryService := services.NewExtractionHistoryService(extractionHistoryRepo, employeeRepo)
+	certificationService := services.NewCertificationService(certificationRepo, employeeRepo, notificationService)
+	candidateStorageService := services.NewCandidateStorageService(employeeRepo, skillRepo, duplicateService, extractionHistoryService, extractionReviewRepo, skillDiscoveryRepo, certificationService, backgroundRepo)
+	extractionReviewService := services.NewExtractionReviewService(extractionReviewRepo, candidateStorageService)
+	resumeImporter := services.NewResumeImporter(extractionService, candidateStorageService)
+	skillDiscoveryService := services.NewSkillDiscoveryService(skillDiscoveryRepo, skillRepo, categoryRepo, extractionHistoryService, skillService, resumeImporter)
 	cvExtractService := services.NewCVExtractService(cvExtractRepo)
+	reextractionService := services.NewReextractionService(reextractionRepo, employeeRepo, extractionHistoryService, cvExtractService, resumeImporter)
+	idempotencyService := services.NewIdempotencyService(idempotencyRepo)
 
 	// Initialize Hugging Face service
-	huggingFaceAPIKey := os.Getenv(constants.EnvHuggingFaceAPIKey)
-	if huggingFaceAPIKey == "" {
+	huggingFaceClient := huggingface.NewClientFromEnv(nil)
+	if !huggingFaceClient.Configured() {
 		log.Warn("HUGGINGFACE_API_KEY not set, Hugging Face service will not be available")
 	}
-	huggingFaceService := services.NewHuggingFaceSkillService(huggingFaceAPIKey)
+	var huggingFaceCacheRepo repositories.HuggingFaceCacheRepository
+	if os.Getenv(constants.EnvHuggingFaceCachePersist) == "true" {
+		huggingFaceCacheRepo, err = repositories.NewHuggingFaceCacheRepository(db.DB)
+		if err != nil {
+			log.Fatal("Failed to initialize Hugging Face cache repository", "error", err)
+		}
+	}
+	huggingFaceService := services.NewHuggingFaceSkillService(huggingFaceClient, huggingFaceCacheRepo)
+	skillEnsemble := services.NewSkillEnsemble(nerService)
+	go huggingFaceService.WarmUp(context.Background())
+
+	// Initialize Google Drive sync service
+	driveClient, err := googledrive.NewClientFromEnv(nil)
+	if err != nil {
+		log.Fatal("Failed to initialize Google Drive client", "error", err)
+	}
+	if driveClient == nil {
+		log.Warn("GOOGLE_DRIVE_CREDENTIALS_PATH not set, Google Drive sync will not be available")
+	}
+	driveService := services.NewGoogleDriveService(driveClient, driveSyncRepo, extractionService, candidateStorageService, cvExtractService)
 
 	// Initialize handlers
 	h := handlers.NewHandlers(employeeService, searchService, skillService, categoryService, aiAgentService, nerService)
@@ -132,7 +208,14 @@ func main() {
 	matchingHandlers := handlers.NewMatchingHandler(aiAgentService)
 	cvExtractHandlers := handlers.NewCVExtractHandlers(cvExtractService)
 	huggingFaceHandlers := handlers.NewHuggingFaceHandlers(huggingFaceService)
-	combinedExtractHandlers := handlers.NewCombinedExtractHandlers(extractionService, aiAgentService, candidateStorageService, cvExtractService, huggingFaceService)
+	combinedExtractHandlers := handlers.NewCombinedExtractHandlers(extractionService, aiAgentService, candidateStorageService, cvExtractService, huggingFaceService, skillEnsemble)
+	driveHandlers := handlers.NewDriveHandlers(driveService)
+	duplicateHandlers := handlers.NewDuplicateHandlers(duplicateService)
+	extractionHistoryHandlers := handlers.NewExtractionHistoryHandlers(extractionHistoryService)
+	extractionReviewHandlers := handlers.NewExtractionReviewHandlers(extractionReviewService)
+	skillDiscoveryHandlers := handlers.NewSkillDiscoveryHandlers(skillDiscoveryService)
+	reextractionHandlers := handlers.NewReextractionHandlers(reextractionService)
+	certificationHandlers := handlers.NewCertificationHandlers(certificationService)
 
 	// Start server
 	port := os.Getenv("PORT")
@@ -141,10 +224,63 @@ func main() {
 	}
 
 	// Setup routes using enhanced structure
-	app := routes.SetupAllRoutes(h, authHandlers, dashboardHandlers, apiKeyHandlers, extractionHandlers, matchingHandlers, cvExtractHandlers, huggingFaceHandlers, combinedExtractHandlers)
+	webhookSignature := middleware.WebhookSignatureMiddleware(apiKeyService)
+	idempotency := middleware.IdempotencyMiddleware(idempotencyService)
+
+	app := routes.SetupAllRoutes(h, authHandlers, dashboardHandlers, apiKeyHandlers, extractionHandlers, matchingHandlers, cvExtractHandlers, huggingFaceHandlers, combinedExtractHandlers, driveHandlers, duplicateHandlers, extractionHistoryHandlers, extractionReviewHandlers, skillDiscoveryHandlers, reextractionHandlers, certificationHandlers, webhookSignature, idempotency)
+
+	// Purge stored idempotent responses and cached extractions once their TTL has passed
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for range ticker.C {
+			purged, err := idempotencyService.PurgeExpired()
+			if err != nil {
+				log.Warn("Failed to purge expired idempotency keys", "error", err)
+			} else if purged > 0 {
+				log.Info("Purged expired idempotency keys", "count", purged)
+			}
+
+			purged, err = huggingFaceService.PurgeExpiredCache()
+			if err != nil {
+				log.Warn("Failed to purge expired Hugging Face cache entries", "error", err)
+			} else if purged > 0 {
+				log.Info("Purged expired Hugging Face cache entries", "count", purged)
+			}
+		}
+	}()
+
+	// Announce the certifications about to expire at startup and then daily; each expiry is
+	// announced once
+	if days := certificationService.NoticeDays(); days > 0 {
+		go func() {
+			ticker := time.NewTicker(24 * time.Hour)
+			defer ticker.Stop()
+			for {
+				notice, err := certificationService.NotifyExpiring()
+				if err != nil {
+					log.Warn("Failed to send certification expiry notices", "error", err)
+				} else if len(notice.Certifications) > 0 {
+					log.Info("Sent certification expiry notices", "count", len(notice.Certifications), "days", days)
+				}
+				<-ticker.C
+			}
+		}()
+	}
 
 	log.Info("Server starting", "port", port)
 	if err := app.Listen(":" + port); err != nil {
 		log.Fatal("Failed to start server", "error", err)
 	}
 }
+
+// listenForSkillCatalogChanges publishes skill catalog changes made by other instances, the
+// resume watcher or direct database edits, as announced by the catalog's NOTIFY triggers
+func listenForSkillCatalogChanges(events *services.SkillCatalogEvents) {
+	err := database.Listen(context.Background(), constants.SkillCatalogChannel, func(string) {
+		events.Publish(services.SkillCatalogEvent{Action: services.SkillCatalogRemoteChange})
+	})
+	if err != nil {
+		logger.Warn("Not listening for skill catalog changes; the skill cache refreshes on expiry", "error", err)
+	}
+}
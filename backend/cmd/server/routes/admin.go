@@ -8,7 +8,7 @@ import (
 )
 
 // SetupAdminRoutes configures admin-only routes with authentication and admin role requirement
-func SetupAdminRoutes(app *fiber.App, authHandlers *handlers.AuthHandlers, apiKeyHandlers *handlers.APIKeyHandlers) {
+func SetupAdminRoutes(app *fiber.App, authHandlers *handlers.AuthHandlers, apiKeyHandlers *handlers.APIKeyHandlers, huggingFaceHandlers *handlers.HuggingFaceHandlers, skillDiscoveryHandlers *handlers.SkillDiscoveryHandlers, reextractionHandlers *handlers.ReextractionHandlers, certificationHandlers *handlers.CertificationHandlers) {
 	// Admin routes with authentication and admin role requirement
 	admin := app.Group("/api/v1/admin", middleware.AuthMiddleware(), middleware.RequireRole("admin"))
 	{
@@ -31,5 +31,27 @@ func SetupAdminRoutes(app *fiber.App, authHandlers *handlers.AuthHandlers, apiKe
 		admin.Post("/api-keys", apiKeyHandlers.CreateAPIKey)
 		admin.Post("/api-keys/:id/rotate", apiKeyHandlers.RotateAPIKey)
 		admin.Post("/api-keys/:id/deactivate", apiKeyHandlers.DeactivateAPIKey)
+		admin.Post("/api-keys/:id/signing-secret", apiKeyHandlers.CreateSigningSecret)
+
+		// Hugging Face extraction cache
+		admin.Delete("/huggingface/cache", huggingFaceHandlers.PurgeCache)
+
+		// Unknown skill terms found in resumes
+		admin.Get("/skill-terms", skillDiscoveryHandlers.ListTerms)
+		admin.Get("/skill-terms/:id", skillDiscoveryHandlers.GetTerm)
+		admin.Post("/skill-terms/:id/approve", skillDiscoveryHandlers.ApproveTerm)
+		admin.Post("/skill-terms/:id/alias", skillDiscoveryHandlers.AliasTerm)
+		admin.Post("/skill-terms/:id/ignore", skillDiscoveryHandlers.IgnoreTerm)
+
+		// Re-extraction of stored resumes
+		admin.Post("/reextractions", reextractionHandlers.StartReextraction)
+		admin.Get("/reextractions/:requestId", reextractionHandlers.GetReextraction)
+		admin.Post("/reextractions/:requestId/resume", reextractionHandlers.ResumeReextraction)
+
+		// Certification catalogue and expiry notices
+		admin.Post("/certifications", certificationHandlers.CreateCatalogEntry)
+		admin.Put("/certifications/:id", certificationHandlers.UpdateCatalogEntry)
+		admin.Delete("/certifications/:id", certificationHandlers.DeleteCatalogEntry)
+		admin.Post("/certifications/expiry-notices", certificationHandlers.SendExpiryNotices)
 	}
 }
@@ -14,10 +14,45 @@ func SetupAPIRoutes(
 	authHandlers *handlers.AuthHandlers,
 	dashboardHandlers *handlers.DashboardHandlers,
 	apiKeyHandlers *handlers.APIKeyHandlers,
+	duplicateHandlers *handlers.DuplicateHandlers,
+	extractionHistoryHandlers *handlers.ExtractionHistoryHandlers,
+	extractionReviewHandlers *handlers.ExtractionReviewHandlers,
+	certificationHandlers *handlers.CertificationHandlers,
+	idempotency fiber.Handler,
 ) {
-	// Protected API routes group
-	api := app.Group("/api/v1", middleware.AuthMiddleware())
+	// Protected API routes group; Idempotency-Key is honoured after authentication
+	api := app.Group("/api/v1", middleware.AuthMiddleware(), idempotency)
 	{
+		// Duplicate employee routes - before the employee wildcard routes
+		api.Get("/employees/duplicates", duplicateHandlers.ListDuplicates)
+		api.Post("/employees/duplicates/scan", duplicateHandlers.ScanDuplicates)
+		api.Post("/employees/duplicates/:id/dismiss", duplicateHandlers.DismissDuplicate)
+		api.Get("/employees/:id/duplicates", duplicateHandlers.GetEmployeeDuplicates)
+		api.Post("/employees/:id/merge", duplicateHandlers.MergeEmployee)
+		api.Get("/employees/:id/merges", duplicateHandlers.GetEmployeeMerges)
+
+		// Extraction history routes - diff before the version wildcard
+		api.Get("/employees/:id/extractions", extractionHistoryHandlers.ListExtractions)
+		api.Get("/employees/:id/extractions/diff", extractionHistoryHandlers.DiffExtractions)
+		api.Get("/employees/:id/extractions/:version", extractionHistoryHandlers.GetExtraction)
+
+		// Employee certification routes
+		api.Get("/employees/:id/certifications", certificationHandlers.ListEmployeeCertifications)
+		api.Post("/employees/:id/certifications", certificationHandlers.AddEmployeeCertification)
+		api.Put("/employees/:id/certifications/:certificationId", certificationHandlers.UpdateEmployeeCertification)
+		api.Delete("/employees/:id/certifications/:certificationId", certificationHandlers.DeleteEmployeeCertification)
+
+		// Certification catalogue routes; changes are admin-only
+		api.Get("/certifications", certificationHandlers.ListCatalog)
+		api.Get("/certifications/:id", certificationHandlers.GetCatalogEntry)
+
+		// Extraction review routes
+		api.Get("/extraction-reviews", extractionReviewHandlers.ListReviews)
+		api.Get("/extraction-reviews/:id", extractionReviewHandlers.GetReview)
+		api.Put("/extraction-reviews/:id", extractionReviewHandlers.UpdateReview)
+		api.Post("/extraction-reviews/:id/approve", extractionReviewHandlers.ApproveReview)
+		api.Post("/extraction-reviews/:id/reject", extractionReviewHandlers.RejectReview)
+
 		// Employee routes
 		api.Get("/employees", h.GetEmployees)
 		api.Get("/employees/:id", h.GetEmployee)
@@ -61,6 +96,7 @@ func SetupAPIRoutes(
 		api.Post("/ai-agent/process", h.AIAgentHandlers.ProcessAIAgentRequest)
 		api.Post("/ai-agents/:id/process-by-id", h.AIAgentHandlers.ProcessAIAgentRequestByID)
 		api.Post("/ai-agents/extract-skills", h.AIAgentHandlers.ExtractSkills)
+		api.Post("/ai-agents/parse-request", h.AIAgentHandlers.ParseJobRequest)
 
 		// Dashboard routes
 		api.Get("/dashboard/stats", dashboardHandlers.GetDashboardStats)
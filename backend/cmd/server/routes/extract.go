@@ -8,16 +8,16 @@ import (
 )
 
 // SetupExtractRoutes configures extraction routes using pure NER with API key authentication
-func SetupExtractRoutes(app *fiber.App, h *handlers.ExtractHandlers) {
-	apiShort := app.Group("/api/v1/extract", middleware.APIKeyMiddleware())
+func SetupExtractRoutes(app *fiber.App, h *handlers.ExtractHandlers, webhookSignature, idempotency fiber.Handler) {
+	apiShort := app.Group("/api/v1/extract", middleware.APIKeyMiddleware(), webhookSignature, idempotency)
 	{
 		apiShort.Post("/process", h.ExtractProcess)
 	}
 }
 
 // SetupCombinedExtractRoutes configures combined NER and Hugging Face extraction routes
-func SetupCombinedExtractRoutes(app *fiber.App, h *handlers.CombinedExtractHandlers) {
-	apiShort := app.Group("/api/v1/extract", middleware.APIKeyMiddleware())
+func SetupCombinedExtractRoutes(app *fiber.App, h *handlers.CombinedExtractHandlers, webhookSignature, idempotency fiber.Handler) {
+	apiShort := app.Group("/api/v1/extract", middleware.APIKeyMiddleware(), webhookSignature, idempotency)
 	{
 		apiShort.Post("/process-combined", h.ExtractProcessCombined)
 		apiShort.Post("/compare-methods", h.CompareExtractionMethods)
@@ -25,10 +25,18 @@ func SetupCombinedExtractRoutes(app *fiber.App, h *handlers.CombinedExtractHandl
 }
 
 // SetupMatchingRoutes configures employee matching routes
-func SetupMatchingRoutes(app *fiber.App, h *handlers.MatchingHandler) {
-	apiShort := app.Group("/api/v1/matching", middleware.APIKeyMiddleware())
+func SetupMatchingRoutes(app *fiber.App, h *handlers.MatchingHandler, webhookSignature, idempotency fiber.Handler) {
+	apiShort := app.Group("/api/v1/matching", middleware.APIKeyMiddleware(), webhookSignature, idempotency)
 	{
 		apiShort.Post("/employees", h.FindMatchingEmployees)
 		apiShort.Get("/history", h.GetMatchingHistory)
 	}
 }
+
+// SetupDriveRoutes configures Google Drive sync routes
+func SetupDriveRoutes(app *fiber.App, h *handlers.DriveHandlers, webhookSignature, idempotency fiber.Handler) {
+	apiShort := app.Group("/api/v1/drive", middleware.APIKeyMiddleware(), webhookSignature, idempotency)
+	{
+		apiShort.Post("/scan", h.ScanFolder)
+	}
+}
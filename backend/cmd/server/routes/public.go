@@ -7,7 +7,7 @@ import (
 )
 
 // SetupPublicRoutes configures public routes (no authentication required)
-func SetupPublicRoutes(app *fiber.App, h *handlers.Handlers, apiKeyHandlers *handlers.APIKeyHandlers) {
+func SetupPublicRoutes(app *fiber.App, h *handlers.Handlers, apiKeyHandlers *handlers.APIKeyHandlers, webhookSignature, idempotency fiber.Handler) {
 	// Health check
 	app.Get("/health", func(c *fiber.Ctx) error {
 		return c.JSON(fiber.Map{"status": "ok"})
@@ -26,6 +26,6 @@ func SetupPublicRoutes(app *fiber.App, h *handlers.Handlers, apiKeyHandlers *han
 	app.Post("/api-keys/validate", apiKeyHandlers.ValidateAPIKey)
 	app.Get("/api-keys/test", apiKeyHandlers.TestAPIKey)
 
-	// Public AI agent endpoint for testing
-	app.Post("/ai-agent/process", h.AIAgentHandlers.ProcessAIAgentRequest)
+	// Public AI agent endpoint for testing; signed once a signing secret is issued (see WEBHOOK_SIGNING.md)
+	app.Post("/ai-agent/process", webhookSignature, idempotency, h.AIAgentHandlers.ProcessAIAgentRequest)
 }
@@ -20,6 +20,15 @@ func SetupAllRoutes(
 	cvExtractHandlers *handlers.CVExtractHandlers,
 	huggingFaceHandlers *handlers.HuggingFaceHandlers,
 	combinedExtractHandlers *handlers.CombinedExtractHandlers,
+	driveHandlers *handlers.DriveHandlers,
+	duplicateHandlers *handlers.DuplicateHandlers,
+	extractionHistoryHandlers *handlers.ExtractionHistoryHandlers,
+	extractionReviewHandlers *handlers.ExtractionReviewHandlers,
+	skillDiscoveryHandlers *handlers.SkillDiscoveryHandlers,
+	reextractionHandlers *handlers.ReextractionHandlers,
+	certificationHandlers *handlers.CertificationHandlers,
+	webhookSignature fiber.Handler,
+	idempotency fiber.Handler,
 ) *fiber.App {
 	app := fiber.New(fiber.Config{
 		ErrorHandler: func(c *fiber.Ctx, err error) error {
@@ -42,25 +51,26 @@ func SetupAllRoutes(
 	app.Use(cors.New(cors.Config{
 		AllowOrigins: corsOrigins,
 		AllowMethods: "GET,POST,PUT,DELETE,OPTIONS",
-		AllowHeaders: "Origin,Content-Type,Accept,Authorization,X-API-Key",
+		AllowHeaders: "Origin,Content-Type,Accept,Authorization,X-API-Key,X-Signature-Key-Id,X-Signature-Timestamp,X-Signature,Idempotency-Key",
 	}))
 
 	// Setup route groups in order of priority
-	SetupPublicRoutes(app, h, apiKeyHandlers)
+	SetupPublicRoutes(app, h, apiKeyHandlers, webhookSignature, idempotency)
 	SetupAuthRoutes(app, authHandlers)
-	SetupExtractRoutes(app, extractionHandlers)
-	SetupCombinedExtractRoutes(app, combinedExtractHandlers)
-	SetupMatchingRoutes(app, matchingHandlers)
-	SetupCVExtractRoutes(app, cvExtractHandlers)
+	SetupExtractRoutes(app, extractionHandlers, webhookSignature, idempotency)
+	SetupCombinedExtractRoutes(app, combinedExtractHandlers, webhookSignature, idempotency)
+	SetupMatchingRoutes(app, matchingHandlers, webhookSignature, idempotency)
+	SetupDriveRoutes(app, driveHandlers, webhookSignature, idempotency)
+	SetupCVExtractRoutes(app, cvExtractHandlers, webhookSignature, idempotency)
 	SetupHuggingFaceRoutes(app, huggingFaceHandlers)
-	SetupAPIRoutes(app, h, authHandlers, dashboardHandlers, apiKeyHandlers)
-	SetupAdminRoutes(app, authHandlers, apiKeyHandlers)
+	SetupAPIRoutes(app, h, authHandlers, dashboardHandlers, apiKeyHandlers, duplicateHandlers, extractionHistoryHandlers, extractionReviewHandlers, certificationHandlers, idempotency)
+	SetupAdminRoutes(app, authHandlers, apiKeyHandlers, huggingFaceHandlers, skillDiscoveryHandlers, reextractionHandlers, certificationHandlers)
 
 	return app
 }
 
 // SetupCVExtractRoutes configures CV extract tracking routes
-func SetupCVExtractRoutes(app *fiber.App, cvExtractHandlers *handlers.CVExtractHandlers) {
+func SetupCVExtractRoutes(app *fiber.App, cvExtractHandlers *handlers.CVExtractHandlers, webhookSignature, idempotency fiber.Handler) {
 	// Register CV extract routes
-	cvExtractHandlers.RegisterCVExtractRoutes(app)
+	cvExtractHandlers.RegisterCVExtractRoutes(app, webhookSignature, idempotency)
 }
@@ -0,0 +1,28 @@
+-- Incremental sync state of each scanned Google Drive folder
+CREATE TABLE drive_sync_folders (
+    folder_id VARCHAR(255) PRIMARY KEY,
+    folder_name VARCHAR(255),
+    recursive BOOLEAN NOT NULL DEFAULT FALSE,
+    change_token VARCHAR(64), -- RFC 3339 modifiedTime; the next scan lists files modified after it
+    last_extract_request_id VARCHAR(255),
+    last_scan_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Outcome of the last processing of each Drive file
+CREATE TABLE drive_sync_files (
+    file_id VARCHAR(255) PRIMARY KEY,
+    folder_id VARCHAR(255) NOT NULL REFERENCES drive_sync_folders(folder_id) ON DELETE CASCADE,
+    file_name VARCHAR(500) NOT NULL,
+    mime_type VARCHAR(255),
+    file_size BIGINT,
+    modified_time TIMESTAMP NOT NULL, -- Drive modifiedTime of the processed revision (UTC)
+    status VARCHAR(20) NOT NULL, -- processed, failed
+    employee_id INTEGER REFERENCES employees(id) ON DELETE SET NULL,
+    error_message TEXT,
+    processed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_drive_sync_files_folder_id ON drive_sync_files(folder_id);
+CREATE INDEX idx_drive_sync_files_status ON drive_sync_files(status);
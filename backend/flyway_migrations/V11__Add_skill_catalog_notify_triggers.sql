@@ -0,0 +1,21 @@
+-- Notify listeners on channel skill_catalog_changed whenever skills, categories or their links
+-- change, so every server instance refreshes its skill extraction cache. The payload is the
+-- changed table; notifications are delivered when the transaction commits.
+CREATE OR REPLACE FUNCTION notify_skill_catalog_changed() RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM pg_notify('skill_catalog_changed', TG_TABLE_NAME);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER skills_catalog_changed
+    AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON skills
+    FOR EACH STATEMENT EXECUTE FUNCTION notify_skill_catalog_changed();
+
+CREATE TRIGGER categories_catalog_changed
+    AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON categories
+    FOR EACH STATEMENT EXECUTE FUNCTION notify_skill_catalog_changed();
+
+CREATE TRIGGER skills_categories_catalog_changed
+    AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON skills_categories
+    FOR EACH STATEMENT EXECUTE FUNCTION notify_skill_catalog_changed();
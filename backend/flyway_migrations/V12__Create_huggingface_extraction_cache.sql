@@ -0,0 +1,10 @@
+-- Hugging Face extraction results shared by all instances, so repeated resumes skip the model
+CREATE TABLE huggingface_extraction_cache (
+    cache_key VARCHAR(64) PRIMARY KEY, -- SHA-256 of the text, model and confidence threshold
+    model_name VARCHAR(255) NOT NULL,
+    response JSONB NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_huggingface_extraction_cache_expires_at ON huggingface_extraction_cache(expires_at);
@@ -0,0 +1,63 @@
+-- Resumes without an email address create employees without one
+ALTER TABLE employees ALTER COLUMN email DROP NOT NULL;
+
+-- Normalized identifiers of each employee, collected from every resume, to recognise the
+-- same person in later resumes
+CREATE TABLE employee_identities (
+    employee_id INTEGER NOT NULL REFERENCES employees(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL, -- email, phone, linkedin, github, fingerprint
+    value VARCHAR(255) NOT NULL, -- Lowercase email or handle, last 10 phone digits, SimHash of the text
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (employee_id, kind, value)
+);
+
+CREATE INDEX idx_employee_identities_value ON employee_identities(value);
+
+-- Pairs of employees that are probably the same person, awaiting review
+CREATE TABLE employee_duplicates (
+    id SERIAL PRIMARY KEY,
+    employee_id INTEGER NOT NULL REFERENCES employees(id) ON DELETE CASCADE, -- The lower ID of the pair
+    duplicate_id INTEGER NOT NULL REFERENCES employees(id) ON DELETE CASCADE,
+    score DECIMAL(4,3) NOT NULL,
+    reasons JSONB NOT NULL DEFAULT '[]',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, dismissed
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (employee_id, duplicate_id),
+    CHECK (employee_id < duplicate_id)
+);
+
+CREATE INDEX idx_employee_duplicates_status ON employee_duplicates(status);
+CREATE INDEX idx_employee_duplicates_duplicate_id ON employee_duplicates(duplicate_id);
+
+-- Employees merged into another; the merged employee is deleted, so it is not a reference
+CREATE TABLE employee_merges (
+    id SERIAL PRIMARY KEY,
+    employee_id INTEGER NOT NULL REFERENCES employees(id) ON DELETE CASCADE, -- The employee kept
+    merged_employee_id INTEGER NOT NULL,
+    merged_name VARCHAR(255) NOT NULL,
+    merged_email VARCHAR(255),
+    score DECIMAL(4,3),
+    reasons JSONB NOT NULL DEFAULT '[]',
+    automatic BOOLEAN NOT NULL DEFAULT FALSE,
+    merged_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_employee_merges_employee_id ON employee_merges(employee_id);
+
+-- Identifiers of existing employees: their email and the phone of their last extraction
+INSERT INTO employee_identities (employee_id, kind, value)
+SELECT id, 'email', LOWER(TRIM(email))
+FROM employees
+WHERE email IS NOT NULL AND email LIKE '%_@_%'
+ON CONFLICT DO NOTHING;
+
+INSERT INTO employee_identities (employee_id, kind, value)
+SELECT id, 'phone', RIGHT(digits, 10)
+FROM (
+    SELECT id, REGEXP_REPLACE(extracted_data->'contact_info'->>'phone', '[^0-9]', '', 'g') AS digits
+    FROM employees
+    WHERE extracted_data->'contact_info'->>'phone' IS NOT NULL
+) phones
+WHERE LENGTH(digits) >= 7
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,27 @@
+-- Every extraction run stored for an employee, oldest first by version; employees.extracted_data
+-- keeps the latest for search
+CREATE TABLE employee_extractions (
+    id SERIAL PRIMARY KEY,
+    employee_id INTEGER NOT NULL REFERENCES employees(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL, -- 1, 2, ... per employee
+    source VARCHAR(100), -- Where the resume came from, as employees.extraction_source
+    resume_url VARCHAR(500),
+    extractor_method VARCHAR(100), -- e.g. database_dictionary, ner+huggingface
+    extractor_version INTEGER, -- Extraction code version; NULL for runs before versions were recorded
+    original_text TEXT,
+    extracted_data JSONB,
+    status VARCHAR(50) NOT NULL DEFAULT 'completed',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    -- Deferrable so merges can renumber the versions of two employees in one statement
+    CONSTRAINT employee_extractions_employee_version_key UNIQUE (employee_id, version) DEFERRABLE INITIALLY IMMEDIATE
+);
+
+CREATE INDEX idx_employee_extractions_employee_id ON employee_extractions(employee_id);
+
+-- The extraction each employee has today becomes its first version
+INSERT INTO employee_extractions (employee_id, version, source, resume_url, extractor_method, original_text,
+                                  extracted_data, status, created_at)
+SELECT id, 1, extraction_source, resume_url, extracted_data->>'extraction_method', original_text,
+       extracted_data, COALESCE(extraction_status, 'completed'), COALESCE(extraction_timestamp, updated_at)
+FROM employees
+WHERE extracted_data IS NOT NULL;
@@ -0,0 +1,21 @@
+-- Extractions held for a reviewer instead of being stored as employees, because their
+-- confidence was low or the candidate name looked wrong
+CREATE TABLE extraction_reviews (
+    id SERIAL PRIMARY KEY,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, approved, rejected
+    reasons JSONB NOT NULL DEFAULT '[]', -- Why the extraction was held
+    confidence_score DECIMAL(4,3),
+    candidate_name VARCHAR(255),
+    extraction_source VARCHAR(100),
+    resume_url VARCHAR(500),
+    original_text TEXT,
+    extracted_data JSONB NOT NULL, -- Edited in place by reviewers before approval
+    employee_id INTEGER REFERENCES employees(id) ON DELETE SET NULL, -- Employee stored on approval
+    reviewed_by VARCHAR(255),
+    review_notes TEXT,
+    reviewed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_extraction_reviews_status ON extraction_reviews(status);
@@ -0,0 +1,42 @@
+-- Other names of catalog skills, matched by the skill extractor like the skill's own name
+CREATE TABLE skill_aliases (
+    id SERIAL PRIMARY KEY,
+    skill_id INTEGER NOT NULL REFERENCES skills(id) ON DELETE CASCADE,
+    alias VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_skill_aliases_alias ON skill_aliases(LOWER(alias));
+CREATE INDEX idx_skill_aliases_skill_id ON skill_aliases(skill_id);
+
+CREATE TRIGGER skill_aliases_catalog_changed
+    AFTER INSERT OR UPDATE OR DELETE OR TRUNCATE ON skill_aliases
+    FOR EACH STATEMENT EXECUTE FUNCTION notify_skill_catalog_changed();
+
+-- Terms found in resumes that look like skills but are not in the catalog, awaiting a decision:
+-- add them as a skill, map them to an existing skill as an alias, or ignore them
+CREATE TABLE discovered_skill_terms (
+    id SERIAL PRIMARY KEY,
+    term VARCHAR(100) NOT NULL, -- As first written
+    normalized_term VARCHAR(100) NOT NULL UNIQUE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- pending, approved, aliased, ignored
+    occurrences INTEGER NOT NULL DEFAULT 0, -- Extractions that found the term
+    sources TEXT[] NOT NULL DEFAULT '{}', -- skills_section, huggingface
+    examples JSONB NOT NULL DEFAULT '[]', -- A few contexts the term was found in
+    skill_id INTEGER REFERENCES skills(id) ON DELETE SET NULL, -- The skill created or aliased
+    resolved_by VARCHAR(255),
+    resolved_at TIMESTAMP,
+    first_seen_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_discovered_skill_terms_status ON discovered_skill_terms(status, occurrences DESC);
+
+-- Employees whose resumes mention a discovered term, re-extracted when the term is resolved
+CREATE TABLE discovered_skill_term_employees (
+    term_id INTEGER NOT NULL REFERENCES discovered_skill_terms(id) ON DELETE CASCADE,
+    employee_id INTEGER NOT NULL REFERENCES employees(id) ON DELETE CASCADE,
+    PRIMARY KEY (term_id, employee_id)
+);
+
+CREATE INDEX idx_discovered_skill_term_employees_employee_id ON discovered_skill_term_employees(employee_id);
@@ -0,0 +1,11 @@
+-- Outcome for each employee of a re-extraction run, tracked as a cv_extract record. Employees
+-- with a result are skipped when an interrupted run is resumed.
+CREATE TABLE reextraction_results (
+    cv_extract_id INTEGER NOT NULL REFERENCES cv_extract(id) ON DELETE CASCADE,
+    employee_id INTEGER NOT NULL REFERENCES employees(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL, -- changed, unchanged, failed
+    changes JSONB, -- Diff against the employee's latest extraction
+    error_message TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (cv_extract_id, employee_id)
+);
@@ -0,0 +1,15 @@
+-- Structured contact details of each employee, taken from their resume. Existing employees get
+-- them when re-extracted (go run ./cmd/reextract).
+ALTER TABLE employees
+ADD COLUMN phone VARCHAR(20), -- E.164, e.g. +34612345678
+ADD COLUMN city VARCHAR(100),
+ADD COLUMN region VARCHAR(100),
+ADD COLUMN country CHAR(2), -- ISO 3166-1 alpha-2
+ADD COLUMN linkedin_url VARCHAR(255),
+ADD COLUMN github_url VARCHAR(255),
+ADD COLUMN gitlab_url VARCHAR(255),
+ADD COLUMN stackoverflow_url VARCHAR(255),
+ADD COLUMN website_url VARCHAR(500);
+
+CREATE INDEX idx_employees_city ON employees(LOWER(city));
+CREATE INDEX idx_employees_country ON employees(country);
@@ -0,0 +1,11 @@
+-- Human languages each employee speaks, with the level their resume states
+CREATE TABLE employee_languages (
+    employee_id INTEGER NOT NULL REFERENCES employees(id) ON DELETE CASCADE,
+    language_code VARCHAR(3) NOT NULL, -- ISO 639-1, e.g. en
+    name VARCHAR(50) NOT NULL, -- In English
+    level VARCHAR(6), -- CEFR A1-C2 or native; NULL when not stated
+    PRIMARY KEY (employee_id, language_code),
+    CHECK (level IN ('A1', 'A2', 'B1', 'B2', 'C1', 'C2', 'native'))
+);
+
+CREATE INDEX idx_employee_languages_language ON employee_languages(language_code, level);
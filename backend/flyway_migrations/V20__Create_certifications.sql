@@ -0,0 +1,65 @@
+-- Certifications catalogue and the certifications each employee holds, taken from their resume
+-- or entered by hand. Existing employees get theirs when re-extracted (go run ./cmd/reextract).
+
+CREATE TABLE certifications (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(200) NOT NULL UNIQUE,
+    issuer VARCHAR(200) NOT NULL,
+    aliases TEXT[] NOT NULL DEFAULT '{}', -- Other names resumes use, e.g. exam codes
+    validity_months INTEGER CHECK (validity_months > 0), -- NULL when it does not expire
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE employee_certifications (
+    id SERIAL PRIMARY KEY,
+    employee_id INTEGER NOT NULL REFERENCES employees(id) ON DELETE CASCADE,
+    certification_id INTEGER REFERENCES certifications(id) ON DELETE SET NULL,
+    name VARCHAR(255) NOT NULL, -- As written in the resume or entered
+    issuer VARCHAR(200),
+    credential_id VARCHAR(255),
+    issued_on DATE,
+    expires_on DATE,
+    source VARCHAR(20) NOT NULL DEFAULT 'manual' CHECK (source IN ('resume', 'manual')),
+    expiry_notified_for DATE, -- The expiry date last announced, so each expiry is announced once
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_employee_certifications_name ON employee_certifications(employee_id, LOWER(name));
+CREATE INDEX idx_employee_certifications_certification ON employee_certifications(certification_id);
+CREATE INDEX idx_employee_certifications_expires_on ON employee_certifications(expires_on);
+
+-- Certifications clients ask for most
+INSERT INTO certifications (name, issuer, aliases, validity_months) VALUES
+('AWS Certified Cloud Practitioner', 'Amazon Web Services', '{"AWS Cloud Practitioner", "CLF-C02"}', 36),
+('AWS Certified Solutions Architect - Associate', 'Amazon Web Services', '{"AWS Solutions Architect Associate", "AWS SAA", "SAA-C03"}', 36),
+('AWS Certified Solutions Architect - Professional', 'Amazon Web Services', '{"AWS Solutions Architect Professional", "AWS SAP", "SAP-C02"}', 36),
+('AWS Certified Developer - Associate', 'Amazon Web Services', '{"AWS Developer Associate", "DVA-C02"}', 36),
+('AWS Certified SysOps Administrator - Associate', 'Amazon Web Services', '{"AWS SysOps Administrator Associate", "SOA-C02"}', 36),
+('AWS Certified DevOps Engineer - Professional', 'Amazon Web Services', '{"AWS DevOps Engineer Professional", "DOP-C02"}', 36),
+('Microsoft Certified: Azure Fundamentals', 'Microsoft', '{"Azure Fundamentals", "AZ-900"}', NULL),
+('Microsoft Certified: Azure Administrator Associate', 'Microsoft', '{"Azure Administrator Associate", "AZ-104"}', 12),
+('Microsoft Certified: Azure Developer Associate', 'Microsoft', '{"Azure Developer Associate", "AZ-204"}', 12),
+('Microsoft Certified: Azure Solutions Architect Expert', 'Microsoft', '{"Azure Solutions Architect Expert", "AZ-305"}', 12),
+('Google Cloud Certified Associate Cloud Engineer', 'Google Cloud', '{"Associate Cloud Engineer", "GCP Associate Cloud Engineer"}', 36),
+('Google Cloud Certified Professional Cloud Architect', 'Google Cloud', '{"Professional Cloud Architect", "GCP Professional Cloud Architect"}', 24),
+('Certified Kubernetes Administrator (CKA)', 'The Linux Foundation', '{"Certified Kubernetes Administrator", "CKA"}', 24),
+('Certified Kubernetes Application Developer (CKAD)', 'The Linux Foundation', '{"Certified Kubernetes Application Developer", "CKAD"}', 24),
+('HashiCorp Certified: Terraform Associate', 'HashiCorp', '{"Terraform Associate"}', 24),
+('Professional Scrum Master I (PSM I)', 'Scrum.org', '{"Professional Scrum Master", "PSM I", "PSM 1"}', NULL),
+('Professional Scrum Product Owner I (PSPO I)', 'Scrum.org', '{"Professional Scrum Product Owner", "PSPO I", "PSPO 1"}', NULL),
+('Certified ScrumMaster (CSM)', 'Scrum Alliance', '{"Certified ScrumMaster", "Certified Scrum Master", "CSM"}', 24),
+('Certified Scrum Product Owner (CSPO)', 'Scrum Alliance', '{"Certified Scrum Product Owner", "CSPO"}', 24),
+('SAFe Agilist', 'Scaled Agile', '{"Leading SAFe", "SAFe Agile"}', 12),
+('Project Management Professional (PMP)', 'Project Management Institute', '{"Project Management Professional", "PMP"}', 36),
+('PRINCE2 Foundation', 'PeopleCert', '{"PRINCE 2 Foundation"}', NULL),
+('PRINCE2 Practitioner', 'PeopleCert', '{"PRINCE 2 Practitioner"}', 36),
+('ITIL 4 Foundation', 'PeopleCert', '{"ITIL Foundation", "ITIL v4 Foundation"}', NULL),
+('Oracle Certified Professional: Java SE Developer', 'Oracle', '{"Oracle Certified Professional Java", "OCP Java", "OCPJP"}', NULL),
+('ISTQB Certified Tester Foundation Level', 'ISTQB', '{"ISTQB Foundation", "ISTQB CTFL", "CTFL"}', NULL),
+('Certified Information Systems Security Professional (CISSP)', 'ISC2', '{"CISSP"}', 36),
+('CompTIA Security+', 'CompTIA', '{}', 36),
+('Red Hat Certified System Administrator (RHCSA)', 'Red Hat', '{"Red Hat Certified System Administrator", "RHCSA"}', 36),
+('Red Hat Certified Engineer (RHCE)', 'Red Hat', '{"Red Hat Certified Engineer", "RHCE"}', 36),
+('Cisco Certified Network Associate (CCNA)', 'Cisco', '{"Cisco Certified Network Associate", "CCNA"}', 36);
@@ -0,0 +1,47 @@
+-- Work history, education and projects of each employee, as extracted from their resume, in the
+-- order the resume lists them. Existing employees get theirs when re-extracted
+-- (go run ./cmd/reextract).
+
+CREATE TABLE employee_positions (
+    id SERIAL PRIMARY KEY,
+    employee_id INTEGER NOT NULL REFERENCES employees(id) ON DELETE CASCADE,
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    company TEXT,
+    title TEXT,
+    start_date DATE, -- The first day of the month, or of the year when the resume gives only years
+    end_date DATE, -- The last day of the month or year; NULL for the current position
+    is_current BOOLEAN NOT NULL DEFAULT FALSE,
+    description TEXT,
+    skills TEXT[] NOT NULL DEFAULT '{}', -- Catalog skills the title or description mentions
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_employee_positions_employee ON employee_positions(employee_id, sort_order);
+CREATE INDEX idx_employee_positions_company ON employee_positions(LOWER(company));
+
+CREATE TABLE employee_education (
+    id SERIAL PRIMARY KEY,
+    employee_id INTEGER NOT NULL REFERENCES employees(id) ON DELETE CASCADE,
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    institution TEXT,
+    degree TEXT,
+    field TEXT, -- Field of study, e.g. Computer Science
+    start_year INTEGER,
+    end_year INTEGER, -- NULL while studying or when not stated
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_employee_education_employee ON employee_education(employee_id, sort_order);
+
+CREATE TABLE employee_projects (
+    id SERIAL PRIMARY KEY,
+    employee_id INTEGER NOT NULL REFERENCES employees(id) ON DELETE CASCADE,
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    name TEXT NOT NULL,
+    role TEXT,
+    description TEXT,
+    technologies TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_employee_projects_employee ON employee_projects(employee_id, sort_order);
@@ -0,0 +1,12 @@
+-- Whether the resume gives only years for a position. Its dates are stored as the first day of
+-- the start year and the last day of the end year, which would otherwise read as January to
+-- December; search counts "2016 - 2018" as two years, like the per-skill years do.
+
+ALTER TABLE employee_positions ADD COLUMN dates_by_year BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Positions stored before this column have no precision. A finished position from January 1
+-- to December 31 was almost always written with years only; re-extraction stores the exact one.
+UPDATE employee_positions
+SET dates_by_year = TRUE
+WHERE EXTRACT(MONTH FROM start_date) = 1 AND EXTRACT(DAY FROM start_date) = 1
+  AND EXTRACT(MONTH FROM end_date) = 12 AND EXTRACT(DAY FROM end_date) = 31;
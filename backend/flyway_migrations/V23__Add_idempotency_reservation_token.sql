@@ -0,0 +1,4 @@
+-- Each reservation of an idempotency key gets its own token. When a retry takes over a key
+-- whose request outlived its lease, the late request can no longer store or release the key.
+
+ALTER TABLE idempotency_keys ADD COLUMN reservation_token VARCHAR(36);
@@ -0,0 +1,18 @@
+-- Files reported without a number are numbered from a sequence, negated, so concurrent reports
+-- never compete for the same number.
+CREATE SEQUENCE cv_extract_unnumbered_file_seq;
+
+-- File counts are recalculated from cv_extract_files. Extracts counted before that table existed
+-- get a row for each file it does not yet account for, so their counts are kept.
+INSERT INTO cv_extract_files (cv_extract_id, file_number, status)
+SELECT e.id, -nextval('cv_extract_unnumbered_file_seq'), missing.status
+FROM cv_extract e
+CROSS JOIN LATERAL (
+    SELECT 'processed' AS status,
+           e.files_processed - (SELECT COUNT(*) FROM cv_extract_files f WHERE f.cv_extract_id = e.id AND f.status = 'processed') AS files
+    UNION ALL
+    SELECT 'failed',
+           e.files_failed - (SELECT COUNT(*) FROM cv_extract_files f WHERE f.cv_extract_id = e.id AND f.status = 'failed')
+) missing
+CROSS JOIN LATERAL generate_series(1, GREATEST(missing.files, 0))
+ORDER BY e.id;
@@ -0,0 +1,5 @@
+-- Add per-integration HMAC signing secrets to API keys
+-- The secret must be readable by the server to verify signatures, so it is stored as issued
+-- and only ever returned once, when it is created or rotated
+ALTER TABLE api_keys ADD COLUMN signing_secret VARCHAR(255);
+ALTER TABLE api_keys ADD COLUMN signing_secret_created_at TIMESTAMP;
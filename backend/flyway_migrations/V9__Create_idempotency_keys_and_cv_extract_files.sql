@@ -0,0 +1,30 @@
+-- Stored responses for requests sent with an Idempotency-Key header
+CREATE TABLE idempotency_keys (
+    id SERIAL PRIMARY KEY,
+    idempotency_key VARCHAR(255) NOT NULL,
+    scope VARCHAR(128) NOT NULL, -- SHA-256 of method, path and caller credentials
+    request_fingerprint VARCHAR(64) NOT NULL, -- SHA-256 of the request body
+    status VARCHAR(20) NOT NULL DEFAULT 'processing', -- processing, completed
+    response_status INTEGER,
+    response_content_type VARCHAR(255),
+    response_body BYTEA,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    UNIQUE (idempotency_key, scope)
+);
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
+
+-- Outcome of each file in a CV extract batch, so progress updates can be repeated safely
+CREATE TABLE cv_extract_files (
+    id SERIAL PRIMARY KEY,
+    cv_extract_id INTEGER NOT NULL REFERENCES cv_extract(id) ON DELETE CASCADE,
+    file_number INTEGER NOT NULL, -- Negative numbers are assigned to files reported without a number
+    status VARCHAR(20) NOT NULL, -- processed, failed
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (cv_extract_id, file_number)
+);
+
+CREATE INDEX idx_cv_extract_files_cv_extract_id ON cv_extract_files(cv_extract_id);
@@ -20,6 +20,17 @@ const (
 	ErrorCodeInvalidAuthFormat   = "INVALID_AUTH_FORMAT"
 	ErrorCodeInvalidServiceToken = "INVALID_SERVICE_TOKEN"
 
+	// Webhook signature errors
+	ErrorCodeMissingSignature = "MISSING_SIGNATURE"
+	ErrorCodeInvalidSignature = "INVALID_SIGNATURE"
+	ErrorCodeExpiredSignature = "EXPIRED_SIGNATURE"
+	ErrorCodeReplayedRequest  = "REPLAYED_REQUEST"
+
+	// Idempotency errors
+	ErrorCodeInvalidIdempotencyKey    = "INVALID_IDEMPOTENCY_KEY"
+	ErrorCodeIdempotencyKeyReused     = "IDEMPOTENCY_KEY_REUSED"
+	ErrorCodeIdempotencyKeyInProgress = "IDEMPOTENCY_KEY_IN_PROGRESS"
+
 	// Validation errors
 	ErrorCodeInvalidID        = "INVALID_ID"
 	ErrorCodeValidationFailed = "VALIDATION_FAILED"
@@ -40,6 +51,18 @@ const (
 	MsgInvalidAuthFormat   = "Invalid authorization format"
 	MsgInvalidServiceToken = "Invalid service token"
 
+	// Webhook signature messages
+	MsgSignatureRequired   = "Request signature required"
+	MsgInvalidSignature    = "Invalid request signature"
+	MsgExpiredSignature    = "Request timestamp outside the allowed window"
+	MsgReplayedRequest     = "Request signature already used"
+	MsgSigningSecretIssued = "Signing secret created successfully. Save it - it won't be shown again!"
+
+	// Idempotency messages
+	MsgInvalidIdempotencyKey    = "Idempotency-Key must be between 1 and 255 characters"
+	MsgIdempotencyKeyReused     = "Idempotency-Key was already used with a different request body"
+	MsgIdempotencyKeyInProgress = "A request with this Idempotency-Key is still being processed"
+
 	// Validation messages
 	MsgInvalidID        = "Invalid ID"
 	MsgInvalidAPIKeyID  = "Invalid API key ID"
@@ -77,6 +100,9 @@ const (
 
 	// Flyway configuration
 	DefaultFlywayLocations = "./flyway_migrations"
+
+	// LISTEN/NOTIFY channel raised by triggers on skills, categories and skills_categories
+	SkillCatalogChannel = "skill_catalog_changed"
 )
 
 // Environment Variables
@@ -104,6 +130,39 @@ const (
 	EnvSMTPPass          = "SMTP_PASS"
 	EnvAdminEmail        = "ADMIN_EMAIL"
 	EnvHuggingFaceAPIKey = "HUGGINGFACE_API_KEY"
+
+	EnvHuggingFaceAPIURL        = "HUGGINGFACE_API_URL"        // Optional: override the inference API base URL
+	EnvHuggingFaceMaxConcurrent = "HUGGINGFACE_MAX_CONCURRENT" // Requests in flight at once; 0 for no limit
+	EnvHuggingFaceRedact        = "HUGGINGFACE_REDACT_PII"     // all (default), off, or a list such as email,phone,name
+
+	EnvHuggingFaceCacheSize       = "HUGGINGFACE_CACHE_SIZE"        // Extraction results kept in memory
+	EnvHuggingFaceCacheTTLMinutes = "HUGGINGFACE_CACHE_TTL_MINUTES" // How long an extraction result is reused
+	EnvHuggingFaceCachePersist    = "HUGGINGFACE_CACHE_PERSIST"     // true to share extraction results through the database
+
+	EnvWebhookSignatureMode      = "WEBHOOK_SIGNATURE_MODE"      // off, optional or required
+	EnvWebhookSignatureTolerance = "WEBHOOK_SIGNATURE_TOLERANCE" // seconds
+	EnvIdempotencyTTLHours       = "IDEMPOTENCY_TTL_HOURS"
+	EnvIdempotencyLeaseMinutes   = "IDEMPOTENCY_LEASE_MINUTES" // How long an unfinished request holds its key
+
+	EnvGoogleDriveCredentialsPath = "GOOGLE_DRIVE_CREDENTIALS_PATH" // Service account JSON key
+	EnvGoogleDriveAccessToken     = "GOOGLE_DRIVE_ACCESS_TOKEN"     // Optional: fixed OAuth token instead of a service account
+	EnvGoogleDriveAPIURL          = "GOOGLE_DRIVE_API_URL"          // Optional: override the Drive API base URL
+
+	EnvResumeWatchDirs     = "RESUME_WATCH_DIRS"     // Comma-separated directories for cmd/resume-watcher
+	EnvResumeWatchInterval = "RESUME_WATCH_INTERVAL" // seconds
+
+	EnvEnsembleWeights        = "ENSEMBLE_WEIGHTS"         // Per-method weights, e.g. dictionary=1,huggingface=0.6
+	EnvEnsembleAgreementBoost = "ENSEMBLE_AGREEMENT_BOOST" // 0-1; share of the remaining doubt removed when methods agree
+	EnvEnsembleMinScore       = "ENSEMBLE_MIN_SCORE"       // 0-1; lowest score of a stored skill
+
+	EnvDuplicateReviewThreshold    = "DUPLICATE_REVIEW_THRESHOLD"     // 0-1; lowest score of a pair flagged for review
+	EnvDuplicateAutoMergeThreshold = "DUPLICATE_AUTO_MERGE_THRESHOLD" // 0-1; lowest score at which a resume updates the matched employee
+
+	EnvReviewConfidenceThreshold = "REVIEW_CONFIDENCE_THRESHOLD" // 0-1; extractions below it are held for review, 0 to store all
+
+	EnvDefaultPhoneCountry = "DEFAULT_PHONE_COUNTRY" // ISO country code, e.g. ES, for national phone numbers of resumes without a location
+
+	EnvCertificationExpiryNoticeDays = "CERTIFICATION_EXPIRY_NOTICE_DAYS" // Days ahead that certification expiries are announced; 0 to turn the notices off
 )
 
 // Development defaults
@@ -131,6 +190,76 @@ const (
 	HeaderAPIKey        = "X-API-Key"
 	HeaderAuthorization = "Authorization"
 	HeaderBearer        = "Bearer"
+
+	HeaderSignatureKeyID     = "X-Signature-Key-Id"
+	HeaderSignatureTimestamp = "X-Signature-Timestamp"
+	HeaderSignature          = "X-Signature"
+
+	HeaderIdempotencyKey     = "Idempotency-Key"
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+)
+
+// Idempotency settings
+const (
+	DefaultIdempotencyTTLHours     = 24
+	DefaultIdempotencyLeaseMinutes = 5
+	MaxIdempotencyKeyLength        = 255
+)
+
+// Skill ensemble settings
+const (
+	DefaultEnsembleDictionaryWeight  = 1.0
+	DefaultEnsembleHuggingFaceWeight = 0.6
+	DefaultEnsembleNonCanonicalScale = 0.5 // Applied to the weight of skills outside the catalog
+	DefaultEnsembleAgreementBoost    = 0.3
+	DefaultEnsembleMinScore          = 0.4
+)
+
+// Resume extraction settings
+const (
+	// ExtractorVersion is recorded with every extraction run; bump it when extraction changes
+	// enough that stored resumes are worth extracting again
+	ExtractorVersion = 5
+
+	DefaultReviewConfidenceThreshold = 0.3
+
+	DefaultReextractionConcurrency = 4
+	MaxReextractionConcurrency     = 16
+)
+
+// Skill discovery settings
+const (
+	DefaultSkillTermsLimit = 100
+	MaxSkillTermsLimit     = 500
+)
+
+// Certification settings
+const (
+	DefaultCertificationExpiryNoticeDays = 30
+)
+
+// Duplicate employee detection settings
+const (
+	DefaultDuplicateReviewThreshold    = 0.5
+	DefaultDuplicateAutoMergeThreshold = 0.95 // Above a shared phone with a similar name (0.90)
+)
+
+// Hugging Face extraction cache settings
+const (
+	DefaultHuggingFaceCacheSize       = 1000
+	DefaultHuggingFaceCacheTTLMinutes = 30
+)
+
+// Webhook signature settings
+const (
+	WebhookSignatureModeOff      = "off"      // Signatures are ignored
+	WebhookSignatureModeOptional = "optional" // Signed requests are verified, unsigned requests pass
+	WebhookSignatureModeRequired = "required" // Every request must be signed
+
+	// Used when WEBHOOK_SIGNATURE_MODE is unset, once a signing secret has been issued; until
+	// then no integration can sign, so unsigned requests pass
+	DefaultWebhookSignatureMode      = WebhookSignatureModeRequired
+	DefaultWebhookSignatureTolerance = 300 // seconds
 )
 
 // Context keys
@@ -139,6 +268,8 @@ const (
 	ContextAuthType     = "auth_type"
 	ContextServiceToken = "service_token"
 	ContextRequestID    = "request_id"
+	ContextAPIKeyID     = "api_key_id"
+	ContextIdempotency  = "idempotency_checked"
 )
 
 // NER (Named Entity Recognition) entity types
@@ -282,6 +413,10 @@ const (
 
 	// Location match bonus
 	LocationMatchBonus = 1.0
+
+	// Bonus for dated work history covering the minimum years asked for, which ranks employees
+	// with a known history above those without
+	MinYearsExperienceMatchBonus = 1.5
 )
 
 // Experience level mappings
@@ -394,3 +529,112 @@ var RequiredQueries = []string{
 	"update_skill",
 	"delete_skill",
 }
+
+// Job request parsing
+const (
+	JobRequestLanguageEnglish = "en"
+	JobRequestLanguageSpanish = "es"
+	JobRequestLocationRemote  = "Remote"
+	JobRequestPriorityHigh    = "high"
+	JobRequestPriorityNormal  = "normal"
+	JobRequestIntentSearch    = "search_candidates"
+)
+
+// JobRequestPreferredMarkers flag a clause as "nice to have" rather than required
+var JobRequestPreferredMarkers = []string{
+	"nice to have", "nice-to-have", "is a plus", "a plus", "plus:", "bonus", "preferred", "preferably",
+	"ideally", "desirable", "would be great", "optional",
+	"deseable", "deseables", "preferible", "preferiblemente", "idealmente", "un plus", "valorable",
+	"se valora", "opcional", "suma puntos",
+}
+
+// JobRequestRequiredMarkers switch a list back to required skills
+var JobRequestRequiredMarkers = []string{
+	"required", "must have", "must-have", "requirements", "mandatory",
+	"requerido", "requeridos", "requisitos", "obligatorio", "indispensable", "excluyente",
+}
+
+// JobRequestSeniorityTerms maps seniority words (EN/ES) to ExperienceLevelMap keys
+var JobRequestSeniorityTerms = map[string]string{
+	"intern":              "junior",
+	"trainee":             "junior",
+	"practicante":         "junior",
+	"junior":              "junior",
+	"jr":                  "junior",
+	"mid":                 "mid",
+	"mid-level":           "mid",
+	"mid level":           "mid",
+	"intermediate":        "mid",
+	"semi senior":         "mid",
+	"semi-senior":         "mid",
+	"semisenior":          "mid",
+	"ssr":                 "mid",
+	"intermedio":          "mid",
+	"senior":              "senior",
+	"sr":                  "senior",
+	"lead":                "staff",
+	"tech lead":           "staff",
+	"líder técnico":       "staff",
+	"lider tecnico":       "staff",
+	"staff":               "staff",
+	"principal engineer":  "principal",
+	"principal developer": "principal",
+	"architect":           "principal",
+	"arquitecto":          "principal",
+}
+
+// JobRequestDepartmentTerms maps role keywords (EN/ES) to department categories
+var JobRequestDepartmentTerms = map[string]string{
+	"backend":             SkillCategoryBackend,
+	"back-end":            SkillCategoryBackend,
+	"back end":            SkillCategoryBackend,
+	"frontend":            SkillCategoryFrontend,
+	"front-end":           SkillCategoryFrontend,
+	"front end":           SkillCategoryFrontend,
+	"fullstack":           SkillCategoryFullStack,
+	"full-stack":          SkillCategoryFullStack,
+	"full stack":          SkillCategoryFullStack,
+	"mobile":              SkillCategoryMobile,
+	"móvil":               SkillCategoryMobile,
+	"movil":               SkillCategoryMobile,
+	"devops":              SkillCategoryDevOps,
+	"sre":                 SkillCategoryDevOps,
+	"data engineer":       SkillCategoryData,
+	"data scientist":      SkillCategoryData,
+	"ingeniero de datos":  SkillCategoryData,
+	"científico de datos": SkillCategoryData,
+	"qa":                  SkillCategoryQA,
+	"tester":              SkillCategoryTesting,
+	"security":            SkillCategorySecurity,
+	"seguridad":           SkillCategorySecurity,
+	"designer":            SkillCategoryDesign,
+	"diseñador":           SkillCategoryDesign,
+	"product manager":     SkillCategoryProduct,
+	"machine learning":    SkillCategoryMachineLearning,
+	"ml engineer":         SkillCategoryMachineLearning,
+	"cloud":               SkillCategoryCloud,
+}
+
+// JobRequestUrgencyTerms raise the priority of a job request
+var JobRequestUrgencyTerms = []string{
+	"urgent", "urgently", "asap", "as soon as possible", "immediately",
+	"urgente", "urgentemente", "lo antes posible", "inmediato", "de inmediato", "cuanto antes",
+}
+
+// JobRequestSpanishHints are common Spanish words used to detect the request language
+var JobRequestSpanishHints = []string{
+	"necesito", "necesitamos", "buscamos", "busco", "busca", "años", "con", "para", "que", "desarrollador",
+	"desarrolladora", "ingeniero", "experiencia", "en", "y", "de", "una", "un", "deseable", "conocimientos",
+}
+
+// JobRequestEnglishHints are common English words used to detect the request language
+var JobRequestEnglishHints = []string{
+	"need", "needs", "looking", "for", "with", "years", "the", "and", "developer", "engineer",
+	"experience", "in", "a", "an", "have", "someone", "who", "knowledge",
+}
+
+// JobRequestNumberWords maps spelled-out numbers (EN/ES) to their values
+var JobRequestNumberWords = map[string]float64{
+	"one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10,
+	"un": 1, "uno": 1, "dos": 2, "tres": 3, "cuatro": 4, "cinco": 5, "seis": 6, "siete": 7, "ocho": 8, "nueve": 9, "diez": 10,
+}
@@ -0,0 +1,130 @@
+package contactinfo
+
+import (
+	"regexp"
+	"strings"
+
+	"stafind-backend/internal/identity"
+)
+
+// Links are a candidate's profile links, as canonical https URLs
+type Links struct {
+	LinkedIn      string
+	GitHub        string
+	GitLab        string
+	StackOverflow string
+	Website       string
+}
+
+// headerLines is how many lines from the top of a resume count as its contact header, where
+// an unlabelled link is the candidate's own site
+const headerLines = 10
+
+var (
+	gitLabPattern        = regexp.MustCompile(`(?i)gitlab\.com/([a-z0-9_][a-z0-9_.\-]*)`)
+	stackOverflowPattern = regexp.MustCompile(`(?i)stackoverflow\.com/users/(\d+)(?:/([a-z0-9\-]+))?`)
+	urlPattern           = regexp.MustCompile(`(?i)(?:https?://|www\.)[^\s,;()<>"']+`)
+	domainPattern        = regexp.MustCompile(`(?i)\b[a-z0-9][a-z0-9\-]*(?:\.[a-z0-9\-]+)*\.[a-z]{2,}(?:/[^\s,;()<>"']*)?`)
+	websiteLabel         = regexp.MustCompile(`(?i)\b(?:website|web|portfolio|blog|homepage|personal site|sitio web|página web|pagina web|portafolio)\b`)
+)
+
+// gitLabPaths are gitlab.com paths that are not user profiles
+var gitLabPaths = map[string]bool{
+	"explore": true, "users": true, "help": true, "dashboard": true, "groups": true, "projects": true,
+}
+
+// notWebsites are hosts whose links are not a personal site: social networks, the profiles
+// kept in their own fields, and email providers
+var notWebsites = []string{
+	"linkedin.com", "github.com", "gitlab.com", "stackoverflow.com", "twitter.com", "x.com",
+	"facebook.com", "instagram.com", "youtube.com", "gmail.com", "hotmail.com", "outlook.com",
+	"yahoo.com", "icloud.com", "live.com",
+}
+
+// FindLinks finds the candidate's profile links in resume text. The personal site is taken
+// from a line labelled as such or, failing that, from a link in the contact header.
+func FindLinks(text string) Links {
+	var links Links
+	for _, handle := range identity.ProfileHandles(text) {
+		switch {
+		case handle.Kind == identity.KindLinkedIn && links.LinkedIn == "":
+			links.LinkedIn = "https://www.linkedin.com/in/" + handle.Value
+		case handle.Kind == identity.KindGitHub && links.GitHub == "":
+			links.GitHub = "https://github.com/" + handle.Value
+		}
+	}
+	for _, match := range gitLabPattern.FindAllStringSubmatch(text, -1) {
+		if handle := strings.TrimRight(strings.ToLower(match[1]), "."); !gitLabPaths[handle] {
+			links.GitLab = "https://gitlab.com/" + handle
+			break
+		}
+	}
+	if match := stackOverflowPattern.FindStringSubmatch(text); match != nil {
+		links.StackOverflow = "https://stackoverflow.com/users/" + match[1]
+		if match[2] != "" {
+			links.StackOverflow += "/" + strings.ToLower(match[2])
+		}
+	}
+	links.Website = findWebsite(text)
+	return links
+}
+
+// findWebsite returns the personal site in text, "" if there is none
+func findWebsite(text string) string {
+	lines := strings.Split(text, "\n")
+	for _, line := range lines {
+		if !websiteLabel.MatchString(line) {
+			continue
+		}
+		// A labelled line may write the site without a scheme, as in "Portfolio: jane.dev"
+		for _, match := range domainPattern.FindAllStringIndex(line, -1) {
+			// Skip both halves of an email address
+			if (match[0] > 0 && line[match[0]-1] == '@') || (match[1] < len(line) && line[match[1]] == '@') {
+				continue
+			}
+			if site := canonicalWebsite(line[match[0]:match[1]]); site != "" {
+				return site
+			}
+		}
+	}
+
+	if len(lines) > headerLines {
+		lines = lines[:headerLines]
+	}
+	for _, line := range lines {
+		for _, match := range urlPattern.FindAllString(line, -1) {
+			if site := canonicalWebsite(match); site != "" {
+				return site
+			}
+		}
+	}
+	return ""
+}
+
+// canonicalWebsite returns a link as an https URL with a lowercase host and no trailing slash,
+// or "" when the link is not a personal site
+func canonicalWebsite(link string) string {
+	link = strings.TrimRight(link, ".:/")
+	for _, scheme := range []string{"https://", "http://"} {
+		if len(link) >= len(scheme) && strings.EqualFold(link[:len(scheme)], scheme) {
+			link = link[len(scheme):]
+			break
+		}
+	}
+
+	host, path := link, ""
+	if slash := strings.IndexByte(link, '/'); slash >= 0 {
+		host, path = link[:slash], link[slash:]
+	}
+	host = strings.ToLower(host)
+	bare := strings.TrimPrefix(host, "www.")
+	if !strings.Contains(bare, ".") {
+		return ""
+	}
+	for _, excluded := range notWebsites {
+		if bare == excluded || strings.HasSuffix(bare, "."+excluded) {
+			return ""
+		}
+	}
+	return "https://" + host + path
+}
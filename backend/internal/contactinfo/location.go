@@ -0,0 +1,212 @@
+package contactinfo
+
+import (
+	"regexp"
+	"strings"
+
+	"stafind-backend/internal/identity"
+)
+
+// Location is a place split into its parts. Country is an ISO 3166-1 alpha-2 code.
+type Location struct {
+	City    string
+	Region  string
+	Country string
+}
+
+// country is a country with the names resumes use for it, in English and Spanish
+type country struct {
+	code    string
+	name    string
+	aliases []string
+}
+
+var countries = []country{
+	{"ES", "Spain", []string{"españa", "espana", "spain", "esp"}},
+	{"PT", "Portugal", []string{"portugal"}},
+	{"FR", "France", []string{"france", "francia"}},
+	{"DE", "Germany", []string{"germany", "alemania", "deutschland"}},
+	{"IT", "Italy", []string{"italy", "italia"}},
+	{"GB", "United Kingdom", []string{"united kingdom", "reino unido", "uk", "u k", "england", "inglaterra", "scotland", "escocia", "great britain", "gran bretaña"}},
+	{"IE", "Ireland", []string{"ireland", "irlanda"}},
+	{"NL", "Netherlands", []string{"netherlands", "the netherlands", "holland", "países bajos", "paises bajos", "holanda"}},
+	{"BE", "Belgium", []string{"belgium", "bélgica", "belgica"}},
+	{"CH", "Switzerland", []string{"switzerland", "suiza"}},
+	{"AT", "Austria", []string{"austria"}},
+	{"PL", "Poland", []string{"poland", "polonia"}},
+	{"SE", "Sweden", []string{"sweden", "suecia"}},
+	{"RO", "Romania", []string{"romania", "rumanía", "rumania"}},
+	{"US", "United States", []string{"united states", "united states of america", "usa", "u s a", "us", "u s", "estados unidos", "eeuu", "ee uu"}},
+	{"CA", "Canada", []string{"canada", "canadá"}},
+	{"MX", "Mexico", []string{"mexico", "méxico"}},
+	{"AR", "Argentina", []string{"argentina"}},
+	{"BR", "Brazil", []string{"brazil", "brasil"}},
+	{"CL", "Chile", []string{"chile"}},
+	{"CO", "Colombia", []string{"colombia"}},
+	{"PE", "Peru", []string{"peru", "perú"}},
+	{"VE", "Venezuela", []string{"venezuela"}},
+	{"EC", "Ecuador", []string{"ecuador"}},
+	{"UY", "Uruguay", []string{"uruguay"}},
+	{"PY", "Paraguay", []string{"paraguay"}},
+	{"BO", "Bolivia", []string{"bolivia"}},
+	{"CR", "Costa Rica", []string{"costa rica"}},
+	{"PA", "Panama", []string{"panama", "panamá"}},
+	{"GT", "Guatemala", []string{"guatemala"}},
+	{"CU", "Cuba", []string{"cuba"}},
+	{"IN", "India", []string{"india"}},
+	{"MA", "Morocco", []string{"morocco", "marruecos"}},
+}
+
+// regions are states and regions that place a location in a country without naming it
+var regions = map[string]string{
+	// Spanish autonomous communities
+	"andalucia": "ES", "andalusia": "ES", "aragon": "ES", "asturias": "ES", "baleares": "ES",
+	"islas baleares": "ES", "illes balears": "ES", "canarias": "ES", "islas canarias": "ES",
+	"cantabria": "ES", "castilla y leon": "ES", "castilla la mancha": "ES", "cataluna": "ES",
+	"catalunya": "ES", "catalonia": "ES", "comunidad de madrid": "ES", "comunidad valenciana": "ES",
+	"comunitat valenciana": "ES", "extremadura": "ES", "galicia": "ES", "la rioja": "ES",
+	"navarra": "ES", "pais vasco": "ES", "euskadi": "ES", "region de murcia": "ES",
+	// US states
+	"al": "US", "ak": "US", "az": "US", "ar": "US", "ca": "US", "co": "US", "ct": "US", "de": "US",
+	"fl": "US", "ga": "US", "hi": "US", "id": "US", "il": "US", "in": "US", "ia": "US", "ks": "US",
+	"ky": "US", "la": "US", "me": "US", "md": "US", "ma": "US", "mi": "US", "mn": "US", "ms": "US",
+	"mo": "US", "mt": "US", "ne": "US", "nv": "US", "nh": "US", "nj": "US", "nm": "US", "ny": "US",
+	"nc": "US", "nd": "US", "oh": "US", "ok": "US", "or": "US", "pa": "US", "ri": "US", "sc": "US",
+	"sd": "US", "tn": "US", "tx": "US", "ut": "US", "vt": "US", "va": "US", "wa": "US", "wv": "US",
+	"wi": "US", "wy": "US", "dc": "US",
+	"california": "US", "texas": "US", "new york state": "US", "florida": "US", "washington state": "US",
+	"massachusetts": "US", "illinois": "US", "colorado": "US", "north carolina": "US", "new jersey": "US",
+	// Canadian provinces
+	"ontario": "CA", "quebec": "CA", "british columbia": "CA", "alberta": "CA",
+	"on": "CA", "qc": "CA", "bc": "CA", "ab": "CA",
+}
+
+// cities are cities that place a location in a country on their own; names shared by cities in
+// several countries, such as Córdoba or Santiago, are left out
+var cities = map[string]string{
+	"madrid": "ES", "barcelona": "ES", "valencia": "ES", "sevilla": "ES", "seville": "ES",
+	"bilbao": "ES", "malaga": "ES", "zaragoza": "ES", "alicante": "ES", "granada": "ES",
+	"palma": "ES", "palma de mallorca": "ES", "murcia": "ES", "valladolid": "ES", "vigo": "ES",
+	"a coruna": "ES", "la coruna": "ES", "san sebastian": "ES", "donostia": "ES", "pamplona": "ES",
+	"lisboa": "PT", "lisbon": "PT", "porto": "PT", "oporto": "PT",
+	"london": "GB", "londres": "GB", "manchester": "GB", "edinburgh": "GB", "dublin": "IE",
+	"paris": "FR", "berlin": "DE", "munich": "DE", "munchen": "DE", "hamburg": "DE",
+	"amsterdam": "NL", "brussels": "BE", "bruselas": "BE", "zurich": "CH", "vienna": "AT",
+	"milan": "IT", "milano": "IT", "rome": "IT", "roma": "IT", "warsaw": "PL", "stockholm": "SE",
+	"new york": "US", "nueva york": "US", "san francisco": "US", "los angeles": "US",
+	"seattle": "US", "boston": "US", "chicago": "US", "austin": "US", "miami": "US",
+	"toronto": "CA", "vancouver": "CA", "montreal": "CA",
+	"mexico city": "MX", "ciudad de mexico": "MX", "cdmx": "MX", "guadalajara": "MX", "monterrey": "MX",
+	"buenos aires": "AR", "rosario": "AR", "mendoza": "AR",
+	"bogota": "CO", "medellin": "CO", "cali": "CO", "barranquilla": "CO",
+	"lima": "PE", "caracas": "VE", "quito": "EC", "guayaquil": "EC", "montevideo": "UY",
+	"asuncion": "PY", "la paz": "BO", "santa cruz de la sierra": "BO",
+	"sao paulo": "BR", "rio de janeiro": "BR", "la habana": "CU", "havana": "CU",
+	"bangalore": "IN", "bengaluru": "IN", "mumbai": "IN", "casablanca": "MA", "rabat": "MA",
+}
+
+// notPlaces are location parts that describe how rather than where someone works
+var notPlaces = map[string]bool{
+	"remote": true, "remoto": true, "hybrid": true, "hibrido": true, "teletrabajo": true,
+	"not specified": true, "not provided": true, "n a": true,
+}
+
+var (
+	locationSeparators = regexp.MustCompile(`\s*(?:,|;|\||/|\s-\s|\s–\s)\s*`)
+	locationParens     = regexp.MustCompile(`\s*\([^)]*\)`)
+)
+
+// countryCodes maps the folded names and codes of every country to its code
+var countryCodes = func() map[string]string {
+	codes := make(map[string]string)
+	for _, c := range countries {
+		codes[strings.ToLower(c.code)] = c.code
+		codes[identity.NormalizeName(c.name)] = c.code
+		for _, alias := range c.aliases {
+			codes[identity.NormalizeName(alias)] = c.code
+		}
+	}
+	return codes
+}()
+
+// CountryCode returns the ISO code of a country given by name, in English or Spanish, or by
+// code; "" when the country is unknown
+func CountryCode(name string) string {
+	return countryCodes[identity.NormalizeName(name)]
+}
+
+// CountryName returns the English name of a country code
+func CountryName(code string) string {
+	for _, c := range countries {
+		if strings.EqualFold(c.code, code) {
+			return c.name
+		}
+	}
+	return ""
+}
+
+// ParseLocation splits a location such as "Madrid, Spain", "Austin, TX" or "Barcelona,
+// Cataluña, España" into city, region and country. Parts such as "Remote" are ignored; the
+// country is inferred from well-known regions and cities when it is not written.
+func ParseLocation(text string) Location {
+	var parts []string
+	for _, part := range locationSeparators.Split(locationParens.ReplaceAllString(text, ""), -1) {
+		part = strings.Trim(strings.TrimSpace(part), ".")
+		if part != "" && !notPlaces[identity.NormalizeName(part)] {
+			parts = append(parts, part)
+		}
+	}
+
+	var location Location
+	if len(parts) == 0 {
+		return location
+	}
+
+	// The country comes last
+	if code := lastPartCountry(parts); code != "" {
+		location.Country = code
+		parts = parts[:len(parts)-1]
+	}
+
+	switch len(parts) {
+	case 0:
+	case 1:
+		if code, isRegion := regions[identity.NormalizeName(parts[0])]; isRegion && len(parts[0]) > 2 && cities[identity.NormalizeName(parts[0])] == "" {
+			location.Region = parts[0]
+			if location.Country == "" {
+				location.Country = code
+			}
+		} else {
+			location.City = parts[0]
+		}
+	default:
+		location.City = parts[0]
+		location.Region = parts[len(parts)-1]
+	}
+
+	if location.Country == "" && location.Region != "" {
+		location.Country = regions[identity.NormalizeName(location.Region)]
+	}
+	if location.Country == "" && location.City != "" {
+		location.Country = cities[identity.NormalizeName(location.City)]
+	}
+	return location
+}
+
+// lastPartCountry returns the country named by the last part of a location. Two-letter parts
+// after a city are read as state codes rather than country codes, as in "Austin, CA", unless
+// the city is a known one of that country, as in "Bogotá, CO" or "Berlin, DE".
+func lastPartCountry(parts []string) string {
+	last := parts[len(parts)-1]
+	code := CountryCode(last)
+	if code == "" || len(parts) == 1 || len(last) != 2 {
+		return code
+	}
+	if _, isRegion := regions[strings.ToLower(last)]; !isRegion {
+		return code
+	}
+	if cities[identity.NormalizeName(parts[0])] == code {
+		return code
+	}
+	return ""
+}
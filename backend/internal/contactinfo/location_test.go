@@ -0,0 +1,54 @@
+package contactinfo
+
+import "testing"
+
+func TestParseLocation(t *testing.T) {
+	tests := []struct {
+		text string
+		want Location
+	}{
+		{"Madrid, Spain", Location{City: "Madrid", Country: "ES"}},
+		{"Barcelona, Cataluña, España", Location{City: "Barcelona", Region: "Cataluña", Country: "ES"}},
+		{"Sevilla", Location{City: "Sevilla", Country: "ES"}},
+		{"Andalucía", Location{Region: "Andalucía", Country: "ES"}},
+		{"Remote - Lisbon", Location{City: "Lisbon", Country: "PT"}},
+		// Two letters after a city are a state code...
+		{"Austin, TX", Location{City: "Austin", Region: "TX", Country: "US"}},
+		{"Denver, CO", Location{City: "Denver", Region: "CO", Country: "US"}},
+		{"Springfield, IN", Location{City: "Springfield", Region: "IN", Country: "US"}},
+		{"Toronto, ON", Location{City: "Toronto", Region: "ON", Country: "CA"}},
+		{"Denver, CO, USA", Location{City: "Denver", Region: "CO", Country: "US"}},
+		// ...unless the city is a known one of the country with that code
+		{"Bogotá, CO", Location{City: "Bogotá", Country: "CO"}},
+		{"Medellín, CO", Location{City: "Medellín", Country: "CO"}},
+		{"Medellín, Antioquia, CO", Location{City: "Medellín", Region: "Antioquia", Country: "CO"}},
+		{"Buenos Aires, AR", Location{City: "Buenos Aires", Country: "AR"}},
+		{"Berlin, DE", Location{City: "Berlin", Country: "DE"}},
+		{"Mumbai, IN", Location{City: "Mumbai", Country: "IN"}},
+		{"Casablanca, MA", Location{City: "Casablanca", Country: "MA"}},
+		{"Boston, MA", Location{City: "Boston", Region: "MA", Country: "US"}},
+		{"CO", Location{Country: "CO"}},
+		{"Remote", Location{}},
+	}
+
+	for _, tt := range tests {
+		if got := ParseLocation(tt.text); got != tt.want {
+			t.Errorf("ParseLocation(%q) = %+v, want %+v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestCountryCode(t *testing.T) {
+	tests := map[string]string{
+		"España":         "ES",
+		"united kingdom": "GB",
+		"EEUU":           "US",
+		"co":             "CO",
+		"Atlantis":       "",
+	}
+	for name, want := range tests {
+		if got := CountryCode(name); got != want {
+			t.Errorf("CountryCode(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+// Package contactinfo structures the contact block of a resume: it normalizes phone numbers
+// to E.164, splits a location into city, region and country, and finds the candidate's
+// LinkedIn, GitHub, GitLab, Stack Overflow and personal site links.
+package contactinfo
+
+import (
+	"regexp"
+	"strings"
+)
+
+// numberingPlan describes the national numbers of a country: its calling code, the trunk
+// prefix dialled before national numbers at home, and how many digits follow the calling code
+type numberingPlan struct {
+	callingCode string
+	trunk       string
+	minDigits   int
+	maxDigits   int
+}
+
+// numberingPlans covers the countries candidates most often come from, by ISO 3166-1 alpha-2
+// code
+var numberingPlans = map[string]numberingPlan{
+	"ES": {"34", "", 9, 9},
+	"PT": {"351", "", 9, 9},
+	"FR": {"33", "0", 9, 9},
+	"DE": {"49", "0", 6, 11},
+	"IT": {"39", "", 6, 11}, // The leading 0 of landlines is kept
+	"GB": {"44", "0", 9, 10},
+	"IE": {"353", "0", 7, 9},
+	"NL": {"31", "0", 9, 9},
+	"BE": {"32", "0", 8, 9},
+	"CH": {"41", "0", 9, 9},
+	"AT": {"43", "0", 4, 13},
+	"PL": {"48", "", 9, 9},
+	"SE": {"46", "0", 7, 9},
+	"RO": {"40", "0", 9, 9},
+	"US": {"1", "1", 10, 10},
+	"CA": {"1", "1", 10, 10},
+	"MX": {"52", "", 10, 10},
+	"AR": {"54", "0", 10, 11},
+	"BR": {"55", "0", 10, 11},
+	"CL": {"56", "", 9, 9},
+	"CO": {"57", "", 10, 10},
+	"PE": {"51", "", 8, 9},
+	"VE": {"58", "0", 10, 10},
+	"EC": {"593", "0", 8, 9},
+	"UY": {"598", "0", 8, 8},
+	"PY": {"595", "0", 9, 9},
+	"BO": {"591", "", 8, 8},
+	"CR": {"506", "", 8, 8},
+	"PA": {"507", "", 7, 8},
+	"GT": {"502", "", 8, 8},
+	"CU": {"53", "0", 8, 8},
+	"IN": {"91", "0", 10, 10},
+	"MA": {"212", "0", 9, 9},
+}
+
+// callingCodeCountries maps calling codes to the country numbers under them are assumed to
+// belong to when the location does not say otherwise
+var callingCodeCountries = func() map[string]string {
+	countries := map[string]string{"1": "US"}
+	for country, plan := range numberingPlans {
+		if _, exists := countries[plan.callingCode]; !exists {
+			countries[plan.callingCode] = country
+		}
+	}
+	return countries
+}()
+
+// E.164 numbers have at most 15 digits; fewer than 8 are extensions or fragments
+const (
+	minPhoneDigits = 8
+	maxPhoneDigits = 15
+)
+
+var (
+	phoneExtension = regexp.MustCompile(`(?i)\s*(?:ext\.?|extension|x|#)\s*\d+\s*$`)
+	phoneCandidate = regexp.MustCompile(`(?:\+|\b00)?\(?\d[\d\s().\-/]{6,}\d`)
+	phoneLabel     = regexp.MustCompile(`(?i)\b(?:phone|telephone|tel|mobile|cell|móvil|movil|teléfono|telefono|celular|whatsapp)\b`)
+
+	// Dates that look like numbers: 2019 - 2021, 01/2019
+	yearRange = regexp.MustCompile(`\b(?:19|20)\d{2}\s*[-–]\s*(?:\d{1,2}[/.])?(?:19|20)\d{2}\b`)
+	monthYear = regexp.MustCompile(`\b(?:0?[1-9]|1[0-2])[/.](?:19|20)\d{2}\b`)
+)
+
+// NormalizePhone formats a phone number as E.164, e.g. "+34612345678", and returns the country
+// it belongs to. Numbers written with an international prefix carry their country; national
+// numbers are read with the numbering plan of country, an ISO code. It returns "" for numbers
+// that cannot be normalized.
+func NormalizePhone(phone, country string) (string, string) {
+	phone = phoneExtension.ReplaceAllString(strings.TrimSpace(phone), "")
+	digits := onlyDigits(phone)
+	if len(digits) < minPhoneDigits {
+		return "", ""
+	}
+
+	switch {
+	case strings.HasPrefix(phone, "+"):
+		return international(digits, country)
+	case strings.HasPrefix(digits, "00"):
+		return international(digits[2:], country)
+	case strings.HasPrefix(digits, "011") && len(digits) > 13:
+		return international(digits[3:], country)
+	}
+
+	plan, known := numberingPlans[strings.ToUpper(country)]
+	if !known {
+		return "", ""
+	}
+	// Written with the calling code but without the +
+	if strings.HasPrefix(digits, plan.callingCode) && plan.fits(len(digits)-len(plan.callingCode)) && !plan.fits(len(digits)) {
+		return "+" + digits, strings.ToUpper(country)
+	}
+	if plan.trunk != "" && strings.HasPrefix(digits, plan.trunk) && plan.fits(len(digits)-len(plan.trunk)) {
+		digits = digits[len(plan.trunk):]
+	}
+	if !plan.fits(len(digits)) {
+		return "", ""
+	}
+	return "+" + plan.callingCode + digits, strings.ToUpper(country)
+}
+
+// international normalizes the digits after an international prefix; country settles calling
+// codes shared by several countries
+func international(digits, country string) (string, string) {
+	if len(digits) < minPhoneDigits || len(digits) > maxPhoneDigits {
+		return "", ""
+	}
+
+	for length := 1; length <= 3 && length < len(digits); length++ {
+		code := digits[:length]
+		codeCountry, known := callingCodeCountries[code]
+		if !known {
+			continue
+		}
+		if plan, exists := numberingPlans[strings.ToUpper(country)]; exists && plan.callingCode == code {
+			codeCountry = strings.ToUpper(country)
+		}
+
+		national := digits[length:]
+		// A trunk prefix written in parentheses, as in +44 (0)20 ..., is not dialled
+		if plan := numberingPlans[codeCountry]; plan.trunk == "0" && strings.HasPrefix(national, "0") && plan.fits(len(national)-1) {
+			national = national[1:]
+		}
+		return "+" + code + national, codeCountry
+	}
+	return "+" + digits, ""
+}
+
+// fits reports whether a national number has as many digits as the plan allows
+func (p numberingPlan) fits(digits int) bool {
+	return digits >= p.minDigits && digits <= p.maxDigits
+}
+
+// FindPhone returns the first phone number in text, preferring lines labelled as phones, then
+// numbers written with an international prefix
+func FindPhone(text string) string {
+	var prefixed string
+	for _, line := range strings.Split(text, "\n") {
+		labelled := phoneLabel.MatchString(line)
+		for _, match := range phoneCandidate.FindAllString(line, -1) {
+			match = strings.TrimSpace(match)
+			digits := onlyDigits(match)
+			if len(digits) < minPhoneDigits || len(digits) > maxPhoneDigits || yearRange.MatchString(match) || monthYear.MatchString(match) {
+				continue
+			}
+			if labelled {
+				return match
+			}
+			if prefixed == "" && (strings.HasPrefix(match, "+") || strings.HasPrefix(match, "00")) {
+				prefixed = match
+			}
+		}
+	}
+	return prefixed
+}
+
+// onlyDigits drops everything but digits
+func onlyDigits(text string) string {
+	var digits strings.Builder
+	for _, r := range text {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+	return digits.String()
+}
@@ -0,0 +1,48 @@
+package contactinfo
+
+import "testing"
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		phone, country    string
+		want, wantCountry string
+	}{
+		{"612 34 56 78", "ES", "+34612345678", "ES"},
+		{"+34 612 34 56 78", "", "+34612345678", "ES"},
+		{"0034 612345678", "US", "+34612345678", "ES"},
+		{"(512) 555-0134", "US", "+15125550134", "US"},
+		{"1-512-555-0134", "US", "+15125550134", "US"},
+		{"+44 (0)20 7946 0958", "", "+442079460958", "GB"},
+		{"300 123 4567", "CO", "+573001234567", "CO"},
+		{"57 300 123 4567", "CO", "+573001234567", "CO"},
+		{"+1 416 555 0134", "CA", "+14165550134", "CA"},
+		{"612 34 56 78 ext. 12", "ES", "+34612345678", "ES"},
+		{"612 34", "ES", "", ""},
+		{"612 34 56 78", "", "", ""},
+	}
+
+	for _, tt := range tests {
+		got, country := NormalizePhone(tt.phone, tt.country)
+		if got != tt.want || country != tt.wantCountry {
+			t.Errorf("NormalizePhone(%q, %q) = %q, %q, want %q, %q", tt.phone, tt.country, got, country, tt.want, tt.wantCountry)
+		}
+	}
+}
+
+// The country a location names is the numbering plan for the phone next to it
+func TestPhoneWithLocationCountry(t *testing.T) {
+	tests := []struct {
+		location, phone, want string
+	}{
+		{"Bogotá, CO", "300 123 4567", "+573001234567"},
+		{"Mumbai, IN", "98765 43210", "+919876543210"},
+		{"Denver, CO", "303 555 0134", "+13035550134"},
+	}
+
+	for _, tt := range tests {
+		country := ParseLocation(tt.location).Country
+		if got, _ := NormalizePhone(tt.phone, country); got != tt.want {
+			t.Errorf("phone %q in %q (%s) = %q, want %q", tt.phone, tt.location, country, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"stafind-backend/internal/logger"
+
+	"github.com/lib/pq"
+)
+
+const (
+	listenerMinReconnect = 10 * time.Second
+	listenerMaxReconnect = time.Minute
+	listenerPingInterval = 90 * time.Second
+)
+
+// Listen receives Postgres notifications on a channel until ctx is done, calling onNotify with
+// each payload. After a lost connection is re-established onNotify is called with an empty
+// payload, because notifications sent in the meantime are lost.
+//
+// LISTEN needs a session; it does not work through a pooler in transaction mode, in which
+// case no notifications arrive and callers should rely on their own expiry.
+func Listen(ctx context.Context, channel string, onNotify func(payload string)) error {
+	config := LoadSharedDBConfig()
+
+	listener := pq.NewListener(config.BuildDSN(), listenerMinReconnect, listenerMaxReconnect,
+		func(event pq.ListenerEventType, err error) {
+			switch event {
+			case pq.ListenerEventConnectionAttemptFailed, pq.ListenerEventDisconnected:
+				logger.Warn("Notification listener connection problem", "channel", channel, "error", err)
+			case pq.ListenerEventReconnected:
+				logger.Info("Notification listener reconnected", "channel", channel)
+			}
+		})
+	defer listener.Close()
+
+	if err := listener.Listen(channel); err != nil {
+		return err
+	}
+	logger.Info("Listening for database notifications", "channel", channel)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case notification := <-listener.Notify:
+			// nil after a reconnect
+			if notification == nil {
+				onNotify("")
+				continue
+			}
+			onNotify(notification.Extra)
+		case <-time.After(listenerPingInterval):
+			// Detect dead connections that would otherwise go unnoticed
+			go listener.Ping()
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// Package extracteval scores resume extraction against hand-labelled resumes. A corpus is a
+// directory of resumes, each with a JSON file of gold labels beside it; every extraction
+// method is scored per field and per skill category with precision, recall and F1, and two
+// runs can be diffed to see what a change fixed and what it broke.
+package extracteval
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"stafind-backend/internal/textextract"
+)
+
+// Labels are the gold-standard answers for one resume. Empty fields are not labelled and are
+// not scored; an empty skills object means the resume has no skills.
+//
+//	{
+//	  "name": "Ana García",
+//	  "email": "ana@example.com",
+//	  "seniority": "Senior",
+//	  "years_experience": 8,
+//	  "skills": {"programming_languages": ["Go", "Python"], "databases": ["PostgreSQL"]}
+//	}
+type Labels struct {
+	Name            string              `json:"name,omitempty"`
+	Email           string              `json:"email,omitempty"`
+	Seniority       string              `json:"seniority,omitempty"` // Junior, Mid or Senior
+	YearsExperience *float64            `json:"years_experience,omitempty"`
+	Skills          map[string][]string `json:"skills,omitempty"` // Skill names by taxonomy category
+}
+
+// Document is a labelled resume of a corpus
+type Document struct {
+	Name   string // File name of the resume
+	Text   string
+	Labels Labels
+}
+
+// LoadCorpus reads every resume in dir that has labels: cv.pdf is labelled by cv.json. Resumes
+// without labels are skipped; labels without a resume are an error, as they are usually a
+// typo in a file name.
+func LoadCorpus(dir string) ([]Document, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read corpus: %w", err)
+	}
+
+	resumes := make(map[string]string)
+	var labelFiles []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		base := strings.TrimSuffix(name, filepath.Ext(name))
+		switch {
+		case strings.EqualFold(filepath.Ext(name), ".json"):
+			labelFiles = append(labelFiles, name)
+		case textextract.IsSupported("", name):
+			resumes[base] = name
+		}
+	}
+	sort.Strings(labelFiles)
+
+	documents := make([]Document, 0, len(labelFiles))
+	for _, labelFile := range labelFiles {
+		base := strings.TrimSuffix(labelFile, filepath.Ext(labelFile))
+		resume, exists := resumes[base]
+		if !exists {
+			return nil, fmt.Errorf("no resume for labels %s", labelFile)
+		}
+
+		labels, err := readLabels(filepath.Join(dir, labelFile))
+		if err != nil {
+			return nil, err
+		}
+		data, err := os.ReadFile(filepath.Join(dir, resume))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", resume, err)
+		}
+		text, err := textextract.Extract(data, "", resume)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract text from %s: %w", resume, err)
+		}
+
+		documents = append(documents, Document{Name: resume, Text: text, Labels: labels})
+	}
+	return documents, nil
+}
+
+func readLabels(path string) (Labels, error) {
+	var labels Labels
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return labels, fmt.Errorf("failed to read labels: %w", err)
+	}
+	if err := json.Unmarshal(data, &labels); err != nil {
+		return labels, fmt.Errorf("invalid labels in %s: %w", filepath.Base(path), err)
+	}
+	return labels, nil
+}
@@ -0,0 +1,244 @@
+package extracteval
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+)
+
+// Method is an extraction method under evaluation
+type Method struct {
+	Name    string
+	Fields  []string // The fields the method extracts
+	Extract func(text string) (Prediction, error)
+}
+
+// Report is the score of one method over a corpus, counted over all its resumes
+type Report struct {
+	Method     string             `json:"method"`
+	Documents  int                `json:"documents"`
+	Failed     int                `json:"failed"`
+	Fields     map[string]Metrics `json:"fields"`
+	Categories map[string]Metrics `json:"categories"`
+	Results    []DocumentScore    `json:"results"`
+}
+
+// Run is the reports of an evaluation, saved so that later runs can be diffed against it
+type Run struct {
+	Corpus    string    `json:"corpus"`
+	CreatedAt time.Time `json:"created_at"`
+	Reports   []Report  `json:"reports"`
+}
+
+// Evaluate runs a method over the corpus
+func Evaluate(method Method, documents []Document) Report {
+	results := make([]DocumentScore, 0, len(documents))
+	for _, document := range documents {
+		prediction, err := method.Extract(document.Text)
+		if err != nil {
+			results = append(results, Failed(document.Name, document.Labels, method.Fields, err))
+			continue
+		}
+		results = append(results, Score(document.Name, document.Labels, prediction))
+	}
+	return Summarize(method.Name, results)
+}
+
+// Summarize adds up document scores into a report
+func Summarize(method string, results []DocumentScore) Report {
+	fields := make(map[string]Counts)
+	categories := make(map[string]Counts)
+	report := Report{Method: method, Documents: len(results), Results: results}
+
+	for _, result := range results {
+		if result.Error != "" {
+			report.Failed++
+		}
+		for field, counts := range result.Fields {
+			fields[field] = fields[field].Add(counts)
+		}
+		for category, counts := range result.Categories {
+			categories[category] = categories[category].Add(counts)
+		}
+	}
+
+	report.Fields = metrics(fields)
+	report.Categories = metrics(categories)
+	return report
+}
+
+func metrics(counts map[string]Counts) map[string]Metrics {
+	result := make(map[string]Metrics, len(counts))
+	for name, c := range counts {
+		result[name] = c.Metrics()
+	}
+	return result
+}
+
+// Report returns the report of a method, or nil
+func (r *Run) Report(method string) *Report {
+	for i := range r.Reports {
+		if r.Reports[i].Method == method {
+			return &r.Reports[i]
+		}
+	}
+	return nil
+}
+
+// Save writes the run as JSON
+func (r *Run) Save(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode run: %w", err)
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// LoadRun reads a run saved by Save
+func LoadRun(path string) (*Run, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read run: %w", err)
+	}
+	run := &Run{}
+	if err := json.Unmarshal(data, run); err != nil {
+		return nil, fmt.Errorf("invalid run %s: %w", path, err)
+	}
+	return run, nil
+}
+
+// MetricsDelta is the change of a field's scores from one run to the next
+type MetricsDelta struct {
+	Before    Metrics `json:"before"`
+	After     Metrics `json:"after"`
+	Precision float64 `json:"precision"`
+	Recall    float64 `json:"recall"`
+	F1        float64 `json:"f1"`
+}
+
+// DocumentDiff is what changed for one resume
+type DocumentDiff struct {
+	Document    string   `json:"document"`
+	Fixed       []string `json:"fixed,omitempty"`         // Labelled skills extracted now but not before
+	Regressed   []string `json:"regressed,omitempty"`     // Labelled skills extracted before but not now
+	NewExtra    []string `json:"new_extra,omitempty"`     // Unlabelled skills extracted now but not before
+	Dropped     []string `json:"dropped_extra,omitempty"` // Unlabelled skills no longer extracted
+	FieldsFixed []string `json:"fields_fixed,omitempty"`  // Single-value fields now right
+	FieldsBroke []string `json:"fields_broke,omitempty"`  // Single-value fields now wrong
+}
+
+// MethodDiff compares the reports of one method in two runs
+type MethodDiff struct {
+	Method     string                  `json:"method"`
+	Fields     map[string]MetricsDelta `json:"fields"`
+	Categories map[string]MetricsDelta `json:"categories"`
+	Documents  []DocumentDiff          `json:"documents"` // Only resumes with changes
+}
+
+// Diff compares two runs method by method. Methods in only one run are left out, as are
+// resumes in only one run.
+func Diff(before, after *Run) []MethodDiff {
+	var diffs []MethodDiff
+	for _, afterReport := range after.Reports {
+		beforeReport := before.Report(afterReport.Method)
+		if beforeReport == nil {
+			continue
+		}
+
+		diff := MethodDiff{
+			Method:     afterReport.Method,
+			Fields:     deltas(beforeReport.Fields, afterReport.Fields),
+			Categories: deltas(beforeReport.Categories, afterReport.Categories),
+			Documents:  []DocumentDiff{},
+		}
+
+		beforeResults := make(map[string]DocumentScore, len(beforeReport.Results))
+		for _, result := range beforeReport.Results {
+			beforeResults[result.Document] = result
+		}
+		for _, result := range afterReport.Results {
+			beforeResult, exists := beforeResults[result.Document]
+			if !exists {
+				continue
+			}
+			if documentDiff, changed := diffDocument(beforeResult, result); changed {
+				diff.Documents = append(diff.Documents, documentDiff)
+			}
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs
+}
+
+func deltas(before, after map[string]Metrics) map[string]MetricsDelta {
+	result := make(map[string]MetricsDelta)
+	for name, afterMetrics := range after {
+		beforeMetrics := before[name]
+		result[name] = MetricsDelta{
+			Before:    beforeMetrics,
+			After:     afterMetrics,
+			Precision: round(afterMetrics.Precision - beforeMetrics.Precision),
+			Recall:    round(afterMetrics.Recall - beforeMetrics.Recall),
+			F1:        round(afterMetrics.F1 - beforeMetrics.F1),
+		}
+	}
+	for name, beforeMetrics := range before {
+		if _, exists := after[name]; !exists {
+			result[name] = MetricsDelta{
+				Before:    beforeMetrics,
+				Precision: -beforeMetrics.Precision,
+				Recall:    -beforeMetrics.Recall,
+				F1:        -beforeMetrics.F1,
+			}
+		}
+	}
+	return result
+}
+
+func diffDocument(before, after DocumentScore) (DocumentDiff, bool) {
+	diff := DocumentDiff{
+		Document:  after.Document,
+		Fixed:     subtract(after.FoundSkills, before.FoundSkills),
+		Regressed: subtract(before.FoundSkills, after.FoundSkills),
+		NewExtra:  subtract(after.Extra, before.Extra),
+		Dropped:   subtract(before.Extra, after.Extra),
+	}
+
+	for field, counts := range after.Fields {
+		if field == FieldSkills {
+			continue
+		}
+		wasRight := before.Fields[field].TruePositives > 0
+		isRight := counts.TruePositives > 0
+		switch {
+		case isRight && !wasRight:
+			diff.FieldsFixed = append(diff.FieldsFixed, field)
+		case wasRight && !isRight:
+			diff.FieldsBroke = append(diff.FieldsBroke, field)
+		}
+	}
+	sort.Strings(diff.FieldsFixed)
+	sort.Strings(diff.FieldsBroke)
+
+	changed := len(diff.Fixed)+len(diff.Regressed)+len(diff.NewExtra)+len(diff.Dropped)+
+		len(diff.FieldsFixed)+len(diff.FieldsBroke) > 0
+	return diff, changed
+}
+
+// subtract returns the values of a that are not in b
+func subtract(a, b []string) []string {
+	exclude := make(map[string]bool, len(b))
+	for _, value := range b {
+		exclude[normalizeText(value)] = true
+	}
+
+	var result []string
+	for _, value := range a {
+		if !exclude[normalizeText(value)] {
+			result = append(result, value)
+		}
+	}
+	return result
+}
@@ -0,0 +1,273 @@
+package extracteval
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"stafind-backend/internal/models"
+)
+
+// Scored fields
+const (
+	FieldSkills          = "skills"
+	FieldName            = "name"
+	FieldEmail           = "email"
+	FieldSeniority       = "seniority"
+	FieldYearsExperience = "years_experience"
+)
+
+// YearsTolerance is how far extracted years of experience may be from the label and still
+// count as correct
+const YearsTolerance = 1.0
+
+// Fields extracted by each kind of method
+var (
+	ResumeFields      = []string{FieldSkills, FieldName, FieldEmail, FieldSeniority, FieldYearsExperience}
+	HuggingFaceFields = []string{FieldSkills}
+)
+
+// uncategorized holds wrongly extracted skills that the extractor gave no category
+const uncategorized = "uncategorized"
+
+// Prediction is what one extraction method found in a resume
+type Prediction struct {
+	Fields          []string // The fields the method extracts; others are not scored
+	Name            string
+	Email           string
+	Seniority       string
+	YearsExperience *float64
+	Skills          map[string][]string // Skill names by category
+}
+
+// FromResume reads a prediction from resume data of the NER extractor or the ensemble
+func FromResume(resume *models.ProcessedResumeData) Prediction {
+	prediction := Prediction{
+		Fields: ResumeFields,
+		Skills: map[string][]string{},
+	}
+	if resume == nil {
+		return prediction
+	}
+
+	// The extractor's placeholder is no answer
+	if resume.CandidateName != "Name not found" {
+		prediction.Name = resume.CandidateName
+	}
+	prediction.Email = resume.ContactInfo.Email
+	prediction.Seniority = resume.SeniorityLevel
+	if years, err := strconv.ParseFloat(strings.TrimSpace(resume.YearsExperience), 64); err == nil {
+		prediction.YearsExperience = &years
+	}
+	for category, skills := range resume.SkillCategories {
+		prediction.Skills[category] = append(prediction.Skills[category], skills...)
+	}
+	return prediction
+}
+
+// FromHuggingFace reads a prediction from a Hugging Face extraction, which only finds skills
+func FromHuggingFace(result *models.HuggingFaceSkillExtractionResponse) Prediction {
+	prediction := Prediction{Fields: HuggingFaceFields, Skills: map[string][]string{}}
+	if result == nil {
+		return prediction
+	}
+	for _, skill := range result.Skills {
+		prediction.Skills[skill.Category] = append(prediction.Skills[skill.Category], skill.Name)
+	}
+	return prediction
+}
+
+// Counts are the outcomes of comparing extracted values with labels
+type Counts struct {
+	TruePositives  int `json:"tp"`
+	FalsePositives int `json:"fp"`
+	FalseNegatives int `json:"fn"`
+}
+
+// Add sums two counts
+func (c Counts) Add(other Counts) Counts {
+	return Counts{
+		TruePositives:  c.TruePositives + other.TruePositives,
+		FalsePositives: c.FalsePositives + other.FalsePositives,
+		FalseNegatives: c.FalseNegatives + other.FalseNegatives,
+	}
+}
+
+// Metrics computes precision, recall and F1
+func (c Counts) Metrics() Metrics {
+	metrics := Metrics{Counts: c}
+	if predicted := c.TruePositives + c.FalsePositives; predicted > 0 {
+		metrics.Precision = round(float64(c.TruePositives) / float64(predicted))
+	}
+	if expected := c.TruePositives + c.FalseNegatives; expected > 0 {
+		metrics.Recall = round(float64(c.TruePositives) / float64(expected))
+	}
+	if metrics.Precision+metrics.Recall > 0 {
+		metrics.F1 = round(2 * metrics.Precision * metrics.Recall / (metrics.Precision + metrics.Recall))
+	}
+	return metrics
+}
+
+// Metrics are counts with the scores computed from them
+type Metrics struct {
+	Counts
+	Precision float64 `json:"precision"`
+	Recall    float64 `json:"recall"`
+	F1        float64 `json:"f1"`
+}
+
+// DocumentScore is how one method did on one resume
+type DocumentScore struct {
+	Document    string            `json:"document"`
+	Error       string            `json:"error,omitempty"` // The extraction failed; every label is missed
+	Fields      map[string]Counts `json:"fields,omitempty"`
+	Categories  map[string]Counts `json:"categories,omitempty"`   // Skill counts by category
+	FoundSkills []string          `json:"found_skills,omitempty"` // Labelled skills that were extracted
+	Missed      []string          `json:"missed,omitempty"`       // Labelled skills that were not extracted
+	Extra       []string          `json:"extra,omitempty"`        // Extracted skills that are not labelled
+	Mismatches  map[string]string `json:"mismatches,omitempty"`   // Wrong single-value fields: "extracted (expected label)"
+}
+
+// Score compares a prediction with the labels of a resume. Skills match by name regardless of
+// category, since extractors use their own categories; a labelled skill counts under its
+// labelled category and a wrongly extracted one under the extractor's category.
+func Score(document string, labels Labels, prediction Prediction) DocumentScore {
+	score := DocumentScore{
+		Document:   document,
+		Fields:     make(map[string]Counts),
+		Categories: make(map[string]Counts),
+		Mismatches: make(map[string]string),
+	}
+
+	for _, field := range prediction.Fields {
+		switch field {
+		case FieldSkills:
+			if labels.Skills != nil {
+				score.scoreSkills(labels.Skills, prediction.Skills)
+			}
+		case FieldName:
+			score.scoreValue(field, labels.Name, prediction.Name, normalizeText)
+		case FieldEmail:
+			score.scoreValue(field, labels.Email, prediction.Email, normalizeText)
+		case FieldSeniority:
+			score.scoreValue(field, labels.Seniority, prediction.Seniority, normalizeText)
+		case FieldYearsExperience:
+			if labels.YearsExperience != nil {
+				score.scoreYears(*labels.YearsExperience, prediction.YearsExperience)
+			}
+		}
+	}
+	return score
+}
+
+// Failed scores a resume the method could not extract: every labelled field it extracts is
+// missed
+func Failed(document string, labels Labels, fields []string, err error) DocumentScore {
+	score := Score(document, labels, Prediction{Fields: fields})
+	score.Error = err.Error()
+	return score
+}
+
+// scoreSkills counts skills as a set: each labelled skill found is a true positive
+func (s *DocumentScore) scoreSkills(labelled, extracted map[string][]string) {
+	expected := make(map[string]string) // Normalized name -> category
+	names := make(map[string]string)
+	for category, skills := range labelled {
+		for _, skill := range skills {
+			key := normalizeText(skill)
+			expected[key] = category
+			names[key] = skill
+		}
+	}
+
+	found := make(map[string]string)
+	for category, skills := range extracted {
+		if category == "" {
+			category = uncategorized
+		}
+		for _, skill := range skills {
+			key := normalizeText(skill)
+			if _, exists := found[key]; !exists {
+				found[key] = category
+				if _, labelled := names[key]; !labelled {
+					names[key] = skill
+				}
+			}
+		}
+	}
+
+	var counts Counts
+	for key, category := range expected {
+		categoryCounts := s.Categories[category]
+		if _, exists := found[key]; exists {
+			counts.TruePositives++
+			categoryCounts.TruePositives++
+			s.FoundSkills = append(s.FoundSkills, names[key])
+		} else {
+			counts.FalseNegatives++
+			categoryCounts.FalseNegatives++
+			s.Missed = append(s.Missed, names[key])
+		}
+		s.Categories[category] = categoryCounts
+	}
+	for key, category := range found {
+		if _, exists := expected[key]; exists {
+			continue
+		}
+		counts.FalsePositives++
+		categoryCounts := s.Categories[category]
+		categoryCounts.FalsePositives++
+		s.Categories[category] = categoryCounts
+		s.Extra = append(s.Extra, names[key])
+	}
+
+	s.Fields[FieldSkills] = counts
+	sort.Strings(s.FoundSkills)
+	sort.Strings(s.Missed)
+	sort.Strings(s.Extra)
+}
+
+// scoreValue scores a single-value field: a wrong value is both a false positive and a
+// missed label
+func (s *DocumentScore) scoreValue(field, label, value string, normalize func(string) string) {
+	if label == "" {
+		return
+	}
+
+	var counts Counts
+	switch {
+	case value == "":
+		counts.FalseNegatives++
+	case normalize(value) == normalize(label):
+		counts.TruePositives++
+	default:
+		counts.FalsePositives++
+		counts.FalseNegatives++
+	}
+	s.Fields[field] = counts
+
+	if counts.TruePositives == 0 {
+		s.Mismatches[field] = value + " (" + label + ")"
+	}
+}
+
+func (s *DocumentScore) scoreYears(label float64, value *float64) {
+	extracted, expected := "", strconv.FormatFloat(label, 'f', -1, 64)
+	if value != nil {
+		extracted = strconv.FormatFloat(*value, 'f', -1, 64)
+		if math.Abs(*value-label) <= YearsTolerance {
+			extracted = expected
+		}
+	}
+	s.scoreValue(FieldYearsExperience, expected, extracted, strings.TrimSpace)
+}
+
+// normalizeText compares values case-insensitively and ignoring spacing
+func normalizeText(value string) string {
+	return strings.Join(strings.Fields(strings.ToLower(value)), " ")
+}
+
+func round(value float64) float64 {
+	return math.Round(value*1000) / 1000
+}
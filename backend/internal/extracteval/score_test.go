@@ -0,0 +1,212 @@
+package extracteval
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const corpusDir = "../../testdata/resumes"
+
+func years(value float64) *float64 {
+	return &value
+}
+
+func TestScoreSkills(t *testing.T) {
+	labels := Labels{Skills: map[string][]string{
+		"programming_languages": {"Go", "Python"},
+		"databases":             {"PostgreSQL"},
+	}}
+	prediction := Prediction{
+		Fields: []string{FieldSkills},
+		Skills: map[string][]string{
+			// Categories of found skills do not matter; names match ignoring case and spacing
+			"backend": {"go", " PostgreSQL "},
+			"":        {"Excel"},
+		},
+	}
+
+	score := Score("cv.txt", labels, prediction)
+	if want := (Counts{TruePositives: 2, FalsePositives: 1, FalseNegatives: 1}); score.Fields[FieldSkills] != want {
+		t.Errorf("skill counts = %+v, want %+v", score.Fields[FieldSkills], want)
+	}
+
+	// Labelled skills count under their labelled category, wrong ones under the extractor's
+	wantCategories := map[string]Counts{
+		"programming_languages": {TruePositives: 1, FalseNegatives: 1},
+		"databases":             {TruePositives: 1},
+		uncategorized:           {FalsePositives: 1},
+	}
+	if !reflect.DeepEqual(score.Categories, wantCategories) {
+		t.Errorf("categories = %+v, want %+v", score.Categories, wantCategories)
+	}
+
+	if !reflect.DeepEqual(score.FoundSkills, []string{"Go", "PostgreSQL"}) ||
+		!reflect.DeepEqual(score.Missed, []string{"Python"}) || !reflect.DeepEqual(score.Extra, []string{"Excel"}) {
+		t.Errorf("found %v, missed %v, extra %v", score.FoundSkills, score.Missed, score.Extra)
+	}
+}
+
+func TestScoreValues(t *testing.T) {
+	labels := Labels{Name: "Ana García", Email: "ana@example.com", Seniority: "Senior", YearsExperience: years(8)}
+	prediction := Prediction{
+		Fields:          ResumeFields,
+		Name:            "ana  garcía",
+		Seniority:       "Mid",
+		YearsExperience: years(7.2),
+	}
+
+	score := Score("cv.txt", labels, prediction)
+	want := map[string]Counts{
+		FieldName:            {TruePositives: 1},
+		FieldEmail:           {FalseNegatives: 1},
+		FieldSeniority:       {FalsePositives: 1, FalseNegatives: 1},
+		FieldYearsExperience: {TruePositives: 1}, // Within YearsTolerance
+	}
+	for field, counts := range want {
+		if score.Fields[field] != counts {
+			t.Errorf("%s = %+v, want %+v", field, score.Fields[field], counts)
+		}
+	}
+	// Skills are not labelled, so they are not scored
+	if _, scored := score.Fields[FieldSkills]; scored {
+		t.Error("unlabelled skills were scored")
+	}
+	if score.Mismatches[FieldSeniority] != "Mid (Senior)" {
+		t.Errorf("seniority mismatch = %q", score.Mismatches[FieldSeniority])
+	}
+
+	prediction.YearsExperience = years(5)
+	if score := Score("cv.txt", labels, prediction); score.Fields[FieldYearsExperience].TruePositives != 0 {
+		t.Error("5 years should not match a label of 8")
+	}
+}
+
+// A method is only scored on the fields it extracts
+func TestScoreOnlyMethodFields(t *testing.T) {
+	labels := Labels{Name: "Ana García", Skills: map[string][]string{"programming_languages": {"Go"}}}
+	score := Score("cv.txt", labels, Prediction{Fields: HuggingFaceFields, Skills: map[string][]string{"x": {"Go"}}})
+	if _, scored := score.Fields[FieldName]; scored || score.Fields[FieldSkills].TruePositives != 1 {
+		t.Errorf("fields = %+v, want only skills", score.Fields)
+	}
+}
+
+func TestFailedMissesEveryLabel(t *testing.T) {
+	labels := Labels{Name: "Ana García", Skills: map[string][]string{"programming_languages": {"Go", "Python"}}}
+	score := Failed("cv.txt", labels, ResumeFields, errors.New("model unavailable"))
+
+	if score.Error != "model unavailable" {
+		t.Errorf("error = %q", score.Error)
+	}
+	if score.Fields[FieldSkills].FalseNegatives != 2 || score.Fields[FieldName].FalseNegatives != 1 {
+		t.Errorf("fields = %+v, want every label missed", score.Fields)
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	metrics := Counts{TruePositives: 3, FalsePositives: 1, FalseNegatives: 2}.Metrics()
+	if metrics.Precision != 0.75 || metrics.Recall != 0.6 || metrics.F1 != 0.667 {
+		t.Errorf("metrics = %+v, want precision 0.75, recall 0.6, F1 0.667", metrics)
+	}
+	if empty := (Counts{}).Metrics(); empty.Precision != 0 || empty.Recall != 0 || empty.F1 != 0 {
+		t.Errorf("metrics of no counts = %+v, want zeros", empty)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	labels := Labels{Seniority: "Senior", Skills: map[string][]string{"programming_languages": {"Go", "Python"}}}
+	before := Score("cv.txt", labels, Prediction{
+		Fields:    ResumeFields,
+		Seniority: "Senior",
+		Skills:    map[string][]string{"x": {"Go", "Excel"}},
+	})
+	after := Score("cv.txt", labels, Prediction{
+		Fields:    ResumeFields,
+		Seniority: "Mid",
+		Skills:    map[string][]string{"x": {"Python", "Word"}},
+	})
+
+	diffs := Diff(
+		&Run{Reports: []Report{Summarize("ner", []DocumentScore{before})}},
+		&Run{Reports: []Report{Summarize("ner", []DocumentScore{after}), Summarize("huggingface", nil)}},
+	)
+	if len(diffs) != 1 || len(diffs[0].Documents) != 1 {
+		t.Fatalf("diffs = %+v, want one changed resume of ner", diffs)
+	}
+
+	want := DocumentDiff{
+		Document:    "cv.txt",
+		Fixed:       []string{"Python"},
+		Regressed:   []string{"Go"},
+		NewExtra:    []string{"Word"},
+		Dropped:     []string{"Excel"},
+		FieldsBroke: []string{FieldSeniority},
+	}
+	if got := diffs[0].Documents[0]; !reflect.DeepEqual(got, want) {
+		t.Errorf("diff = %+v, want %+v", got, want)
+	}
+	if delta := diffs[0].Fields[FieldSeniority]; delta.F1 != -1 {
+		t.Errorf("seniority F1 change = %v, want -1", delta.F1)
+	}
+}
+
+// Every resume of the committed corpus is labelled and readable
+func TestLoadCorpus(t *testing.T) {
+	documents, err := LoadCorpus(corpusDir)
+	if err != nil {
+		t.Fatalf("LoadCorpus() error = %v", err)
+	}
+	if len(documents) < 5 {
+		t.Fatalf("got %d labelled resumes, want at least 5", len(documents))
+	}
+	for _, document := range documents {
+		if document.Text == "" || document.Labels.Name == "" || len(document.Labels.Skills) == 0 {
+			t.Errorf("%s: text %d bytes, labels %+v", document.Name, len(document.Text), document.Labels)
+		}
+	}
+
+	// A perfect method scores 1 on every field
+	byText := make(map[string]Labels, len(documents))
+	for _, document := range documents {
+		byText[document.Text] = document.Labels
+	}
+	report := Evaluate(Method{
+		Name:   "gold",
+		Fields: ResumeFields,
+		Extract: func(text string) (Prediction, error) {
+			labels := byText[text]
+			return Prediction{
+				Fields:          ResumeFields,
+				Name:            labels.Name,
+				Email:           labels.Email,
+				Seniority:       labels.Seniority,
+				YearsExperience: labels.YearsExperience,
+				Skills:          labels.Skills,
+			}, nil
+		},
+	}, documents)
+	for field, metrics := range report.Fields {
+		if metrics.F1 != 1 {
+			t.Errorf("gold %s = %+v, want F1 1", field, metrics)
+		}
+	}
+}
+
+func TestLoadCorpusRejectsOrphanLabels(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "ana.txt"), []byte("Ana García"), 0o644)
+	os.WriteFile(filepath.Join(dir, "ana.json"), []byte(`{"name": "Ana García"}`), 0o644)
+	os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("Not labelled"), 0o644)
+
+	documents, err := LoadCorpus(dir)
+	if err != nil || len(documents) != 1 {
+		t.Fatalf("LoadCorpus() = %d documents, %v; want the labelled resume only", len(documents), err)
+	}
+
+	os.WriteFile(filepath.Join(dir, "luis.json"), []byte(`{"name": "Luis"}`), 0o644)
+	if _, err := LoadCorpus(dir); err == nil {
+		t.Error("LoadCorpus() should fail on labels without a resume")
+	}
+}
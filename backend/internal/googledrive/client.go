@@ -0,0 +1,283 @@
+// Package googledrive is a minimal client for the Google Drive v3 REST API
+package googledrive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"stafind-backend/internal/constants"
+	"stafind-backend/internal/models"
+)
+
+// Google Drive constants
+const (
+	DefaultBaseURL     = "https://www.googleapis.com"
+	DriveReadonlyScope = "https://www.googleapis.com/auth/drive.readonly"
+
+	MimeTypeFolder      = "application/vnd.google-apps.folder"
+	MimeTypeGoogleDoc   = "application/vnd.google-apps.document"
+	exportMimeTypePlain = "text/plain"
+
+	fileFields = "id,name,mimeType,size,createdTime,modifiedTime,parents,webViewLink"
+	pageSize   = 1000
+)
+
+// MaxDownloadSize caps the size of a downloaded file
+const MaxDownloadSize = 25 << 20
+
+// HTTPClient is the subset of *http.Client the Drive client needs, so a fake Drive API can be injected
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client lists and downloads Google Drive files
+type Client struct {
+	baseURL    string
+	httpClient HTTPClient
+	tokens     TokenSource
+}
+
+// NewClient creates a Drive client. An empty baseURL uses the public Google API.
+func NewClient(baseURL string, httpClient HTTPClient, tokens TokenSource) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 60 * time.Second}
+	}
+
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: httpClient,
+		tokens:     tokens,
+	}
+}
+
+// NewClientFromEnv builds a client from the GOOGLE_DRIVE_* environment variables. It returns
+// nil without an error when neither a service account nor an access token is configured.
+func NewClientFromEnv(httpClient HTTPClient) (*Client, error) {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 60 * time.Second}
+	}
+	baseURL := os.Getenv(constants.EnvGoogleDriveAPIURL)
+
+	if token := os.Getenv(constants.EnvGoogleDriveAccessToken); token != "" {
+		return NewClient(baseURL, httpClient, StaticToken(token)), nil
+	}
+
+	config := models.GoogleDriveConfig{
+		CredentialsPath: os.Getenv(constants.EnvGoogleDriveCredentialsPath),
+		Scopes:          []string{DriveReadonlyScope},
+	}
+	if config.CredentialsPath == "" {
+		return nil, nil
+	}
+
+	tokens, err := NewServiceAccountTokenSource(config, httpClient)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(baseURL, httpClient, tokens), nil
+}
+
+// driveFile mirrors the Drive API file resource; size is sent as a string
+type driveFile struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	MimeType     string    `json:"mimeType"`
+	Size         string    `json:"size"`
+	CreatedTime  time.Time `json:"createdTime"`
+	ModifiedTime time.Time `json:"modifiedTime"`
+	Parents      []string  `json:"parents"`
+	WebViewLink  string    `json:"webViewLink"`
+}
+
+type fileList struct {
+	NextPageToken string      `json:"nextPageToken"`
+	Files         []driveFile `json:"files"`
+}
+
+// GetFolder returns the metadata of a folder
+func (c *Client) GetFolder(ctx context.Context, folderID string) (*models.GoogleDriveFolder, error) {
+	params := url.Values{}
+	params.Set("fields", fileFields)
+	params.Set("supportsAllDrives", "true")
+
+	var file driveFile
+	if err := c.getJSON(ctx, "/drive/v3/files/"+url.PathEscape(folderID), params, &file); err != nil {
+		return nil, err
+	}
+	if file.MimeType != MimeTypeFolder {
+		return nil, fmt.Errorf("%s is not a folder", folderID)
+	}
+
+	return &models.GoogleDriveFolder{
+		ID:           file.ID,
+		Name:         file.Name,
+		MimeType:     file.MimeType,
+		CreatedTime:  file.CreatedTime,
+		ModifiedTime: file.ModifiedTime,
+		Parents:      file.Parents,
+	}, nil
+}
+
+// ListFiles returns the files directly inside a folder. When modifiedAfter is set, only
+// files modified after it are returned.
+func (c *Client) ListFiles(ctx context.Context, folderID string, modifiedAfter time.Time) ([]models.GoogleDriveFile, error) {
+	query := fmt.Sprintf("'%s' in parents and trashed = false and mimeType != '%s'", escapeQuery(folderID), MimeTypeFolder)
+	if !modifiedAfter.IsZero() {
+		query += fmt.Sprintf(" and modifiedTime > '%s'", modifiedAfter.UTC().Format(time.RFC3339Nano))
+	}
+
+	items, err := c.list(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]models.GoogleDriveFile, 0, len(items))
+	for _, item := range items {
+		size, _ := strconv.ParseInt(item.Size, 10, 64)
+		files = append(files, models.GoogleDriveFile{
+			ID:           item.ID,
+			Name:         item.Name,
+			MimeType:     item.MimeType,
+			Size:         size,
+			CreatedTime:  item.CreatedTime,
+			ModifiedTime: item.ModifiedTime,
+			Parents:      item.Parents,
+			WebViewLink:  item.WebViewLink,
+		})
+	}
+	return files, nil
+}
+
+// ListSubfolders returns the folders directly inside a folder
+func (c *Client) ListSubfolders(ctx context.Context, folderID string) ([]models.GoogleDriveFolder, error) {
+	query := fmt.Sprintf("'%s' in parents and trashed = false and mimeType = '%s'", escapeQuery(folderID), MimeTypeFolder)
+
+	items, err := c.list(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	folders := make([]models.GoogleDriveFolder, 0, len(items))
+	for _, item := range items {
+		folders = append(folders, models.GoogleDriveFolder{
+			ID:           item.ID,
+			Name:         item.Name,
+			MimeType:     item.MimeType,
+			CreatedTime:  item.CreatedTime,
+			ModifiedTime: item.ModifiedTime,
+			Parents:      item.Parents,
+		})
+	}
+	return folders, nil
+}
+
+// Download returns the content of a file and its MIME type. Google Docs are exported as plain text.
+func (c *Client) Download(ctx context.Context, file models.GoogleDriveFile) ([]byte, string, error) {
+	params := url.Values{}
+	path := "/drive/v3/files/" + url.PathEscape(file.ID)
+	mimeType := file.MimeType
+
+	if file.MimeType == MimeTypeGoogleDoc {
+		path += "/export"
+		params.Set("mimeType", exportMimeTypePlain)
+		mimeType = exportMimeTypePlain
+	} else {
+		params.Set("alt", "media")
+		params.Set("supportsAllDrives", "true")
+	}
+
+	resp, err := c.do(ctx, path, params)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, MaxDownloadSize+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to download %s: %w", file.Name, err)
+	}
+	if len(data) > MaxDownloadSize {
+		return nil, "", fmt.Errorf("file %s is larger than %d bytes", file.Name, MaxDownloadSize)
+	}
+
+	return data, mimeType, nil
+}
+
+func (c *Client) list(ctx context.Context, query string) ([]driveFile, error) {
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("fields", "nextPageToken,files("+fileFields+")")
+	params.Set("pageSize", strconv.Itoa(pageSize))
+	params.Set("supportsAllDrives", "true")
+	params.Set("includeItemsFromAllDrives", "true")
+
+	var files []driveFile
+	for {
+		var page fileList
+		if err := c.getJSON(ctx, "/drive/v3/files", params, &page); err != nil {
+			return nil, err
+		}
+		files = append(files, page.Files...)
+
+		if page.NextPageToken == "" {
+			return files, nil
+		}
+		params.Set("pageToken", page.NextPageToken)
+	}
+}
+
+func (c *Client) getJSON(ctx context.Context, path string, params url.Values, target interface{}) error {
+	resp, err := c.do(ctx, path, params)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
+		return fmt.Errorf("failed to decode Google Drive response: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) do(ctx context.Context, path string, params url.Values) (*http.Response, error) {
+	token, err := c.tokens.Token(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Google Drive access token: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("google drive request failed: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("google drive returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return resp, nil
+}
+
+// escapeQuery escapes a value for use inside a quoted Drive query string
+func escapeQuery(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	return strings.ReplaceAll(value, `'`, `\'`)
+}
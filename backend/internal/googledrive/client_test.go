@@ -0,0 +1,137 @@
+package googledrive
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"stafind-backend/internal/models"
+)
+
+// handlerClient serves requests with an http.Handler instead of the network
+type handlerClient struct {
+	handler http.Handler
+}
+
+func (c handlerClient) Do(req *http.Request) (*http.Response, error) {
+	recorder := httptest.NewRecorder()
+	c.handler.ServeHTTP(recorder, req)
+	return recorder.Result(), nil
+}
+
+func newTestClient(handler http.HandlerFunc) *Client {
+	return NewClient("https://drive.test", handlerClient{handler}, StaticToken("test-token"))
+}
+
+func writeJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(value)
+}
+
+func TestListFilesFollowsPages(t *testing.T) {
+	modified := time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+	var queries []string
+
+	client := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer test-token" {
+			t.Errorf("Authorization = %q", got)
+		}
+		if r.URL.Path != "/drive/v3/files" {
+			t.Errorf("path = %q, want /drive/v3/files", r.URL.Path)
+		}
+		queries = append(queries, r.URL.Query().Get("q"))
+
+		switch r.URL.Query().Get("pageToken") {
+		case "":
+			writeJSON(w, fileList{
+				NextPageToken: "page-2",
+				Files:         []driveFile{{ID: "f1", Name: "ana.pdf", MimeType: "application/pdf", Size: "2048", ModifiedTime: modified}},
+			})
+		case "page-2":
+			writeJSON(w, fileList{
+				Files: []driveFile{{ID: "f2", Name: "luis.docx", MimeType: "application/vnd.openxmlformats-officedocument.wordprocessingml.document", ModifiedTime: modified}},
+			})
+		default:
+			t.Errorf("unexpected page token %q", r.URL.Query().Get("pageToken"))
+		}
+	})
+
+	files, err := client.ListFiles(context.Background(), "folder'1", modified.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("ListFiles() error = %v", err)
+	}
+	if len(files) != 2 || files[0].ID != "f1" || files[1].ID != "f2" {
+		t.Fatalf("files = %+v, want f1 and f2", files)
+	}
+	if files[0].Size != 2048 {
+		t.Errorf("size = %d, want 2048", files[0].Size)
+	}
+
+	if len(queries) != 2 {
+		t.Fatalf("got %d list requests, want 2", len(queries))
+	}
+	want := `'folder\'1' in parents and trashed = false and mimeType != 'application/vnd.google-apps.folder' and modifiedTime > '2024-03-01T09:00:00Z'`
+	if queries[0] != want {
+		t.Errorf("q = %s, want %s", queries[0], want)
+	}
+}
+
+func TestGetFolderRejectsFiles(t *testing.T) {
+	client := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, driveFile{ID: "f1", Name: "ana.pdf", MimeType: "application/pdf"})
+	})
+
+	if _, err := client.GetFolder(context.Background(), "f1"); err == nil {
+		t.Error("GetFolder() of a file should fail")
+	}
+}
+
+func TestDownload(t *testing.T) {
+	client := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/drive/v3/files/doc1/export":
+			if got := r.URL.Query().Get("mimeType"); got != "text/plain" {
+				t.Errorf("export mimeType = %q, want text/plain", got)
+			}
+			w.Write([]byte("Ana Garcia\nBackend Engineer"))
+		case "/drive/v3/files/pdf1":
+			if got := r.URL.Query().Get("alt"); got != "media" {
+				t.Errorf("alt = %q, want media", got)
+			}
+			w.Write([]byte("%PDF-1.4"))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	data, mimeType, err := client.Download(context.Background(), models.GoogleDriveFile{ID: "doc1", Name: "Ana", MimeType: MimeTypeGoogleDoc})
+	if err != nil {
+		t.Fatalf("Download() of a Google Doc error = %v", err)
+	}
+	if mimeType != "text/plain" || string(data) != "Ana Garcia\nBackend Engineer" {
+		t.Errorf("Download() of a Google Doc = %q, %q", data, mimeType)
+	}
+
+	data, mimeType, err = client.Download(context.Background(), models.GoogleDriveFile{ID: "pdf1", Name: "ana.pdf", MimeType: "application/pdf"})
+	if err != nil {
+		t.Fatalf("Download() of a PDF error = %v", err)
+	}
+	if mimeType != "application/pdf" || string(data) != "%PDF-1.4" {
+		t.Errorf("Download() of a PDF = %q, %q", data, mimeType)
+	}
+}
+
+func TestErrorStatus(t *testing.T) {
+	client := newTestClient(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":{"message":"File not found"}}`, http.StatusNotFound)
+	})
+
+	_, _, err := client.Download(context.Background(), models.GoogleDriveFile{ID: "gone", Name: "gone.pdf"})
+	if err == nil || !strings.Contains(err.Error(), "status 404") || !strings.Contains(err.Error(), "File not found") {
+		t.Errorf("Download() error = %v, want the status and message", err)
+	}
+}
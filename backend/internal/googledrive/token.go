@@ -0,0 +1,143 @@
+package googledrive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"stafind-backend/internal/models"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const defaultTokenURI = "https://oauth2.googleapis.com/token"
+
+// TokenSource provides OAuth access tokens for Drive requests
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// StaticToken is a fixed access token, e.g. for local runs against a fake Drive API
+type StaticToken string
+
+// Token returns the fixed token
+func (t StaticToken) Token(ctx context.Context) (string, error) {
+	return string(t), nil
+}
+
+// serviceAccountKey holds the fields used from a service account JSON key file
+type serviceAccountKey struct {
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// ServiceAccountTokenSource exchanges a signed service account JWT for access tokens and
+// caches them until shortly before they expire
+type ServiceAccountTokenSource struct {
+	key        serviceAccountKey
+	scopes     []string
+	httpClient HTTPClient
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+// NewServiceAccountTokenSource loads the service account key at config.CredentialsPath
+func NewServiceAccountTokenSource(config models.GoogleDriveConfig, httpClient HTTPClient) (*ServiceAccountTokenSource, error) {
+	data, err := os.ReadFile(config.CredentialsPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Google Drive credentials: %w", err)
+	}
+
+	var key serviceAccountKey
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, fmt.Errorf("failed to parse Google Drive credentials: %w", err)
+	}
+	if key.ClientEmail == "" || key.PrivateKey == "" {
+		return nil, fmt.Errorf("google drive credentials must be a service account key")
+	}
+	if key.TokenURI == "" {
+		key.TokenURI = defaultTokenURI
+	}
+
+	scopes := config.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{DriveReadonlyScope}
+	}
+
+	return &ServiceAccountTokenSource{
+		key:        key,
+		scopes:     scopes,
+		httpClient: httpClient,
+	}, nil
+}
+
+// Token returns a cached access token or requests a new one
+func (s *ServiceAccountTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && time.Now().Before(s.expires) {
+		return s.token, nil
+	}
+
+	privateKey, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(s.key.PrivateKey))
+	if err != nil {
+		return "", fmt.Errorf("invalid service account private key: %w", err)
+	}
+
+	now := time.Now()
+	assertion, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":   s.key.ClientEmail,
+		"scope": strings.Join(s.scopes, " "),
+		"aud":   s.key.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	}).SignedString(privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign service account assertion: %w", err)
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "urn:ietf:params:oauth:grant-type:jwt-bearer")
+	form.Set("assertion", assertion)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.key.TokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("token endpoint returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode token response: %w", err)
+	}
+
+	s.token = result.AccessToken
+	// Refresh a minute early so in-flight requests never carry an expired token
+	s.expires = now.Add(time.Duration(result.ExpiresIn)*time.Second - time.Minute)
+	return s.token, nil
+}
@@ -137,6 +137,21 @@ func (h *AIAgentHandlers) ExtractSkills(c *fiber.Ctx) error {
 	return c.JSON(response)
 }
 
+// ParseJobRequest returns the structured search understood from a free-text job request
+func (h *AIAgentHandlers) ParseJobRequest(c *fiber.Ctx) error {
+	var req models.ParseJobRequestRequest
+	if err := c.BodyParser(&req); err != nil {
+		return BadRequest(c, err.Error())
+	}
+
+	parsed, err := h.aiAgentService.ParseJobRequest(req.Text)
+	if err != nil {
+		return handleServiceError(c, err)
+	}
+
+	return c.JSON(parsed)
+}
+
 // GetAIAgentRequests returns all AI agent requests with pagination
 func (h *AIAgentHandlers) GetAIAgentRequests(c *fiber.Ctx) error {
 	limitStr := c.Query("limit", "10")
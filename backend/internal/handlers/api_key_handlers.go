@@ -105,6 +105,24 @@ func (h *APIKeyHandlers) RotateAPIKey(c *fiber.Ctx) error {
 	})
 }
 
+// CreateSigningSecret issues or rotates the webhook signing secret of an API key
+func (h *APIKeyHandlers) CreateSigningSecret(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return c.Status(constants.StatusBadRequest).JSON(fiber.Map{"error": constants.MsgInvalidAPIKeyID})
+	}
+
+	response, err := h.apiKeyService.CreateSigningSecret(id)
+	if err != nil {
+		return c.Status(constants.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.Status(constants.StatusCreated).JSON(fiber.Map{
+		"message": constants.MsgSigningSecretIssued,
+		"data":    response,
+	})
+}
+
 // ValidateAPIKey validates an API key
 func (h *APIKeyHandlers) ValidateAPIKey(c *fiber.Ctx) error {
 	apiKey := c.Get(constants.HeaderAPIKey)
@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"stafind-backend/internal/models"
+	"stafind-backend/internal/services"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// CertificationHandlers handles the certification catalogue and the certifications employees hold
+type CertificationHandlers struct {
+	certificationService *services.CertificationService
+}
+
+// NewCertificationHandlers creates new certification handlers
+func NewCertificationHandlers(certificationService *services.CertificationService) *CertificationHandlers {
+	return &CertificationHandlers{
+		certificationService: certificationService,
+	}
+}
+
+// ListCatalog returns the certification catalogue
+func (h *CertificationHandlers) ListCatalog(c *fiber.Ctx) error {
+	certifications, err := h.certificationService.ListCatalog()
+	if err != nil {
+		return h.serviceError(c, "Failed to get certifications", err)
+	}
+
+	return c.JSON(fiber.Map{
+		"certifications": certifications,
+		"count":          len(certifications),
+	})
+}
+
+// GetCatalogEntry returns a catalogue certification
+func (h *CertificationHandlers) GetCatalogEntry(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid certification ID"})
+	}
+
+	certification, err := h.certificationService.GetCatalogEntry(id)
+	if err != nil {
+		return h.serviceError(c, "Failed to get certification", err)
+	}
+
+	return c.JSON(certification)
+}
+
+// CreateCatalogEntry adds a certification to the catalogue
+func (h *CertificationHandlers) CreateCatalogEntry(c *fiber.Ctx) error {
+	var req models.CertificationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+	}
+
+	certification, err := h.certificationService.CreateCatalogEntry(&req)
+	if err != nil {
+		return h.serviceError(c, "Failed to create certification", err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(certification)
+}
+
+// UpdateCatalogEntry updates a catalogue certification
+func (h *CertificationHandlers) UpdateCatalogEntry(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid certification ID"})
+	}
+
+	var req models.CertificationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+	}
+
+	certification, err := h.certificationService.UpdateCatalogEntry(id, &req)
+	if err != nil {
+		return h.serviceError(c, "Failed to update certification", err)
+	}
+
+	return c.JSON(certification)
+}
+
+// DeleteCatalogEntry removes a certification from the catalogue
+func (h *CertificationHandlers) DeleteCatalogEntry(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid certification ID"})
+	}
+
+	if err := h.certificationService.DeleteCatalogEntry(id); err != nil {
+		return h.serviceError(c, "Failed to delete certification", err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// ListEmployeeCertifications returns the certifications of an employee, soonest to expire first
+func (h *CertificationHandlers) ListEmployeeCertifications(c *fiber.Ctx) error {
+	employeeID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid employee ID"})
+	}
+
+	certifications, err := h.certificationService.ListEmployeeCertifications(employeeID)
+	if err != nil {
+		return h.serviceError(c, "Failed to get employee certifications", err)
+	}
+
+	return c.JSON(fiber.Map{
+		"certifications": certifications,
+		"count":          len(certifications),
+	})
+}
+
+// AddEmployeeCertification adds a certification to an employee
+func (h *CertificationHandlers) AddEmployeeCertification(c *fiber.Ctx) error {
+	employeeID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid employee ID"})
+	}
+
+	var req models.EmployeeCertificationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+	}
+
+	certification, err := h.certificationService.AddEmployeeCertification(employeeID, &req)
+	if err != nil {
+		return h.serviceError(c, "Failed to add employee certification", err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(certification)
+}
+
+// UpdateEmployeeCertification replaces a certification of an employee
+func (h *CertificationHandlers) UpdateEmployeeCertification(c *fiber.Ctx) error {
+	employeeID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid employee ID"})
+	}
+	id, err := strconv.Atoi(c.Params("certificationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid certification ID"})
+	}
+
+	var req models.EmployeeCertificationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+	}
+
+	certification, err := h.certificationService.UpdateEmployeeCertification(employeeID, id, &req)
+	if err != nil {
+		return h.serviceError(c, "Failed to update employee certification", err)
+	}
+
+	return c.JSON(certification)
+}
+
+// DeleteEmployeeCertification removes a certification from an employee
+func (h *CertificationHandlers) DeleteEmployeeCertification(c *fiber.Ctx) error {
+	employeeID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid employee ID"})
+	}
+	id, err := strconv.Atoi(c.Params("certificationId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid certification ID"})
+	}
+
+	if err := h.certificationService.DeleteEmployeeCertification(employeeID, id); err != nil {
+		return h.serviceError(c, "Failed to delete employee certification", err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// SendExpiryNotices announces the certifications about to expire now rather than at the next
+// daily run
+func (h *CertificationHandlers) SendExpiryNotices(c *fiber.Ctx) error {
+	notice, err := h.certificationService.NotifyExpiring()
+	if err != nil {
+		return h.serviceError(c, "Failed to send certification expiry notices", err)
+	}
+
+	return c.JSON(notice)
+}
+
+// serviceError maps service errors to their status, and anything else to 500
+func (h *CertificationHandlers) serviceError(c *fiber.Ctx, message string, err error) error {
+	switch err.(type) {
+	case *services.ValidationError, *services.NotFoundError, *services.ConflictError:
+		return handleServiceError(c, err)
+	}
+	return InternalServerErrorWithDetails(c, message, err.Error())
+}
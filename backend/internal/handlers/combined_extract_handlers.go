@@ -3,6 +3,7 @@ package handlers
 import (
 	"encoding/json"
 	"fmt"
+	"stafind-backend/internal/extracteval"
 	"stafind-backend/internal/models"
 	"stafind-backend/internal/services"
 	"time"
@@ -17,6 +18,7 @@ type CombinedExtractHandlers struct {
 	candidateStorageService *services.CandidateStorageService
 	cvExtractService        services.CVExtractService
 	huggingFaceService      services.HuggingFaceSkillExtractionService
+	skillEnsemble           *services.SkillEnsemble
 }
 
 // NewCombinedExtractHandlers creates new combined extraction handlers
@@ -26,6 +28,7 @@ func NewCombinedExtractHandlers(
 	candidateStorageService *services.CandidateStorageService,
 	cvExtractService services.CVExtractService,
 	huggingFaceService services.HuggingFaceSkillExtractionService,
+	skillEnsemble *services.SkillEnsemble,
 ) *CombinedExtractHandlers {
 	return &CombinedExtractHandlers{
 		extractionService:       extractionService,
@@ -33,6 +36,7 @@ func NewCombinedExtractHandlers(
 		candidateStorageService: candidateStorageService,
 		cvExtractService:        cvExtractService,
 		huggingFaceService:      huggingFaceService,
+		skillEnsemble:           skillEnsemble,
 	}
 }
 
@@ -51,6 +55,9 @@ func (h *CombinedExtractHandlers) ExtractProcessCombined(c *fiber.Ctx) error {
 			"error": "text field is required",
 		})
 	}
+	if request.ProcessingType == "" {
+		request.ProcessingType = "candidate_extraction"
+	}
 
 	// Start timing
 	startTime := time.Now()
@@ -102,7 +109,7 @@ func (h *CombinedExtractHandlers) ExtractProcessCombined(c *fiber.Ctx) error {
 
 	// Hugging Face Extraction
 	go func() {
-		huggingFaceResult, err := h.huggingFaceService.ExtractSkillsFromText(request.Text)
+		huggingFaceResult, err := h.huggingFaceService.ExtractSkillsFromText(c.UserContext(), request.Text)
 		if err != nil {
 			errorChan <- fmt.Errorf("Hugging Face extraction failed: %w", err)
 			return
@@ -149,16 +156,17 @@ func (h *CombinedExtractHandlers) ExtractProcessCombined(c *fiber.Ctx) error {
 		})
 	}
 
-	// Parse NER result if available
-	var nerExtractedData map[string]interface{}
+	// NER provides the resume structure; only candidate extraction produces it
+	var nerExtractedData *models.ProcessedResumeData
 	if nerResult != nil {
-		if err := json.Unmarshal([]byte(nerResult.ProcessedContent), &nerExtractedData); err != nil {
-			extractionErrors = append(extractionErrors, "Failed to parse NER result: "+err.Error())
+		nerExtractedData = nerResult.Resume
+		if nerExtractedData == nil {
+			extractionErrors = append(extractionErrors, fmt.Sprintf("NER processing type %q does not extract candidate data", request.ProcessingType))
 		}
 	}
 
-	// Combine results
-	combinedResult := h.combineExtractionResults(nerExtractedData, huggingFaceResult, extractionErrors)
+	// One ranked, deduplicated skill list from both extractors
+	combinedResult := h.skillEnsemble.Merge(nerExtractedData, huggingFaceResult)
 
 	// Process candidate extraction and store in employees table
 	extractionSource := request.ExtractionSource
@@ -199,8 +207,7 @@ func (h *CombinedExtractHandlers) ExtractProcessCombined(c *fiber.Ctx) error {
 		_, err := h.cvExtractService.UpdateFileProgress(
 			request.ExtractRequestId,
 			request.FileNumber,
-			1, // files processed (current file)
-			0, // files failed
+			models.CVExtractFileStatusProcessed,
 		)
 		if err != nil {
 			fmt.Printf("Warning: Failed to update CV extract progress: %v\n", err)
@@ -254,6 +261,7 @@ func (h *CombinedExtractHandlers) ExtractProcessCombined(c *fiber.Ctx) error {
 			"combined_data":    combinedResult,
 			"ner_data":         nerExtractedData,
 			"huggingface_data": huggingFaceResult,
+			"errors":           extractionErrors,
 			"processing_time":  time.Since(startTime).Milliseconds(),
 			"processing_type":  request.ProcessingType,
 			"metadata":         request.Metadata,
@@ -267,6 +275,7 @@ func (h *CombinedExtractHandlers) ExtractProcessCombined(c *fiber.Ctx) error {
 			"processing_time":  candidateResult.ProcessingTime,
 			"status":           candidateResult.Status,
 			"message":          candidateResult.Message,
+			"review_id":        candidateResult.ReviewID,
 		},
 		"message": "Combined extraction processing completed successfully",
 	}
@@ -274,80 +283,16 @@ func (h *CombinedExtractHandlers) ExtractProcessCombined(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(response)
 }
 
-// combineExtractionResults combines NER and Hugging Face extraction results
-func (h *CombinedExtractHandlers) combineExtractionResults(
-	nerData map[string]interface{},
-	huggingFaceResult *models.HuggingFaceSkillExtractionResponse,
-	errors []string,
-) map[string]interface{} {
-	combined := make(map[string]interface{})
-
-	// Start with NER data as base
-	for key, value := range nerData {
-		combined[key] = value
-	}
-
-	// Add Hugging Face skills if available
-	if huggingFaceResult != nil && huggingFaceResult.Success {
-		// Convert Hugging Face skills to the expected format
-		huggingFaceSkills := make(map[string][]string)
-		for _, skill := range huggingFaceResult.Skills {
-			category := skill.Category
-			if category == "" {
-				category = "Other"
-			}
-			if huggingFaceSkills[category] == nil {
-				huggingFaceSkills[category] = []string{}
-			}
-			huggingFaceSkills[category] = append(huggingFaceSkills[category], skill.Name)
-		}
-
-		// Add Hugging Face skills to combined result
-		combined["huggingface_skills"] = huggingFaceSkills
-		combined["huggingface_confidence"] = huggingFaceResult.ConfidenceScore
-		combined["huggingface_total_skills"] = huggingFaceResult.TotalSkills
-		combined["huggingface_model_used"] = huggingFaceResult.ModelUsed
-
-		// Merge skills with existing NER skills
-		if existingSkills, ok := combined["skills"].(map[string]interface{}); ok {
-			// Merge Hugging Face skills with existing skills
-			for category, skills := range huggingFaceSkills {
-				if existingSkills[category] == nil {
-					existingSkills[category] = []string{}
-				}
-				// Add new skills that don't already exist
-				existingSkillList := existingSkills[category].([]string)
-				for _, newSkill := range skills {
-					found := false
-					for _, existingSkill := range existingSkillList {
-						if existingSkill == newSkill {
-							found = true
-							break
-						}
-					}
-					if !found {
-						existingSkillList = append(existingSkillList, newSkill)
-					}
-				}
-				existingSkills[category] = existingSkillList
-			}
-		} else {
-			// No existing skills, use Hugging Face skills as base
-			combined["skills"] = huggingFaceSkills
-		}
-	}
-
-	// Add extraction metadata
-	combined["extraction_errors"] = errors
-	combined["extraction_timestamp"] = time.Now()
-	combined["extraction_methods_used"] = []string{"ner", "huggingface"}
-
-	return combined
+// compareMethodsRequest is an extraction request with optional gold labels for the text
+type compareMethodsRequest struct {
+	models.ExtractProcessRequest
+	Expected *extracteval.Labels `json:"expected,omitempty"`
 }
 
-// CompareExtractionMethods compares NER vs Hugging Face extraction results
+// CompareExtractionMethods compares NER vs Hugging Face extraction results. When the request
+// carries the expected labels, every method is scored against them like extract-eval does.
 func (h *CombinedExtractHandlers) CompareExtractionMethods(c *fiber.Ctx) error {
-	var request models.ExtractProcessRequest
+	var request compareMethodsRequest
 	if err := c.BodyParser(&request); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error":   "Invalid request body",
@@ -360,19 +305,21 @@ func (h *CombinedExtractHandlers) CompareExtractionMethods(c *fiber.Ctx) error {
 			"error": "text field is required",
 		})
 	}
+	request.ProcessingType = "candidate_extraction"
 
 	startTime := time.Now()
 
 	// Run both extractions
-	nerResult, nerErr := h.extractionService.ProcessText(&request)
-	huggingFaceResult, hfErr := h.huggingFaceService.ExtractSkillsFromText(request.Text)
+	nerResult, nerErr := h.extractionService.ProcessText(&request.ExtractProcessRequest)
+	huggingFaceResult, hfErr := h.huggingFaceService.ExtractSkillsFromText(c.UserContext(), request.Text)
 
-	// Parse NER result
-	var nerData map[string]interface{}
+	var nerData *models.ProcessedResumeData
 	if nerResult != nil {
-		json.Unmarshal([]byte(nerResult.ProcessedContent), &nerData)
+		nerData = nerResult.Resume
 	}
 
+	ensembleData := h.skillEnsemble.Merge(nerData, huggingFaceResult)
+
 	// Create comparison response
 	comparison := fiber.Map{
 		"success":         true,
@@ -414,16 +361,13 @@ func (h *CombinedExtractHandlers) CompareExtractionMethods(c *fiber.Ctx) error {
 		},
 		"comparison": fiber.Map{
 			"ner_skills_count": func() int {
-				if skills, ok := nerData["skills"].(map[string]interface{}); ok {
-					total := 0
-					for _, skillList := range skills {
-						if list, ok := skillList.([]string); ok {
-							total += len(list)
-						}
+				total := 0
+				if nerData != nil {
+					for _, skillList := range nerData.SkillCategories {
+						total += len(skillList)
 					}
-					return total
 				}
-				return 0
+				return total
 			}(),
 			"huggingface_skills_count": func() int {
 				if huggingFaceResult != nil {
@@ -432,8 +376,8 @@ func (h *CombinedExtractHandlers) CompareExtractionMethods(c *fiber.Ctx) error {
 				return 0
 			}(),
 			"ner_confidence": func() float64 {
-				if confidence, ok := nerData["confidence_score"].(float64); ok {
-					return confidence
+				if nerData != nil {
+					return nerData.ConfidenceScore
 				}
 				return 0.0
 			}(),
@@ -443,7 +387,24 @@ func (h *CombinedExtractHandlers) CompareExtractionMethods(c *fiber.Ctx) error {
 				}
 				return 0.0
 			}(),
+			"agreement": h.skillEnsemble.Agreement(nerData, huggingFaceResult),
 		},
+		"ensemble": ensembleData.RankedSkills,
+	}
+
+	if request.Expected != nil {
+		evaluate := func(method string, prediction extracteval.Prediction, err error) extracteval.Report {
+			result := extracteval.Score("request", *request.Expected, prediction)
+			if err != nil {
+				result = extracteval.Failed("request", *request.Expected, prediction.Fields, err)
+			}
+			return extracteval.Summarize(method, []extracteval.DocumentScore{result})
+		}
+		comparison["evaluation"] = []extracteval.Report{
+			evaluate("ner", extracteval.FromResume(nerData), nerErr),
+			evaluate("huggingface", extracteval.FromHuggingFace(huggingFaceResult), hfErr),
+			evaluate("ensemble", extracteval.FromResume(ensembleData), nerErr),
+		}
 	}
 
 	return c.Status(fiber.StatusOK).JSON(comparison)
@@ -305,10 +305,10 @@ func (h *CVExtractHandlers) GetRecentExtracts(c *fiber.Ctx) error {
 	})
 }
 
-// RegisterCVExtractRoutes registers CV extract routes
-func (h *CVExtractHandlers) RegisterCVExtractRoutes(app *fiber.App) {
+// RegisterCVExtractRoutes registers CV extract routes behind the given middleware
+func (h *CVExtractHandlers) RegisterCVExtractRoutes(app *fiber.App, middleware ...fiber.Handler) {
 	// CV extract routes
-	api := app.Group("/api/v1/cv-extract")
+	api := app.Group("/api/v1/cv-extract", middleware...)
 	api.Post("/", h.CreateOrUpdateExtract)
 	api.Get("/stats", h.GetExtractStats)
 	api.Get("/", h.ListExtracts)
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"errors"
+	"stafind-backend/internal/models"
+	"stafind-backend/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// DriveHandlers handles Google Drive sync endpoints
+type DriveHandlers struct {
+	driveService services.GoogleDriveService
+}
+
+// NewDriveHandlers creates new Google Drive handlers
+func NewDriveHandlers(driveService services.GoogleDriveService) *DriveHandlers {
+	return &DriveHandlers{
+		driveService: driveService,
+	}
+}
+
+// ScanFolder scans a Google Drive folder and processes new or changed resumes
+func (h *DriveHandlers) ScanFolder(c *fiber.Ctx) error {
+	var request models.GoogleDriveScanRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+	}
+
+	response, err := h.driveService.ScanFolder(c.UserContext(), &request)
+	if err != nil {
+		if errors.Is(err, services.ErrGoogleDriveNotConfigured) {
+			return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+				"error": "Google Drive is not configured",
+			})
+		}
+		switch err.(type) {
+		case *services.ValidationError, *services.NotFoundError, *services.ConflictError:
+			return handleServiceError(c, err)
+		}
+		return InternalServerErrorWithDetails(c, "Failed to scan Google Drive folder", err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response)
+}
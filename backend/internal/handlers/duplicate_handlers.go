@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"stafind-backend/internal/models"
+	"stafind-backend/internal/services"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// DuplicateHandlers handles review and merge of duplicate employees
+type DuplicateHandlers struct {
+	duplicateService *services.DuplicateService
+}
+
+// NewDuplicateHandlers creates new duplicate employee handlers
+func NewDuplicateHandlers(duplicateService *services.DuplicateService) *DuplicateHandlers {
+	return &DuplicateHandlers{
+		duplicateService: duplicateService,
+	}
+}
+
+// ListDuplicates returns the flagged pairs of employees, pending unless ?status=dismissed
+func (h *DuplicateHandlers) ListDuplicates(c *fiber.Ctx) error {
+	duplicates, err := h.duplicateService.ListDuplicates(c.Query("status"))
+	if err != nil {
+		return h.serviceError(c, "Failed to get duplicate employees", err)
+	}
+
+	return c.JSON(fiber.Map{
+		"duplicates": duplicates,
+		"count":      len(duplicates),
+	})
+}
+
+// ScanDuplicates compares every pair of employees and flags probable duplicates;
+// ?auto_merge=true merges the pairs at or above the auto-merge threshold
+func (h *DuplicateHandlers) ScanDuplicates(c *fiber.Ctx) error {
+	result, err := h.duplicateService.Scan(c.QueryBool("auto_merge"))
+	if err != nil {
+		return InternalServerErrorWithDetails(c, "Failed to scan for duplicate employees", err.Error())
+	}
+
+	return c.JSON(result)
+}
+
+// DismissDuplicate marks a flagged pair as different people
+func (h *DuplicateHandlers) DismissDuplicate(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid duplicate ID"})
+	}
+
+	duplicate, err := h.duplicateService.Dismiss(id)
+	if err != nil {
+		return h.serviceError(c, "Failed to dismiss duplicate employees", err)
+	}
+
+	return c.JSON(duplicate)
+}
+
+// GetEmployeeDuplicates returns the employees that may be the same person as an employee
+func (h *DuplicateHandlers) GetEmployeeDuplicates(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid employee ID"})
+	}
+
+	candidates, err := h.duplicateService.CandidatesFor(id)
+	if err != nil {
+		return h.serviceError(c, "Failed to find duplicate employees", err)
+	}
+
+	return c.JSON(fiber.Map{
+		"employee_id": id,
+		"candidates":  candidates,
+		"count":       len(candidates),
+	})
+}
+
+// MergeEmployee merges the employee in the body into the employee in the URL
+func (h *DuplicateHandlers) MergeEmployee(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid employee ID"})
+	}
+
+	var req models.MergeEmployeesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+	}
+	if req.SourceEmployeeID <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "source_employee_id is required"})
+	}
+
+	result, err := h.duplicateService.Merge(id, req.SourceEmployeeID, false)
+	if err != nil {
+		return h.serviceError(c, "Failed to merge employees", err)
+	}
+
+	return c.JSON(result)
+}
+
+// GetEmployeeMerges returns the employees merged into an employee
+func (h *DuplicateHandlers) GetEmployeeMerges(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid employee ID"})
+	}
+
+	merges, err := h.duplicateService.ListMerges(id)
+	if err != nil {
+		return h.serviceError(c, "Failed to get employee merges", err)
+	}
+
+	return c.JSON(fiber.Map{
+		"employee_id": id,
+		"merges":      merges,
+		"count":       len(merges),
+	})
+}
+
+// serviceError maps service errors to their status, and anything else to 500
+func (h *DuplicateHandlers) serviceError(c *fiber.Ctx, message string, err error) error {
+	switch err.(type) {
+	case *services.ValidationError, *services.NotFoundError, *services.ConflictError:
+		return handleServiceError(c, err)
+	}
+	return InternalServerErrorWithDetails(c, message, err.Error())
+}
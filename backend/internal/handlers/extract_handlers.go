@@ -106,23 +106,9 @@ func (h *ExtractHandlers) ExtractCandidateInfo(c *fiber.Ctx) error {
 		})
 	}
 
-	// Parse the structured result
-	var candidateInfo map[string]interface{}
-	if err := json.Unmarshal([]byte(result.ProcessedContent), &candidateInfo); err != nil {
-		// If JSON parsing fails, return the raw result with a warning
-		return c.Status(fiber.StatusOK).JSON(fiber.Map{
-			"success":           true,
-			"candidate_info":    result.ProcessedContent,
-			"warning":           "Could not parse structured data, returning raw result",
-			"processing_time":   result.ProcessingTime,
-			"model_used":        result.ModelUsed,
-			"extraction_method": "Pure NER (Prose)",
-		})
-	}
-
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"success":           true,
-		"candidate_info":    candidateInfo,
+		"candidate_info":    result.Resume,
 		"processing_time":   result.ProcessingTime,
 		"model_used":        result.ModelUsed,
 		"extraction_method": "Pure NER (Prose)",
@@ -296,6 +282,9 @@ func (h *ExtractHandlers) ExtractProcess(c *fiber.Ctx) error {
 			"error": "text field is required",
 		})
 	}
+	if request.ProcessingType == "" {
+		request.ProcessingType = "candidate_extraction"
+	}
 
 	// CV Extract Tracking: Create or update extract record
 	var cvExtract *models.CVExtract
@@ -346,15 +335,16 @@ func (h *ExtractHandlers) ExtractProcess(c *fiber.Ctx) error {
 		})
 	}
 
-	var extractedData map[string]interface{}
-	if err := json.Unmarshal([]byte(result.ProcessedContent), &extractedData); err != nil {
+	// Only candidate extraction produces resume data that can be stored
+	extractedData := result.Resume
+	if extractedData == nil {
+		err := fmt.Errorf("processing type %q does not extract candidate data", request.ProcessingType)
 		// CV Extract Tracking: Update file failure count instead of marking entire extraction as failed
 		if cvExtract != nil && request.ExtractRequestId != "" {
 			cvExtract, updateErr := h.cvExtractService.UpdateFileProgress(
 				request.ExtractRequestId,
 				request.FileNumber,
-				cvExtract.FilesProcessed, // keep current processed count
-				cvExtract.FilesFailed+1,  // increment failed count
+				models.CVExtractFileStatusFailed,
 			)
 			if updateErr != nil {
 				fmt.Printf("Warning: Failed to update CV extract progress: %v\n", updateErr)
@@ -398,8 +388,7 @@ func (h *ExtractHandlers) ExtractProcess(c *fiber.Ctx) error {
 			cvExtract, updateErr := h.cvExtractService.UpdateFileProgress(
 				request.ExtractRequestId,
 				request.FileNumber,
-				cvExtract.FilesProcessed, // keep current processed count
-				cvExtract.FilesFailed+1,  // increment failed count
+				models.CVExtractFileStatusFailed,
 			)
 			if updateErr != nil {
 				fmt.Printf("Warning: Failed to update CV extract progress: %v\n", updateErr)
@@ -430,12 +419,11 @@ func (h *ExtractHandlers) ExtractProcess(c *fiber.Ctx) error {
 		// Calculate total processing time
 		totalTimeMs := int64(result.ProcessingTime + candidateResult.ProcessingTime)
 
-		// Update file progress - increment the processed count and get updated record back
+		// Record this file as processed and get the updated record back
 		cvExtract, err := h.cvExtractService.UpdateFileProgress(
 			request.ExtractRequestId,
 			request.FileNumber,
-			cvExtract.FilesProcessed+1, // increment processed count
-			cvExtract.FilesFailed,      // keep current failed count
+			models.CVExtractFileStatusProcessed,
 		)
 		if err != nil {
 			fmt.Printf("Warning: Failed to update CV extract progress: %v\n", err)
@@ -476,6 +464,7 @@ func (h *ExtractHandlers) ExtractProcess(c *fiber.Ctx) error {
 			"processing_time":  candidateResult.ProcessingTime,
 			"status":           candidateResult.Status,
 			"message":          candidateResult.Message,
+			"review_id":        candidateResult.ReviewID,
 		},
 		"message": "File processing completed successfully",
 	}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"stafind-backend/internal/services"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ExtractionHistoryHandlers handles the extraction history of employees
+type ExtractionHistoryHandlers struct {
+	historyService *services.ExtractionHistoryService
+}
+
+// NewExtractionHistoryHandlers creates new extraction history handlers
+func NewExtractionHistoryHandlers(historyService *services.ExtractionHistoryService) *ExtractionHistoryHandlers {
+	return &ExtractionHistoryHandlers{
+		historyService: historyService,
+	}
+}
+
+// ListExtractions returns the extraction runs of an employee, newest first
+func (h *ExtractionHistoryHandlers) ListExtractions(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid employee ID"})
+	}
+
+	extractions, err := h.historyService.List(id)
+	if err != nil {
+		return h.serviceError(c, "Failed to get employee extractions", err)
+	}
+
+	return c.JSON(fiber.Map{
+		"employee_id": id,
+		"extractions": extractions,
+		"count":       len(extractions),
+	})
+}
+
+// GetExtraction returns one version of an employee's extraction with its text and data
+func (h *ExtractionHistoryHandlers) GetExtraction(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid employee ID"})
+	}
+	version, err := strconv.Atoi(c.Params("version"))
+	if err != nil || version <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid extraction version"})
+	}
+
+	extraction, err := h.historyService.Get(id, version)
+	if err != nil {
+		return h.serviceError(c, "Failed to get employee extraction", err)
+	}
+
+	return c.JSON(extraction)
+}
+
+// DiffExtractions compares two versions of an employee's extraction, ?from=1&to=3; without
+// them the latest version is compared with the one before
+func (h *ExtractionHistoryHandlers) DiffExtractions(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid employee ID"})
+	}
+
+	diff, err := h.historyService.Diff(id, c.QueryInt("from"), c.QueryInt("to"))
+	if err != nil {
+		return h.serviceError(c, "Failed to compare employee extractions", err)
+	}
+
+	return c.JSON(diff)
+}
+
+// serviceError maps service errors to their status, and anything else to 500
+func (h *ExtractionHistoryHandlers) serviceError(c *fiber.Ctx, message string, err error) error {
+	switch err.(type) {
+	case *services.ValidationError, *services.NotFoundError, *services.ConflictError:
+		return handleServiceError(c, err)
+	}
+	return InternalServerErrorWithDetails(c, message, err.Error())
+}
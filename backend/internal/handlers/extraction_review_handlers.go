@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"stafind-backend/internal/models"
+	"stafind-backend/internal/services"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ExtractionReviewHandlers handles the queue of extractions held for review
+type ExtractionReviewHandlers struct {
+	reviewService *services.ExtractionReviewService
+}
+
+// NewExtractionReviewHandlers creates new extraction review handlers
+func NewExtractionReviewHandlers(reviewService *services.ExtractionReviewService) *ExtractionReviewHandlers {
+	return &ExtractionReviewHandlers{
+		reviewService: reviewService,
+	}
+}
+
+// ListReviews returns the held extractions, pending unless ?status=approved or rejected
+func (h *ExtractionReviewHandlers) ListReviews(c *fiber.Ctx) error {
+	reviews, err := h.reviewService.List(c.Query("status"))
+	if err != nil {
+		return h.serviceError(c, "Failed to get extraction reviews", err)
+	}
+
+	return c.JSON(fiber.Map{
+		"reviews": reviews,
+		"count":   len(reviews),
+	})
+}
+
+// GetReview returns a held extraction with its resume text
+func (h *ExtractionReviewHandlers) GetReview(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid review ID"})
+	}
+
+	review, err := h.reviewService.Get(id)
+	if err != nil {
+		return h.serviceError(c, "Failed to get extraction review", err)
+	}
+
+	return c.JSON(review)
+}
+
+// UpdateReview replaces the extracted data of a pending review with corrections
+func (h *ExtractionReviewHandlers) UpdateReview(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid review ID"})
+	}
+
+	var req models.UpdateExtractionReviewRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+	}
+
+	review, err := h.reviewService.Update(id, req.ExtractedData)
+	if err != nil {
+		return h.serviceError(c, "Failed to update extraction review", err)
+	}
+
+	return c.JSON(review)
+}
+
+// ApproveReview stores a pending extraction as an employee, with the corrections in the body
+// if any
+func (h *ExtractionReviewHandlers) ApproveReview(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid review ID"})
+	}
+
+	var req models.ResolveExtractionReviewRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Invalid request body",
+				"details": err.Error(),
+			})
+		}
+	}
+
+	result, err := h.reviewService.Approve(id, req.ExtractedData, reviewer(c), req.Notes)
+	if err != nil {
+		return h.serviceError(c, "Failed to approve extraction review", err)
+	}
+
+	return c.JSON(result)
+}
+
+// RejectReview discards a pending extraction
+func (h *ExtractionReviewHandlers) RejectReview(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid review ID"})
+	}
+
+	var req models.ResolveExtractionReviewRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Invalid request body",
+				"details": err.Error(),
+			})
+		}
+	}
+
+	result, err := h.reviewService.Reject(id, reviewer(c), req.Notes)
+	if err != nil {
+		return h.serviceError(c, "Failed to reject extraction review", err)
+	}
+
+	return c.JSON(result)
+}
+
+// reviewer returns the email of the authenticated user resolving a review
+func reviewer(c *fiber.Ctx) string {
+	email, _ := c.Locals("user_email").(string)
+	return email
+}
+
+// serviceError maps service errors to their status, and anything else to 500
+func (h *ExtractionReviewHandlers) serviceError(c *fiber.Ctx, message string, err error) error {
+	switch err.(type) {
+	case *services.ValidationError, *services.NotFoundError, *services.ConflictError:
+		return handleServiceError(c, err)
+	}
+	return InternalServerErrorWithDetails(c, message, err.Error())
+}
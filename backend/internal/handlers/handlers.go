@@ -114,6 +114,9 @@ func (h *Handlers) SearchEmployees(c *fiber.Ctx) error {
 	}
 
 	matches, err := h.searchService.SearchEmployees(&searchReq)
+	if _, invalid := err.(*services.ValidationError); invalid {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
@@ -49,7 +49,7 @@ func (h *HuggingFaceHandlers) ExtractSkills(c *fiber.Ctx) error {
 	}
 
 	// Extract skills
-	response, err := h.huggingFaceService.ExtractSkills(&request)
+	response, err := h.huggingFaceService.ExtractSkills(c.UserContext(), &request)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"success": false,
@@ -83,7 +83,7 @@ func (h *HuggingFaceHandlers) ExtractSkillsFromText(c *fiber.Ctx) error {
 	}
 
 	// Extract skills using default settings
-	response, err := h.huggingFaceService.ExtractSkillsFromText(request.Text)
+	response, err := h.huggingFaceService.ExtractSkillsFromText(c.UserContext(), request.Text)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"success": false,
@@ -156,23 +156,44 @@ func (h *HuggingFaceHandlers) GetStats(c *fiber.Ctx) error {
 
 // HealthCheck provides a health check endpoint for the Hugging Face service
 func (h *HuggingFaceHandlers) HealthCheck(c *fiber.Ctx) error {
+	health := h.huggingFaceService.GetHealth()
+
 	err := h.huggingFaceService.HealthCheck()
 	if err != nil {
 		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
 			"status":    "unhealthy",
 			"error":     err.Error(),
+			"models":    health.Models,
 			"timestamp": time.Now(),
 			"service":   "Hugging Face Skill Extraction Service",
 		})
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"status":    "healthy",
+		"status":    health.Status,
+		"models":    health.Models,
 		"timestamp": time.Now(),
 		"service":   "Hugging Face Skill Extraction Service",
 	})
 }
 
+// PurgeCache removes every cached extraction result, so that the next requests call the models again
+func (h *HuggingFaceHandlers) PurgeCache(c *fiber.Ctx) error {
+	result, err := h.huggingFaceService.PurgeCache()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"error":   "Failed to purge cache",
+			"details": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"success": true,
+		"purged":  result,
+	})
+}
+
 // BatchExtractSkills extracts skills from multiple texts in batch
 func (h *HuggingFaceHandlers) BatchExtractSkills(c *fiber.Ctx) error {
 	var request struct {
@@ -218,7 +239,7 @@ func (h *HuggingFaceHandlers) BatchExtractSkills(c *fiber.Ctx) error {
 			Categories:          request.Categories,
 		}
 
-		response, err := h.huggingFaceService.ExtractSkills(&extractRequest)
+		response, err := h.huggingFaceService.ExtractSkills(c.UserContext(), &extractRequest)
 		if err != nil {
 			errors = append(errors, fmt.Sprintf("Text %d: %s", i+1, err.Error()))
 			results = append(results, models.HuggingFaceSkillExtractionResponse{
@@ -286,7 +307,7 @@ func (h *HuggingFaceHandlers) CompareModels(c *fiber.Ctx) error {
 			ModelName: modelName,
 		}
 
-		response, err := h.huggingFaceService.ExtractSkills(&extractRequest)
+		response, err := h.huggingFaceService.ExtractSkills(c.UserContext(), &extractRequest)
 		if err != nil {
 			errors = append(errors, fmt.Sprintf("Model %s: %s", modelName, err.Error()))
 			comparisons = append(comparisons, fiber.Map{
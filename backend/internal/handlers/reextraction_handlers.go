@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"stafind-backend/internal/models"
+	"stafind-backend/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ReextractionHandlers handles runs that reprocess the stored resumes of employees
+type ReextractionHandlers struct {
+	reextractionService *services.ReextractionService
+}
+
+// NewReextractionHandlers creates new re-extraction handlers
+func NewReextractionHandlers(reextractionService *services.ReextractionService) *ReextractionHandlers {
+	return &ReextractionHandlers{
+		reextractionService: reextractionService,
+	}
+}
+
+// StartReextraction starts a run for the employees matching the filter in the body, in the
+// background; follow it with GetReextraction
+func (h *ReextractionHandlers) StartReextraction(c *fiber.Ctx) error {
+	var req models.ReextractionRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Invalid request body",
+				"details": err.Error(),
+			})
+		}
+	}
+
+	run, employeeIDs, err := h.reextractionService.Start(&req)
+	if err != nil {
+		return h.serviceError(c, "Failed to start re-extraction", err)
+	}
+	h.reextractionService.RunInBackground(run, employeeIDs)
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"run":       run,
+		"employees": len(employeeIDs),
+	})
+}
+
+// GetReextraction returns a run's progress and the outcome, with its diff, for each employee
+// processed so far
+func (h *ReextractionHandlers) GetReextraction(c *fiber.Ctx) error {
+	run, err := h.reextractionService.Get(c.Params("requestId"))
+	if err != nil {
+		return h.serviceError(c, "Failed to get re-extraction", err)
+	}
+
+	return c.JSON(run)
+}
+
+// ResumeReextraction continues an interrupted run with the employees it has not processed, in
+// the background
+func (h *ReextractionHandlers) ResumeReextraction(c *fiber.Ctx) error {
+	run, employeeIDs, err := h.reextractionService.Resume(c.Params("requestId"))
+	if err != nil {
+		return h.serviceError(c, "Failed to resume re-extraction", err)
+	}
+	h.reextractionService.RunInBackground(run, employeeIDs)
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"run":       run,
+		"employees": len(employeeIDs),
+	})
+}
+
+// serviceError maps service errors to their status, and anything else to 500
+func (h *ReextractionHandlers) serviceError(c *fiber.Ctx, message string, err error) error {
+	switch err.(type) {
+	case *services.ValidationError, *services.NotFoundError, *services.ConflictError:
+		return handleServiceError(c, err)
+	}
+	return InternalServerErrorWithDetails(c, message, err.Error())
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"stafind-backend/internal/models"
+	"stafind-backend/internal/services"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SkillDiscoveryHandlers handles the terms found in resumes that look like unknown skills
+type SkillDiscoveryHandlers struct {
+	discoveryService *services.SkillDiscoveryService
+}
+
+// NewSkillDiscoveryHandlers creates new skill discovery handlers
+func NewSkillDiscoveryHandlers(discoveryService *services.SkillDiscoveryService) *SkillDiscoveryHandlers {
+	return &SkillDiscoveryHandlers{
+		discoveryService: discoveryService,
+	}
+}
+
+// ListTerms returns discovered terms, pending unless ?status=approved, aliased or ignored, most
+// frequent first, up to ?limit
+func (h *SkillDiscoveryHandlers) ListTerms(c *fiber.Ctx) error {
+	terms, err := h.discoveryService.List(c.Query("status"), c.QueryInt("limit"))
+	if err != nil {
+		return h.serviceError(c, "Failed to get skill terms", err)
+	}
+
+	return c.JSON(fiber.Map{
+		"terms": terms,
+		"count": len(terms),
+	})
+}
+
+// GetTerm returns a discovered term with its examples and suggested categories
+func (h *SkillDiscoveryHandlers) GetTerm(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid skill term ID"})
+	}
+
+	term, err := h.discoveryService.Get(id)
+	if err != nil {
+		return h.serviceError(c, "Failed to get skill term", err)
+	}
+
+	return c.JSON(term)
+}
+
+// ApproveTerm adds a discovered term to the skill catalog, named and categorized as in the
+// body if any
+func (h *SkillDiscoveryHandlers) ApproveTerm(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid skill term ID"})
+	}
+
+	var req models.ApproveSkillTermRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Invalid request body",
+				"details": err.Error(),
+			})
+		}
+	}
+
+	result, err := h.discoveryService.Approve(id, &req, reviewer(c))
+	if err != nil {
+		return h.serviceError(c, "Failed to approve skill term", err)
+	}
+
+	return c.JSON(result)
+}
+
+// AliasTerm maps a discovered term to an existing skill
+func (h *SkillDiscoveryHandlers) AliasTerm(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid skill term ID"})
+	}
+
+	var req models.AliasSkillTermRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+	}
+	if req.SkillID <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "skill_id is required"})
+	}
+
+	result, err := h.discoveryService.Alias(id, req.SkillID, reviewer(c))
+	if err != nil {
+		return h.serviceError(c, "Failed to alias skill term", err)
+	}
+
+	return c.JSON(result)
+}
+
+// IgnoreTerm stops a discovered term from being offered for approval
+func (h *SkillDiscoveryHandlers) IgnoreTerm(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid skill term ID"})
+	}
+
+	result, err := h.discoveryService.Ignore(id, reviewer(c))
+	if err != nil {
+		return h.serviceError(c, "Failed to ignore skill term", err)
+	}
+
+	return c.JSON(result)
+}
+
+// serviceError maps service errors to their status, and anything else to 500
+func (h *SkillDiscoveryHandlers) serviceError(c *fiber.Ctx, message string, err error) error {
+	switch err.(type) {
+	case *services.ValidationError, *services.NotFoundError, *services.ConflictError:
+		return handleServiceError(c, err)
+	}
+	return InternalServerErrorWithDetails(c, message, err.Error())
+}
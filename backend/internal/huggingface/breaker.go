@@ -0,0 +1,142 @@
+package huggingface
+
+import (
+	"sync"
+	"time"
+
+	"stafind-backend/internal/models"
+)
+
+// Circuit breaker states
+const (
+	StateClosed   = "closed"    // Requests flow normally
+	StateOpen     = "open"      // Requests fail fast until the cooldown ends
+	StateHalfOpen = "half_open" // One probe request decides whether to close again
+)
+
+// breaker stops calls to a model after repeated failures, so a model that is down does not
+// hold every extraction for the full retry budget
+type breaker struct {
+	mutex               sync.Mutex
+	model               string
+	threshold           int
+	cooldown            time.Duration
+	state               string
+	consecutiveFailures int
+	openedAt            time.Time
+	probing             bool
+
+	requests    int64
+	failures    int64
+	retries     int64
+	loading     bool
+	lastError   string
+	lastFailure time.Time
+	lastSuccess time.Time
+}
+
+func newBreaker(model string, threshold int, cooldown time.Duration) *breaker {
+	return &breaker{model: model, threshold: threshold, cooldown: cooldown, state: StateClosed}
+}
+
+// allow reports whether a request may be made now. After the cooldown one request is let
+// through as a probe; others keep failing fast until it finishes.
+func (b *breaker) allow(now time.Time) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		if now.Sub(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = StateHalfOpen
+		b.probing = true
+		return true
+	case StateHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// success records a successful request and closes the circuit
+func (b *breaker) success(now time.Time) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.requests++
+	b.state = StateClosed
+	b.consecutiveFailures = 0
+	b.probing = false
+	b.loading = false
+	b.lastSuccess = now
+}
+
+// failure records a failed request. The circuit opens after threshold consecutive failures,
+// or at once when a probe fails.
+func (b *breaker) failure(now time.Time, err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.requests++
+	b.failures++
+	b.consecutiveFailures++
+	b.probing = false
+	b.lastError = err.Error()
+	b.lastFailure = now
+
+	if b.state == StateHalfOpen || b.consecutiveFailures >= b.threshold {
+		b.state = StateOpen
+		b.openedAt = now
+	}
+}
+
+// release ends a request that neither succeeded nor failed, such as a cancelled one
+func (b *breaker) release() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.probing = false
+}
+
+// retried counts a retry, noting whether the model was still loading
+func (b *breaker) retried(loading bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.retries++
+	b.loading = loading
+}
+
+// health returns a snapshot of the breaker
+func (b *breaker) health() models.HuggingFaceModelHealth {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	health := models.HuggingFaceModelHealth{
+		Model:               b.model,
+		State:               b.state,
+		ConsecutiveFailures: b.consecutiveFailures,
+		TotalRequests:       b.requests,
+		FailedRequests:      b.failures,
+		Retries:             b.retries,
+		Loading:             b.loading,
+		LastError:           b.lastError,
+	}
+	if !b.lastFailure.IsZero() {
+		lastFailure := b.lastFailure
+		health.LastFailureAt = &lastFailure
+	}
+	if !b.lastSuccess.IsZero() {
+		lastSuccess := b.lastSuccess
+		health.LastSuccessAt = &lastSuccess
+	}
+	if b.state == StateOpen {
+		openUntil := b.openedAt.Add(b.cooldown)
+		health.OpenUntil = &openUntil
+	}
+	return health
+}
@@ -0,0 +1,360 @@
+// Package huggingface is a client for the Hugging Face inference API that rides out its
+// everyday failures: models that answer 503 while loading, 429 rate limits and brief
+// outages are retried with jittered backoff, honouring the API's estimated_time and
+// Retry-After hints. A circuit breaker per model fails fast once a model keeps failing, and
+// a semaphore caps concurrent requests.
+package huggingface
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"stafind-backend/internal/constants"
+	"stafind-backend/internal/models"
+)
+
+// DefaultBaseURL is the public inference API
+const DefaultBaseURL = "https://api-inference.huggingface.co"
+
+// maxResponseSize caps the size of a model response
+const maxResponseSize = 10 << 20
+
+// ErrCircuitOpen is returned without calling a model whose circuit breaker is open
+var ErrCircuitOpen = errors.New("model circuit breaker is open")
+
+// HTTPClient is the subset of *http.Client the client needs, so a fake inference API can be injected
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Config tunes retries, concurrency and the circuit breakers
+type Config struct {
+	MaxAttempts      int           // Attempts per call, including the first
+	BaseDelay        time.Duration // Backoff before the first retry, doubled for each later one
+	MaxDelay         time.Duration // Longest backoff without a hint from the API
+	MaxWait          time.Duration // Longest total wait between attempts of one call
+	MaxConcurrent    int           // Requests in flight at once; 0 for no limit
+	BreakerThreshold int           // Consecutive failed calls that open a model's circuit
+	BreakerCooldown  time.Duration // How long an open circuit fails fast before a probe
+}
+
+// DefaultConfig waits up to two minutes for a loading model and opens a circuit after five
+// failed calls in a row
+func DefaultConfig() Config {
+	return Config{
+		MaxAttempts:      5,
+		BaseDelay:        500 * time.Millisecond,
+		MaxDelay:         30 * time.Second,
+		MaxWait:          2 * time.Minute,
+		MaxConcurrent:    4,
+		BreakerThreshold: 5,
+		BreakerCooldown:  time.Minute,
+	}
+}
+
+// APIError is an error response of the inference API
+type APIError struct {
+	StatusCode int
+	Message    string
+	RetryAfter time.Duration // From estimated_time or Retry-After; 0 when not given
+	Loading    bool          // The model is being loaded
+}
+
+func (e *APIError) Error() string {
+	if strings.Contains(e.Message, "sufficient permissions") || strings.Contains(e.Message, "authentication method") {
+		return fmt.Sprintf("hugging Face API permission error: %s. Please check your API key has Inference API permissions enabled", e.Message)
+	}
+	if e.Message != "" {
+		return fmt.Sprintf("hugging Face API error (status %d): %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("hugging Face API returned status %d", e.StatusCode)
+}
+
+// Client calls Hugging Face models. It is safe for concurrent use.
+type Client struct {
+	baseURL    string
+	apiKey     string
+	httpClient HTTPClient
+	config     Config
+	slots      chan struct{}
+
+	mutex    sync.Mutex
+	breakers map[string]*breaker
+}
+
+// NewClient creates an inference API client. An empty baseURL uses the public API.
+func NewClient(baseURL, apiKey string, httpClient HTTPClient, config Config) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	if config.MaxAttempts < 1 {
+		config.MaxAttempts = 1
+	}
+	if config.BreakerThreshold < 1 {
+		config.BreakerThreshold = 1
+	}
+
+	client := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		apiKey:     apiKey,
+		httpClient: httpClient,
+		config:     config,
+		breakers:   make(map[string]*breaker),
+	}
+	if config.MaxConcurrent > 0 {
+		client.slots = make(chan struct{}, config.MaxConcurrent)
+	}
+	return client
+}
+
+// NewClientFromEnv builds a client from the HUGGINGFACE_* environment variables
+func NewClientFromEnv(httpClient HTTPClient) *Client {
+	config := DefaultConfig()
+	if value := os.Getenv(constants.EnvHuggingFaceMaxConcurrent); value != "" {
+		if n, err := strconv.Atoi(value); err == nil && n >= 0 {
+			config.MaxConcurrent = n
+		}
+	}
+	return NewClient(os.Getenv(constants.EnvHuggingFaceAPIURL), os.Getenv(constants.EnvHuggingFaceAPIKey), httpClient, config)
+}
+
+// Configured reports whether an API key is set
+func (c *Client) Configured() bool {
+	return c.apiKey != ""
+}
+
+// ModelURL returns the inference endpoint of a model
+func (c *Client) ModelURL(model string) string {
+	return c.baseURL + "/models/" + model
+}
+
+// Infer posts payload as JSON to a model and returns the response body, retrying failures
+// that may pass
+func (c *Client) Infer(ctx context.Context, model string, payload interface{}) ([]byte, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request payload: %w", err)
+	}
+
+	b := c.breaker(model)
+	if !b.allow(time.Now()) {
+		return nil, fmt.Errorf("%w: %s", ErrCircuitOpen, model)
+	}
+
+	deadline := time.Now().Add(c.config.MaxWait)
+	for attempt := 1; ; attempt++ {
+		response, err := c.post(ctx, model, body)
+		if err == nil {
+			b.success(time.Now())
+			return response, nil
+		}
+
+		switch {
+		case ctx.Err() != nil:
+			b.release()
+			return nil, ctx.Err()
+		case !retryable(err):
+			// The request itself is wrong; the model is not at fault
+			b.release()
+			return nil, err
+		case attempt == c.config.MaxAttempts:
+			b.failure(time.Now(), err)
+			return nil, err
+		}
+
+		delay := c.backoff(attempt, err)
+		if time.Until(deadline) < delay {
+			b.failure(time.Now(), err)
+			return nil, err
+		}
+
+		var apiErr *APIError
+		b.retried(errors.As(err, &apiErr) && apiErr.Loading)
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			b.release()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Warm sends a small input to a model so that the API loads it before real requests arrive
+func (c *Client) Warm(ctx context.Context, model string) error {
+	_, err := c.Infer(ctx, model, map[string]interface{}{"inputs": "Warm up"})
+	return err
+}
+
+// Health returns the state of the given models and of every other model called so far,
+// ordered by name
+func (c *Client) Health(modelNames ...string) []models.HuggingFaceModelHealth {
+	for _, model := range modelNames {
+		c.breaker(model)
+	}
+
+	c.mutex.Lock()
+	breakers := make([]*breaker, 0, len(c.breakers))
+	for _, b := range c.breakers {
+		breakers = append(breakers, b)
+	}
+	c.mutex.Unlock()
+
+	health := make([]models.HuggingFaceModelHealth, 0, len(breakers))
+	for _, b := range breakers {
+		health = append(health, b.health())
+	}
+	sort.Slice(health, func(i, j int) bool { return health[i].Model < health[j].Model })
+	return health
+}
+
+func (c *Client) breaker(model string) *breaker {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	b, exists := c.breakers[model]
+	if !exists {
+		b = newBreaker(model, c.config.BreakerThreshold, c.config.BreakerCooldown)
+		c.breakers[model] = b
+	}
+	return b
+}
+
+// post makes one request, holding a concurrency slot only while it is in flight
+func (c *Client) post(ctx context.Context, model string, body []byte) ([]byte, error) {
+	if c.slots != nil {
+		select {
+		case c.slots <- struct{}{}:
+			defer func() { <-c.slots }()
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.ModelURL(model), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request to Hugging Face API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	response, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseAPIError(resp, response)
+	}
+	return response, nil
+}
+
+// parseAPIError reads the error message and retry hints of an error response
+func parseAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+
+	var payload struct {
+		Error         interface{} `json:"error"`
+		EstimatedTime float64     `json:"estimated_time"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil {
+		switch message := payload.Error.(type) {
+		case string:
+			apiErr.Message = message
+		case []interface{}:
+			parts := make([]string, 0, len(message))
+			for _, part := range message {
+				parts = append(parts, fmt.Sprint(part))
+			}
+			apiErr.Message = strings.Join(parts, "; ")
+		}
+		if payload.EstimatedTime > 0 {
+			apiErr.RetryAfter = time.Duration(payload.EstimatedTime * float64(time.Second))
+		}
+	} else {
+		apiErr.Message = strings.TrimSpace(string(body))
+	}
+
+	apiErr.Loading = resp.StatusCode == http.StatusServiceUnavailable &&
+		(payload.EstimatedTime > 0 || strings.Contains(strings.ToLower(apiErr.Message), "loading"))
+
+	if apiErr.RetryAfter == 0 {
+		apiErr.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+	}
+	return apiErr
+}
+
+// parseRetryAfter reads a Retry-After header in seconds or as an HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(at); wait > 0 {
+			return wait
+		}
+	}
+	return 0
+}
+
+// retryable reports whether a failed request may succeed when repeated: rate limits, server
+// errors, loading models and network failures
+func retryable(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return true
+	}
+
+	switch apiErr.StatusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// backoff returns the wait before the next attempt: the API's hint when it gave one,
+// exponential otherwise, with jitter so that waiting callers do not retry together
+func (c *Client) backoff(attempt int, err error) time.Duration {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		return apiErr.RetryAfter + jitter(c.config.BaseDelay)
+	}
+
+	delay := c.config.BaseDelay << (attempt - 1)
+	if delay <= 0 || delay > c.config.MaxDelay {
+		delay = c.config.MaxDelay
+	}
+	return delay/2 + jitter(delay/2)
+}
+
+// jitter returns a random duration below max
+func jitter(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(max)))
+}
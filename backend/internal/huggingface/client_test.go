@@ -0,0 +1,252 @@
+package huggingface
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testConfig retries quickly so the tests do not wait on real backoff
+func testConfig() Config {
+	return Config{
+		MaxAttempts:      3,
+		BaseDelay:        time.Millisecond,
+		MaxDelay:         5 * time.Millisecond,
+		MaxWait:          time.Second,
+		MaxConcurrent:    2,
+		BreakerThreshold: 2,
+		BreakerCooldown:  50 * time.Millisecond,
+	}
+}
+
+// fakeAPI answers each request with the next response in turn, repeating the last one
+type fakeAPI struct {
+	requests  int32
+	responses []func(w http.ResponseWriter)
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	n := int(atomic.AddInt32(&f.requests, 1))
+	if n > len(f.responses) {
+		n = len(f.responses)
+	}
+	f.responses[n-1](w)
+}
+
+func (f *fakeAPI) count() int {
+	return int(atomic.LoadInt32(&f.requests))
+}
+
+func status(code int, body string) func(w http.ResponseWriter) {
+	return func(w http.ResponseWriter) {
+		w.WriteHeader(code)
+		fmt.Fprint(w, body)
+	}
+}
+
+func newTestClient(t *testing.T, api http.Handler, config Config) *Client {
+	t.Helper()
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+	return NewClient(server.URL, "test-key", server.Client(), config)
+}
+
+func TestInferWaitsForLoadingModel(t *testing.T) {
+	api := &fakeAPI{responses: []func(w http.ResponseWriter){
+		status(http.StatusServiceUnavailable, `{"error":"Model dslim/bert-base-NER is currently loading","estimated_time":0.05}`),
+		status(http.StatusOK, `[]`),
+	}}
+	client := newTestClient(t, api, testConfig())
+
+	started := time.Now()
+	body, err := client.Infer(context.Background(), "dslim/bert-base-NER", map[string]string{"inputs": "Go"})
+	if err != nil {
+		t.Fatalf("Infer() error = %v", err)
+	}
+	if string(body) != "[]" {
+		t.Errorf("Infer() = %s, want []", body)
+	}
+	if api.count() != 2 {
+		t.Errorf("made %d requests, want 2", api.count())
+	}
+	if elapsed := time.Since(started); elapsed < 50*time.Millisecond {
+		t.Errorf("retried after %v, want at least the estimated_time of 50ms", elapsed)
+	}
+
+	health := client.Health()[0]
+	if health.State != StateClosed || health.Retries != 1 || health.FailedRequests != 0 {
+		t.Errorf("health = %+v, want closed with one retry and no failures", health)
+	}
+}
+
+func TestInferHonoursRetryAfter(t *testing.T) {
+	rateLimited := func(w http.ResponseWriter) {
+		w.Header().Set("Retry-After", "1")
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"error":"Rate limit reached"}`)
+	}
+
+	// The hint fits the budget: the client waits for it and retries
+	api := &fakeAPI{responses: []func(w http.ResponseWriter){rateLimited, status(http.StatusOK, `[]`)}}
+	config := testConfig()
+	config.MaxWait = 2 * time.Second
+	client := newTestClient(t, api, config)
+
+	started := time.Now()
+	if _, err := client.Infer(context.Background(), "model", nil); err != nil {
+		t.Fatalf("Infer() error = %v", err)
+	}
+	if elapsed := time.Since(started); elapsed < time.Second {
+		t.Errorf("retried after %v, want at least the Retry-After of 1s", elapsed)
+	}
+
+	// The hint is longer than the budget: the client gives up at once instead of retrying early
+	api = &fakeAPI{responses: []func(w http.ResponseWriter){rateLimited}}
+	config.MaxWait = 100 * time.Millisecond
+	client = newTestClient(t, api, config)
+
+	_, err := client.Infer(context.Background(), "model", nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests || apiErr.RetryAfter != time.Second {
+		t.Fatalf("Infer() error = %v, want the 429 with its Retry-After", err)
+	}
+	if api.count() != 1 {
+		t.Errorf("made %d requests, want 1", api.count())
+	}
+}
+
+func TestInferDoesNotRetryBadRequests(t *testing.T) {
+	api := &fakeAPI{responses: []func(w http.ResponseWriter){status(http.StatusBadRequest, `{"error":["inputs is required"]}`)}}
+	client := newTestClient(t, api, testConfig())
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.Infer(context.Background(), "model", nil); err == nil {
+			t.Fatal("Infer() of a bad request should fail")
+		}
+	}
+	if api.count() != 3 {
+		t.Errorf("made %d requests, want one per call", api.count())
+	}
+	// The model is not at fault, so its circuit stays closed
+	if health := client.Health()[0]; health.State != StateClosed || health.FailedRequests != 0 {
+		t.Errorf("health = %+v, want closed without failures", health)
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	failing := status(http.StatusInternalServerError, `{"error":"internal error"}`)
+	api := &fakeAPI{responses: []func(w http.ResponseWriter){failing}}
+	config := testConfig()
+	config.MaxAttempts = 1
+	client := newTestClient(t, api, config)
+	ctx := context.Background()
+
+	// Two failed calls open the circuit; the next fails fast without a request
+	for i := 0; i < 2; i++ {
+		if _, err := client.Infer(ctx, "model", nil); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("call %d error = %v, want the API error", i+1, err)
+		}
+	}
+	if _, err := client.Infer(ctx, "model", nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Infer() with an open circuit error = %v, want ErrCircuitOpen", err)
+	}
+	if api.count() != 2 {
+		t.Fatalf("made %d requests, want 2", api.count())
+	}
+	health := client.Health()[0]
+	if health.State != StateOpen || health.OpenUntil == nil {
+		t.Fatalf("health = %+v, want open", health)
+	}
+
+	// After the cooldown one probe goes through; a failed probe opens the circuit again at once
+	time.Sleep(config.BreakerCooldown)
+	if _, err := client.Infer(ctx, "model", nil); err == nil || errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("probe error = %v, want the API error", err)
+	}
+	if _, err := client.Infer(ctx, "model", nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Infer() after a failed probe error = %v, want ErrCircuitOpen", err)
+	}
+
+	// A successful probe closes it
+	api.responses = []func(w http.ResponseWriter){status(http.StatusOK, `[]`)}
+	atomic.StoreInt32(&api.requests, 0)
+	time.Sleep(config.BreakerCooldown)
+	if _, err := client.Infer(ctx, "model", nil); err != nil {
+		t.Fatalf("probe error = %v", err)
+	}
+	if health := client.Health()[0]; health.State != StateClosed || health.ConsecutiveFailures != 0 {
+		t.Errorf("health = %+v, want closed", health)
+	}
+}
+
+// While the half-open probe is in flight, other calls keep failing fast
+func TestCircuitBreakerSingleProbe(t *testing.T) {
+	release := make(chan struct{})
+	var requests int32
+	api := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		<-release
+		fmt.Fprint(w, `[]`)
+	})
+	config := testConfig()
+	config.MaxAttempts = 1
+	config.BreakerThreshold = 1
+	client := newTestClient(t, api, config)
+	ctx := context.Background()
+
+	if _, err := client.Infer(ctx, "model", nil); err == nil {
+		t.Fatal("first call should fail")
+	}
+	time.Sleep(config.BreakerCooldown)
+
+	probe := make(chan error, 1)
+	go func() {
+		_, err := client.Infer(ctx, "model", nil)
+		probe <- err
+	}()
+	for atomic.LoadInt32(&requests) < 2 {
+		time.Sleep(time.Millisecond)
+	}
+
+	if health := client.Health()[0]; health.State != StateHalfOpen {
+		t.Errorf("state during the probe = %s, want %s", health.State, StateHalfOpen)
+	}
+	if _, err := client.Infer(ctx, "model", nil); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("call during the probe error = %v, want ErrCircuitOpen", err)
+	}
+
+	close(release)
+	if err := <-probe; err != nil {
+		t.Fatalf("probe error = %v", err)
+	}
+	if _, err := client.Infer(ctx, "model", nil); err != nil {
+		t.Errorf("call after a successful probe error = %v", err)
+	}
+}
+
+func TestInferStopsWhenCancelled(t *testing.T) {
+	api := &fakeAPI{responses: []func(w http.ResponseWriter){
+		status(http.StatusServiceUnavailable, `{"error":"Model is currently loading","estimated_time":10}`),
+	}}
+	config := testConfig()
+	config.MaxWait = time.Minute
+	client := newTestClient(t, api, config)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.Infer(ctx, "model", nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Infer() error = %v, want the context error", err)
+	}
+	// A cancelled call says nothing about the model
+	if health := client.Health()[0]; health.FailedRequests != 0 || health.State != StateClosed {
+		t.Errorf("health = %+v, want closed without failures", health)
+	}
+}
@@ -0,0 +1,134 @@
+// Package identity recognises the same person across resumes. It normalizes the identifiers
+// a resume carries (emails, phone numbers, LinkedIn and GitHub profiles), compares names
+// tolerating accents, initials and a missing second surname, and fingerprints resume text so
+// that two uploads of the same document can be told apart from two different people.
+package identity
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Identifier kinds
+const (
+	KindEmail       = "email"
+	KindPhone       = "phone"
+	KindLinkedIn    = "linkedin"
+	KindGitHub      = "github"
+	KindFingerprint = "fingerprint"
+)
+
+// Identifier is one normalized identifier of a person
+type Identifier struct {
+	Kind  string
+	Value string
+}
+
+// minPhoneDigits is the shortest number that identifies a line rather than an extension
+const minPhoneDigits = 7
+
+// phoneDigits is how many trailing digits are compared, so numbers with and without a
+// country code or trunk prefix match
+const phoneDigits = 10
+
+var (
+	linkedInPattern = regexp.MustCompile(`(?i)linkedin\.com/in/([a-z0-9][a-z0-9\-_%.]*)`)
+	gitHubPattern   = regexp.MustCompile(`(?i)github\.com/([a-z0-9](?:[a-z0-9]|-[a-z0-9]){0,38})`)
+)
+
+// gitHubPaths are github.com paths that are not user profiles
+var gitHubPaths = map[string]bool{
+	"about": true, "features": true, "orgs": true, "topics": true, "marketplace": true,
+	"pricing": true, "settings": true, "login": true, "join": true, "explore": true,
+}
+
+// placeholderDomains are reserved for examples (RFC 2606); addresses there identify nobody,
+// such as the firstname.lastname@example.com the extractor makes up for resumes without one
+var placeholderDomains = map[string]bool{
+	"example.com": true, "example.org": true, "example.net": true,
+}
+
+// NormalizeEmail lowercases an address; it returns "" for something that is not an address
+// or is a placeholder
+func NormalizeEmail(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	at := strings.LastIndex(email, "@")
+	if at < 1 || at == len(email)-1 || strings.ContainsAny(email, " \t\n") || placeholderDomains[email[at+1:]] {
+		return ""
+	}
+	return email
+}
+
+// NormalizePhone keeps the last ten digits of a number; it returns "" for numbers too short
+// to identify anyone
+func NormalizePhone(phone string) string {
+	var digits strings.Builder
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+
+	value := digits.String()
+	if len(value) < minPhoneDigits {
+		return ""
+	}
+	if len(value) > phoneDigits {
+		value = value[len(value)-phoneDigits:]
+	}
+	return value
+}
+
+// ProfileHandles finds LinkedIn and GitHub profiles in text, as lowercase handles
+func ProfileHandles(text string) []Identifier {
+	var identifiers []Identifier
+	seen := make(map[Identifier]bool)
+	add := func(identifier Identifier) {
+		if !seen[identifier] {
+			seen[identifier] = true
+			identifiers = append(identifiers, identifier)
+		}
+	}
+
+	for _, match := range linkedInPattern.FindAllStringSubmatch(text, -1) {
+		add(Identifier{Kind: KindLinkedIn, Value: strings.TrimRight(strings.ToLower(match[1]), ".")})
+	}
+	for _, match := range gitHubPattern.FindAllStringSubmatch(text, -1) {
+		if handle := strings.ToLower(match[1]); !gitHubPaths[handle] {
+			add(Identifier{Kind: KindGitHub, Value: handle})
+		}
+	}
+	return identifiers
+}
+
+// accentFolds maps accented Latin letters to their base letter
+var accentFolds = map[rune]rune{
+	'á': 'a', 'à': 'a', 'â': 'a', 'ä': 'a', 'ã': 'a', 'å': 'a',
+	'é': 'e', 'è': 'e', 'ê': 'e', 'ë': 'e',
+	'í': 'i', 'ì': 'i', 'î': 'i', 'ï': 'i',
+	'ó': 'o', 'ò': 'o', 'ô': 'o', 'ö': 'o', 'õ': 'o', 'ø': 'o',
+	'ú': 'u', 'ù': 'u', 'û': 'u', 'ü': 'u',
+	'ñ': 'n', 'ç': 'c', 'ý': 'y', 'ÿ': 'y',
+}
+
+// NormalizeName lowercases a name, folds accents and drops punctuation, so "José  Pérez-Gil"
+// becomes "jose perez gil"
+func NormalizeName(name string) string {
+	var normalized strings.Builder
+	space := true
+	for _, r := range strings.ToLower(name) {
+		if folded, exists := accentFolds[r]; exists {
+			r = folded
+		}
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			normalized.WriteRune(r)
+			space = false
+		case !space:
+			normalized.WriteRune(' ')
+			space = true
+		}
+	}
+	return strings.TrimSpace(normalized.String())
+}
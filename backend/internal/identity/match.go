@@ -0,0 +1,112 @@
+package identity
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Signal strengths: how sure one kind of evidence alone makes a match. Signals combine as
+// independent evidence, score = 1 - Π(1 - signal), so a similar name with the same phone is
+// surer than either alone.
+const (
+	emailSignal       = 1.0  // Addresses are personal
+	profileSignal     = 0.95 // A LinkedIn or GitHub profile belongs to one person
+	phoneSignal       = 0.8  // Phones are shared by households and reassigned
+	fingerprintSignal = 0.8  // The same document; templates make different people look alike
+	nameSignal        = 0.6  // Names repeat, more so common ones
+)
+
+// Thresholds for a comparison to count as a signal
+const (
+	NameThreshold        = 0.85
+	FingerprintThreshold = 0.9
+)
+
+// Profile is what is known about a person for matching
+type Profile struct {
+	Name        string
+	Identifiers []Identifier
+}
+
+// Add appends an identifier unless it is empty or already known
+func (p *Profile) Add(kind, value string) {
+	if value == "" {
+		return
+	}
+	for _, identifier := range p.Identifiers {
+		if identifier.Kind == kind && identifier.Value == value {
+			return
+		}
+	}
+	p.Identifiers = append(p.Identifiers, Identifier{Kind: kind, Value: value})
+}
+
+// Values returns the identifiers of a kind
+func (p *Profile) Values(kind string) []string {
+	var values []string
+	for _, identifier := range p.Identifiers {
+		if identifier.Kind == kind {
+			values = append(values, identifier.Value)
+		}
+	}
+	return values
+}
+
+// Compare scores how likely two profiles are the same person, from 0 to 1, with the evidence
+// for it
+func Compare(a, b Profile) (float64, []string) {
+	var reasons []string
+	doubt := 1.0
+	signal := func(strength float64, reason string) {
+		doubt *= 1 - strength
+		reasons = append(reasons, reason)
+	}
+
+	for _, kind := range []string{KindEmail, KindLinkedIn, KindGitHub, KindPhone} {
+		if value, shared := shared(a.Values(kind), b.Values(kind)); shared {
+			strength := phoneSignal
+			switch kind {
+			case KindEmail:
+				strength = emailSignal
+			case KindLinkedIn, KindGitHub:
+				strength = profileSignal
+			}
+			signal(strength, fmt.Sprintf("same %s %s", kind, value))
+		}
+	}
+
+	best := 0.0
+	for _, x := range a.Values(KindFingerprint) {
+		for _, y := range b.Values(KindFingerprint) {
+			best = max(best, FingerprintSimilarity(x, y))
+		}
+	}
+	if best >= FingerprintThreshold {
+		signal(fingerprintSignal*best, fmt.Sprintf("resume text %.0f%% similar", best*100))
+	}
+
+	if similarity := NameSimilarity(a.Name, b.Name); similarity >= NameThreshold {
+		signal(nameSignal*similarity, fmt.Sprintf("name %.0f%% similar", similarity*100))
+	}
+
+	sort.Strings(reasons)
+	return 1 - doubt, reasons
+}
+
+// Shares reports whether two profiles have an identifier of a kind in common
+func Shares(a, b Profile, kind string) bool {
+	_, shared := shared(a.Values(kind), b.Values(kind))
+	return shared
+}
+
+// shared returns a value present in both lists
+func shared(a, b []string) (string, bool) {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return x, true
+			}
+		}
+	}
+	return "", false
+}
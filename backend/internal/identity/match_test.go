@@ -0,0 +1,95 @@
+package identity
+
+import (
+	"math"
+	"testing"
+)
+
+// profile builds a profile from kind/value pairs
+func profile(name string, identifiers ...string) Profile {
+	p := Profile{Name: name}
+	for i := 0; i+1 < len(identifiers); i += 2 {
+		p.Add(identifiers[i], identifiers[i+1])
+	}
+	return p
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     Profile
+		want     float64
+		reasons  int
+		sameMail bool
+	}{
+		{
+			name:     "same email",
+			a:        profile("Ana García", KindEmail, "ana@acme.com"),
+			b:        profile("A. García", KindEmail, "ana@acme.com"),
+			want:     1,
+			reasons:  2,
+			sameMail: true,
+		},
+		{
+			name:    "same LinkedIn profile",
+			a:       profile("Ana García", KindLinkedIn, "anagarcia"),
+			b:       profile("Pedro Martínez", KindLinkedIn, "anagarcia"),
+			want:    profileSignal,
+			reasons: 1,
+		},
+		{
+			name:    "same phone and similar name",
+			a:       profile("Ana García López", KindPhone, "3001234567"),
+			b:       profile("Ana Garcia", KindPhone, "3001234567"),
+			want:    1 - (1-phoneSignal)*(1-nameSignal),
+			reasons: 2,
+		},
+		{
+			name:    "same phone only",
+			a:       profile("Ana García", KindPhone, "3001234567"),
+			b:       profile("Pedro Martínez", KindPhone, "3001234567"),
+			want:    phoneSignal,
+			reasons: 1,
+		},
+		{
+			name: "nothing in common",
+			a:    profile("Ana García", KindEmail, "ana@acme.com", KindPhone, "3001234567"),
+			b:    profile("Pedro Martínez", KindEmail, "pedro@acme.com", KindPhone, "3009876543"),
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, reasons := Compare(tt.a, tt.b)
+			if math.Abs(score-tt.want) > 0.001 {
+				t.Errorf("score = %.3f, want %.3f (%v)", score, tt.want, reasons)
+			}
+			if len(reasons) != tt.reasons {
+				t.Errorf("reasons = %v, want %d", reasons, tt.reasons)
+			}
+			if got := Shares(tt.a, tt.b, KindEmail); got != tt.sameMail {
+				t.Errorf("Shares(email) = %v, want %v", got, tt.sameMail)
+			}
+
+			reverse, _ := Compare(tt.b, tt.a)
+			if reverse != score {
+				t.Errorf("score = %.3f, but %.3f the other way round", score, reverse)
+			}
+		})
+	}
+}
+
+func TestNormalizePhone(t *testing.T) {
+	tests := map[string]string{
+		"+57 300 123 4567": "3001234567",
+		"(300) 123-4567":   "3001234567",
+		"123 45":           "",
+		"":                 "",
+	}
+	for input, want := range tests {
+		if got := NormalizePhone(input); got != want {
+			t.Errorf("NormalizePhone(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
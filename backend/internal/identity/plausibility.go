@@ -0,0 +1,54 @@
+package identity
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// headingWords are words of resume headings and labels that the name heuristic mistakes for
+// a name, such as "Curriculum Vitae" or "Hoja de Vida"
+var headingWords = map[string]bool{
+	"curriculum": true, "vitae": true, "cv": true, "resume": true, "hoja": true, "vida": true,
+	"profile": true, "perfil": true, "summary": true, "resumen": true, "contact": true,
+	"contacto": true, "personal": true, "information": true, "informacion": true, "datos": true,
+	"experience": true, "experiencia": true, "objective": true, "objetivo": true, "page": true,
+	"pagina": true, "professional": true, "profesional": true,
+}
+
+// noName is what the name heuristic returns when it finds nothing
+const noName = "Name not found"
+
+// Name length limits in words
+const (
+	minNameWords = 2
+	maxNameWords = 5
+)
+
+// NameProblem explains why an extracted name is probably not a person's name, or returns ""
+// when it looks like one
+func NameProblem(name string) string {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.EqualFold(name, noName) {
+		return "no candidate name found"
+	}
+	if strings.ContainsFunc(name, func(r rune) bool {
+		return unicode.IsDigit(r) || strings.ContainsRune("@/\\|:;#_=+<>[]{}", r)
+	}) {
+		return fmt.Sprintf("name %q contains digits or symbols", name)
+	}
+
+	words := strings.Fields(NormalizeName(name))
+	switch {
+	case len(words) < minNameWords:
+		return fmt.Sprintf("name %q is a single word", name)
+	case len(words) > maxNameWords:
+		return fmt.Sprintf("name %q has more than %d words", name, maxNameWords)
+	}
+	for _, word := range words {
+		if headingWords[word] {
+			return fmt.Sprintf("name %q looks like a heading", name)
+		}
+	}
+	return ""
+}
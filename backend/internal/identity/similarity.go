@@ -0,0 +1,163 @@
+package identity
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/bits"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// shingleSize is the number of words hashed together by Fingerprint
+const shingleSize = 3
+
+// minFingerprintWords is the shortest text worth fingerprinting; shorter texts are too alike
+const minFingerprintWords = 50
+
+// NameSimilarity compares two names from 0 to 1. Each word of one name is paired with its
+// closest word of the other (Jaro-Winkler), so word order does not matter, initials match
+// the words they abbreviate, and "Ana García" is the same as "Ana García López". A single
+// word is never enough to match a longer name.
+func NameSimilarity(a, b string) float64 {
+	wordsA := strings.Fields(NormalizeName(a))
+	wordsB := strings.Fields(NormalizeName(b))
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return 0
+	}
+	if len(wordsA) > len(wordsB) {
+		wordsA, wordsB = wordsB, wordsA
+	}
+
+	used := make([]bool, len(wordsB))
+	var total float64
+	for _, word := range wordsA {
+		best, bestIndex := 0.0, -1
+		for i, other := range wordsB {
+			if used[i] {
+				continue
+			}
+			if similarity := wordSimilarity(word, other); similarity > best {
+				best, bestIndex = similarity, i
+			}
+		}
+		if bestIndex >= 0 {
+			used[bestIndex] = true
+		}
+		total += best
+	}
+
+	// Dropping a second surname is common; dropping everything but the first name is not
+	words := len(wordsA)
+	if words < 2 {
+		words = len(wordsB)
+	}
+	return total / float64(words)
+}
+
+// wordSimilarity compares two name words; an initial matches any word it starts
+func wordSimilarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	if (len(a) == 1 && strings.HasPrefix(b, a)) || (len(b) == 1 && strings.HasPrefix(a, b)) {
+		return 0.9
+	}
+	return jaroWinkler(a, b)
+}
+
+// jaroWinkler is the Jaro-Winkler similarity of two strings
+func jaroWinkler(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 || len(rb) == 0 {
+		return 0
+	}
+
+	window := max(len(ra), len(rb))/2 - 1
+	if window < 0 {
+		window = 0
+	}
+
+	matchedA := make([]bool, len(ra))
+	matchedB := make([]bool, len(rb))
+	matches := 0
+	for i := range ra {
+		for j := max(0, i-window); j < min(len(rb), i+window+1); j++ {
+			if !matchedB[j] && ra[i] == rb[j] {
+				matchedA[i], matchedB[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions, j := 0, 0
+	for i := range ra {
+		if !matchedA[i] {
+			continue
+		}
+		for !matchedB[j] {
+			j++
+		}
+		if ra[i] != rb[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	jaro := (m/float64(len(ra)) + m/float64(len(rb)) + (m-float64(transpositions)/2)/m) / 3
+
+	prefix := 0
+	for prefix < min(4, len(ra), len(rb)) && ra[prefix] == rb[prefix] {
+		prefix++
+	}
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
+
+// Fingerprint returns a SimHash of the text's word shingles as 16 hex digits, or "" for a
+// text too short to fingerprint. Near-identical texts, such as two exports of one resume,
+// differ in few bits.
+func Fingerprint(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) < minFingerprintWords {
+		return ""
+	}
+
+	var weights [64]int
+	for i := 0; i+shingleSize <= len(words); i++ {
+		hash := fnv.New64a()
+		hash.Write([]byte(strings.Join(words[i:i+shingleSize], " ")))
+		sum := hash.Sum64()
+		for bit := 0; bit < 64; bit++ {
+			if sum&(1<<bit) != 0 {
+				weights[bit]++
+			} else {
+				weights[bit]--
+			}
+		}
+	}
+
+	var fingerprint uint64
+	for bit, weight := range weights {
+		if weight > 0 {
+			fingerprint |= 1 << bit
+		}
+	}
+	return fmt.Sprintf("%016x", fingerprint)
+}
+
+// FingerprintSimilarity compares two fingerprints from 0 to 1 by the share of equal bits
+func FingerprintSimilarity(a, b string) float64 {
+	x, errA := strconv.ParseUint(a, 16, 64)
+	y, errB := strconv.ParseUint(b, 16, 64)
+	if errA != nil || errB != nil {
+		return 0
+	}
+	return 1 - float64(bits.OnesCount64(x^y))/64
+}
@@ -0,0 +1,49 @@
+package identity
+
+import "testing"
+
+func TestNameSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		min, max float64
+	}{
+		{"Ana García", "Ana García", 1, 1},
+		{"José Pérez", "jose perez", 1, 1},
+		{"Pérez, José", "José Pérez", 1, 1},
+		{"Ana García López", "Ana García", 1, 1},
+		{"J. Smith", "John Smith", 0.9, 0.99},
+		{"Jon Smith", "John Smith", 0.9, 0.99},
+		{"Ana", "Ana García", 0, 0.5},
+		{"Ana García", "Pedro Martínez", 0, 0.6},
+		{"", "Ana García", 0, 0},
+	}
+
+	for _, tt := range tests {
+		got := NameSimilarity(tt.a, tt.b)
+		if got < tt.min || got > tt.max {
+			t.Errorf("NameSimilarity(%q, %q) = %.3f, want between %.2f and %.2f", tt.a, tt.b, got, tt.min, tt.max)
+		}
+		if reverse := NameSimilarity(tt.b, tt.a); reverse != got {
+			t.Errorf("NameSimilarity(%q, %q) = %.3f, but %.3f the other way round", tt.a, tt.b, got, reverse)
+		}
+	}
+}
+
+func TestFingerprintSimilarity(t *testing.T) {
+	text := "Senior backend engineer with ten years of experience building payment platforms in Go " +
+		"and Java. Led the migration of a monolith to services running on Kubernetes, designed the " +
+		"event pipeline on Kafka and mentored a team of six engineers. Previously worked on search " +
+		"at an online retailer, tuning Elasticsearch clusters and writing the indexing jobs in Python."
+
+	if Fingerprint("too short to fingerprint") != "" {
+		t.Error("a short text should not be fingerprinted")
+	}
+
+	same := Fingerprint(text)
+	if got := FingerprintSimilarity(same, Fingerprint(text+" References available on request.")); got < FingerprintThreshold {
+		t.Errorf("near-identical texts are %.2f similar, want at least %.2f", got, FingerprintThreshold)
+	}
+	if got := FingerprintSimilarity(same, "not a fingerprint"); got != 0 {
+		t.Errorf("similarity to an invalid fingerprint = %.2f, want 0", got)
+	}
+}
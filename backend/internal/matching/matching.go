@@ -3,10 +3,59 @@ package matching
 import (
 	"sort"
 	"stafind-backend/internal/constants"
+	"stafind-backend/internal/contactinfo"
+	"stafind-backend/internal/identity"
 	"stafind-backend/internal/models"
+	"stafind-backend/internal/resumeparser"
+	"stafind-backend/internal/spokenlang"
 	"strings"
+	"time"
 )
 
+// sectorCompanies are the words and well-known names that mark a company of a sector, so that
+// an employers filter such as "bank" finds "BBVA" and "Banco Santander" too
+var sectorCompanies = map[string][]string{
+	"bank": {
+		"bank", "banco", "banca", "bancaria", "banking", "caixa", "caixabank", "bbva", "santander", "sabadell",
+		"bankinter", "unicaja", "kutxabank", "abanca", "ibercaja", "ing", "hsbc", "barclays", "citi", "citibank",
+		"jpmorgan", "jp morgan", "goldman sachs", "morgan stanley", "deutsche bank", "bnp paribas", "revolut", "n26",
+	},
+	"insurance": {
+		"insurance", "seguros", "aseguradora", "mapfre", "axa", "allianz", "zurich", "generali", "mutua",
+		"sanitas", "adeslas", "aviva", "metlife",
+	},
+	"consulting": {
+		"consulting", "consultancy", "consultora", "consultoria", "accenture", "deloitte", "pwc", "kpmg", "ey",
+		"capgemini", "everis", "ntt data", "indra", "mckinsey", "bcg", "sopra steria", "minsait",
+	},
+	"telecom": {
+		"telecom", "telecommunications", "telecomunicaciones", "telefonica", "movistar", "vodafone", "orange",
+		"masmovil", "ericsson", "nokia", "huawei",
+	},
+}
+
+// sectorNames map the ways a sector is asked for to its sectorCompanies key
+var sectorNames = map[string]string{
+	"bank": "bank", "banks": "bank", "banking": "bank", "banco": "bank", "banca": "bank", "finance": "bank",
+	"insurance": "insurance", "insurer": "insurance", "seguros": "insurance", "aseguradora": "insurance",
+	"consulting": "consulting", "consultancy": "consulting", "consultora": "consulting", "consultoria": "consulting",
+	"telecom": "telecom", "telco": "telecom", "telecommunications": "telecom", "telecomunicaciones": "telecom",
+}
+
+// fieldAliases are the fields of study an abbreviation asked for in a degrees filter stands for
+var fieldAliases = map[string][]string{
+	"cs": {
+		"computer science", "computer sciences", "computing", "computer engineering", "ciencias de la computacion",
+		"ciencia de la computacion", "informatica", "ingenieria informatica", "computacion",
+	},
+	"it":  {"information technology", "information systems", "sistemas de informacion", "tecnologias de la informacion"},
+	"se":  {"software engineering", "ingenieria del software", "ingenieria de software"},
+	"ee":  {"electrical engineering", "electronic engineering", "ingenieria electrica", "ingenieria electronica"},
+	"ai":  {"artificial intelligence", "inteligencia artificial"},
+	"phd": {"phd", "ph d", "doctorate", "doctorado", "doctor of philosophy"},
+	"mba": {"mba", "master of business administration"},
+}
+
 // MatchEngine handles the matching logic between job requests and employees
 type MatchEngine struct{}
 
@@ -19,10 +68,19 @@ func NewMatchEngine() *MatchEngine {
 func (me *MatchEngine) SearchEmployees(searchReq *models.SearchRequest, employees []models.Employee) []models.Match {
 	var matches []models.Match
 
+	// A search by filters alone lists every employee that passes them
+	filtersOnly := hasFilters(searchReq) && !hasScoringCriteria(searchReq)
+
 	for _, employee := range employees {
+		if !me.passesContactFilters(searchReq, &employee) || !me.speaksRequiredLanguages(searchReq, &employee) ||
+			!me.holdsRequiredCertifications(searchReq, &employee) || !me.workedAtRequiredEmployers(searchReq, &employee) ||
+			!me.hasRequiredDegrees(searchReq, &employee) || !me.hasMinimumExperience(searchReq, &employee) {
+			continue
+		}
+
 		score, matchingSkills := me.calculateMatchScore(searchReq, &employee)
 
-		if score > 0 {
+		if score > 0 || filtersOnly {
 			match := models.Match{
 				EmployeeID:     employee.ID,
 				MatchScore:     score,
@@ -52,6 +110,268 @@ func (me *MatchEngine) SearchEmployees(searchReq *models.SearchRequest, employee
 	return matches
 }
 
+// passesContactFilters reports whether an employee is in the requested city and country and
+// has every requested profile link
+func (me *MatchEngine) passesContactFilters(searchReq *models.SearchRequest, employee *models.Employee) bool {
+	if searchReq.City != "" && identity.NormalizeName(searchReq.City) != identity.NormalizeName(employee.City) {
+		return false
+	}
+	if searchReq.Country != "" {
+		country := contactinfo.CountryCode(searchReq.Country)
+		if country == "" || country != employee.Country {
+			return false
+		}
+	}
+	for _, profile := range searchReq.Profiles {
+		if profileURL(&employee.EmployeeContact, profile) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// profileURL returns an employee's link for a profile name such as "github" or "stack_overflow",
+// "" when the employee has none or the profile is unknown
+func profileURL(contact *models.EmployeeContact, profile string) string {
+	switch strings.NewReplacer(" ", "", "_", "", "-", "").Replace(strings.ToLower(profile)) {
+	case "linkedin":
+		return contact.LinkedInURL
+	case "github":
+		return contact.GitHubURL
+	case "gitlab":
+		return contact.GitLabURL
+	case "stackoverflow":
+		return contact.StackOverflowURL
+	case "website", "web", "portfolio":
+		return contact.WebsiteURL
+	}
+	return ""
+}
+
+// speaksRequiredLanguages reports whether an employee speaks every requested language at the
+// requested level or above. A language without a stated level only meets requirements without one.
+func (me *MatchEngine) speaksRequiredLanguages(searchReq *models.SearchRequest, employee *models.Employee) bool {
+	for _, requirement := range searchReq.Languages {
+		code := spokenlang.Code(requirement.Language)
+		minLevel := spokenlang.ParseLevel(requirement.MinLevel)
+
+		speaks := false
+		for _, language := range employee.Languages {
+			if language.Code == code && spokenlang.AtLeast(language.Level, minLevel) {
+				speaks = true
+				break
+			}
+		}
+		if !speaks {
+			return false
+		}
+	}
+	return true
+}
+
+// holdsRequiredCertifications reports whether an employee holds an unexpired certification for
+// every requested one. A request names a certification or part of it, such as "AWS" or "Scrum",
+// and matches certifications whose name, catalogue name or issuer has its words in a row, each
+// word abbreviated or not: "scrum" matches "Certified ScrumMaster".
+func (me *MatchEngine) holdsRequiredCertifications(searchReq *models.SearchRequest, employee *models.Employee) bool {
+	today := time.Now().Format("2006-01-02")
+	for _, requirement := range searchReq.Certifications {
+		terms := strings.Fields(identity.NormalizeName(requirement))
+		if len(terms) == 0 {
+			continue
+		}
+
+		holds := false
+		for _, certification := range employee.Certifications {
+			if certification.ExpiresOn != "" && certification.ExpiresOn < today {
+				continue
+			}
+			if hasWordPrefixes(certification.Name, terms) || hasWordPrefixes(certification.CatalogName, terms) ||
+				hasWordPrefixes(certification.Issuer, terms) {
+				holds = true
+				break
+			}
+		}
+		if !holds {
+			return false
+		}
+	}
+	return true
+}
+
+// workedAtRequiredEmployers reports whether an employee has worked, now or before, at each
+// requested employer: a company whose name has the request's words, or a sector such as "bank"
+// whose well-known names or words the company has
+func (me *MatchEngine) workedAtRequiredEmployers(searchReq *models.SearchRequest, employee *models.Employee) bool {
+	for _, requirement := range searchReq.Employers {
+		terms := strings.Fields(identity.NormalizeName(requirement))
+		if len(terms) == 0 {
+			continue
+		}
+
+		worked := false
+		for _, position := range employee.Positions {
+			if hasWordPrefixes(position.Company, terms) || inSector(position.Company, requirement) {
+				worked = true
+				break
+			}
+		}
+		if !worked {
+			return false
+		}
+	}
+	return true
+}
+
+// inSector reports whether a company belongs to the sector a term such as "a bank" names; false
+// when the term names no sector
+func inSector(company, term string) bool {
+	words := strings.Fields(identity.NormalizeName(term))
+	if len(words) == 0 {
+		return false
+	}
+	// "a bank" or "banking sector" name the sector by one word
+	sector := ""
+	for _, word := range words {
+		if sector = sectorNames[word]; sector != "" {
+			break
+		}
+	}
+	if sector == "" {
+		return false
+	}
+
+	name := " " + identity.NormalizeName(company) + " "
+	for _, marker := range sectorCompanies[sector] {
+		if strings.Contains(name, " "+marker+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// hasRequiredDegrees reports whether an employee has each requested degree: a degree or field
+// of study with the request's words, such as "master" or "computer science", or a field an
+// abbreviation such as "CS" stands for. The word "degree" is ignored, so "CS degree" asks for CS.
+func (me *MatchEngine) hasRequiredDegrees(searchReq *models.SearchRequest, employee *models.Employee) bool {
+	for _, requirement := range searchReq.Degrees {
+		var terms []string
+		for _, word := range strings.Fields(identity.NormalizeName(requirement)) {
+			if word != "degree" && word != "titulo" {
+				terms = append(terms, word)
+			}
+		}
+		if len(terms) == 0 {
+			continue
+		}
+
+		has := false
+		for _, education := range employee.Education {
+			if degreeMatches(education.Degree+" "+education.Field, terms) {
+				has = true
+				break
+			}
+		}
+		if !has {
+			return false
+		}
+	}
+	return true
+}
+
+// hasMinimumExperience reports whether an employee's dated work history covers the requested
+// years. Concurrent positions count once. Employees without dated positions pass, since their
+// history is unknown rather than short; they miss the bonus of calculateMatchScore instead.
+func (me *MatchEngine) hasMinimumExperience(searchReq *models.SearchRequest, employee *models.Employee) bool {
+	if searchReq.MinYearsExperience <= 0 {
+		return true
+	}
+	years, known := experienceYears(employee.Positions, time.Now())
+	return !known || years >= searchReq.MinYearsExperience
+}
+
+// experienceYears returns the years covered by dated positions, counted the way the resume
+// parser counts them for each skill, and whether any position is dated
+func experienceYears(positions []models.EmployeePosition, now time.Time) (float64, bool) {
+	var experience []models.WorkExperience
+	for _, position := range positions {
+		if position.StartDate == "" {
+			continue
+		}
+		experience = append(experience, models.WorkExperience{
+			StartDate: positionDate(position.StartDate, position.DatesByYear),
+			EndDate:   positionDate(position.EndDate, position.DatesByYear),
+			Current:   position.Current,
+		})
+	}
+	if len(experience) == 0 {
+		return 0, false
+	}
+	return resumeparser.ExperienceYears(experience, now), true
+}
+
+// positionDate turns a stored YYYY-MM-DD date back into the YYYY-MM or YYYY date of the resume
+func positionDate(date string, byYear bool) string {
+	switch {
+	case byYear && len(date) >= len("2006"):
+		return date[:len("2006")]
+	case len(date) >= len("2006-01"):
+		return date[:len("2006-01")]
+	}
+	return date
+}
+
+// degreeMatches reports whether a degree and field of study have the words of a degrees filter,
+// or a field its abbreviation stands for
+func degreeMatches(text string, terms []string) bool {
+	if hasWordPrefixes(text, terms) {
+		return true
+	}
+	if len(terms) != 1 {
+		return false
+	}
+
+	normalized := " " + identity.NormalizeName(text) + " "
+	for _, field := range fieldAliases[terms[0]] {
+		if strings.Contains(normalized, " "+field+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// hasWordPrefixes reports whether text has consecutive words starting with each of terms in order
+func hasWordPrefixes(text string, terms []string) bool {
+	words := strings.Fields(identity.NormalizeName(text))
+	for start := 0; start+len(terms) <= len(words); start++ {
+		matches := true
+		for i, term := range terms {
+			if !strings.HasPrefix(words[start+i], term) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+// hasFilters reports whether a search filters employees by location, profile links, languages,
+// certifications, employers, degrees or years of experience
+func hasFilters(searchReq *models.SearchRequest) bool {
+	return searchReq.City != "" || searchReq.Country != "" || len(searchReq.Profiles) > 0 || len(searchReq.Languages) > 0 ||
+		len(searchReq.Certifications) > 0 || len(searchReq.Employers) > 0 || len(searchReq.Degrees) > 0 ||
+		searchReq.MinYearsExperience > 0
+}
+
+// hasScoringCriteria reports whether a search has criteria that score employees
+func hasScoringCriteria(searchReq *models.SearchRequest) bool {
+	return len(searchReq.RequiredSkills) > 0 || len(searchReq.PreferredSkills) > 0 ||
+		searchReq.Department != "" || searchReq.ExperienceLevel != "" || searchReq.Location != ""
+}
+
 // calculateMatchScore calculates the match score between search criteria and employee
 func (me *MatchEngine) calculateMatchScore(searchReq *models.SearchRequest, employee *models.Employee) (float64, []string) {
 	var totalScore float64
@@ -82,7 +402,10 @@ func (me *MatchEngine) calculateMatchScore(searchReq *models.SearchRequest, empl
 	// Location bonus
 	locationBonus := me.calculateLocationBonus(searchReq.Location, employee.Location)
 
-	totalScore = requiredScore + preferredScore + departmentBonus + experienceBonus + locationBonus
+	// Years of experience bonus
+	yearsBonus := me.calculateYearsBonus(searchReq.MinYearsExperience, employee.Positions)
+
+	totalScore = requiredScore + preferredScore + departmentBonus + experienceBonus + locationBonus + yearsBonus
 
 	return totalScore, matchingSkills
 }
@@ -229,8 +552,11 @@ func (me *MatchEngine) calculateExperienceBonus(jobLevel, employeeLevel string)
 
 	levelMap := constants.ExperienceLevelMap
 
-	jobLevelNum := levelMap[jobLevel]
-	employeeLevelNum := levelMap[employeeLevel]
+	jobLevelNum, jobKnown := levelMap[strings.ToLower(jobLevel)]
+	employeeLevelNum := levelMap[strings.ToLower(employeeLevel)]
+	if !jobKnown {
+		return 0
+	}
 
 	if employeeLevelNum >= jobLevelNum {
 		// Employee meets or exceeds required level
@@ -246,8 +572,24 @@ func (me *MatchEngine) calculateLocationBonus(jobLocation, employeeLocation stri
 	if jobLocation == "" || employeeLocation == "" {
 		return 0
 	}
-	if jobLocation == employeeLocation {
+
+	// Accept "Medellín" for an employee located in "Medellín, Colombia"
+	jobLocation = strings.ToLower(strings.TrimSpace(jobLocation))
+	employeeLocation = strings.ToLower(strings.TrimSpace(employeeLocation))
+	if jobLocation == employeeLocation || strings.Contains(employeeLocation, jobLocation) {
 		return constants.LocationMatchBonus
 	}
 	return 0
 }
+
+// calculateYearsBonus calculates the bonus for dated work history covering the minimum years.
+// Employees without dated positions get none, so they rank below those known to qualify.
+func (me *MatchEngine) calculateYearsBonus(minYears float64, positions []models.EmployeePosition) float64 {
+	if minYears <= 0 {
+		return 0
+	}
+	if years, known := experienceYears(positions, time.Now()); known && years >= minYears {
+		return constants.MinYearsExperienceMatchBonus
+	}
+	return 0
+}
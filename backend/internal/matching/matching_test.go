@@ -0,0 +1,121 @@
+package matching
+
+import (
+	"testing"
+	"time"
+
+	"stafind-backend/internal/models"
+	"stafind-backend/internal/resumeparser"
+)
+
+func TestExperienceYears(t *testing.T) {
+	now := time.Date(2024, time.June, 15, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		positions []models.EmployeePosition
+		want      float64
+	}{
+		{
+			name:      "whole months",
+			positions: []models.EmployeePosition{{StartDate: "2017-01-01", EndDate: "2019-12-31"}},
+			want:      3,
+		},
+		{
+			name:      "year-only dates count year to year",
+			positions: []models.EmployeePosition{{StartDate: "2016-01-01", EndDate: "2018-12-31", DatesByYear: true}},
+			want:      2,
+		},
+		{
+			name:      "year-only dates within one year",
+			positions: []models.EmployeePosition{{StartDate: "2018-01-01", EndDate: "2018-12-31", DatesByYear: true}},
+			want:      0.5,
+		},
+		{
+			name:      "current position runs through this month",
+			positions: []models.EmployeePosition{{StartDate: "2022-06-01", Current: true}},
+			want:      2.1,
+		},
+		{
+			name: "overlapping positions count once",
+			positions: []models.EmployeePosition{
+				{StartDate: "2018-01-01", EndDate: "2020-12-31"},
+				{StartDate: "2020-01-01", EndDate: "2021-12-31"},
+			},
+			want: 4,
+		},
+		{
+			name: "undated positions are left out",
+			positions: []models.EmployeePosition{
+				{Company: "Acme"},
+				{StartDate: "2023-01-01", EndDate: "2023-06-30"},
+			},
+			want: 0.5,
+		},
+	}
+
+	for _, tt := range tests {
+		if got, known := experienceYears(tt.positions, now); got != tt.want || !known {
+			t.Errorf("%s: experienceYears() = %v, %v, want %v", tt.name, got, known, tt.want)
+		}
+	}
+
+	if _, known := experienceYears([]models.EmployeePosition{{Company: "Acme"}}, now); known {
+		t.Error("experienceYears() of undated positions should be unknown")
+	}
+}
+
+// Search counts the years of stored positions the way the resume parser counts per-skill years
+func TestExperienceYearsMatchesResumeParser(t *testing.T) {
+	now := time.Date(2024, time.June, 15, 0, 0, 0, 0, time.UTC)
+	resume := []models.WorkExperience{
+		{StartDate: "2016", EndDate: "2018"},
+		{StartDate: "2019", EndDate: "2019"},
+		{StartDate: "2020-03", EndDate: "2021-08"},
+		{StartDate: "2023-01", Current: true},
+	}
+	stored := []models.EmployeePosition{
+		{StartDate: "2016-01-01", EndDate: "2018-12-31", DatesByYear: true},
+		{StartDate: "2019-01-01", EndDate: "2019-12-31", DatesByYear: true},
+		{StartDate: "2020-03-01", EndDate: "2021-08-31"},
+		{StartDate: "2023-01-01", Current: true},
+	}
+
+	want := resumeparser.ExperienceYears(resume, now)
+	if got, _ := experienceYears(stored, now); got != want {
+		t.Errorf("experienceYears() = %v, resume parser = %v", got, want)
+	}
+}
+
+func TestSearchEmployeesMinYearsExperience(t *testing.T) {
+	goSkill := []models.Skill{{Name: "Go"}}
+	employees := []models.Employee{
+		{ID: 1, Name: "Senior", Skills: goSkill, Positions: []models.EmployeePosition{{StartDate: "2015-01-01", EndDate: "2022-12-31"}}},
+		{ID: 2, Name: "Junior", Skills: goSkill, Positions: []models.EmployeePosition{{StartDate: "2021-01-01", EndDate: "2022-12-31"}}},
+		{ID: 3, Name: "No history", Skills: goSkill},
+		{ID: 4, Name: "Years only", Skills: goSkill, Positions: []models.EmployeePosition{
+			{StartDate: "2016-01-01", EndDate: "2018-12-31", DatesByYear: true},
+		}},
+	}
+
+	// Employees known to be short of the minimum are left out; those with no dated history
+	// stay, ranked below the ones known to qualify
+	engine := NewMatchEngine()
+	matches := engine.SearchEmployees(&models.SearchRequest{RequiredSkills: []string{"Go"}, MinYearsExperience: 3}, employees)
+	if len(matches) != 2 || matches[0].EmployeeID != 1 || matches[1].EmployeeID != 3 {
+		t.Errorf("matches = %+v, want employees 1 and 3", matches)
+	}
+	if matches[0].MatchScore <= matches[1].MatchScore {
+		t.Errorf("scores = %v and %v, want the known history first", matches[0].MatchScore, matches[1].MatchScore)
+	}
+
+	// "2016 - 2018" is two years
+	matches = engine.SearchEmployees(&models.SearchRequest{MinYearsExperience: 2}, employees)
+	if len(matches) != 4 || matches[3].EmployeeID != 3 {
+		t.Errorf("matches = %+v, want every employee, the one without history last", matches)
+	}
+
+	matches = engine.SearchEmployees(&models.SearchRequest{RequiredSkills: []string{"Go"}}, employees)
+	if len(matches) != 4 {
+		t.Errorf("without a minimum got %d matches, want 4", len(matches))
+	}
+}
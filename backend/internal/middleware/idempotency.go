@@ -0,0 +1,154 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+
+	"stafind-backend/internal/constants"
+	"stafind-backend/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// IdempotencyStore reserves Idempotency-Key values and keeps the responses sent for them.
+// Begin returns a token for a new reservation, or the existing record and no token. Complete
+// and Release report false when a retry has taken the reservation over.
+type IdempotencyStore interface {
+	Begin(key, scope, fingerprint string) (*models.IdempotencyRecord, string, error)
+	Complete(key, scope, token string, responseStatus int, contentType string, body []byte) (bool, error)
+	Release(key, scope, token string) (bool, error)
+}
+
+// IdempotencyMiddleware honours the Idempotency-Key header on POST and PUT requests.
+// The first request with a key is processed and its response stored; retries with the
+// same key and body get the stored response back, and retries with a different body
+// are rejected. A retry while the first request is still running gets 409 until the
+// store's processing lease runs out. Keys are scoped to the route and credentials, so it must run after
+// authentication.
+func IdempotencyMiddleware(store IdempotencyStore) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if c.Method() != fiber.MethodPost && c.Method() != fiber.MethodPut {
+			return c.Next()
+		}
+
+		key := c.Get(constants.HeaderIdempotencyKey)
+		if key == "" {
+			return c.Next()
+		}
+
+		// Groups sharing a prefix run their middleware again for the same request
+		if c.Locals(constants.ContextIdempotency) != nil {
+			return c.Next()
+		}
+		c.Locals(constants.ContextIdempotency, true)
+
+		if len(key) > constants.MaxIdempotencyKeyLength {
+			return c.Status(constants.StatusBadRequest).JSON(fiber.Map{
+				"error": constants.MsgInvalidIdempotencyKey,
+				"code":  constants.ErrorCodeInvalidIdempotencyKey,
+			})
+		}
+
+		scope := idempotencyScope(c)
+		fingerprint := hashParts(string(c.Body()))
+
+		record, token, err := store.Begin(key, scope, fingerprint)
+		if err != nil {
+			// Fail open: a storage problem must not block the integration
+			log.Printf("Idempotency check failed for %s %s: %v", c.Method(), c.Path(), err)
+			return c.Next()
+		}
+
+		if token == "" {
+			if record.RequestFingerprint != fingerprint {
+				return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+					"error": constants.MsgIdempotencyKeyReused,
+					"code":  constants.ErrorCodeIdempotencyKeyReused,
+				})
+			}
+			if record.Status != models.IdempotencyStatusCompleted || record.ResponseStatus == nil {
+				return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+					"error": constants.MsgIdempotencyKeyInProgress,
+					"code":  constants.ErrorCodeIdempotencyKeyInProgress,
+				})
+			}
+
+			if record.ResponseContentType != nil {
+				c.Set(fiber.HeaderContentType, *record.ResponseContentType)
+			}
+			c.Set(constants.HeaderIdempotentReplayed, "true")
+			return c.Status(*record.ResponseStatus).Send(record.ResponseBody)
+		}
+
+		// A panicking handler sent no response, so the key is freed for the retry
+		defer func() {
+			if r := recover(); r != nil {
+				releaseIdempotencyKey(store, key, scope, token)
+				panic(r)
+			}
+		}()
+
+		if err := c.Next(); err != nil {
+			releaseIdempotencyKey(store, key, scope, token)
+			return err
+		}
+
+		status := c.Response().StatusCode()
+		if !isReplayableStatus(status) {
+			releaseIdempotencyKey(store, key, scope, token)
+			return nil
+		}
+
+		body := append([]byte(nil), c.Response().Body()...)
+		contentType := string(c.Response().Header.ContentType())
+		stored, err := store.Complete(key, scope, token, status, contentType, body)
+		if err != nil {
+			log.Printf("Failed to store idempotent response for %s %s: %v", c.Method(), c.Path(), err)
+			releaseIdempotencyKey(store, key, scope, token)
+		} else if !stored {
+			log.Printf("Idempotency key %q of %s %s was taken over by a retry; its response is kept", key, c.Method(), c.Path())
+		}
+
+		return nil
+	}
+}
+
+// isReplayableStatus reports whether a response is final for the request. Server errors,
+// auth failures and rate limits may succeed on retry, so they are not stored.
+func isReplayableStatus(status int) bool {
+	switch status {
+	case fiber.StatusUnauthorized, fiber.StatusForbidden, fiber.StatusTooManyRequests:
+		return false
+	}
+	return status < fiber.StatusInternalServerError
+}
+
+func releaseIdempotencyKey(store IdempotencyStore, key, scope, token string) {
+	released, err := store.Release(key, scope, token)
+	if err != nil {
+		log.Printf("Failed to release idempotency key %q: %v", key, err)
+	} else if !released {
+		log.Printf("Idempotency key %q was taken over by a retry; leaving it to the retry", key)
+	}
+}
+
+// idempotencyScope ties a key to the route and the caller's credentials
+func idempotencyScope(c *fiber.Ctx) string {
+	return hashParts(
+		c.Method(),
+		c.Path(),
+		c.Get(constants.HeaderAPIKey),
+		c.Get(constants.HeaderAuthorization),
+		c.Get(constants.HeaderSignatureKeyID),
+	)
+}
+
+func hashParts(parts ...string) string {
+	hash := sha256.New()
+	for _, part := range parts {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,145 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"stafind-backend/internal/constants"
+	"stafind-backend/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/recover"
+)
+
+// fakeStore keeps idempotency records in memory, each with the token of its reservation
+type fakeStore struct {
+	records  map[string]*models.IdempotencyRecord
+	tokens   map[string]string
+	reserved int
+	released int
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{records: make(map[string]*models.IdempotencyRecord), tokens: make(map[string]string)}
+}
+
+func (s *fakeStore) Begin(key, scope, fingerprint string) (*models.IdempotencyRecord, string, error) {
+	if record, exists := s.records[key+scope]; exists {
+		return record, "", nil
+	}
+	s.records[key+scope] = &models.IdempotencyRecord{
+		IdempotencyKey:     key,
+		Scope:              scope,
+		RequestFingerprint: fingerprint,
+		Status:             models.IdempotencyStatusProcessing,
+	}
+	s.reserved++
+	s.tokens[key+scope] = fmt.Sprintf("token-%d", s.reserved)
+	return nil, s.tokens[key+scope], nil
+}
+
+func (s *fakeStore) Complete(key, scope, token string, responseStatus int, contentType string, body []byte) (bool, error) {
+	if s.tokens[key+scope] != token {
+		return false, nil
+	}
+	record := s.records[key+scope]
+	record.Status = models.IdempotencyStatusCompleted
+	record.ResponseStatus = &responseStatus
+	record.ResponseContentType = &contentType
+	record.ResponseBody = body
+	return true, nil
+}
+
+func (s *fakeStore) Release(key, scope, token string) (bool, error) {
+	if s.tokens[key+scope] != token {
+		return false, nil
+	}
+	delete(s.records, key+scope)
+	delete(s.tokens, key+scope)
+	s.released++
+	return true, nil
+}
+
+func sendWithKey(t *testing.T, app *fiber.App, key string) int {
+	t.Helper()
+	req := httptest.NewRequest("POST", "/process", strings.NewReader(`{"file":1}`))
+	req.Header.Set(constants.HeaderIdempotencyKey, key)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	return resp.StatusCode
+}
+
+// A handler that panics leaves no response to replay, so its key is released for the retry
+func TestIdempotencyReleasesKeyOnPanic(t *testing.T) {
+	store := newFakeStore()
+	calls := 0
+	app := fiber.New()
+	app.Use(recover.New())
+	app.Post("/process", IdempotencyMiddleware(store), func(c *fiber.Ctx) error {
+		calls++
+		if calls == 1 {
+			panic("extraction crashed")
+		}
+		return c.SendStatus(fiber.StatusCreated)
+	})
+
+	if status := sendWithKey(t, app, "job-1"); status != fiber.StatusInternalServerError {
+		t.Fatalf("panicking request status = %d, want 500", status)
+	}
+	if store.released != 1 || len(store.records) != 0 {
+		t.Fatalf("released %d keys, %d records left; want the key released", store.released, len(store.records))
+	}
+
+	if status := sendWithKey(t, app, "job-1"); status != fiber.StatusCreated {
+		t.Errorf("retry status = %d, want 201", status)
+	}
+	if status := sendWithKey(t, app, "job-1"); status != fiber.StatusCreated || calls != 2 {
+		t.Errorf("replay status = %d after %d calls, want the stored 201 without a third call", status, calls)
+	}
+}
+
+func TestIdempotencyRejectsKeyInProgress(t *testing.T) {
+	store := newFakeStore()
+	app := fiber.New()
+	app.Post("/process", IdempotencyMiddleware(store), func(c *fiber.Ctx) error {
+		// A retry arrives while this request is still running
+		if status := sendWithKey(t, c.App(), "job-1"); status != fiber.StatusConflict {
+			t.Errorf("retry during the request status = %d, want 409", status)
+		}
+		return c.SendStatus(fiber.StatusCreated)
+	})
+
+	if status := sendWithKey(t, app, "job-1"); status != fiber.StatusCreated {
+		t.Fatalf("first request status = %d, want 201", status)
+	}
+}
+
+// A request that outlived its lease does not store its response over the retry's reservation
+func TestIdempotencyKeepsTakenOverKey(t *testing.T) {
+	store := newFakeStore()
+	app := fiber.New()
+	app.Post("/process", IdempotencyMiddleware(store), func(c *fiber.Ctx) error {
+		for key, record := range store.records {
+			// A retry takes the key over while this request is still running
+			store.tokens[key] = "retry"
+			record.Status = models.IdempotencyStatusProcessing
+		}
+		return c.SendStatus(fiber.StatusCreated)
+	})
+
+	if status := sendWithKey(t, app, "job-1"); status != fiber.StatusCreated {
+		t.Fatalf("slow request status = %d, want 201", status)
+	}
+	if len(store.records) != 1 {
+		t.Fatalf("%d records left, want the retry's reservation", len(store.records))
+	}
+	for _, record := range store.records {
+		if record.Status != models.IdempotencyStatusProcessing || record.ResponseStatus != nil {
+			t.Errorf("record = %+v, want the retry's reservation still processing", record)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package middleware
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"stafind-backend/internal/constants"
+	"stafind-backend/internal/webhook"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SigningSecretProvider looks up the HMAC signing secret of an integration's API key
+type SigningSecretProvider interface {
+	GetSigningSecret(keyID int) (string, error)
+	HasSigningSecrets() (bool, error)
+}
+
+// WebhookSignatureMiddleware verifies HMAC-SHA256 request signatures from inbound integrations.
+// The mode comes from WEBHOOK_SIGNATURE_MODE: "off" skips verification, "optional" verifies
+// signed requests and lets unsigned ones through, "required" rejects unsigned requests. Left
+// unset, the mode is "required" once an active API key has a signing secret; before that no
+// integration can sign, so unsigned requests pass. Only "required" protects an endpoint: in
+// "optional" mode a request stripped of its signature headers passes unverified, so it has to
+// be chosen explicitly. An unknown mode falls back to "required".
+//
+// Accepted signatures are remembered in memory to reject replays, so the protection holds per
+// server process: a restart forgets them, and replicas do not share them.
+func WebhookSignatureMiddleware(secrets SigningSecretProvider) fiber.Handler {
+	mode := strings.ToLower(os.Getenv(constants.EnvWebhookSignatureMode))
+	// Without an explicit mode, unsigned requests are rejected only once someone can sign
+	requiredOnceIssued := mode == ""
+	switch mode {
+	case constants.WebhookSignatureModeOff, constants.WebhookSignatureModeOptional, constants.WebhookSignatureModeRequired:
+	default:
+		if mode != "" {
+			log.Printf("Unknown %s %q, using %q", constants.EnvWebhookSignatureMode, mode, constants.DefaultWebhookSignatureMode)
+		}
+		mode = constants.DefaultWebhookSignatureMode
+	}
+
+	tolerance := time.Duration(constants.DefaultWebhookSignatureTolerance) * time.Second
+	if value := os.Getenv(constants.EnvWebhookSignatureTolerance); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+			tolerance = time.Duration(seconds) * time.Second
+		}
+	}
+
+	seen := newSignatureReplayCache(tolerance)
+
+	return func(c *fiber.Ctx) error {
+		if mode == constants.WebhookSignatureModeOff {
+			return c.Next()
+		}
+
+		// Groups sharing a prefix run their middleware again for the same request
+		if c.Locals(constants.ContextAPIKeyID) != nil {
+			return c.Next()
+		}
+
+		keyIDHeader := c.Get(constants.HeaderSignatureKeyID)
+		timestampHeader := c.Get(constants.HeaderSignatureTimestamp)
+		signature := c.Get(constants.HeaderSignature)
+
+		if keyIDHeader == "" && timestampHeader == "" && signature == "" {
+			if mode == constants.WebhookSignatureModeOptional {
+				return c.Next()
+			}
+			if requiredOnceIssued {
+				issued, err := secrets.HasSigningSecrets()
+				if err != nil {
+					log.Printf("Failed to check signing secrets: %v", err)
+				} else if !issued {
+					return c.Next()
+				}
+			}
+			return signatureError(c, constants.MsgSignatureRequired, constants.ErrorCodeMissingSignature)
+		}
+
+		keyID, err := strconv.Atoi(keyIDHeader)
+		if err != nil || signature == "" {
+			return signatureError(c, constants.MsgInvalidSignature, constants.ErrorCodeInvalidSignature)
+		}
+
+		timestamp, err := strconv.ParseInt(timestampHeader, 10, 64)
+		if err != nil {
+			return signatureError(c, constants.MsgInvalidSignature, constants.ErrorCodeInvalidSignature)
+		}
+		if !webhook.WithinTolerance(timestamp, time.Now(), tolerance) {
+			return signatureError(c, constants.MsgExpiredSignature, constants.ErrorCodeExpiredSignature)
+		}
+
+		secret, err := secrets.GetSigningSecret(keyID)
+		if err != nil {
+			return signatureError(c, constants.MsgInvalidSignature, constants.ErrorCodeInvalidSignature)
+		}
+
+		if !webhook.VerifySignature(secret, timestamp, c.Body(), signature) {
+			return signatureError(c, constants.MsgInvalidSignature, constants.ErrorCodeInvalidSignature)
+		}
+
+		// A valid signature may only be used once inside the tolerance window. The header may
+		// list extra candidates and the key ID may be zero-padded, so the replay key is the
+		// signature that matched, under the parsed key ID.
+		if !seen.add(strconv.Itoa(keyID) + ":" + webhook.ComputeSignature(secret, timestamp, c.Body())) {
+			return signatureError(c, constants.MsgReplayedRequest, constants.ErrorCodeReplayedRequest)
+		}
+
+		c.Locals(constants.ContextAPIKeyID, keyID)
+		c.Locals(constants.ContextAuthType, "signature")
+
+		return c.Next()
+	}
+}
+
+// signatureError sends a 401 response for a failed signature check
+func signatureError(c *fiber.Ctx, message, code string) error {
+	return c.Status(constants.StatusUnauthorized).JSON(fiber.Map{
+		"error": message,
+		"code":  code,
+	})
+}
+
+// signatureReplayCache remembers accepted signatures until they fall outside the tolerance window
+type signatureReplayCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]time.Time
+}
+
+func newSignatureReplayCache(ttl time.Duration) *signatureReplayCache {
+	return &signatureReplayCache{
+		// Timestamps are accepted up to ttl in either direction
+		ttl:     2 * ttl,
+		entries: make(map[string]time.Time),
+	}
+}
+
+// add records a signature and returns false if it was already recorded
+func (r *signatureReplayCache) add(signature string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for key, expiresAt := range r.entries {
+		if now.After(expiresAt) {
+			delete(r.entries, key)
+		}
+	}
+
+	if _, exists := r.entries[signature]; exists {
+		return false
+	}
+	r.entries[signature] = now.Add(r.ttl)
+	return true
+}
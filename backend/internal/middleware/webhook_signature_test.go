@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"stafind-backend/internal/constants"
+	"stafind-backend/internal/webhook"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// fakeSecrets issues one signing secret, for key 7, unless none are issued
+type fakeSecrets struct {
+	noneIssued bool
+}
+
+func (s fakeSecrets) GetSigningSecret(keyID int) (string, error) {
+	if s.noneIssued || keyID != 7 {
+		return "", errors.New("no signing secret")
+	}
+	return "whsec_test", nil
+}
+
+func (s fakeSecrets) HasSigningSecrets() (bool, error) {
+	return !s.noneIssued, nil
+}
+
+func TestWebhookSignatureRejectsReplays(t *testing.T) {
+	t.Setenv(constants.EnvWebhookSignatureMode, constants.WebhookSignatureModeRequired)
+
+	app := fiber.New()
+	app.Post("/hook", WebhookSignatureMiddleware(fakeSecrets{}), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	body := `{"text":"resume"}`
+	timestamp := time.Now().Unix()
+	signature := webhook.ComputeSignature("whsec_test", timestamp, []byte(body))
+
+	send := func(keyID, signature string) int {
+		req := httptest.NewRequest("POST", "/hook", strings.NewReader(body))
+		req.Header.Set(constants.HeaderSignatureKeyID, keyID)
+		req.Header.Set(constants.HeaderSignatureTimestamp, strconv.FormatInt(timestamp, 10))
+		req.Header.Set(constants.HeaderSignature, signature)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		return resp.StatusCode
+	}
+
+	if status := send("7", signature); status != fiber.StatusOK {
+		t.Fatalf("first request status = %d, want 200", status)
+	}
+
+	replays := map[string][2]string{
+		"same headers":         {"7", signature},
+		"extra candidate":      {"7", signature + ",x"},
+		"leading candidate":    {"7", "v1=00, " + signature},
+		"surrounding spaces":   {"7", " " + signature + " "},
+		"zero-padded key ID":   {"07", signature},
+		"candidate whitespace": {"7", signature + ", "},
+	}
+	for name, headers := range replays {
+		if status := send(headers[0], headers[1]); status != fiber.StatusUnauthorized {
+			t.Errorf("%s: replay status = %d, want 401", name, status)
+		}
+	}
+}
+
+func TestWebhookSignatureRequiredRejectsUnsigned(t *testing.T) {
+	t.Setenv(constants.EnvWebhookSignatureMode, constants.WebhookSignatureModeRequired)
+
+	app := fiber.New()
+	app.Post("/hook", WebhookSignatureMiddleware(fakeSecrets{}), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/hook", strings.NewReader("{}")))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusUnauthorized {
+		t.Errorf("unsigned request status = %d, want 401", resp.StatusCode)
+	}
+}
+
+func TestWebhookSignatureModes(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    string
+		secrets fakeSecrets
+		want    int
+	}{
+		{"unset mode with an issued secret", "", fakeSecrets{}, fiber.StatusUnauthorized},
+		{"unset mode before any secret is issued", "", fakeSecrets{noneIssued: true}, fiber.StatusOK},
+		{"explicit optional", constants.WebhookSignatureModeOptional, fakeSecrets{}, fiber.StatusOK},
+		{"explicit required before any secret is issued", constants.WebhookSignatureModeRequired, fakeSecrets{noneIssued: true}, fiber.StatusUnauthorized},
+		{"unknown mode", "strict", fakeSecrets{noneIssued: true}, fiber.StatusUnauthorized},
+		{"off", constants.WebhookSignatureModeOff, fakeSecrets{}, fiber.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(constants.EnvWebhookSignatureMode, tt.mode)
+
+			app := fiber.New()
+			app.Post("/hook", WebhookSignatureMiddleware(tt.secrets), func(c *fiber.Ctx) error {
+				return c.SendStatus(fiber.StatusOK)
+			})
+
+			resp, err := app.Test(httptest.NewRequest("POST", "/hook", strings.NewReader("{}")))
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Errorf("unsigned request status = %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
@@ -25,6 +25,13 @@ type CreateAPIKeyRequest struct {
 	Permissions []string   `json:"permissions,omitempty"`
 }
 
+// SigningSecretResponse represents the response when creating or rotating a signing secret
+type SigningSecretResponse struct {
+	KeyID         int    `json:"key_id"`
+	ServiceName   string `json:"service_name"`
+	SigningSecret string `json:"signing_secret"` // Only returned once when created
+}
+
 // APIKeyResponse represents the response when creating an API key
 type APIKeyResponse struct {
 	ID          int        `json:"id"`
@@ -0,0 +1,43 @@
+package models
+
+// EmployeePosition is a position in an employee's work history. Dates are YYYY-MM-DD.
+type EmployeePosition struct {
+	ID          int      `json:"id" db:"id"`
+	EmployeeID  int      `json:"employee_id" db:"employee_id"`
+	Company     string   `json:"company,omitempty" db:"company"`
+	Title       string   `json:"title,omitempty" db:"title"`
+	StartDate   string   `json:"start_date,omitempty" db:"start_date"`
+	EndDate     string   `json:"end_date,omitempty" db:"end_date"` // Empty for the current position
+	Current     bool     `json:"current" db:"is_current"`
+	DatesByYear bool     `json:"dates_by_year" db:"dates_by_year"` // The resume gives only years, so the dates span whole years
+	Description string   `json:"description,omitempty" db:"description"`
+	Skills      []string `json:"skills" db:"skills"` // Catalog skills the title or description mentions
+}
+
+// EmployeeEducation is a degree or course of study of an employee
+type EmployeeEducation struct {
+	ID          int    `json:"id" db:"id"`
+	EmployeeID  int    `json:"employee_id" db:"employee_id"`
+	Institution string `json:"institution,omitempty" db:"institution"`
+	Degree      string `json:"degree,omitempty" db:"degree"`
+	Field       string `json:"field,omitempty" db:"field"` // Field of study, e.g. Computer Science
+	StartYear   *int   `json:"start_year,omitempty" db:"start_year"`
+	EndYear     *int   `json:"end_year,omitempty" db:"end_year"` // Unset while studying or when not stated
+}
+
+// EmployeeProject is a project an employee lists in their resume
+type EmployeeProject struct {
+	ID           int      `json:"id" db:"id"`
+	EmployeeID   int      `json:"employee_id" db:"employee_id"`
+	Name         string   `json:"name" db:"name"`
+	Role         string   `json:"role,omitempty" db:"role"`
+	Description  string   `json:"description,omitempty" db:"description"`
+	Technologies []string `json:"technologies" db:"technologies"`
+}
+
+// EmployeeBackground is an employee's work history, education and projects
+type EmployeeBackground struct {
+	Positions []EmployeePosition  `json:"positions"`
+	Education []EmployeeEducation `json:"education"`
+	Projects  []EmployeeProject   `json:"projects"`
+}
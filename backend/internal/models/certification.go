@@ -0,0 +1,79 @@
+package models
+
+import "time"
+
+// Sources of employee certifications
+const (
+	CertificationSourceResume = "resume" // Replaced when the employee's resume is extracted again
+	CertificationSourceManual = "manual" // Entered by hand; extraction never changes it
+)
+
+// Certification is a certification in the catalogue that employee certifications are matched to
+type Certification struct {
+	ID             int       `json:"id" db:"id"`
+	Name           string    `json:"name" db:"name"`
+	Issuer         string    `json:"issuer" db:"issuer"`
+	Aliases        []string  `json:"aliases" db:"aliases"`                           // Other names resumes use, e.g. exam codes
+	ValidityMonths *int      `json:"validity_months,omitempty" db:"validity_months"` // Unset when it does not expire
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// CertificationRequest creates or updates a catalogue certification
+type CertificationRequest struct {
+	Name           string   `json:"name" validate:"required"`
+	Issuer         string   `json:"issuer" validate:"required"`
+	Aliases        []string `json:"aliases,omitempty"`
+	ValidityMonths *int     `json:"validity_months,omitempty"`
+}
+
+// ResumeCertification is a certification as listed in a resume
+type ResumeCertification struct {
+	Name         string `json:"name"`
+	Issuer       string `json:"issuer,omitempty"`
+	CredentialID string `json:"credential_id,omitempty"`
+	IssuedOn     string `json:"issued_on,omitempty"`  // YYYY-MM, or YYYY when only the year is given
+	ExpiresOn    string `json:"expires_on,omitempty"` // YYYY-MM, or YYYY when only the year is given
+}
+
+// EmployeeCertification is a certification an employee holds. Dates are YYYY-MM-DD.
+type EmployeeCertification struct {
+	ID                int       `json:"id" db:"id"`
+	EmployeeID        int       `json:"employee_id" db:"employee_id"`
+	CertificationID   *int      `json:"certification_id,omitempty" db:"certification_id"` // Unset when not in the catalogue
+	CatalogName       string    `json:"catalog_name,omitempty"`                           // Name in the catalogue
+	Name              string    `json:"name" db:"name"`                                   // As written in the resume or entered
+	Issuer            string    `json:"issuer,omitempty" db:"issuer"`
+	CredentialID      string    `json:"credential_id,omitempty" db:"credential_id"`
+	IssuedOn          string    `json:"issued_on,omitempty" db:"issued_on"`
+	ExpiresOn         string    `json:"expires_on,omitempty" db:"expires_on"` // Empty when it does not expire
+	Source            string    `json:"source" db:"source"`
+	ExpiryNotifiedFor string    `json:"expiry_notified_for,omitempty" db:"expiry_notified_for"` // The expiry date last announced
+	CreatedAt         time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// EmployeeCertificationRequest adds or updates a certification of an employee. A certification in
+// the catalogue may be given by ID, in which case Name and Issuer default to the catalogue's; the
+// expiry defaults to the issue date plus the catalogue validity.
+type EmployeeCertificationRequest struct {
+	CertificationID *int   `json:"certification_id,omitempty"`
+	Name            string `json:"name,omitempty"`
+	Issuer          string `json:"issuer,omitempty"`
+	CredentialID    string `json:"credential_id,omitempty"`
+	IssuedOn        string `json:"issued_on,omitempty"`  // YYYY-MM-DD
+	ExpiresOn       string `json:"expires_on,omitempty"` // YYYY-MM-DD
+}
+
+// ExpiringCertification is an employee certification about to expire, with its holder
+type ExpiringCertification struct {
+	EmployeeCertification
+	EmployeeName  string `json:"employee_name"`
+	EmployeeEmail string `json:"employee_email"`
+}
+
+// CertificationExpiryNotice reports a run of the certification expiry notifications
+type CertificationExpiryNotice struct {
+	Days           int                     `json:"days"` // How far ahead expiries were looked for
+	Certifications []ExpiringCertification `json:"certifications"`
+}
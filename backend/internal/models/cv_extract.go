@@ -71,6 +71,12 @@ const (
 	CVExtractStatusFailed     = "failed"
 )
 
+// Constants for the outcome of a single file in a CV extraction batch
+const (
+	CVExtractFileStatusProcessed = "processed"
+	CVExtractFileStatusFailed    = "failed"
+)
+
 // CVExtractMetadata represents additional metadata for CV extraction
 type CVExtractMetadata struct {
 	Source           *string                `json:"source,omitempty"`          // e.g., "bulk_upload", "api", "scheduled"
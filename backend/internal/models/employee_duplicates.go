@@ -0,0 +1,82 @@
+package models
+
+import "time"
+
+// Duplicate review statuses
+const (
+	DuplicateStatusPending   = "pending"
+	DuplicateStatusDismissed = "dismissed"
+)
+
+// EmployeeIdentity is a normalized identifier of an employee, such as an email or phone
+type EmployeeIdentity struct {
+	Kind  string `json:"kind" db:"kind"` // email, phone, linkedin, github, fingerprint
+	Value string `json:"value" db:"value"`
+}
+
+// EmployeeIdentityProfile is an employee's name with every identifier collected for it
+type EmployeeIdentityProfile struct {
+	EmployeeID int                `json:"employee_id"`
+	Name       string             `json:"name"`
+	Identities []EmployeeIdentity `json:"identities"`
+}
+
+// DuplicateCandidate is an existing employee that may be the same person
+type DuplicateCandidate struct {
+	EmployeeID int      `json:"employee_id"`
+	Name       string   `json:"name"`
+	Score      float64  `json:"score"`   // 0-1
+	Reasons    []string `json:"reasons"` // Evidence such as "same phone 1155551234"
+}
+
+// EmployeeDuplicate is a pair of employees flagged as probably the same person
+type EmployeeDuplicate struct {
+	ID             int       `json:"id" db:"id"`
+	EmployeeID     int       `json:"employee_id" db:"employee_id"`
+	EmployeeName   string    `json:"employee_name"`
+	EmployeeEmail  string    `json:"employee_email"`
+	DuplicateID    int       `json:"duplicate_id" db:"duplicate_id"`
+	DuplicateName  string    `json:"duplicate_name"`
+	DuplicateEmail string    `json:"duplicate_email"`
+	Score          float64   `json:"score" db:"score"`
+	Reasons        []string  `json:"reasons" db:"reasons"`
+	Status         string    `json:"status" db:"status"`
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// DuplicateScanResult reports a scan of all employees for duplicates
+type DuplicateScanResult struct {
+	EmployeesScanned int                 `json:"employees_scanned"`
+	PairsFlagged     int                 `json:"pairs_flagged"`
+	PairsMerged      int                 `json:"pairs_merged"` // Only when the scan auto-merges
+	Duplicates       []EmployeeDuplicate `json:"duplicates"`   // Pending pairs after the scan
+}
+
+// MergeEmployeesRequest asks to merge another employee into the one in the URL
+type MergeEmployeesRequest struct {
+	SourceEmployeeID int `json:"source_employee_id" validate:"required"`
+}
+
+// EmployeeMerge records an employee merged into another
+type EmployeeMerge struct {
+	ID               int       `json:"id" db:"id"`
+	EmployeeID       int       `json:"employee_id" db:"employee_id"` // The employee kept
+	MergedEmployeeID int       `json:"merged_employee_id" db:"merged_employee_id"`
+	MergedName       string    `json:"merged_name" db:"merged_name"`
+	MergedEmail      *string   `json:"merged_email,omitempty" db:"merged_email"`
+	Score            *float64  `json:"score,omitempty" db:"score"`
+	Reasons          []string  `json:"reasons" db:"reasons"`
+	Automatic        bool      `json:"automatic" db:"automatic"`
+	MergedAt         time.Time `json:"merged_at" db:"merged_at"`
+}
+
+// EmployeeMergeResult reports a merge
+type EmployeeMergeResult struct {
+	Employee         *Employee `json:"employee"`
+	MergedEmployeeID int       `json:"merged_employee_id"`
+	SkillsMerged     int64     `json:"skills_merged"`
+	MatchesMoved     int64     `json:"matches_moved"`
+	FilesMoved       int64     `json:"files_moved"` // Google Drive files now linked to the kept employee
+	ExtractionMoved  bool      `json:"extraction_moved"`
+}
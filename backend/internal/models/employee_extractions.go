@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// EmployeeExtraction is one extraction run stored for an employee
+type EmployeeExtraction struct {
+	ID               int                  `json:"id" db:"id"`
+	EmployeeID       int                  `json:"employee_id" db:"employee_id"`
+	Version          int                  `json:"version" db:"version"` // 1 for the first run
+	Source           string               `json:"source" db:"source"`
+	ResumeURL        string               `json:"resume_url,omitempty" db:"resume_url"`
+	ExtractorMethod  string               `json:"extractor_method" db:"extractor_method"`
+	ExtractorVersion *int                 `json:"extractor_version,omitempty" db:"extractor_version"` // Unknown for runs stored before versions were recorded
+	Status           string               `json:"status" db:"status"`
+	CreatedAt        time.Time            `json:"created_at" db:"created_at"`
+	OriginalText     string               `json:"original_text,omitempty" db:"original_text"`   // Only for a single version
+	ExtractedData    *ProcessedResumeData `json:"extracted_data,omitempty" db:"extracted_data"` // Only for a single version
+}
+
+// ExtractionFieldChange is a field whose value differs between two extractions
+type ExtractionFieldChange struct {
+	Field string `json:"field"` // e.g. current_role, seniority_level
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// ExtractionDiff compares two extractions of an employee
+type ExtractionDiff struct {
+	EmployeeID       int                     `json:"employee_id"`
+	FromVersion      int                     `json:"from_version"` // 0 when there is no earlier extraction
+	ToVersion        int                     `json:"to_version"`
+	SkillsAdded      []string                `json:"skills_added"`
+	SkillsRemoved    []string                `json:"skills_removed"`
+	PositionsAdded   []string                `json:"positions_added"` // "Role at Company"
+	PositionsRemoved []string                `json:"positions_removed"`
+	FieldChanges     []ExtractionFieldChange `json:"field_changes"`
+	TextChanged      bool                    `json:"text_changed"`
+	Summary          []string                `json:"summary"` // Readable lines, as in CandidateExtractionResult.ChangesSummary
+}
+
+// HasChanges reports whether the two extractions differ
+func (d *ExtractionDiff) HasChanges() bool {
+	return len(d.SkillsAdded) > 0 || len(d.SkillsRemoved) > 0 || len(d.PositionsAdded) > 0 ||
+		len(d.PositionsRemoved) > 0 || len(d.FieldChanges) > 0 || d.TextChanged
+}
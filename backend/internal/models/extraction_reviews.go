@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// Extraction review statuses
+const (
+	ReviewStatusPending  = "pending"
+	ReviewStatusApproved = "approved"
+	ReviewStatusRejected = "rejected"
+)
+
+// ExtractionReview is an extraction held for a reviewer instead of being stored
+type ExtractionReview struct {
+	ID               int                  `json:"id" db:"id"`
+	Status           string               `json:"status" db:"status"`
+	Reasons          []string             `json:"reasons" db:"reasons"`
+	ConfidenceScore  float64              `json:"confidence_score" db:"confidence_score"`
+	CandidateName    string               `json:"candidate_name" db:"candidate_name"`
+	ExtractionSource string               `json:"extraction_source,omitempty" db:"extraction_source"`
+	ResumeURL        string               `json:"resume_url,omitempty" db:"resume_url"`
+	OriginalText     string               `json:"original_text,omitempty" db:"original_text"` // Only for a single review
+	ExtractedData    *ProcessedResumeData `json:"extracted_data" db:"extracted_data"`
+	EmployeeID       *int                 `json:"employee_id,omitempty" db:"employee_id"` // Set on approval
+	ReviewedBy       string               `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	ReviewNotes      string               `json:"review_notes,omitempty" db:"review_notes"`
+	ReviewedAt       *time.Time           `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	CreatedAt        time.Time            `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time            `json:"updated_at" db:"updated_at"`
+}
+
+// UpdateExtractionReviewRequest replaces the data of a held extraction
+type UpdateExtractionReviewRequest struct {
+	ExtractedData *ProcessedResumeData `json:"extracted_data" validate:"required"`
+}
+
+// ResolveExtractionReviewRequest approves or rejects a held extraction. On approval,
+// ExtractedData optionally replaces the held data before it is stored.
+type ResolveExtractionReviewRequest struct {
+	ExtractedData *ProcessedResumeData `json:"extracted_data,omitempty"`
+	Notes         string               `json:"notes,omitempty"`
+}
+
+// ExtractionReviewResult reports an approved or rejected extraction
+type ExtractionReviewResult struct {
+	Review    *ExtractionReview          `json:"review"`
+	Candidate *CandidateExtractionResult `json:"candidate,omitempty"` // The stored employee, on approval
+}
@@ -34,20 +34,23 @@ type GoogleDriveScanRequest struct {
 	FileTypes       []string `json:"file_types"` // e.g., ["application/pdf", "application/msword"]
 	ExtractSkills   bool     `json:"extract_skills"`
 	ProcessExisting bool     `json:"process_existing"` // Whether to process files already in database
+	MaxFiles        int      `json:"max_files"`        // Optional cap on files processed per scan; the rest wait for the next scan
 }
 
 // GoogleDriveScanResponse represents the response from scanning a Google Drive folder
 type GoogleDriveScanResponse struct {
-	FolderID        string                   `json:"folder_id"`
-	FolderName      string                   `json:"folder_name"`
-	TotalFiles      int                      `json:"total_files"`
-	ProcessedFiles  int                      `json:"processed_files"`
-	SkippedFiles    int                      `json:"skipped_files"`
-	FailedFiles     int                      `json:"failed_files"`
-	Files           []GoogleDriveFileProcess `json:"files"`
-	ExtractedSkills []Skill                  `json:"extracted_skills,omitempty"`
-	Errors          []string                 `json:"errors,omitempty"`
-	ProcessingTime  time.Duration            `json:"processing_time"`
+	FolderID         string                   `json:"folder_id"`
+	FolderName       string                   `json:"folder_name"`
+	ExtractRequestID string                   `json:"extract_request_id,omitempty"` // CV extract record tracking this scan
+	ChangeToken      string                   `json:"change_token,omitempty"`       // Files modified after this are listed by the next scan
+	TotalFiles       int                      `json:"total_files"`
+	ProcessedFiles   int                      `json:"processed_files"`
+	SkippedFiles     int                      `json:"skipped_files"`
+	FailedFiles      int                      `json:"failed_files"`
+	Files            []GoogleDriveFileProcess `json:"files"`
+	ExtractedSkills  []Skill                  `json:"extracted_skills,omitempty"`
+	Errors           []string                 `json:"errors,omitempty"`
+	ProcessingTime   time.Duration            `json:"processing_time"`
 }
 
 // GoogleDriveFileProcess represents a file that was processed during scanning
@@ -55,7 +58,11 @@ type GoogleDriveFileProcess struct {
 	FileID          string    `json:"file_id"`
 	FileName        string    `json:"file_name"`
 	FileSize        int64     `json:"file_size"`
+	MimeType        string    `json:"mime_type,omitempty"`
+	ModifiedTime    time.Time `json:"modified_time"`
 	Status          string    `json:"status"` // "processed", "skipped", "failed"
+	EmployeeID      int       `json:"employee_id,omitempty"`
+	Action          string    `json:"action,omitempty"` // "created", "updated", "no_changes"
 	ExtractedSkills []Skill   `json:"extracted_skills,omitempty"`
 	Error           string    `json:"error,omitempty"`
 	ProcessedAt     time.Time `json:"processed_at"`
@@ -75,3 +82,36 @@ type GoogleDriveAuthToken struct {
 	Expiry       time.Time `json:"expiry"`
 	TokenType    string    `json:"token_type"`
 }
+
+// GoogleDriveSyncFolder holds the incremental sync state of a scanned folder
+type GoogleDriveSyncFolder struct {
+	FolderID             string     `json:"folder_id" db:"folder_id"`
+	FolderName           *string    `json:"folder_name,omitempty" db:"folder_name"`
+	Recursive            bool       `json:"recursive" db:"recursive"`
+	ChangeToken          *string    `json:"change_token,omitempty" db:"change_token"`
+	LastExtractRequestID *string    `json:"last_extract_request_id,omitempty" db:"last_extract_request_id"`
+	LastScanAt           *time.Time `json:"last_scan_at,omitempty" db:"last_scan_at"`
+	CreatedAt            time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt            time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// GoogleDriveSyncFile records the outcome of the last processing of a Drive file
+type GoogleDriveSyncFile struct {
+	FileID       string    `json:"file_id" db:"file_id"`
+	FolderID     string    `json:"folder_id" db:"folder_id"`
+	FileName     string    `json:"file_name" db:"file_name"`
+	MimeType     *string   `json:"mime_type,omitempty" db:"mime_type"`
+	FileSize     *int64    `json:"file_size,omitempty" db:"file_size"`
+	ModifiedTime time.Time `json:"modified_time" db:"modified_time"`
+	Status       string    `json:"status" db:"status"`
+	EmployeeID   *int      `json:"employee_id,omitempty" db:"employee_id"`
+	ErrorMessage *string   `json:"error_message,omitempty" db:"error_message"`
+	ProcessedAt  time.Time `json:"processed_at" db:"processed_at"`
+}
+
+// Constants for Google Drive file processing status
+const (
+	GoogleDriveFileStatusProcessed = "processed"
+	GoogleDriveFileStatusSkipped   = "skipped"
+	GoogleDriveFileStatusFailed    = "failed"
+)
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+)
+
+// HuggingFaceCacheEntry is a persisted Hugging Face extraction result
+type HuggingFaceCacheEntry struct {
+	CacheKey  string    `json:"cache_key" db:"cache_key"`
+	ModelName string    `json:"model_name" db:"model_name"`
+	Response  []byte    `json:"-" db:"response"` // JSON of a HuggingFaceSkillExtractionResponse
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	ExpiresAt time.Time `json:"expires_at" db:"expires_at"`
+}
+
+// HuggingFaceCacheStats reports the use of the Hugging Face extraction cache
+type HuggingFaceCacheStats struct {
+	Entries        int   `json:"entries"`
+	Capacity       int   `json:"capacity"`
+	Hits           int64 `json:"hits"`
+	PersistentHits int64 `json:"persistent_hits"` // Hits found in the database after a memory miss
+	Misses         int64 `json:"misses"`
+	Evictions      int64 `json:"evictions"`
+	Expirations    int64 `json:"expirations"`
+	Persistent     bool  `json:"persistent"`
+}
+
+// HuggingFaceCachePurgeResult reports what a cache purge removed
+type HuggingFaceCachePurgeResult struct {
+	MemoryEntries     int   `json:"memory_entries"`
+	PersistentEntries int64 `json:"persistent_entries"`
+}
@@ -1,6 +1,9 @@
 package models
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 // HuggingFaceSkillExtractionRequest represents a request for skill extraction using Hugging Face
 type HuggingFaceSkillExtractionRequest struct {
@@ -19,22 +22,25 @@ type HuggingFaceSkillExtractionResponse struct {
 	Categories      map[string][]string `json:"categories"`
 	TotalSkills     int                 `json:"total_skills"`
 	ConfidenceScore float64             `json:"confidence_score"`
+	Evidence        []SkillEvidence     `json:"evidence"`
 	ModelUsed       string              `json:"model_used"`
 	ProcessingTime  time.Duration       `json:"processing_time"`
 	RawResponse     interface{}         `json:"raw_response,omitempty"`
+	Redactions      map[string]int      `json:"redactions,omitempty"` // Personal data removed before the model call, by kind
 	Error           string              `json:"error,omitempty"`
 }
 
 // HuggingFaceSkill represents a skill extracted using Hugging Face models
 type HuggingFaceSkill struct {
-	Name           string   `json:"name"`
-	Category       string   `json:"category"`
-	Confidence     float64  `json:"confidence"`
-	StartPosition  int      `json:"start_position"`
-	EndPosition    int      `json:"end_position"`
-	Context        string   `json:"context,omitempty"`
-	NormalizedName string   `json:"normalized_name"`
-	Synonyms       []string `json:"synonyms,omitempty"`
+	Name           string         `json:"name"`
+	Category       string         `json:"category"`
+	Confidence     float64        `json:"confidence"`
+	StartPosition  int            `json:"start_position"`
+	EndPosition    int            `json:"end_position"`
+	Context        string         `json:"context,omitempty"`
+	NormalizedName string         `json:"normalized_name"`
+	Synonyms       []string       `json:"synonyms,omitempty"`
+	Mentions       []SkillMention `json:"mentions,omitempty"`
 }
 
 // HuggingFaceNERResult represents the raw NER result from Hugging Face
@@ -78,26 +84,65 @@ type SkillExtractionConfig struct {
 	MaxSkillsPerText    int                               `json:"max_skills_per_text"`
 	EnableCaching       bool                              `json:"enable_caching"`
 	CacheExpiry         time.Duration                     `json:"cache_expiry"`
+	MaxCacheEntries     int                               `json:"max_cache_entries"`
+	Redaction           PIIRedactionConfig                `json:"redaction"`
+}
+
+// PIIRedactionConfig selects the personal data removed from text before it is sent to an
+// external model
+type PIIRedactionConfig struct {
+	Enabled     bool `json:"enabled"`
+	Emails      bool `json:"emails"`
+	Phones      bool `json:"phones"`
+	URLs        bool `json:"urls"`
+	NationalIDs bool `json:"national_ids"`
+	PersonNames bool `json:"person_names"`
 }
 
 // SkillExtractionStats represents statistics for skill extraction
 type SkillExtractionStats struct {
-	TotalRequests         int64            `json:"total_requests"`
-	SuccessfulRequests    int64            `json:"successful_requests"`
-	FailedRequests        int64            `json:"failed_requests"`
-	AverageProcessingTime time.Duration    `json:"average_processing_time"`
-	MostUsedModel         string           `json:"most_used_model"`
-	SkillsExtracted       int64            `json:"skills_extracted"`
-	CategoriesFound       map[string]int64 `json:"categories_found"`
-	LastUpdated           time.Time        `json:"last_updated"`
+	TotalRequests         int64                    `json:"total_requests"`
+	SuccessfulRequests    int64                    `json:"successful_requests"`
+	FailedRequests        int64                    `json:"failed_requests"`
+	AverageProcessingTime time.Duration            `json:"average_processing_time"`
+	MostUsedModel         string                   `json:"most_used_model"`
+	SkillsExtracted       int64                    `json:"skills_extracted"`
+	CategoriesFound       map[string]int64         `json:"categories_found"`
+	LastUpdated           time.Time                `json:"last_updated"`
+	Cache                 HuggingFaceCacheStats    `json:"cache"`
+	ModelHealth           []HuggingFaceModelHealth `json:"model_health"`
+}
+
+// HuggingFaceHealth is the overall state of the Hugging Face models: healthy when every
+// model's circuit is closed, degraded when some are open and unhealthy when none can be used
+type HuggingFaceHealth struct {
+	Status string                   `json:"status"`
+	Models []HuggingFaceModelHealth `json:"models"`
+}
+
+// HuggingFaceModelHealth is the circuit breaker state and call history of one model
+type HuggingFaceModelHealth struct {
+	Model               string     `json:"model"`
+	State               string     `json:"state"` // closed, open or half_open
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	TotalRequests       int64      `json:"total_requests"`
+	FailedRequests      int64      `json:"failed_requests"`
+	Retries             int64      `json:"retries"`
+	Loading             bool       `json:"loading"` // The last retry waited for the model to load
+	LastError           string     `json:"last_error,omitempty"`
+	LastFailureAt       *time.Time `json:"last_failure_at,omitempty"`
+	LastSuccessAt       *time.Time `json:"last_success_at,omitempty"`
+	OpenUntil           *time.Time `json:"open_until,omitempty"`
 }
 
 // HuggingFaceSkillExtractionService interface
 type HuggingFaceSkillExtractionService interface {
-	ExtractSkills(request *HuggingFaceSkillExtractionRequest) (*HuggingFaceSkillExtractionResponse, error)
-	ExtractSkillsFromText(text string) (*HuggingFaceSkillExtractionResponse, error)
+	ExtractSkills(ctx context.Context, request *HuggingFaceSkillExtractionRequest) (*HuggingFaceSkillExtractionResponse, error)
+	ExtractSkillsFromText(ctx context.Context, text string) (*HuggingFaceSkillExtractionResponse, error)
 	GetAvailableModels() ([]string, error)
 	GetModelConfig(modelName string) (*HuggingFaceModelConfig, error)
 	GetStats() (*SkillExtractionStats, error)
+	GetHealth() *HuggingFaceHealth
+	PurgeCache() (*HuggingFaceCachePurgeResult, error)
 	HealthCheck() error
 }
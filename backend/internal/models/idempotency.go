@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+)
+
+// IdempotencyRecord represents a stored response for a request sent with an Idempotency-Key header
+type IdempotencyRecord struct {
+	ID                  int        `json:"id" db:"id"`
+	IdempotencyKey      string     `json:"idempotency_key" db:"idempotency_key"`
+	Scope               string     `json:"scope" db:"scope"`
+	RequestFingerprint  string     `json:"request_fingerprint" db:"request_fingerprint"`
+	Status              string     `json:"status" db:"status"`
+	ResponseStatus      *int       `json:"response_status,omitempty" db:"response_status"`
+	ResponseContentType *string    `json:"response_content_type,omitempty" db:"response_content_type"`
+	ResponseBody        []byte     `json:"-" db:"response_body"`
+	CreatedAt           time.Time  `json:"created_at" db:"created_at"`
+	CompletedAt         *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+	ExpiresAt           time.Time  `json:"expires_at" db:"expires_at"`
+}
+
+// Constants for idempotency record status
+const (
+	IdempotencyStatusProcessing = "processing"
+	IdempotencyStatusCompleted  = "completed"
+)
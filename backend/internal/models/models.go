@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 )
 
@@ -23,6 +24,25 @@ type Employee struct {
 	Skills              []Skill                `json:"skills,omitempty"`
 	CreatedAt           time.Time              `json:"created_at" db:"created_at"`
 	UpdatedAt           time.Time              `json:"updated_at" db:"updated_at"`
+	Languages           []SpokenLanguage       `json:"languages,omitempty"`
+	EmployeeContact
+	Certifications []EmployeeCertification `json:"certifications,omitempty"`
+	Positions      []EmployeePosition      `json:"positions,omitempty"` // Work history, on GET /employees/:id
+	Education      []EmployeeEducation     `json:"education,omitempty"`
+	Projects       []EmployeeProject       `json:"projects,omitempty"`
+}
+
+// EmployeeContact holds an employee's structured contact details and profile links
+type EmployeeContact struct {
+	Phone            string `json:"phone,omitempty" db:"phone"` // E.164, e.g. +34612345678
+	City             string `json:"city,omitempty" db:"city"`
+	Region           string `json:"region,omitempty" db:"region"`
+	Country          string `json:"country,omitempty" db:"country"` // ISO 3166-1 alpha-2
+	LinkedInURL      string `json:"linkedin_url,omitempty" db:"linkedin_url"`
+	GitHubURL        string `json:"github_url,omitempty" db:"github_url"`
+	GitLabURL        string `json:"gitlab_url,omitempty" db:"gitlab_url"`
+	StackOverflowURL string `json:"stackoverflow_url,omitempty" db:"stackoverflow_url"`
+	WebsiteURL       string `json:"website_url,omitempty" db:"website_url"`
 }
 
 // Category represents a skill category
@@ -107,13 +127,114 @@ type ProcessedResumeData struct {
 	ProfessionalSummary string           `json:"professional_summary"`
 	FileMetadata        FileMetadata     `json:"file_metadata"`
 	ProcessingTimestamp string           `json:"processing_timestamp"`
+
+	SkillCategories  map[string][]string         `json:"skill_categories"`         // Skills by taxonomy category, as stored in employee_skills
+	SkillProficiency map[string]SkillProficiency `json:"skill_proficiency"`        // Proficiency stated in the text, by skill name
+	SkillEvidence    []SkillEvidence             `json:"skill_evidence"`           // Where and how each skill was found
+	Languages        []string                    `json:"languages"`                // Spoken languages as written in the resume
+	SpokenLanguages  []SpokenLanguage            `json:"spoken_languages"`         // Spoken languages with the level stated
+	RankedSkills     []RankedSkill               `json:"ranked_skills,omitempty"`  // Ensemble scores when several extractors ran
+	UnknownSkills    []UnknownSkillTerm          `json:"unknown_skills,omitempty"` // Terms that look like skills but are not in the catalog
+	ConfidenceScore  float64                     `json:"confidence_score"`
+	ExtractionMethod string                      `json:"extraction_method"`
+
+	CertificationDetails []ResumeCertification `json:"certification_details"` // Certifications with their issuer, credential ID and dates
+}
+
+// LanguageLevelNative is the level of a spoken language above CEFR C2
+const LanguageLevelNative = "native"
+
+// SpokenLanguage is a human language a candidate speaks
+type SpokenLanguage struct {
+	Code  string `json:"code" db:"language_code"`    // ISO 639-1, e.g. "en"
+	Name  string `json:"name"`                       // In English
+	Level string `json:"level,omitempty" db:"level"` // CEFR A1-C2 or native; empty when not stated
+}
+
+// LanguageRequirement asks for a spoken language at a minimum level
+type LanguageRequirement struct {
+	Language string `json:"language"`            // Name in English or Spanish, or ISO 639-1 code
+	MinLevel string `json:"min_level,omitempty"` // CEFR A1-C2, native, or a word such as "fluent"
+}
+
+// UnknownSkillTerm is a term found in a resume that looks like a skill but is not in the skill
+// catalog
+type UnknownSkillTerm struct {
+	Term    string `json:"term"`
+	Source  string `json:"source"`            // skills_section, huggingface
+	Context string `json:"context,omitempty"` // The text around the term
+}
+
+// RankedSkill is one skill of an ensemble extraction with the votes of the extractors that
+// found it
+type RankedSkill struct {
+	Skill      string      `json:"skill"`
+	Categories []string    `json:"categories"`
+	Canonical  bool        `json:"canonical"` // The skill is in the skill catalog
+	Score      float64     `json:"score"`     // 0-1
+	Accepted   bool        `json:"accepted"`  // The score reached the ensemble minimum; only accepted skills are stored
+	Votes      []SkillVote `json:"votes"`
+}
+
+// SkillVote is one extractor's finding of a skill
+type SkillVote struct {
+	Method     string  `json:"method"` // dictionary, huggingface
+	Name       string  `json:"name"`   // The skill as the extractor reported it
+	Confidence float64 `json:"confidence"`
+	Weight     float64 `json:"weight"`
+}
+
+// ExtractionAgreement compares the skills found by the dictionary and Hugging Face
+// extractors after both are mapped to catalog skills
+type ExtractionAgreement struct {
+	DictionarySkills   int      `json:"dictionary_skills"`
+	HuggingFaceSkills  int      `json:"huggingface_skills"`
+	SharedSkills       []string `json:"shared_skills"`
+	OnlyDictionary     []string `json:"only_dictionary"`
+	OnlyHuggingFace    []string `json:"only_huggingface"`
+	Jaccard            float64  `json:"jaccard"`             // Shared skills over all skills found
+	OverlapCoefficient float64  `json:"overlap_coefficient"` // Shared skills over the smaller list
+	CanonicalRate      float64  `json:"canonical_rate"`      // Share of Hugging Face skills found in the skill catalog
+}
+
+// SkillEvidence explains why a skill was extracted: where the text mentions it, which
+// extraction stages found it and how confident the extractor is
+type SkillEvidence struct {
+	Skill      string         `json:"skill"`
+	Categories []string       `json:"categories"`
+	Sources    []string       `json:"sources"` // dictionary, alias, huggingface
+	Mentions   []SkillMention `json:"mentions"`
+	Confidence float64        `json:"confidence"` // 0-1
+}
+
+// SkillMention is one mention of a skill. Offsets count characters (not bytes) from the
+// start of the extracted text, end exclusive.
+type SkillMention struct {
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+	Text  string `json:"text"` // The surface form as written
+}
+
+// SkillProficiency is a proficiency level (1-5) inferred from the words around a skill
+// mention, such as "expert in Go" or "conocimientos básicos de Python"
+type SkillProficiency struct {
+	Level    int    `json:"level"`
+	Evidence string `json:"evidence"` // The phrase the level was inferred from
 }
 
 // ContactInfo represents contact information
 type ContactInfo struct {
-	Email    string `json:"email"`
-	Phone    string `json:"phone"`
-	Location string `json:"location"`
+	Email            string `json:"email"`
+	Phone            string `json:"phone"` // E.164 when the number could be normalized
+	Location         string `json:"location"`
+	City             string `json:"city,omitempty"`
+	Region           string `json:"region,omitempty"`
+	Country          string `json:"country,omitempty"` // ISO 3166-1 alpha-2
+	LinkedInURL      string `json:"linkedin_url,omitempty"`
+	GitHubURL        string `json:"github_url,omitempty"`
+	GitLabURL        string `json:"gitlab_url,omitempty"`
+	StackOverflowURL string `json:"stackoverflow_url,omitempty"`
+	WebsiteURL       string `json:"website_url,omitempty"`
 }
 
 // ResumeSkills represents skills extracted from resume
@@ -132,6 +253,9 @@ type WorkExperience struct {
 	Duration    string `json:"duration"`
 	Years       string `json:"years"`
 	Description string `json:"description"`
+	StartDate   string `json:"start_date,omitempty"` // YYYY-MM, or YYYY when the resume gives only years
+	EndDate     string `json:"end_date,omitempty"`   // Same format; empty for the current position
+	Current     bool   `json:"current,omitempty"`
 }
 
 // Project represents a project entry
@@ -147,7 +271,9 @@ type Project struct {
 type Education struct {
 	Institution string `json:"institution"`
 	Degree      string `json:"degree"`
-	Year        string `json:"year"`
+	Field       string `json:"field,omitempty"` // Field of study, when the degree names one
+	StartYear   string `json:"start_year,omitempty"`
+	Year        string `json:"year"` // The year finished; empty while studying
 }
 
 // FileMetadata represents file metadata from Google Drive
@@ -356,6 +482,69 @@ type CreateEmployeeRequest struct {
 	CurrentProject string             `json:"current_project"`
 	ResumeUrl      string             `json:"resume_url"`
 	Skills         []EmployeeSkillReq `json:"skills"`
+	Languages      []SpokenLanguage   `json:"languages"`
+	EmployeeContact
+	// ContactFields names, by JSON key, the contact fields the request body sent; an update
+	// writes those and keeps the stored value of the rest
+	ContactFields map[string]bool `json:"-"`
+}
+
+// contactFieldNames are the JSON keys of EmployeeContact
+var contactFieldNames = []string{
+	"phone", "city", "region", "country",
+	"linkedin_url", "github_url", "gitlab_url", "stackoverflow_url", "website_url",
+}
+
+// UnmarshalJSON decodes the request and records which contact fields the body sent, so an
+// empty string clears a field while a missing key leaves it alone
+func (r *CreateEmployeeRequest) UnmarshalJSON(data []byte) error {
+	type request CreateEmployeeRequest
+	if err := json.Unmarshal(data, (*request)(r)); err != nil {
+		return err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	r.ContactFields = nil
+	for _, name := range contactFieldNames {
+		if _, ok := fields[name]; ok {
+			if r.ContactFields == nil {
+				r.ContactFields = make(map[string]bool)
+			}
+			r.ContactFields[name] = true
+		}
+	}
+	return nil
+}
+
+// MergeContact fills the contact fields the request did not send with the stored ones
+func (r *CreateEmployeeRequest) MergeContact(stored EmployeeContact) {
+	sent := r.EmployeeContact
+	r.EmployeeContact = stored
+	for name := range r.ContactFields {
+		switch name {
+		case "phone":
+			r.Phone = sent.Phone
+		case "city":
+			r.City = sent.City
+		case "region":
+			r.Region = sent.Region
+		case "country":
+			r.Country = sent.Country
+		case "linkedin_url":
+			r.LinkedInURL = sent.LinkedInURL
+		case "github_url":
+			r.GitHubURL = sent.GitHubURL
+		case "gitlab_url":
+			r.GitLabURL = sent.GitLabURL
+		case "stackoverflow_url":
+			r.StackOverflowURL = sent.StackOverflowURL
+		case "website_url":
+			r.WebsiteURL = sent.WebsiteURL
+		}
+	}
 }
 
 // EmployeeSkillReq represents a skill request for an employee
@@ -367,12 +556,42 @@ type EmployeeSkillReq struct {
 
 // SearchRequest represents a request to search for employees
 type SearchRequest struct {
-	RequiredSkills  []string `json:"required_skills"`
-	PreferredSkills []string `json:"preferred_skills"`
-	Department      string   `json:"department"`
-	ExperienceLevel string   `json:"experience_level"`
-	Location        string   `json:"location"`
-	MinMatchScore   float64  `json:"min_match_score"`
+	RequiredSkills     []string              `json:"required_skills"`
+	PreferredSkills    []string              `json:"preferred_skills"`
+	Department         string                `json:"department"`
+	ExperienceLevel    string                `json:"experience_level"`
+	Location           string                `json:"location"`
+	City               string                `json:"city,omitempty"`                 // Only employees in this city
+	Country            string                `json:"country,omitempty"`              // Only employees in this country, by name or ISO code
+	Profiles           []string              `json:"profiles,omitempty"`             // Only employees with all these profiles: linkedin, github, gitlab, stackoverflow, website
+	Languages          []LanguageRequirement `json:"languages,omitempty"`            // Only employees who speak all these languages, e.g. English at B2 or above
+	Certifications     []string              `json:"certifications,omitempty"`       // Only employees holding all these certifications, unexpired, by name or a word such as "AWS" or "Scrum"
+	Employers          []string              `json:"employers,omitempty"`            // Only employees who worked at each of these, by company name or a sector such as "bank"
+	Degrees            []string              `json:"degrees,omitempty"`              // Only employees with each of these degrees, by degree or field, e.g. "CS" or "Master"
+	MinYearsExperience float64               `json:"min_years_experience,omitempty"` // Leaves out employees whose dated work history is shorter; ranks those it covers first
+	MinMatchScore      float64               `json:"min_match_score"`
+}
+
+// ParseJobRequestRequest represents a request to parse a free-text job request
+type ParseJobRequestRequest struct {
+	Text string `json:"text" binding:"required"`
+}
+
+// ParsedJobRequest represents the structured interpretation of a free-text job request
+type ParsedJobRequest struct {
+	OriginalText    string                     `json:"original_text"`
+	Language        string                     `json:"language"`
+	SearchRequest   SearchRequest              `json:"search_request"`
+	SearchCriteria  SearchCriteria             `json:"search_criteria"`
+	Interpretations []JobRequestInterpretation `json:"interpretations"`
+}
+
+// JobRequestInterpretation records one value the parser understood and the text it came from,
+// so the requester can review and correct it
+type JobRequestInterpretation struct {
+	Field    string `json:"field"`
+	Value    string `json:"value"`
+	Evidence string `json:"evidence"`
 }
 
 // DashboardStats represents dashboard statistics
@@ -436,13 +655,14 @@ type AIAgentRequest struct {
 
 // AIAgentResponse represents the response from the AI agent
 type AIAgentResponse struct {
-	RequestID      int            `json:"request_id"`
-	Matches        []AIAgentMatch `json:"matches"`
-	Summary        string         `json:"summary"`
-	ProcessingTime int64          `json:"processing_time_ms"`
-	Status         string         `json:"status"`
-	Error          string         `json:"error,omitempty"`
-	CreatedAt      time.Time      `json:"created_at"`
+	RequestID      int               `json:"request_id"`
+	Matches        []AIAgentMatch    `json:"matches"`
+	Summary        string            `json:"summary"`
+	ProcessingTime int64             `json:"processing_time_ms"`
+	Status         string            `json:"status"`
+	Error          string            `json:"error,omitempty"`
+	ParsedRequest  *ParsedJobRequest `json:"parsed_request,omitempty"`
+	CreatedAt      time.Time         `json:"created_at"`
 }
 
 // AIAgentMatch represents a match result from the AI agent
@@ -508,6 +728,7 @@ type ExtractAIResponse struct {
 	ProcessingType   string                 `json:"processing_type"`
 	Metadata         map[string]interface{} `json:"metadata,omitempty"`
 	Timestamp        time.Time              `json:"timestamp"`
+	Resume           *ProcessedResumeData   `json:"-"` // Set for candidate_extraction; ProcessedContent holds its JSON
 }
 
 // CandidateInfo represents structured candidate information extracted from resume
@@ -592,15 +813,19 @@ type ExtractProcessRequest struct {
 
 // CandidateExtractionResult represents the result of candidate extraction and storage
 type CandidateExtractionResult struct {
-	EmployeeID      int                    `json:"employee_id"`
-	Action          string                 `json:"action"` // "created", "updated", "no_changes"
-	Employee        *Employee              `json:"employee,omitempty"`
-	ExtractedData   map[string]interface{} `json:"extracted_data"`
-	ChangesDetected bool                   `json:"changes_detected"`
-	ChangesSummary  []string               `json:"changes_summary,omitempty"`
-	ProcessingTime  time.Duration          `json:"processing_time"`
-	Status          string                 `json:"status"`
-	Message         string                 `json:"message"`
+	EmployeeID      int                  `json:"employee_id"`
+	Action          string               `json:"action"` // "created", "updated", "no_changes", "pending_review"
+	Employee        *Employee            `json:"employee,omitempty"`
+	ExtractedData   *ProcessedResumeData `json:"extracted_data"`
+	ChangesDetected bool                 `json:"changes_detected"`
+	ChangesSummary  []string             `json:"changes_summary,omitempty"`
+	ProcessingTime  time.Duration        `json:"processing_time"`
+	Status          string               `json:"status"`
+	Message         string               `json:"message"`
+
+	PossibleDuplicates []DuplicateCandidate `json:"possible_duplicates,omitempty"` // Existing employees flagged for review
+	ExtractionVersion  int                  `json:"extraction_version,omitempty"`  // Version of this run in the employee's extraction history
+	ReviewID           int                  `json:"review_id,omitempty"`           // Set when the extraction was held for review instead of stored
 }
 
 // MatchingResult represents the result of candidate matching
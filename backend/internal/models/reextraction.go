@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// Re-extraction result statuses
+const (
+	ReextractionStatusChanged   = "changed" // Would change, for a dry run
+	ReextractionStatusUnchanged = "unchanged"
+	ReextractionStatusFailed    = "failed"
+)
+
+// ReextractionFilter selects the employees a re-extraction run reprocesses from their stored
+// resume text. Versions and dates are those of each employee's latest extraction.
+type ReextractionFilter struct {
+	Source                string     `json:"source,omitempty"`                  // Extraction source, e.g. google_drive
+	ExtractorVersionBelow int        `json:"extractor_version_below,omitempty"` // Only employees extracted by an older extractor
+	ExtractedAfter        *time.Time `json:"extracted_after,omitempty"`
+	ExtractedBefore       *time.Time `json:"extracted_before,omitempty"`
+}
+
+// ReextractionRequest starts a re-extraction run
+type ReextractionRequest struct {
+	Filter      ReextractionFilter `json:"filter"`
+	DryRun      bool               `json:"dry_run"`               // Diff without storing anything
+	Concurrency int                `json:"concurrency,omitempty"` // Employees processed at once
+}
+
+// ReextractionResult is the outcome of re-extracting one employee
+type ReextractionResult struct {
+	EmployeeID   int             `json:"employee_id" db:"employee_id"`
+	EmployeeName string          `json:"employee_name,omitempty" db:"name"`
+	Status       string          `json:"status" db:"status"`
+	Changes      *ExtractionDiff `json:"changes,omitempty" db:"changes"`
+	ErrorMessage string          `json:"error_message,omitempty" db:"error_message"`
+	CreatedAt    time.Time       `json:"created_at" db:"created_at"`
+}
+
+// ReextractionRun is a re-extraction run, with its progress tracked as a CV extract
+type ReextractionRun struct {
+	RequestID string               `json:"request_id"`
+	Request   ReextractionRequest  `json:"request"`
+	Progress  *CVExtract           `json:"progress"`
+	Results   []ReextractionResult `json:"results,omitempty"`
+}
@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+// Discovered skill term statuses
+const (
+	SkillTermStatusPending  = "pending"
+	SkillTermStatusApproved = "approved" // Added to the catalog as a skill
+	SkillTermStatusAliased  = "aliased"  // Mapped to an existing skill
+	SkillTermStatusIgnored  = "ignored"
+)
+
+// Sources of unknown skill terms
+const (
+	SkillTermSourceSkillsSection = "skills_section"
+	SkillTermSourceHuggingFace   = "huggingface"
+)
+
+// DiscoveredSkillTerm is a term found in resumes that looks like a skill but is not in the
+// skill catalog, with how often and where it was found
+type DiscoveredSkillTerm struct {
+	ID                  int        `json:"id" db:"id"`
+	Term                string     `json:"term" db:"term"`
+	Status              string     `json:"status" db:"status"`
+	Occurrences         int        `json:"occurrences" db:"occurrences"` // Extractions that found the term
+	EmployeeCount       int        `json:"employee_count" db:"employee_count"`
+	Sources             []string   `json:"sources" db:"sources"`
+	Examples            []string   `json:"examples" db:"examples"` // Contexts the term was found in
+	SuggestedCategories []string   `json:"suggested_categories,omitempty"`
+	SkillID             *int       `json:"skill_id,omitempty" db:"skill_id"` // The skill created or aliased
+	ResolvedBy          string     `json:"resolved_by,omitempty" db:"resolved_by"`
+	ResolvedAt          *time.Time `json:"resolved_at,omitempty" db:"resolved_at"`
+	FirstSeenAt         time.Time  `json:"first_seen_at" db:"first_seen_at"`
+	LastSeenAt          time.Time  `json:"last_seen_at" db:"last_seen_at"`
+}
+
+// ApproveSkillTermRequest adds a discovered term to the catalog. Name defaults to the term and
+// Categories, category IDs, to the categories suggested for it.
+type ApproveSkillTermRequest struct {
+	Name       string `json:"name,omitempty"`
+	Categories []int  `json:"categories,omitempty"`
+}
+
+// AliasSkillTermRequest maps a discovered term to an existing skill
+type AliasSkillTermRequest struct {
+	SkillID int `json:"skill_id" validate:"required"`
+}
+
+// SkillTermResolution reports a resolved term and the re-extraction of the employees whose
+// resumes mention it
+type SkillTermResolution struct {
+	Term                 *DiscoveredSkillTerm `json:"term"`
+	Skill                *Skill               `json:"skill,omitempty"`
+	EmployeesReextracted int                  `json:"employees_reextracted"` // Queued for re-extraction in the background
+}
@@ -32,3 +32,15 @@ UPDATE api_keys SET last_used_at = $1 WHERE key_hash = $2
 
 -- Query name: delete_api_key
 DELETE FROM api_keys WHERE id = $1
+
+-- Query name: set_api_key_signing_secret
+UPDATE api_keys SET signing_secret = $2, signing_secret_created_at = $3 WHERE id = $1
+
+-- Query name: get_api_key_signing_secret
+SELECT COALESCE(signing_secret, '') FROM api_keys WHERE id = $1
+
+-- Query name: has_api_key_signing_secrets
+SELECT EXISTS (
+    SELECT 1 FROM api_keys
+    WHERE is_active = TRUE AND COALESCE(signing_secret, '') <> ''
+)
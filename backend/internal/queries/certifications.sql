@@ -0,0 +1,121 @@
+-- Certification SQL queries
+
+-- Get the certification catalogue
+-- Query name: list_certifications
+SELECT id, name, issuer, aliases, validity_months, created_at, updated_at
+FROM certifications
+ORDER BY name
+
+-- Get a catalogue certification by ID
+-- Query name: get_certification
+SELECT id, name, issuer, aliases, validity_months, created_at, updated_at
+FROM certifications
+WHERE id = $1
+
+-- Add a certification to the catalogue
+-- Query name: create_certification
+INSERT INTO certifications (name, issuer, aliases, validity_months)
+VALUES ($1, $2, $3, $4)
+RETURNING id, created_at, updated_at
+
+-- Update a catalogue certification
+-- Query name: update_certification
+UPDATE certifications
+SET name = $2, issuer = $3, aliases = $4, validity_months = $5, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING created_at, updated_at
+
+-- Remove a certification from the catalogue; employee certifications keep their own name
+-- Query name: delete_certification
+DELETE FROM certifications WHERE id = $1
+
+-- Get the certifications of an employee, soonest to expire first
+-- Query name: get_employee_certifications
+SELECT ec.id, ec.employee_id, ec.certification_id, COALESCE(c.name, ''), ec.name,
+       COALESCE(ec.issuer, ''), COALESCE(ec.credential_id, ''),
+       COALESCE(TO_CHAR(ec.issued_on, 'YYYY-MM-DD'), ''), COALESCE(TO_CHAR(ec.expires_on, 'YYYY-MM-DD'), ''),
+       ec.source, COALESCE(TO_CHAR(ec.expiry_notified_for, 'YYYY-MM-DD'), ''), ec.created_at, ec.updated_at
+FROM employee_certifications ec
+LEFT JOIN certifications c ON c.id = ec.certification_id
+WHERE ec.employee_id = $1
+ORDER BY ec.expires_on NULLS LAST, ec.name
+
+-- Get the certifications of every employee
+-- Query name: get_all_employee_certifications
+SELECT ec.id, ec.employee_id, ec.certification_id, COALESCE(c.name, ''), ec.name,
+       COALESCE(ec.issuer, ''), COALESCE(ec.credential_id, ''),
+       COALESCE(TO_CHAR(ec.issued_on, 'YYYY-MM-DD'), ''), COALESCE(TO_CHAR(ec.expires_on, 'YYYY-MM-DD'), ''),
+       ec.source, COALESCE(TO_CHAR(ec.expiry_notified_for, 'YYYY-MM-DD'), ''), ec.created_at, ec.updated_at
+FROM employee_certifications ec
+LEFT JOIN certifications c ON c.id = ec.certification_id
+ORDER BY ec.employee_id, ec.expires_on NULLS LAST, ec.name
+
+-- Get a certification of an employee
+-- Query name: get_employee_certification
+SELECT ec.id, ec.employee_id, ec.certification_id, COALESCE(c.name, ''), ec.name,
+       COALESCE(ec.issuer, ''), COALESCE(ec.credential_id, ''),
+       COALESCE(TO_CHAR(ec.issued_on, 'YYYY-MM-DD'), ''), COALESCE(TO_CHAR(ec.expires_on, 'YYYY-MM-DD'), ''),
+       ec.source, COALESCE(TO_CHAR(ec.expiry_notified_for, 'YYYY-MM-DD'), ''), ec.created_at, ec.updated_at
+FROM employee_certifications ec
+LEFT JOIN certifications c ON c.id = ec.certification_id
+WHERE ec.id = $1 AND ec.employee_id = $2
+
+-- Add a certification to an employee
+-- Query name: create_employee_certification
+INSERT INTO employee_certifications (employee_id, certification_id, name, issuer, credential_id, issued_on, expires_on, source)
+VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, '')::DATE, NULLIF($7, '')::DATE, $8)
+RETURNING id
+
+-- Update a certification of an employee; edited certifications are kept on re-extraction
+-- Query name: update_employee_certification
+UPDATE employee_certifications
+SET certification_id = $3, name = $4, issuer = NULLIF($5, ''), credential_id = NULLIF($6, ''),
+    issued_on = NULLIF($7, '')::DATE, expires_on = NULLIF($8, '')::DATE, source = 'manual',
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND employee_id = $2
+
+-- Remove a certification from an employee
+-- Query name: delete_employee_certification
+DELETE FROM employee_certifications WHERE id = $1 AND employee_id = $2
+
+-- Store a certification found in an employee's resume. One found before is updated unless it
+-- was entered or edited by hand, which only has its blanks filled.
+-- Query name: upsert_resume_certification
+INSERT INTO employee_certifications (employee_id, certification_id, name, issuer, credential_id, issued_on, expires_on, source)
+VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, '')::DATE, NULLIF($7, '')::DATE, 'resume')
+ON CONFLICT (employee_id, LOWER(name)) DO UPDATE
+SET certification_id = COALESCE(employee_certifications.certification_id, EXCLUDED.certification_id),
+    issuer = CASE WHEN employee_certifications.source = 'resume' THEN EXCLUDED.issuer
+                  ELSE COALESCE(employee_certifications.issuer, EXCLUDED.issuer) END,
+    credential_id = CASE WHEN employee_certifications.source = 'resume' THEN EXCLUDED.credential_id
+                         ELSE COALESCE(employee_certifications.credential_id, EXCLUDED.credential_id) END,
+    issued_on = CASE WHEN employee_certifications.source = 'resume' THEN EXCLUDED.issued_on
+                     ELSE COALESCE(employee_certifications.issued_on, EXCLUDED.issued_on) END,
+    expires_on = CASE WHEN employee_certifications.source = 'resume' THEN EXCLUDED.expires_on
+                      ELSE COALESCE(employee_certifications.expires_on, EXCLUDED.expires_on) END,
+    updated_at = CURRENT_TIMESTAMP
+
+-- Remove the certifications taken from an employee's resume that it no longer lists
+-- Query name: remove_stale_resume_certifications
+DELETE FROM employee_certifications
+WHERE employee_id = $1 AND source = 'resume' AND NOT (LOWER(name) = ANY($2::TEXT[]))
+
+-- Mark the certifications expiring within a number of days that were not announced yet as
+-- announced, and return them with their holders
+-- Query name: claim_expiring_certifications
+UPDATE employee_certifications ec
+SET expiry_notified_for = ec.expires_on
+FROM employees e
+WHERE e.id = ec.employee_id
+  AND ec.expires_on BETWEEN CURRENT_DATE AND CURRENT_DATE + $1::INTEGER
+  AND ec.expiry_notified_for IS DISTINCT FROM ec.expires_on
+RETURNING ec.id, ec.employee_id, ec.certification_id,
+          COALESCE((SELECT c.name FROM certifications c WHERE c.id = ec.certification_id), ''), ec.name,
+          COALESCE(ec.issuer, ''), COALESCE(ec.credential_id, ''),
+          COALESCE(TO_CHAR(ec.issued_on, 'YYYY-MM-DD'), ''), COALESCE(TO_CHAR(ec.expires_on, 'YYYY-MM-DD'), ''),
+          ec.source, COALESCE(TO_CHAR(ec.expiry_notified_for, 'YYYY-MM-DD'), ''), ec.created_at, ec.updated_at,
+          e.name, e.email
+
+-- Mark certifications as not announced again, when announcing them failed
+-- Query name: release_expiring_certifications
+UPDATE employee_certifications SET expiry_notified_for = NULL WHERE id = ANY($1::INTEGER[])
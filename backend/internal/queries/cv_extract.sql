@@ -125,3 +125,31 @@ SELECT id, extract_request_id, status, num_files, files_processed, files_failed,
 FROM cv_extract
 WHERE status = $1
 ORDER BY created_at DESC
+
+-- Lock a CV extract while a file outcome is recorded, so concurrent reports count each other
+-- Query name: lock_cv_extract
+SELECT id FROM cv_extract WHERE id = $1 FOR UPDATE
+
+-- Record the outcome of a numbered file in a CV extract batch
+-- Query name: upsert_cv_extract_file
+INSERT INTO cv_extract_files (cv_extract_id, file_number, status)
+VALUES ($1, $2, $3)
+ON CONFLICT (cv_extract_id, file_number)
+DO UPDATE SET status = EXCLUDED.status, updated_at = CURRENT_TIMESTAMP
+
+-- Record the outcome of a file reported without a number, under a negative number of its own
+-- Query name: insert_unnumbered_cv_extract_file
+INSERT INTO cv_extract_files (cv_extract_id, file_number, status)
+VALUES ($1, -nextval('cv_extract_unnumbered_file_seq'), $2)
+
+-- Recalculate file counts of a CV extract from its file outcomes
+-- Query name: refresh_cv_extract_file_counts
+UPDATE cv_extract
+SET files_processed = (SELECT COUNT(*) FROM cv_extract_files WHERE cv_extract_id = $1 AND status = 'processed'),
+    files_failed = (SELECT COUNT(*) FROM cv_extract_files WHERE cv_extract_id = $1 AND status = 'failed'),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, extract_request_id, status, num_files, files_processed, files_failed,
+          total_processing_time_ms, average_processing_time_ms,
+          started_at, completed_at, error_message, metadata,
+          created_at, updated_at
@@ -0,0 +1,44 @@
+-- Google Drive sync SQL queries
+
+-- Get the sync state of a folder
+-- Query name: get_drive_sync_folder
+SELECT folder_id, folder_name, recursive, change_token, last_extract_request_id,
+       last_scan_at, created_at, updated_at
+FROM drive_sync_folders
+WHERE folder_id = $1
+
+-- Create or update the sync state of a folder after a scan
+-- Query name: upsert_drive_sync_folder
+INSERT INTO drive_sync_folders (folder_id, folder_name, recursive, change_token, last_extract_request_id, last_scan_at, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+ON CONFLICT (folder_id)
+DO UPDATE SET
+    folder_name = EXCLUDED.folder_name,
+    recursive = EXCLUDED.recursive,
+    change_token = EXCLUDED.change_token,
+    last_extract_request_id = COALESCE(EXCLUDED.last_extract_request_id, drive_sync_folders.last_extract_request_id),
+    last_scan_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+
+-- Get the last processing result of a file
+-- Query name: get_drive_sync_file
+SELECT file_id, folder_id, file_name, mime_type, file_size, modified_time,
+       status, employee_id, error_message, processed_at
+FROM drive_sync_files
+WHERE file_id = $1
+
+-- Record the processing result of a file
+-- Query name: upsert_drive_sync_file
+INSERT INTO drive_sync_files (file_id, folder_id, file_name, mime_type, file_size, modified_time, status, employee_id, error_message, processed_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, CURRENT_TIMESTAMP)
+ON CONFLICT (file_id)
+DO UPDATE SET
+    folder_id = EXCLUDED.folder_id,
+    file_name = EXCLUDED.file_name,
+    mime_type = EXCLUDED.mime_type,
+    file_size = EXCLUDED.file_size,
+    modified_time = EXCLUDED.modified_time,
+    status = EXCLUDED.status,
+    employee_id = EXCLUDED.employee_id,
+    error_message = EXCLUDED.error_message,
+    processed_at = CURRENT_TIMESTAMP
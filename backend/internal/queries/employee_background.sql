@@ -0,0 +1,65 @@
+-- Employee work history, education and project SQL queries
+
+-- Get the positions of an employee, in the order the resume lists them
+-- Query name: get_employee_positions
+SELECT id, employee_id, COALESCE(company, ''), COALESCE(title, ''),
+       COALESCE(TO_CHAR(start_date, 'YYYY-MM-DD'), ''), COALESCE(TO_CHAR(end_date, 'YYYY-MM-DD'), ''),
+       is_current, dates_by_year, COALESCE(description, ''), skills
+FROM employee_positions
+WHERE employee_id = $1
+ORDER BY sort_order, id
+
+-- Get the positions of every employee
+-- Query name: get_all_employee_positions
+SELECT id, employee_id, COALESCE(company, ''), COALESCE(title, ''),
+       COALESCE(TO_CHAR(start_date, 'YYYY-MM-DD'), ''), COALESCE(TO_CHAR(end_date, 'YYYY-MM-DD'), ''),
+       is_current, dates_by_year, COALESCE(description, ''), skills
+FROM employee_positions
+ORDER BY employee_id, sort_order, id
+
+-- Remove the positions of an employee
+-- Query name: remove_employee_positions
+DELETE FROM employee_positions WHERE employee_id = $1
+
+-- Add a position to an employee's work history
+-- Query name: add_employee_position
+INSERT INTO employee_positions (employee_id, sort_order, company, title, start_date, end_date, is_current, dates_by_year, description, skills)
+VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, '')::DATE, NULLIF($6, '')::DATE, $7, $8, NULLIF($9, ''), $10)
+
+-- Get the education of an employee, in the order the resume lists it
+-- Query name: get_employee_education
+SELECT id, employee_id, COALESCE(institution, ''), COALESCE(degree, ''), COALESCE(field, ''), start_year, end_year
+FROM employee_education
+WHERE employee_id = $1
+ORDER BY sort_order, id
+
+-- Get the education of every employee
+-- Query name: get_all_employee_education
+SELECT id, employee_id, COALESCE(institution, ''), COALESCE(degree, ''), COALESCE(field, ''), start_year, end_year
+FROM employee_education
+ORDER BY employee_id, sort_order, id
+
+-- Remove the education of an employee
+-- Query name: remove_employee_education
+DELETE FROM employee_education WHERE employee_id = $1
+
+-- Add a degree or course of study to an employee's education
+-- Query name: add_employee_education
+INSERT INTO employee_education (employee_id, sort_order, institution, degree, field, start_year, end_year)
+VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, ''), $6, $7)
+
+-- Get the projects of an employee, in the order the resume lists them
+-- Query name: get_employee_projects
+SELECT id, employee_id, name, COALESCE(role, ''), COALESCE(description, ''), technologies
+FROM employee_projects
+WHERE employee_id = $1
+ORDER BY sort_order, id
+
+-- Remove the projects of an employee
+-- Query name: remove_employee_projects
+DELETE FROM employee_projects WHERE employee_id = $1
+
+-- Add a project to an employee
+-- Query name: add_employee_project
+INSERT INTO employee_projects (employee_id, sort_order, name, role, description, technologies)
+VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6)
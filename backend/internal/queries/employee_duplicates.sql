@@ -0,0 +1,178 @@
+-- Duplicate employee detection and merge SQL queries
+
+-- Get every employee's name with its identifiers
+-- Query name: list_employee_identity_profiles
+SELECT e.id, e.name, i.kind, i.value
+FROM employees e
+LEFT JOIN employee_identities i ON i.employee_id = e.id
+ORDER BY e.id
+
+-- Add an identifier to an employee
+-- Query name: add_employee_identity
+INSERT INTO employee_identities (employee_id, kind, value)
+VALUES ($1, $2, $3)
+ON CONFLICT DO NOTHING
+
+-- Flag a pair of employees, refreshing the score of a pending pair; dismissed pairs stay dismissed
+-- Query name: upsert_employee_duplicate
+INSERT INTO employee_duplicates (employee_id, duplicate_id, score, reasons)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (employee_id, duplicate_id)
+DO UPDATE SET
+    score = EXCLUDED.score,
+    reasons = EXCLUDED.reasons,
+    updated_at = CURRENT_TIMESTAMP
+WHERE employee_duplicates.status = 'pending'
+
+-- Get flagged pairs by status, most likely first
+-- Query name: list_employee_duplicates
+SELECT d.id, d.employee_id, e.name, COALESCE(e.email, ''), d.duplicate_id, x.name, COALESCE(x.email, ''),
+       d.score, d.reasons, d.status, d.created_at, d.updated_at
+FROM employee_duplicates d
+JOIN employees e ON e.id = d.employee_id
+JOIN employees x ON x.id = d.duplicate_id
+WHERE d.status = $1
+ORDER BY d.score DESC, d.id
+
+-- Get a flagged pair by ID
+-- Query name: get_employee_duplicate
+SELECT d.id, d.employee_id, e.name, COALESCE(e.email, ''), d.duplicate_id, x.name, COALESCE(x.email, ''),
+       d.score, d.reasons, d.status, d.created_at, d.updated_at
+FROM employee_duplicates d
+JOIN employees e ON e.id = d.employee_id
+JOIN employees x ON x.id = d.duplicate_id
+WHERE d.id = $1
+
+-- Change the status of a flagged pair
+-- Query name: update_employee_duplicate_status
+UPDATE employee_duplicates
+SET status = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+
+-- Move skills to the kept employee, keeping the higher proficiency and years of both
+-- Query name: merge_employee_skills
+INSERT INTO employee_skills (employee_id, skill_id, proficiency_level, years_experience)
+SELECT $1, skill_id, proficiency_level, years_experience
+FROM employee_skills
+WHERE employee_id = $2
+ON CONFLICT (employee_id, skill_id)
+DO UPDATE SET
+    proficiency_level = GREATEST(employee_skills.proficiency_level, EXCLUDED.proficiency_level),
+    years_experience = GREATEST(employee_skills.years_experience, EXCLUDED.years_experience)
+
+-- Move identifiers to the kept employee
+-- Query name: merge_employee_identities
+INSERT INTO employee_identities (employee_id, kind, value)
+SELECT $1, kind, value
+FROM employee_identities
+WHERE employee_id = $2
+ON CONFLICT DO NOTHING
+
+-- Link the kept employee to the discovered skill terms of the merged one
+-- Query name: merge_employee_skill_terms
+INSERT INTO discovered_skill_term_employees (term_id, employee_id)
+SELECT term_id, $1
+FROM discovered_skill_term_employees
+WHERE employee_id = $2
+ON CONFLICT DO NOTHING
+
+-- Give the kept employee the spoken languages only the merged one has
+-- Query name: merge_employee_languages
+INSERT INTO employee_languages (employee_id, language_code, name, level)
+SELECT $1, language_code, name, level
+FROM employee_languages
+WHERE employee_id = $2
+ON CONFLICT DO NOTHING
+
+-- Move to the kept employee the certifications it does not already hold
+-- Query name: merge_employee_certifications
+UPDATE employee_certifications
+SET employee_id = $1, updated_at = CURRENT_TIMESTAMP
+WHERE employee_id = $2
+  AND LOWER(name) NOT IN (SELECT LOWER(name) FROM employee_certifications WHERE employee_id = $1)
+
+-- Move the work history to the kept employee when it has none
+-- Query name: merge_employee_positions
+UPDATE employee_positions SET employee_id = $1
+WHERE employee_id = $2 AND NOT EXISTS (SELECT 1 FROM employee_positions WHERE employee_id = $1)
+
+-- Move the education to the kept employee when it has none
+-- Query name: merge_employee_education
+UPDATE employee_education SET employee_id = $1
+WHERE employee_id = $2 AND NOT EXISTS (SELECT 1 FROM employee_education WHERE employee_id = $1)
+
+-- Move the projects to the kept employee when it has none
+-- Query name: merge_employee_projects
+UPDATE employee_projects SET employee_id = $1
+WHERE employee_id = $2 AND NOT EXISTS (SELECT 1 FROM employee_projects WHERE employee_id = $1)
+
+-- Move matches to the kept employee
+-- Query name: merge_employee_matches
+UPDATE matches SET employee_id = $1 WHERE employee_id = $2
+
+-- Move processed Google Drive files to the kept employee
+-- Query name: merge_employee_drive_files
+UPDATE drive_sync_files SET employee_id = $1 WHERE employee_id = $2
+
+-- Fill contact details the kept employee lacks from the merged one; the location moves as a whole
+-- Query name: merge_employee_contact
+UPDATE employees t
+SET phone = COALESCE(t.phone, s.phone),
+    city = CASE WHEN t.city IS NULL AND t.country IS NULL THEN s.city ELSE t.city END,
+    region = CASE WHEN t.city IS NULL AND t.country IS NULL THEN s.region ELSE t.region END,
+    country = COALESCE(t.country, s.country),
+    linkedin_url = COALESCE(t.linkedin_url, s.linkedin_url),
+    github_url = COALESCE(t.github_url, s.github_url),
+    gitlab_url = COALESCE(t.gitlab_url, s.gitlab_url),
+    stackoverflow_url = COALESCE(t.stackoverflow_url, s.stackoverflow_url),
+    website_url = COALESCE(t.website_url, s.website_url)
+FROM employees s
+WHERE t.id = $1 AND s.id = $2
+
+-- Delete the merged employee, returning what the kept employee may take over
+-- Query name: delete_merged_employee
+DELETE FROM employees
+WHERE id = $1
+RETURNING name, email, location, bio, current_project, resume_url,
+          original_text, extracted_data, extraction_timestamp, extraction_source, extraction_status
+
+-- Fill details the kept employee lacks
+-- Query name: merge_employee_details
+UPDATE employees
+SET email = COALESCE(email, $2),
+    location = COALESCE(NULLIF(location, ''), $3),
+    bio = COALESCE(NULLIF(bio, ''), $4),
+    current_project = COALESCE(current_project, $5),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+
+-- Take over the merged employee's extraction when it is newer
+-- Query name: merge_employee_extraction
+UPDATE employees
+SET resume_url = $2, original_text = $3, extracted_data = $4, extraction_timestamp = $5,
+    extraction_source = $6, extraction_status = $7, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND (extraction_timestamp IS NULL OR extraction_timestamp < $5)
+
+-- Record a merge
+-- Query name: create_employee_merge
+INSERT INTO employee_merges (employee_id, merged_employee_id, merged_name, merged_email, score, reasons, automatic)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, merged_at
+
+-- Get the employees merged into an employee, newest first
+-- Query name: list_employee_merges
+SELECT id, employee_id, merged_employee_id, merged_name, merged_email, score, reasons, automatic, merged_at
+FROM employee_merges
+WHERE employee_id = $1
+ORDER BY merged_at DESC, id DESC
+
+-- Move the extraction history to the kept employee, numbering both histories' runs by date
+-- Query name: merge_employee_extraction_history
+UPDATE employee_extractions x
+SET employee_id = $1, version = v.version
+FROM (
+    SELECT id, ROW_NUMBER() OVER (ORDER BY created_at, id) AS version
+    FROM employee_extractions
+    WHERE employee_id IN ($1, $2)
+) v
+WHERE x.id = v.id
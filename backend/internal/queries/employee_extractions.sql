@@ -0,0 +1,33 @@
+-- Employee extraction history SQL queries
+
+-- Record an extraction run as the employee's next version
+-- Query name: create_employee_extraction
+INSERT INTO employee_extractions (employee_id, version, source, resume_url, extractor_method, extractor_version,
+                                  original_text, extracted_data, status)
+SELECT $1, COALESCE(MAX(version), 0) + 1, NULLIF($2, ''), NULLIF($3, ''), NULLIF($4, ''), $5, $6, $7, $8
+FROM employee_extractions
+WHERE employee_id = $1
+RETURNING id, version, created_at
+
+-- Get an employee's extraction runs without their text and data, newest first
+-- Query name: list_employee_extractions
+SELECT id, employee_id, version, source, resume_url, extractor_method, extractor_version, status, created_at
+FROM employee_extractions
+WHERE employee_id = $1
+ORDER BY version DESC
+
+-- Get one version of an employee's extraction
+-- Query name: get_employee_extraction
+SELECT id, employee_id, version, source, resume_url, extractor_method, extractor_version, status, created_at,
+       original_text, extracted_data
+FROM employee_extractions
+WHERE employee_id = $1 AND version = $2
+
+-- Get an employee's latest extraction
+-- Query name: get_latest_employee_extraction
+SELECT id, employee_id, version, source, resume_url, extractor_method, extractor_version, status, created_at,
+       original_text, extracted_data
+FROM employee_extractions
+WHERE employee_id = $1
+ORDER BY version DESC
+LIMIT 1
@@ -2,14 +2,18 @@
 
 -- Get all employees with their basic information
 -- Query name: get_all_employees
-SELECT e.id, e.name, e.email, e.department, e.level, e.location, e.bio, e.current_project, e.resume_url, e.created_at, e.updated_at
+SELECT e.id, e.name, COALESCE(e.email, '') AS email, e.department, e.level, e.location, e.bio, e.current_project, e.resume_url, e.created_at, e.updated_at
 FROM employees e
 ORDER BY e.name;
 
 -- Get all employees with skills in a single query (no N+1)
 -- Query name: get_all_employees_with_skills
 SELECT 
-    e.id, e.name, e.email, e.department, e.level, e.location, e.bio, e.current_project, e.resume_url, e.created_at, e.updated_at,
+    e.id, e.name, COALESCE(e.email, '') AS email, e.department, e.level, e.location, e.bio, e.current_project, e.resume_url,
+    COALESCE(e.phone, '') AS phone, COALESCE(e.city, '') AS city, COALESCE(e.region, '') AS region, COALESCE(e.country, '') AS country,
+    COALESCE(e.linkedin_url, '') AS linkedin_url, COALESCE(e.github_url, '') AS github_url, COALESCE(e.gitlab_url, '') AS gitlab_url,
+    COALESCE(e.stackoverflow_url, '') AS stackoverflow_url, COALESCE(e.website_url, '') AS website_url,
+    e.created_at, e.updated_at,
     s.id as skill_id, s.name as skill_name, es.proficiency_level, es.years_experience
 FROM employees e
 LEFT JOIN employee_skills es ON e.id = es.employee_id
@@ -18,14 +22,21 @@ ORDER BY e.id, s.name;
 
 -- Get employee by ID
 -- Query name: get_employee_by_id
-SELECT e.id, e.name, e.email, e.department, e.level, e.location, e.bio, e.current_project, e.resume_url, e.created_at, e.updated_at
+SELECT e.id, e.name, COALESCE(e.email, '') AS email, e.department, e.level, e.location, e.bio, e.current_project, e.resume_url,
+       COALESCE(e.phone, '') AS phone, COALESCE(e.city, '') AS city, COALESCE(e.region, '') AS region, COALESCE(e.country, '') AS country,
+       COALESCE(e.linkedin_url, '') AS linkedin_url, COALESCE(e.github_url, '') AS github_url, COALESCE(e.gitlab_url, '') AS gitlab_url,
+       COALESCE(e.stackoverflow_url, '') AS stackoverflow_url, COALESCE(e.website_url, '') AS website_url,
+       e.created_at, e.updated_at
 FROM employees e
 WHERE e.id = $1;
 
 -- Get employee by email with extraction data
 -- Query name: get_employee_by_email
-SELECT e.id, e.name, e.email, e.department, e.level, e.location, e.bio, e.current_project, e.resume_url,
+SELECT e.id, e.name, COALESCE(e.email, '') AS email, e.department, e.level, e.location, e.bio, e.current_project, e.resume_url,
        e.original_text, e.extracted_data, e.extraction_timestamp, e.extraction_source, e.extraction_status,
+       COALESCE(e.phone, '') AS phone, COALESCE(e.city, '') AS city, COALESCE(e.region, '') AS region, COALESCE(e.country, '') AS country,
+       COALESCE(e.linkedin_url, '') AS linkedin_url, COALESCE(e.github_url, '') AS github_url, COALESCE(e.gitlab_url, '') AS gitlab_url,
+       COALESCE(e.stackoverflow_url, '') AS stackoverflow_url, COALESCE(e.website_url, '') AS website_url,
        e.created_at, e.updated_at
 FROM employees e
 WHERE e.email = $1;
@@ -33,30 +44,38 @@ WHERE e.email = $1;
 -- Create new employee
 -- Query name: create_employee
 INSERT INTO employees (name, email, department, level, location, bio, current_project, resume_url)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+VALUES ($1, NULLIF($2, ''), $3, $4, $5, $6, $7, $8)
 RETURNING id, created_at, updated_at;
 
 -- Create new employee with extraction data
 -- Query name: create_employee_with_extraction
 INSERT INTO employees (name, email, department, level, location, bio, current_project, resume_url,
                       original_text, extracted_data, extraction_timestamp, extraction_source, extraction_status)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+VALUES ($1, NULLIF($2, ''), $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 RETURNING id, created_at, updated_at;
 
 -- Update employee
 -- Query name: update_employee
 UPDATE employees 
-SET name = $1, email = $2, department = $3, level = $4, location = $5, bio = $6, current_project = $7, updated_at = CURRENT_TIMESTAMP
+SET name = $1, email = NULLIF($2, ''), department = $3, level = $4, location = $5, bio = $6, current_project = $7, updated_at = CURRENT_TIMESTAMP
 WHERE id = $8;
 
 -- Update employee with extraction data
 -- Query name: update_employee_extraction
 UPDATE employees 
-SET name = $1, email = $2, department = $3, level = $4, location = $5, bio = $6, current_project = $7, resume_url = $8,
+SET name = $1, email = NULLIF($2, ''), department = $3, level = $4, location = $5, bio = $6, current_project = $7, resume_url = $8,
     original_text = $9, extracted_data = $10, extraction_timestamp = $11, extraction_source = $12, extraction_status = $13,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $14;
 
+-- Set employee contact details
+-- Query name: update_employee_contact
+UPDATE employees
+SET phone = NULLIF($2, ''), city = NULLIF($3, ''), region = NULLIF($4, ''), country = NULLIF($5, ''),
+    linkedin_url = NULLIF($6, ''), github_url = NULLIF($7, ''), gitlab_url = NULLIF($8, ''),
+    stackoverflow_url = NULLIF($9, ''), website_url = NULLIF($10, '')
+WHERE id = $1;
+
 -- Delete employee
 -- Query name: delete_employee
 DELETE FROM employees WHERE id = $1;
@@ -78,9 +97,32 @@ VALUES ($1, $2, $3, $4);
 -- Query name: remove_employee_skills
 DELETE FROM employee_skills WHERE employee_id = $1;
 
+-- Get employee spoken languages
+-- Query name: get_employee_languages
+SELECT language_code, name, COALESCE(level, '') AS level
+FROM employee_languages
+WHERE employee_id = $1
+ORDER BY name;
+
+-- Get the spoken languages of all employees
+-- Query name: get_all_employee_languages
+SELECT employee_id, language_code, name, COALESCE(level, '') AS level
+FROM employee_languages
+ORDER BY employee_id, name;
+
+-- Add employee spoken language
+-- Query name: add_employee_language
+INSERT INTO employee_languages (employee_id, language_code, name, level)
+VALUES ($1, $2, $3, NULLIF($4, ''))
+ON CONFLICT (employee_id, language_code) DO UPDATE SET name = EXCLUDED.name, level = EXCLUDED.level;
+
+-- Remove all employee spoken languages
+-- Query name: remove_employee_languages
+DELETE FROM employee_languages WHERE employee_id = $1;
+
 -- Get employees with specific skills (optimized for matching)
 -- Query name: get_employees_with_skills
-SELECT DISTINCT e.id, e.name, e.email, e.department, e.level, e.location, e.bio, e.current_project, e.resume_url, e.created_at, e.updated_at
+SELECT DISTINCT e.id, e.name, COALESCE(e.email, '') AS email, e.department, e.level, e.location, e.bio, e.current_project, e.resume_url, e.created_at, e.updated_at
 FROM employees e
 JOIN employee_skills es ON e.id = es.employee_id
 JOIN skills s ON es.skill_id = s.id
@@ -90,7 +132,7 @@ ORDER BY e.name;
 -- Get employees with skills in a single query (no N+1)
 -- Query name: get_employees_with_skills_optimized
 SELECT 
-    e.id, e.name, e.email, e.department, e.level, e.location, e.bio, e.current_project, e.resume_url, e.created_at, e.updated_at,
+    e.id, e.name, COALESCE(e.email, '') AS email, e.department, e.level, e.location, e.bio, e.current_project, e.resume_url, e.created_at, e.updated_at,
     s.id as skill_id, s.name as skill_name, es.proficiency_level, es.years_experience
 FROM employees e
 JOIN employee_skills es ON e.id = es.employee_id
@@ -106,7 +148,7 @@ ORDER BY e.id, s.name;
 
 -- Get employees with skills and their matching skills (for scoring)
 -- Query name: get_employees_with_matching_skills
-SELECT e.id, e.name, e.email, e.department, e.level, e.location, e.bio, e.current_project, e.created_at, e.updated_at,
+SELECT e.id, e.name, COALESCE(e.email, '') AS email, e.department, e.level, e.location, e.bio, e.current_project, e.created_at, e.updated_at,
        s.name as skill_name, es.proficiency_level, es.years_experience
 FROM employees e
 JOIN employee_skills es ON e.id = es.employee_id
@@ -0,0 +1,49 @@
+-- Extraction review queue SQL queries
+
+-- Hold an extraction for review
+-- Query name: create_extraction_review
+INSERT INTO extraction_reviews (reasons, confidence_score, candidate_name, extraction_source, resume_url,
+                                original_text, extracted_data)
+VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, ''), $6, $7)
+RETURNING id, status, created_at, updated_at
+
+-- Get held extractions by status without their text, oldest first
+-- Query name: list_extraction_reviews
+SELECT id, status, reasons, confidence_score, candidate_name, extraction_source, resume_url, extracted_data,
+       employee_id, reviewed_by, review_notes, reviewed_at, created_at, updated_at, NULL AS original_text
+FROM extraction_reviews
+WHERE status = $1
+ORDER BY created_at, id
+
+-- Get a held extraction by ID
+-- Query name: get_extraction_review
+SELECT id, status, reasons, confidence_score, candidate_name, extraction_source, resume_url, extracted_data,
+       employee_id, reviewed_by, review_notes, reviewed_at, created_at, updated_at, original_text
+FROM extraction_reviews
+WHERE id = $1
+
+-- Replace the data of a pending extraction with a reviewer's edits
+-- Query name: update_extraction_review_data
+UPDATE extraction_reviews
+SET extracted_data = $2, candidate_name = NULLIF($3, ''), updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND status = 'pending'
+
+-- Approve or reject a pending extraction; only one reviewer can resolve it
+-- Query name: resolve_extraction_review
+UPDATE extraction_reviews
+SET status = $2, reviewed_by = NULLIF($3, ''), review_notes = NULLIF($4, ''),
+    reviewed_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND status = 'pending'
+
+-- Return an approved extraction whose employee could not be stored to the queue
+-- Query name: reopen_extraction_review
+UPDATE extraction_reviews
+SET status = 'pending', reviewed_by = NULL, review_notes = NULL, reviewed_at = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+
+-- Link an approved extraction to the employee stored from it
+-- Query name: set_extraction_review_employee
+UPDATE extraction_reviews
+SET employee_id = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
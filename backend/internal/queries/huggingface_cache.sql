@@ -0,0 +1,26 @@
+-- Hugging Face extraction cache SQL queries
+
+-- Get an unexpired cached extraction
+-- Query name: get_huggingface_cache_entry
+SELECT cache_key, model_name, response, created_at, expires_at
+FROM huggingface_extraction_cache
+WHERE cache_key = $1 AND expires_at > CURRENT_TIMESTAMP
+
+-- Store an extraction, replacing an older one with the same key
+-- Query name: upsert_huggingface_cache_entry
+INSERT INTO huggingface_extraction_cache (cache_key, model_name, response, created_at, expires_at)
+VALUES ($1, $2, $3, CURRENT_TIMESTAMP, $4)
+ON CONFLICT (cache_key)
+DO UPDATE SET
+    model_name = EXCLUDED.model_name,
+    response = EXCLUDED.response,
+    created_at = CURRENT_TIMESTAMP,
+    expires_at = EXCLUDED.expires_at
+
+-- Remove expired cached extractions
+-- Query name: delete_expired_huggingface_cache_entries
+DELETE FROM huggingface_extraction_cache WHERE expires_at < CURRENT_TIMESTAMP
+
+-- Remove every cached extraction
+-- Query name: delete_all_huggingface_cache_entries
+DELETE FROM huggingface_extraction_cache
@@ -0,0 +1,46 @@
+-- Idempotency key SQL queries
+
+-- Reserve a key; an expired record with the same key and scope is replaced, and so is one
+-- still processing since before $5, whose request has crashed or hung. $6 identifies this
+-- reservation, so only its request can complete or release the key.
+-- Query name: reserve_idempotency_key
+INSERT INTO idempotency_keys (idempotency_key, scope, request_fingerprint, status, created_at, expires_at, reservation_token)
+VALUES ($1, $2, $3, 'processing', CURRENT_TIMESTAMP, $4, $6)
+ON CONFLICT (idempotency_key, scope)
+DO UPDATE SET
+    request_fingerprint = EXCLUDED.request_fingerprint,
+    reservation_token = EXCLUDED.reservation_token,
+    status = 'processing',
+    response_status = NULL,
+    response_content_type = NULL,
+    response_body = NULL,
+    created_at = CURRENT_TIMESTAMP,
+    completed_at = NULL,
+    expires_at = EXCLUDED.expires_at
+WHERE idempotency_keys.expires_at < CURRENT_TIMESTAMP
+   OR (idempotency_keys.status = 'processing' AND idempotency_keys.created_at < $5)
+RETURNING id
+
+-- Get an idempotency record by key and scope
+-- Query name: get_idempotency_key
+SELECT id, idempotency_key, scope, request_fingerprint, status,
+       response_status, response_content_type, response_body,
+       created_at, completed_at, expires_at
+FROM idempotency_keys
+WHERE idempotency_key = $1 AND scope = $2
+
+-- Store the response of a reserved key, unless another reservation has taken it over
+-- Query name: complete_idempotency_key
+UPDATE idempotency_keys
+SET status = 'completed', response_status = $4, response_content_type = $5,
+    response_body = $6, completed_at = CURRENT_TIMESTAMP
+WHERE idempotency_key = $1 AND scope = $2 AND reservation_token = $3 AND status = 'processing'
+
+-- Release a reserved key so the request can be retried, unless another reservation has taken it over
+-- Query name: delete_idempotency_key
+DELETE FROM idempotency_keys
+WHERE idempotency_key = $1 AND scope = $2 AND reservation_token = $3 AND status = 'processing'
+
+-- Remove expired idempotency records
+-- Query name: delete_expired_idempotency_keys
+DELETE FROM idempotency_keys WHERE expires_at < CURRENT_TIMESTAMP
@@ -3,7 +3,7 @@
 -- Get matches by employee ID
 -- Query name: get_matches_by_employee_id
 SELECT m.id, m.employee_id, m.match_score, m.matching_skills, m.notes, m.created_at,
-       e.id as employee_id, e.name as employee_name, e.email as employee_email, e.department as employee_department, 
+       e.id as employee_id, e.name as employee_name, COALESCE(e.email, '') as employee_email, e.department as employee_department, 
        e.level as employee_level, e.location as employee_location, e.bio as employee_bio, e.resume_url,
        e.created_at as employee_created_at, e.updated_at as employee_updated_at
 FROM matches m
@@ -32,7 +32,7 @@ ORDER BY s.name;
 -- Get all matches with employee information
 -- Query name: get_all_matches
 SELECT m.id, m.employee_id, m.match_score, m.matching_skills, m.notes, m.created_at,
-       e.id, e.name, e.email, e.department, e.level, e.location, e.bio, e.current_project, e.resume_url, e.created_at, e.updated_at
+       e.id, e.name, COALESCE(e.email, '') AS email, e.department, e.level, e.location, e.bio, e.current_project, e.resume_url, e.created_at, e.updated_at
 FROM matches m
 LEFT JOIN employees e ON m.employee_id = e.id
 ORDER BY m.created_at DESC;
@@ -0,0 +1,41 @@
+-- Re-extraction run SQL queries
+
+-- Get the employees whose latest extraction kept the resume text and matches a re-extraction
+-- filter, that have no result in a run yet. Runs stored before versions were recorded count as
+-- extractor version 0.
+-- Query name: list_reextraction_candidates
+SELECT e.id
+FROM employees e
+JOIN LATERAL (
+    SELECT x.source, x.extractor_version, x.original_text, x.created_at
+    FROM employee_extractions x
+    WHERE x.employee_id = e.id
+    ORDER BY x.version DESC
+    LIMIT 1
+) latest ON TRUE
+WHERE COALESCE(latest.original_text, '') <> ''
+  AND ($1 = '' OR latest.source = $1)
+  AND ($2 = 0 OR COALESCE(latest.extractor_version, 0) < $2)
+  AND ($3::timestamp IS NULL OR latest.created_at >= $3)
+  AND ($4::timestamp IS NULL OR latest.created_at < $4)
+  AND NOT EXISTS (
+      SELECT 1 FROM reextraction_results r
+      WHERE r.cv_extract_id = $5 AND r.employee_id = e.id
+  )
+ORDER BY e.id
+
+-- Store the outcome of re-extracting an employee in a run
+-- Query name: save_reextraction_result
+INSERT INTO reextraction_results (cv_extract_id, employee_id, status, changes, error_message)
+VALUES ($1, $2, $3, $4, NULLIF($5, ''))
+ON CONFLICT (cv_extract_id, employee_id) DO UPDATE
+SET status = EXCLUDED.status, changes = EXCLUDED.changes, error_message = EXCLUDED.error_message,
+    created_at = CURRENT_TIMESTAMP
+
+-- Get the outcomes of a run, with employee names
+-- Query name: list_reextraction_results
+SELECT r.employee_id, e.name, r.status, r.changes, r.error_message, r.created_at
+FROM reextraction_results r
+JOIN employees e ON e.id = r.employee_id
+WHERE r.cv_extract_id = $1
+ORDER BY r.employee_id
@@ -0,0 +1,55 @@
+-- Skill discovery SQL queries
+
+-- Count a term found by an extraction, adding its source and context
+-- Query name: record_discovered_skill_term
+INSERT INTO discovered_skill_terms (term, normalized_term, occurrences, sources, examples)
+VALUES ($1, $2, 1, ARRAY[$3::TEXT],
+        CASE WHEN $4::TEXT = '' THEN '[]'::JSONB ELSE JSONB_BUILD_ARRAY($4::TEXT) END)
+ON CONFLICT (normalized_term) DO UPDATE
+SET occurrences = discovered_skill_terms.occurrences + 1,
+    sources = CASE WHEN $3::TEXT = ANY(discovered_skill_terms.sources) THEN discovered_skill_terms.sources
+                   ELSE ARRAY_APPEND(discovered_skill_terms.sources, $3::TEXT) END,
+    examples = CASE WHEN $4::TEXT = ''
+                      OR JSONB_ARRAY_LENGTH(discovered_skill_terms.examples) >= 5
+                      OR discovered_skill_terms.examples @> JSONB_BUILD_ARRAY($4::TEXT)
+                    THEN discovered_skill_terms.examples
+                    ELSE discovered_skill_terms.examples || JSONB_BUILD_ARRAY($4::TEXT) END,
+    last_seen_at = CURRENT_TIMESTAMP
+RETURNING id
+
+-- Link a discovered term to an employee whose resume mentions it
+-- Query name: link_discovered_skill_term_employee
+INSERT INTO discovered_skill_term_employees (term_id, employee_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING
+
+-- Get discovered terms by status, most frequent first
+-- Query name: list_discovered_skill_terms
+SELECT t.id, t.term, t.status, t.occurrences,
+       (SELECT COUNT(*) FROM discovered_skill_term_employees e WHERE e.term_id = t.id) AS employee_count,
+       t.sources, t.examples, t.skill_id, t.resolved_by, t.resolved_at, t.first_seen_at, t.last_seen_at
+FROM discovered_skill_terms t
+WHERE t.status = $1
+ORDER BY t.occurrences DESC, t.last_seen_at DESC, t.id
+LIMIT $2
+
+-- Get a discovered term by ID
+-- Query name: get_discovered_skill_term
+SELECT t.id, t.term, t.status, t.occurrences,
+       (SELECT COUNT(*) FROM discovered_skill_term_employees e WHERE e.term_id = t.id) AS employee_count,
+       t.sources, t.examples, t.skill_id, t.resolved_by, t.resolved_at, t.first_seen_at, t.last_seen_at
+FROM discovered_skill_terms t
+WHERE t.id = $1
+
+-- Resolve a pending or ignored term; approved and aliased terms are final
+-- Query name: resolve_discovered_skill_term
+UPDATE discovered_skill_terms
+SET status = $2, skill_id = $3, resolved_by = NULLIF($4, ''), resolved_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND status IN ('pending', 'ignored')
+
+-- Get the employees whose resumes mention a discovered term
+-- Query name: list_discovered_skill_term_employees
+SELECT employee_id
+FROM discovered_skill_term_employees
+WHERE term_id = $1
+ORDER BY employee_id
@@ -31,6 +31,14 @@ LEFT JOIN skills_categories sc ON s.id = sc.skill_id
 LEFT JOIN categories c ON sc.category_id = c.id
 ORDER BY s.name, c.name;
 
+-- Get every skill alias
+-- Query name: get_skill_aliases
+SELECT skill_id, alias FROM skill_aliases ORDER BY skill_id, alias;
+
+-- Add an alias to a skill
+-- Query name: add_skill_alias
+INSERT INTO skill_aliases (skill_id, alias) VALUES ($1, $2);
+
 -- Search skills by name (case-insensitive)
 -- Query name: search_skills
 SELECT id, name FROM skills WHERE LOWER(name) LIKE LOWER($1) ORDER BY name;
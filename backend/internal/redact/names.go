@@ -0,0 +1,137 @@
+package redact
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"stafind-backend/internal/skillmatch"
+)
+
+// headerLines is how far into a resume the candidate's name is looked for
+const headerLines = 5
+
+// nameLabelRegex finds a name written after a label, e.g. "Nombre: Ana García"
+var nameLabelRegex = regexp.MustCompile(`(?im)^[ \t]*(?:full\s+name|name|nombre(?:\s+completo)?|nombre\s+y\s+apellidos?)[ \t]*:[ \t]*(.+)$`)
+
+// nameParticles may be written in lowercase inside a name
+var nameParticles = map[string]bool{
+	"de": true, "del": true, "la": true, "las": true, "los": true, "y": true,
+	"da": true, "das": true, "do": true, "dos": true, "van": true, "von": true, "der": true,
+}
+
+// notNames are words of titles, headings and job titles, lines that otherwise look like a name
+var notNames = map[string]bool{
+	"curriculum": true, "vitae": true, "resume": true, "résumé": true, "cv": true,
+	"hoja": true, "vida": true, "profile": true, "perfil": true, "summary": true,
+	"contact": true, "contacto": true, "information": true, "información": true,
+	"experience": true, "experiencia": true, "education": true, "educación": true,
+	"skills": true, "senior": true, "junior": true, "engineer": true, "developer": true,
+	"ingeniero": true, "desarrollador": true, "manager": true, "analyst": true,
+	"architect": true, "consultant": true, "designer": true, "lead": true,
+}
+
+// skillWords are technology names that are also written capitalised like a name. A line of
+// nothing but these is a skill list, and a name part that is one is only removed as part of
+// the whole name, so "Ruby Chen" does not remove every "Ruby".
+var skillWords = map[string]bool{
+	"go": true, "golang": true, "rust": true, "java": true, "python": true, "ruby": true,
+	"rails": true, "scala": true, "swift": true, "kotlin": true, "dart": true, "flutter": true,
+	"elixir": true, "erlang": true, "julia": true, "perl": true, "php": true, "laravel": true,
+	"react": true, "angular": true, "vue": true, "svelte": true, "node": true, "django": true,
+	"flask": true, "spring": true, "docker": true, "kubernetes": true, "terraform": true,
+	"ansible": true, "jenkins": true, "kafka": true, "spark": true, "redis": true, "linux": true,
+	"aws": true, "azure": true, "gcp": true, "oracle": true, "mongodb": true, "postgresql": true,
+	"mysql": true, "graphql": true, "typescript": true, "javascript": true, "sql": true,
+}
+
+// findNames finds the candidate's name at the top of the resume or after a label, then every
+// occurrence of it and of its parts, so "Ana García" also removes a later "García"
+func findNames(text string) []span {
+	var names []string
+	for _, match := range nameLabelRegex.FindAllStringSubmatch(text, -1) {
+		if name := strings.TrimSpace(match[1]); looksLikeName(name) {
+			names = append(names, name)
+		}
+	}
+
+	lines := 0
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if looksLikeName(line) {
+			names = append(names, line)
+			break
+		}
+		if lines++; lines == headerLines {
+			break
+		}
+	}
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	// Case-sensitive, so a surname that is also a word ("Rose", "Del Valle") is only removed
+	// where it is written as a name
+	var patterns []skillmatch.Pattern
+	addTerm := func(term string) {
+		patterns = append(patterns,
+			skillmatch.Pattern{Term: term, Value: term, CaseSensitive: true},
+			skillmatch.Pattern{Term: strings.ToUpper(term), Value: term, CaseSensitive: true},
+		)
+	}
+	for _, name := range names {
+		addTerm(name)
+		for _, part := range strings.Fields(name) {
+			if len([]rune(part)) > 1 && !nameParticles[part] && !skillWords[strings.ToLower(part)] {
+				addTerm(part)
+			}
+		}
+	}
+
+	var spans []span
+	for _, match := range skillmatch.New(patterns).FindAll(text) {
+		spans = append(spans, span{kind: KindName, start: match.Start, end: match.End})
+	}
+	return spans
+}
+
+// looksLikeName reports whether a line is two to five capitalised words, allowing lowercase
+// particles like "de" and hyphenated or apostrophised parts. At least one word must not be a
+// skill word, so "Go Rust" is not a name.
+func looksLikeName(line string) bool {
+	words := strings.Fields(line)
+	if len(words) < 2 || len(words) > 5 {
+		return false
+	}
+
+	capitalised, skills := 0, 0
+	for _, word := range words {
+		if nameParticles[word] {
+			continue
+		}
+		if notNames[strings.ToLower(word)] {
+			return false
+		}
+
+		first := true
+		for _, r := range word {
+			switch {
+			case first && !unicode.IsUpper(r):
+				return false
+			case unicode.IsLetter(r), r == '-', r == '\'', r == '.':
+			default:
+				return false
+			}
+			first = false
+		}
+		capitalised++
+		if skillWords[strings.ToLower(word)] {
+			skills++
+		}
+	}
+	return capitalised >= 2 && skills < capitalised
+}
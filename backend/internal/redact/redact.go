@@ -0,0 +1,311 @@
+// Package redact removes personal data from resume text before it is sent to an external
+// model. Emails, phone numbers, URLs, national identity numbers and the candidate's name are
+// replaced with placeholders such as "[EMAIL]", and offsets the model reports in the redacted
+// text are mapped back to the original.
+package redact
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"stafind-backend/internal/models"
+)
+
+// Kinds of personal data
+const (
+	KindEmail      = "email"
+	KindURL        = "url"
+	KindNationalID = "national_id"
+	KindPhone      = "phone"
+	KindName       = "name"
+)
+
+// placeholders replace each kind of personal data
+var placeholders = map[string]string{
+	KindEmail:      "[EMAIL]",
+	KindURL:        "[URL]",
+	KindNationalID: "[ID]",
+	KindPhone:      "[PHONE]",
+	KindName:       "[NAME]",
+}
+
+// Replacement is one redacted span. Offsets count characters, like the offsets of Hugging
+// Face models.
+type Replacement struct {
+	Kind          string
+	Start         int // Offset in the original text
+	End           int
+	RedactedStart int // Offset of the placeholder in the redacted text
+	RedactedEnd   int
+}
+
+// Result is redacted text and the spans that were replaced, in order
+type Result struct {
+	Text         string
+	Replacements []Replacement
+}
+
+// span is a match in byte offsets
+type span struct {
+	kind       string
+	start, end int
+}
+
+// Redact replaces the personal data selected by config. With redaction disabled the text is
+// returned unchanged.
+func Redact(text string, config models.PIIRedactionConfig) *Result {
+	if !config.Enabled {
+		return &Result{Text: text}
+	}
+
+	// Earlier kinds win overlaps: an email is not also a name, an ID not also a phone
+	var detected []span
+	if config.Emails {
+		detected = accept(detected, findEmails(text))
+	}
+	if config.URLs {
+		detected = accept(detected, findURLs(text))
+	}
+	if config.NationalIDs {
+		detected = accept(detected, findNationalIDs(text))
+	}
+	if config.Phones {
+		detected = accept(detected, findPhones(text))
+	}
+	if config.PersonNames {
+		detected = accept(detected, findNames(text))
+	}
+	sort.Slice(detected, func(i, j int) bool { return detected[i].start < detected[j].start })
+
+	return replace(text, detected)
+}
+
+// accept adds the spans that do not overlap one already accepted
+func accept(accepted, spans []span) []span {
+	existing := len(accepted)
+	for _, s := range spans {
+		overlaps := false
+		for _, a := range accepted[:existing] {
+			if s.start < a.end && s.end > a.start {
+				overlaps = true
+				break
+			}
+		}
+		if !overlaps {
+			accepted = append(accepted, s)
+		}
+	}
+	return accepted
+}
+
+// replace builds the redacted text from sorted, non-overlapping spans
+func replace(text string, spans []span) *Result {
+	result := &Result{Replacements: make([]Replacement, 0, len(spans))}
+
+	var (
+		redacted      strings.Builder
+		previous      int // Byte offset in text after the last span
+		originalRunes int // Characters of text before previous
+		redactedRunes int // Characters written to redacted
+	)
+	for _, s := range spans {
+		between := utf8.RuneCountInString(text[previous:s.start])
+		redacted.WriteString(text[previous:s.start])
+		originalRunes += between
+		redactedRunes += between
+
+		placeholder := placeholders[s.kind]
+		length := utf8.RuneCountInString(text[s.start:s.end])
+		result.Replacements = append(result.Replacements, Replacement{
+			Kind:          s.kind,
+			Start:         originalRunes,
+			End:           originalRunes + length,
+			RedactedStart: redactedRunes,
+			RedactedEnd:   redactedRunes + utf8.RuneCountInString(placeholder),
+		})
+		redacted.WriteString(placeholder)
+		originalRunes += length
+		redactedRunes += utf8.RuneCountInString(placeholder)
+		previous = s.end
+	}
+	redacted.WriteString(text[previous:])
+
+	result.Text = redacted.String()
+	return result
+}
+
+// OriginalSpan maps a span of the redacted text to the original text. It reports false when
+// the span touches a placeholder, since whatever a model found there is personal data.
+func (r *Result) OriginalSpan(start, end int) (int, int, bool) {
+	shift := 0 // Original minus redacted offset after the replacements passed so far
+	for _, replacement := range r.Replacements {
+		if start < replacement.RedactedEnd && end > replacement.RedactedStart {
+			return 0, 0, false
+		}
+		if start < replacement.RedactedStart {
+			break
+		}
+		shift = replacement.End - replacement.RedactedEnd
+	}
+	return start + shift, end + shift, true
+}
+
+// Counts returns the number of redacted spans of each kind
+func (r *Result) Counts() map[string]int {
+	counts := make(map[string]int)
+	for _, replacement := range r.Replacements {
+		counts[replacement.Kind]++
+	}
+	return counts
+}
+
+// DefaultConfig redacts every kind of personal data
+func DefaultConfig() models.PIIRedactionConfig {
+	return models.PIIRedactionConfig{
+		Enabled:     true,
+		Emails:      true,
+		Phones:      true,
+		URLs:        true,
+		NationalIDs: true,
+		PersonNames: true,
+	}
+}
+
+// ParseConfig reads a redaction setting such as "off", "all" or "email,phone,name". An empty
+// value redacts everything; unknown kinds are ignored.
+func ParseConfig(value string) models.PIIRedactionConfig {
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch value {
+	case "", "all", "on", "true":
+		return DefaultConfig()
+	case "off", "none", "false":
+		return models.PIIRedactionConfig{}
+	}
+
+	config := models.PIIRedactionConfig{Enabled: true}
+	for _, kind := range strings.Split(value, ",") {
+		switch strings.TrimSpace(kind) {
+		case KindEmail:
+			config.Emails = true
+		case KindPhone:
+			config.Phones = true
+		case KindURL:
+			config.URLs = true
+		case KindNationalID:
+			config.NationalIDs = true
+		case KindName:
+			config.PersonNames = true
+		}
+	}
+	return config
+}
+
+var emailRegex = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+
+func findEmails(text string) []span {
+	return regexSpans(text, KindEmail, emailRegex)
+}
+
+var (
+	urlRegex     = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>"]+`)
+	profileRegex = regexp.MustCompile(`(?i)\b(?:linkedin\.com|github\.com|gitlab\.com|bitbucket\.org|twitter\.com|x\.com|behance\.net|dribbble\.com)/[^\s<>"]*`)
+)
+
+func findURLs(text string) []span {
+	spans := accept(regexSpans(text, KindURL, urlRegex), regexSpans(text, KindURL, profileRegex))
+	for i := range spans {
+		// Sentence punctuation after a link is not part of it
+		spans[i].end = spans[i].start + len(strings.TrimRight(text[spans[i].start:spans[i].end], ".,;:)]"))
+	}
+	return spans
+}
+
+var (
+	// labelledIDRegex finds a number after the name of an identity document; only the number
+	// is redacted
+	labelledIDRegex = regexp.MustCompile(`(?i)\b(?:DNI|NIE|NIF|SSN|CUIT|CUIL|RUT|RUN|CPF|CURP|RFC|passport(?:\s+(?:no\.?|number))?|pasaporte|c[ée]dula(?:\s+de\s+identidad)?|documento(?:\s+de\s+identidad)?)\b[\s:#.ºª°nN-]{0,4}([A-Z0-9][A-Za-z0-9.\-]{4,}[A-Za-z0-9])`)
+
+	// idRegexes are number formats that are identity numbers without a label: US SSN, Spanish
+	// DNI and NIE, Brazilian CPF and Argentine CUIT/CUIL
+	idRegexes = []*regexp.Regexp{
+		regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`),
+		regexp.MustCompile(`\b\d{8}-?[A-Z]\b`),
+		regexp.MustCompile(`\b[XYZ]-?\d{7}-?[A-Z]\b`),
+		regexp.MustCompile(`\b\d{3}\.\d{3}\.\d{3}-\d{2}\b`),
+		regexp.MustCompile(`\b(?:20|23|24|27|30|33|34)-\d{8}-\d\b`),
+	}
+)
+
+func findNationalIDs(text string) []span {
+	var spans []span
+	for _, match := range labelledIDRegex.FindAllStringSubmatchIndex(text, -1) {
+		spans = append(spans, span{kind: KindNationalID, start: match[2], end: match[3]})
+	}
+	for _, regex := range idRegexes {
+		spans = accept(spans, regexSpans(text, KindNationalID, regex))
+	}
+	return spans
+}
+
+var (
+	phoneRegex = regexp.MustCompile(`\+?\(?\d[\d \t().\-]{6,18}\d`)
+
+	// Number runs that look like phones but are dates, year ranges, versions such as
+	// "10.0.19041.1" or amounts grouped in thousands such as "12 345 678"
+	dateRegex           = regexp.MustCompile(`^\d{1,2}[./-]\d{1,2}[./-]\d{2,4}$`)
+	yearRangeRegex      = regexp.MustCompile(`^(?:19|20)\d{2}\s*-\s*(?:19|20)\d{2}$`)
+	dottedRegex         = regexp.MustCompile(`^\d+(?:\.\d+)+$`)
+	thousandsGroupRegex = regexp.MustCompile(`^\d{1,2}(?: \d{3})+$`)
+)
+
+// Digits in a phone number, with country code
+const (
+	minPhoneDigits = 8
+	maxPhoneDigits = 15
+)
+
+func findPhones(text string) []span {
+	var spans []span
+	for _, s := range regexSpans(text, KindPhone, phoneRegex) {
+		candidate := strings.TrimSpace(text[s.start:s.end])
+		if dateRegex.MatchString(candidate) || yearRangeRegex.MatchString(candidate) ||
+			isVersion(candidate) || thousandsGroupRegex.MatchString(candidate) {
+			continue
+		}
+
+		digits := 0
+		for _, r := range candidate {
+			if r >= '0' && r <= '9' {
+				digits++
+			}
+		}
+		if digits >= minPhoneDigits && digits <= maxPhoneDigits {
+			spans = append(spans, s)
+		}
+	}
+	return spans
+}
+
+// isVersion reports whether a dotted number is a version rather than a phone. Phones written
+// with dots group their digits in twos to fours, as in "555.123.4567" or "01.23.45.67.89".
+func isVersion(candidate string) bool {
+	if !dottedRegex.MatchString(candidate) {
+		return false
+	}
+	for _, group := range strings.Split(candidate, ".") {
+		if len(group) < 2 || len(group) > 4 {
+			return true
+		}
+	}
+	return false
+}
+
+func regexSpans(text, kind string, regex *regexp.Regexp) []span {
+	var spans []span
+	for _, match := range regex.FindAllStringIndex(text, -1) {
+		spans = append(spans, span{kind: kind, start: match[0], end: match[1]})
+	}
+	return spans
+}
@@ -0,0 +1,78 @@
+package redact
+
+import (
+	"strings"
+	"testing"
+
+	"stafind-backend/internal/models"
+)
+
+func TestFindPhones(t *testing.T) {
+	tests := []struct {
+		text  string
+		phone string // "" when the text has no phone
+	}{
+		{"Call +34 612 345 678 today", "+34 612 345 678"},
+		{"Phone: (555) 123-4567", "(555) 123-4567"},
+		{"Tel. 555.123.4567", "555.123.4567"},
+		{"Móvil 06 12 34 56 78", "06 12 34 56 78"},
+		{"Móvil 612 345 678", "612 345 678"},
+		{"Tested on Windows 10.0.19041.1", ""},
+		{"Upgraded the cluster to 1.28.3.1024", ""},
+		{"Served 12 345 678 requests a day", ""},
+		{"A budget of 1 250 000 euros", ""},
+		{"Acme Corp, 01/03/2019 - 2021", ""},
+		{"Employee ID 1234", ""},
+	}
+
+	for _, tt := range tests {
+		spans := findPhones(tt.text)
+		if tt.phone == "" {
+			if len(spans) != 0 {
+				t.Errorf("%q: found phone %q, want none", tt.text, tt.text[spans[0].start:spans[0].end])
+			}
+			continue
+		}
+		if len(spans) != 1 || tt.text[spans[0].start:spans[0].end] != tt.phone {
+			t.Errorf("%q: found %+v, want %q", tt.text, spans, tt.phone)
+		}
+	}
+}
+
+func TestFindNamesSkipsSkillLines(t *testing.T) {
+	text := "Go Rust\nSenior Backend Engineer\nBuilt services in Go and Rust"
+	if spans := findNames(text); len(spans) != 0 {
+		t.Errorf("found names %+v in a skill line, want none", spans)
+	}
+
+	// A name with a skill word keeps the word elsewhere in the text
+	text = "Ruby Chen\nBackend Engineer\nRuby on Rails and Go. Contact Chen or RUBY CHEN."
+	result := Redact(text, DefaultConfig())
+	want := "[NAME]\nBackend Engineer\nRuby on Rails and Go. Contact [NAME] or [NAME]."
+	if result.Text != want {
+		t.Errorf("Redact() = %q, want %q", result.Text, want)
+	}
+}
+
+func TestRedactMapsOffsetsBack(t *testing.T) {
+	text := "Ana García\nana@example.com, +34 612 345 678\nSkills: Go, Kubernetes"
+	result := Redact(text, DefaultConfig())
+
+	if counts := result.Counts(); counts[KindName] != 1 || counts[KindEmail] != 1 || counts[KindPhone] != 1 {
+		t.Fatalf("counts = %v, want one name, email and phone", counts)
+	}
+
+	// Offsets count characters, and "García" has a two-byte rune
+	redactedStart := strings.Index(result.Text, "Kubernetes")
+	start, end, ok := result.OriginalSpan(redactedStart, redactedStart+len("Kubernetes"))
+	if original := []rune(text); !ok || string(original[start:end]) != "Kubernetes" {
+		t.Errorf("OriginalSpan() = %d, %d, %v; want the span of Kubernetes", start, end, ok)
+	}
+	if _, _, ok := result.OriginalSpan(0, 3); ok {
+		t.Error("a span in a placeholder should not map back")
+	}
+
+	if unchanged := Redact(text, models.PIIRedactionConfig{}); unchanged.Text != text {
+		t.Errorf("disabled redaction changed the text to %q", unchanged.Text)
+	}
+}
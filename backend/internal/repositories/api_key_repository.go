@@ -205,3 +205,51 @@ func (r *apiKeyRepository) Delete(id int) error {
 
 	return nil
 }
+
+// SetSigningSecret sets or rotates the HMAC signing secret of an API key
+func (r *apiKeyRepository) SetSigningSecret(id int, secret string) error {
+	query := r.MustGetQuery("set_api_key_signing_secret")
+
+	result, err := r.db.Exec(query, id, secret, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to set signing secret: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check signing secret update: %v", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("API key not found")
+	}
+
+	return nil
+}
+
+// GetSigningSecret retrieves the HMAC signing secret of an API key, empty if none was issued
+func (r *apiKeyRepository) GetSigningSecret(id int) (string, error) {
+	query := r.MustGetQuery("get_api_key_signing_secret")
+
+	var secret string
+	err := r.db.QueryRow(query, id).Scan(&secret)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", fmt.Errorf("API key not found")
+		}
+		return "", fmt.Errorf("failed to get signing secret: %v", err)
+	}
+
+	return secret, nil
+}
+
+// HasSigningSecrets reports whether any active API key has a signing secret
+func (r *apiKeyRepository) HasSigningSecrets() (bool, error) {
+	query := r.MustGetQuery("has_api_key_signing_secrets")
+
+	var exists bool
+	if err := r.db.QueryRow(query).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check signing secrets: %v", err)
+	}
+
+	return exists, nil
+}
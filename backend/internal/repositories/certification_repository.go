@@ -0,0 +1,292 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"stafind-backend/internal/models"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+type certificationRepository struct {
+	*BaseRepository
+}
+
+// NewCertificationRepository creates a new certification repository
+func NewCertificationRepository(db *sql.DB) (CertificationRepository, error) {
+	baseRepo, err := NewBaseRepository(db)
+	if err != nil {
+		return nil, err
+	}
+
+	return &certificationRepository{BaseRepository: baseRepo}, nil
+}
+
+// ListCatalog retrieves the certification catalogue by name
+func (r *certificationRepository) ListCatalog() ([]models.Certification, error) {
+	rows, err := r.db.Query(r.MustGetQuery("list_certifications"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get certifications: %w", err)
+	}
+	defer rows.Close()
+
+	certifications := []models.Certification{}
+	for rows.Next() {
+		certification, err := scanCertification(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan certification: %w", err)
+		}
+		certifications = append(certifications, *certification)
+	}
+
+	return certifications, rows.Err()
+}
+
+// GetCatalogEntry retrieves a catalogue certification
+func (r *certificationRepository) GetCatalogEntry(id int) (*models.Certification, error) {
+	certification, err := scanCertification(r.db.QueryRow(r.MustGetQuery("get_certification"), id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("certification not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get certification: %w", err)
+	}
+
+	return certification, nil
+}
+
+// CreateCatalogEntry adds a certification to the catalogue, setting its ID and timestamps
+func (r *certificationRepository) CreateCatalogEntry(certification *models.Certification) error {
+	err := r.db.QueryRow(r.MustGetQuery("create_certification"),
+		certification.Name, certification.Issuer, pq.Array(certification.Aliases), certification.ValidityMonths,
+	).Scan(&certification.ID, &certification.CreatedAt, &certification.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create certification: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateCatalogEntry updates a catalogue certification, setting its timestamps
+func (r *certificationRepository) UpdateCatalogEntry(certification *models.Certification) error {
+	err := r.db.QueryRow(r.MustGetQuery("update_certification"),
+		certification.ID, certification.Name, certification.Issuer, pq.Array(certification.Aliases), certification.ValidityMonths,
+	).Scan(&certification.CreatedAt, &certification.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("certification not found: %w", err)
+		}
+		return fmt.Errorf("failed to update certification: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteCatalogEntry removes a certification from the catalogue and reports whether it existed
+func (r *certificationRepository) DeleteCatalogEntry(id int) (bool, error) {
+	result, err := r.db.Exec(r.MustGetQuery("delete_certification"), id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete certification: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	return rows > 0, err
+}
+
+// ListByEmployee retrieves the certifications of an employee, soonest to expire first
+func (r *certificationRepository) ListByEmployee(employeeID int) ([]models.EmployeeCertification, error) {
+	rows, err := r.db.Query(r.MustGetQuery("get_employee_certifications"), employeeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get employee certifications: %w", err)
+	}
+	defer rows.Close()
+
+	certifications := []models.EmployeeCertification{}
+	for rows.Next() {
+		certification, err := scanEmployeeCertification(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan employee certification: %w", err)
+		}
+		certifications = append(certifications, *certification)
+	}
+
+	return certifications, rows.Err()
+}
+
+// Get retrieves a certification of an employee
+func (r *certificationRepository) Get(employeeID, id int) (*models.EmployeeCertification, error) {
+	certification, err := scanEmployeeCertification(r.db.QueryRow(r.MustGetQuery("get_employee_certification"), id, employeeID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("employee certification not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get employee certification: %w", err)
+	}
+
+	return certification, nil
+}
+
+// Create adds a certification to an employee, setting its ID
+func (r *certificationRepository) Create(certification *models.EmployeeCertification) error {
+	err := r.db.QueryRow(r.MustGetQuery("create_employee_certification"),
+		certification.EmployeeID, certification.CertificationID, certification.Name, certification.Issuer,
+		certification.CredentialID, certification.IssuedOn, certification.ExpiresOn, certification.Source,
+	).Scan(&certification.ID)
+	if err != nil {
+		return fmt.Errorf("failed to create employee certification: %w", err)
+	}
+
+	return nil
+}
+
+// Update updates a certification of an employee, which from then on counts as entered by hand,
+// and reports whether it existed
+func (r *certificationRepository) Update(certification *models.EmployeeCertification) (bool, error) {
+	result, err := r.db.Exec(r.MustGetQuery("update_employee_certification"),
+		certification.ID, certification.EmployeeID, certification.CertificationID, certification.Name, certification.Issuer,
+		certification.CredentialID, certification.IssuedOn, certification.ExpiresOn,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to update employee certification: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	return rows > 0, err
+}
+
+// Delete removes a certification from an employee and reports whether it existed
+func (r *certificationRepository) Delete(employeeID, id int) (bool, error) {
+	result, err := r.db.Exec(r.MustGetQuery("delete_employee_certification"), id, employeeID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete employee certification: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	return rows > 0, err
+}
+
+// SyncResumeCertifications stores the certifications found in an employee's resume and removes
+// those an earlier extraction found that the resume no longer lists. Certifications entered or
+// edited by hand are kept.
+func (r *certificationRepository) SyncResumeCertifications(employeeID int, certifications []models.EmployeeCertification) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := r.MustGetQuery("upsert_resume_certification")
+	names := make([]string, 0, len(certifications))
+	for _, certification := range certifications {
+		_, err := tx.Exec(query, employeeID, certification.CertificationID, certification.Name, certification.Issuer,
+			certification.CredentialID, certification.IssuedOn, certification.ExpiresOn)
+		if err != nil {
+			return fmt.Errorf("failed to store certification %q: %w", certification.Name, err)
+		}
+		names = append(names, strings.ToLower(certification.Name))
+	}
+
+	if _, err := tx.Exec(r.MustGetQuery("remove_stale_resume_certifications"), employeeID, pq.Array(names)); err != nil {
+		return fmt.Errorf("failed to remove stale certifications: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// ClaimExpiring marks the certifications expiring within days that were not announced yet as
+// announced and returns them with their holders, so that each expiry is announced once even
+// with several instances running
+func (r *certificationRepository) ClaimExpiring(days int) ([]models.ExpiringCertification, error) {
+	rows, err := r.db.Query(r.MustGetQuery("claim_expiring_certifications"), days)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim expiring certifications: %w", err)
+	}
+	defer rows.Close()
+
+	var expiring []models.ExpiringCertification
+	for rows.Next() {
+		var certification models.ExpiringCertification
+		var certificationID sql.NullInt64
+		err := rows.Scan(
+			&certification.ID, &certification.EmployeeID, &certificationID, &certification.CatalogName,
+			&certification.Name, &certification.Issuer, &certification.CredentialID, &certification.IssuedOn,
+			&certification.ExpiresOn, &certification.Source, &certification.ExpiryNotifiedFor,
+			&certification.CreatedAt, &certification.UpdatedAt, &certification.EmployeeName, &certification.EmployeeEmail,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan expiring certification: %w", err)
+		}
+		if certificationID.Valid {
+			id := int(certificationID.Int64)
+			certification.CertificationID = &id
+		}
+		expiring = append(expiring, certification)
+	}
+
+	return expiring, rows.Err()
+}
+
+// ReleaseExpiring marks certifications as not announced, so the next run announces them again
+func (r *certificationRepository) ReleaseExpiring(ids []int) error {
+	if _, err := r.db.Exec(r.MustGetQuery("release_expiring_certifications"), pq.Array(ids)); err != nil {
+		return fmt.Errorf("failed to release expiring certifications: %w", err)
+	}
+	return nil
+}
+
+// scanCertification scans a catalogue certification from a row or rows
+func scanCertification(row interface{ Scan(...interface{}) error }) (*models.Certification, error) {
+	var certification models.Certification
+	var validityMonths sql.NullInt64
+	err := row.Scan(
+		&certification.ID,
+		&certification.Name,
+		&certification.Issuer,
+		pq.Array(&certification.Aliases),
+		&validityMonths,
+		&certification.CreatedAt,
+		&certification.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if validityMonths.Valid {
+		months := int(validityMonths.Int64)
+		certification.ValidityMonths = &months
+	}
+	if certification.Aliases == nil {
+		certification.Aliases = []string{}
+	}
+
+	return &certification, nil
+}
+
+// scanEmployeeCertification scans an employee certification from a row or rows
+func scanEmployeeCertification(row interface{ Scan(...interface{}) error }) (*models.EmployeeCertification, error) {
+	var certification models.EmployeeCertification
+	var certificationID sql.NullInt64
+	err := row.Scan(
+		&certification.ID,
+		&certification.EmployeeID,
+		&certificationID,
+		&certification.CatalogName,
+		&certification.Name,
+		&certification.Issuer,
+		&certification.CredentialID,
+		&certification.IssuedOn,
+		&certification.ExpiresOn,
+		&certification.Source,
+		&certification.ExpiryNotifiedFor,
+		&certification.CreatedAt,
+		&certification.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if certificationID.Valid {
+		id := int(certificationID.Int64)
+		certification.CertificationID = &id
+	}
+
+	return &certification, nil
+}
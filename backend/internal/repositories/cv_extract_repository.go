@@ -20,6 +20,7 @@ type CVExtractRepository interface {
 	GetStats(filters CVExtractStatsFilters) (*models.CVExtractStats, error)
 	GetRecent(limit int) ([]models.CVExtract, error)
 	GetByStatus(status string) ([]models.CVExtract, error)
+	RecordFileResult(id int, fileNumber int, status string) (*models.CVExtract, error)
 }
 
 // CVExtractFilters represents filters for listing CV extraction records
@@ -539,3 +540,62 @@ func (r *cvExtractRepository) GetByStatus(status string) ([]models.CVExtract, er
 
 	return records, nil
 }
+
+// RecordFileResult stores the outcome of one file and recalculates the extract's file counts.
+// Recording the same file number again overwrites its outcome instead of counting it twice;
+// files without a number (fileNumber <= 0) are always counted as new files. Reports for the
+// same extract are recorded one at a time, so each recount sees the others' files.
+func (r *cvExtractRepository) RecordFileResult(id int, fileNumber int, status string) (*models.CVExtract, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var lockedID int
+	if err := tx.QueryRow(r.MustGetQuery("lock_cv_extract"), id).Scan(&lockedID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("CV extraction not found")
+		}
+		return nil, fmt.Errorf("failed to lock CV extraction: %w", err)
+	}
+
+	if fileNumber > 0 {
+		_, err = tx.Exec(r.MustGetQuery("upsert_cv_extract_file"), id, fileNumber, status)
+	} else {
+		_, err = tx.Exec(r.MustGetQuery("insert_unnumbered_cv_extract_file"), id, status)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to record CV extraction file: %w", err)
+	}
+
+	var cvExtract models.CVExtract
+	err = tx.QueryRow(r.MustGetQuery("refresh_cv_extract_file_counts"), id).Scan(
+		&cvExtract.ID,
+		&cvExtract.ExtractRequestID,
+		&cvExtract.Status,
+		&cvExtract.NumFiles,
+		&cvExtract.FilesProcessed,
+		&cvExtract.FilesFailed,
+		&cvExtract.TotalProcessingTimeMs,
+		&cvExtract.AverageProcessingTimeMs,
+		&cvExtract.StartedAt,
+		&cvExtract.CompletedAt,
+		&cvExtract.ErrorMessage,
+		&cvExtract.Metadata,
+		&cvExtract.CreatedAt,
+		&cvExtract.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("CV extraction not found")
+		}
+		return nil, fmt.Errorf("failed to update CV extraction file counts: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit CV extraction file result: %w", err)
+	}
+
+	return &cvExtract, nil
+}
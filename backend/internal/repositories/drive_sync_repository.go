@@ -0,0 +1,113 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"stafind-backend/internal/models"
+)
+
+type driveSyncRepository struct {
+	*BaseRepository
+}
+
+// NewDriveSyncRepository creates a new Google Drive sync repository
+func NewDriveSyncRepository(db *sql.DB) (DriveSyncRepository, error) {
+	baseRepo, err := NewBaseRepository(db)
+	if err != nil {
+		return nil, err
+	}
+
+	return &driveSyncRepository{BaseRepository: baseRepo}, nil
+}
+
+// GetFolder retrieves the sync state of a folder. The error wraps sql.ErrNoRows when the folder was never scanned.
+func (r *driveSyncRepository) GetFolder(folderID string) (*models.GoogleDriveSyncFolder, error) {
+	query := r.MustGetQuery("get_drive_sync_folder")
+
+	folder := &models.GoogleDriveSyncFolder{}
+	err := r.db.QueryRow(query, folderID).Scan(
+		&folder.FolderID,
+		&folder.FolderName,
+		&folder.Recursive,
+		&folder.ChangeToken,
+		&folder.LastExtractRequestID,
+		&folder.LastScanAt,
+		&folder.CreatedAt,
+		&folder.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("drive sync folder not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get drive sync folder: %w", err)
+	}
+
+	return folder, nil
+}
+
+// UpsertFolder creates or updates the sync state of a folder
+func (r *driveSyncRepository) UpsertFolder(folder *models.GoogleDriveSyncFolder) error {
+	query := r.MustGetQuery("upsert_drive_sync_folder")
+
+	_, err := r.db.Exec(query,
+		folder.FolderID,
+		folder.FolderName,
+		folder.Recursive,
+		folder.ChangeToken,
+		folder.LastExtractRequestID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save drive sync folder: %w", err)
+	}
+
+	return nil
+}
+
+// GetFile retrieves the last processing result of a file. The error wraps sql.ErrNoRows when the file was never processed.
+func (r *driveSyncRepository) GetFile(fileID string) (*models.GoogleDriveSyncFile, error) {
+	query := r.MustGetQuery("get_drive_sync_file")
+
+	file := &models.GoogleDriveSyncFile{}
+	err := r.db.QueryRow(query, fileID).Scan(
+		&file.FileID,
+		&file.FolderID,
+		&file.FileName,
+		&file.MimeType,
+		&file.FileSize,
+		&file.ModifiedTime,
+		&file.Status,
+		&file.EmployeeID,
+		&file.ErrorMessage,
+		&file.ProcessedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("drive sync file not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get drive sync file: %w", err)
+	}
+
+	return file, nil
+}
+
+// UpsertFile records the processing result of a file
+func (r *driveSyncRepository) UpsertFile(file *models.GoogleDriveSyncFile) error {
+	query := r.MustGetQuery("upsert_drive_sync_file")
+
+	_, err := r.db.Exec(query,
+		file.FileID,
+		file.FolderID,
+		file.FileName,
+		file.MimeType,
+		file.FileSize,
+		file.ModifiedTime.UTC(),
+		file.Status,
+		file.EmployeeID,
+		file.ErrorMessage,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save drive sync file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,224 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"stafind-backend/internal/models"
+
+	"github.com/lib/pq"
+)
+
+type employeeBackgroundRepository struct {
+	*BaseRepository
+}
+
+// NewEmployeeBackgroundRepository creates a new employee background repository
+func NewEmployeeBackgroundRepository(db *sql.DB) (EmployeeBackgroundRepository, error) {
+	baseRepo, err := NewBaseRepository(db)
+	if err != nil {
+		return nil, err
+	}
+
+	return &employeeBackgroundRepository{BaseRepository: baseRepo}, nil
+}
+
+// Get retrieves the work history, education and projects of an employee
+func (r *employeeBackgroundRepository) Get(employeeID int) (*models.EmployeeBackground, error) {
+	return getEmployeeBackground(r.BaseRepository, employeeID)
+}
+
+// GetAllSearchable retrieves the work history and education of every employee, by employee ID,
+// for the search filters. Projects are left out.
+func (r *employeeBackgroundRepository) GetAllSearchable() (map[int]*models.EmployeeBackground, error) {
+	backgrounds := make(map[int]*models.EmployeeBackground)
+	background := func(employeeID int) *models.EmployeeBackground {
+		if backgrounds[employeeID] == nil {
+			backgrounds[employeeID] = &models.EmployeeBackground{}
+		}
+		return backgrounds[employeeID]
+	}
+
+	positions, err := r.db.Query(r.MustGetQuery("get_all_employee_positions"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get employee positions: %w", err)
+	}
+	defer positions.Close()
+	for positions.Next() {
+		position, err := scanEmployeePosition(positions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan employee position: %w", err)
+		}
+		b := background(position.EmployeeID)
+		b.Positions = append(b.Positions, *position)
+	}
+	if err := positions.Err(); err != nil {
+		return nil, err
+	}
+
+	education, err := r.db.Query(r.MustGetQuery("get_all_employee_education"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get employee education: %w", err)
+	}
+	defer education.Close()
+	for education.Next() {
+		degree, err := scanEmployeeEducation(education)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan employee education: %w", err)
+		}
+		b := background(degree.EmployeeID)
+		b.Education = append(b.Education, *degree)
+	}
+
+	return backgrounds, education.Err()
+}
+
+// Replace stores an employee's work history, education and projects in place of the stored
+// ones. A nil list keeps the stored one.
+func (r *employeeBackgroundRepository) Replace(employeeID int, background *models.EmployeeBackground) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if background.Positions != nil {
+		if _, err := tx.Exec(r.MustGetQuery("remove_employee_positions"), employeeID); err != nil {
+			return fmt.Errorf("failed to remove employee positions: %w", err)
+		}
+		query := r.MustGetQuery("add_employee_position")
+		for i, position := range background.Positions {
+			_, err := tx.Exec(query, employeeID, i, position.Company, position.Title, position.StartDate, position.EndDate,
+				position.Current, position.DatesByYear, position.Description, pq.Array(nonNil(position.Skills)))
+			if err != nil {
+				return fmt.Errorf("failed to add employee position: %w", err)
+			}
+		}
+	}
+
+	if background.Education != nil {
+		if _, err := tx.Exec(r.MustGetQuery("remove_employee_education"), employeeID); err != nil {
+			return fmt.Errorf("failed to remove employee education: %w", err)
+		}
+		query := r.MustGetQuery("add_employee_education")
+		for i, degree := range background.Education {
+			_, err := tx.Exec(query, employeeID, i, degree.Institution, degree.Degree, degree.Field, degree.StartYear, degree.EndYear)
+			if err != nil {
+				return fmt.Errorf("failed to add employee education: %w", err)
+			}
+		}
+	}
+
+	if background.Projects != nil {
+		if _, err := tx.Exec(r.MustGetQuery("remove_employee_projects"), employeeID); err != nil {
+			return fmt.Errorf("failed to remove employee projects: %w", err)
+		}
+		query := r.MustGetQuery("add_employee_project")
+		for i, project := range background.Projects {
+			_, err := tx.Exec(query, employeeID, i, project.Name, project.Role, project.Description,
+				pq.Array(nonNil(project.Technologies)))
+			if err != nil {
+				return fmt.Errorf("failed to add employee project: %w", err)
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+// getEmployeeBackground retrieves the work history, education and projects of an employee
+func getEmployeeBackground(r *BaseRepository, employeeID int) (*models.EmployeeBackground, error) {
+	background := &models.EmployeeBackground{
+		Positions: []models.EmployeePosition{},
+		Education: []models.EmployeeEducation{},
+		Projects:  []models.EmployeeProject{},
+	}
+
+	positions, err := r.db.Query(r.MustGetQuery("get_employee_positions"), employeeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get employee positions: %w", err)
+	}
+	defer positions.Close()
+	for positions.Next() {
+		position, err := scanEmployeePosition(positions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan employee position: %w", err)
+		}
+		background.Positions = append(background.Positions, *position)
+	}
+	if err := positions.Err(); err != nil {
+		return nil, err
+	}
+
+	education, err := r.db.Query(r.MustGetQuery("get_employee_education"), employeeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get employee education: %w", err)
+	}
+	defer education.Close()
+	for education.Next() {
+		degree, err := scanEmployeeEducation(education)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan employee education: %w", err)
+		}
+		background.Education = append(background.Education, *degree)
+	}
+	if err := education.Err(); err != nil {
+		return nil, err
+	}
+
+	projects, err := r.db.Query(r.MustGetQuery("get_employee_projects"), employeeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get employee projects: %w", err)
+	}
+	defer projects.Close()
+	for projects.Next() {
+		var project models.EmployeeProject
+		err := projects.Scan(&project.ID, &project.EmployeeID, &project.Name, &project.Role, &project.Description,
+			pq.Array(&project.Technologies))
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan employee project: %w", err)
+		}
+		project.Technologies = nonNil(project.Technologies)
+		background.Projects = append(background.Projects, project)
+	}
+
+	return background, projects.Err()
+}
+
+// scanEmployeePosition scans an employee position from rows
+func scanEmployeePosition(rows *sql.Rows) (*models.EmployeePosition, error) {
+	var position models.EmployeePosition
+	err := rows.Scan(&position.ID, &position.EmployeeID, &position.Company, &position.Title, &position.StartDate,
+		&position.EndDate, &position.Current, &position.DatesByYear, &position.Description, pq.Array(&position.Skills))
+	if err != nil {
+		return nil, err
+	}
+	position.Skills = nonNil(position.Skills)
+	return &position, nil
+}
+
+// scanEmployeeEducation scans a degree of an employee from rows
+func scanEmployeeEducation(rows *sql.Rows) (*models.EmployeeEducation, error) {
+	var degree models.EmployeeEducation
+	var startYear, endYear sql.NullInt64
+	err := rows.Scan(&degree.ID, &degree.EmployeeID, &degree.Institution, &degree.Degree, &degree.Field, &startYear, &endYear)
+	if err != nil {
+		return nil, err
+	}
+	if startYear.Valid {
+		year := int(startYear.Int64)
+		degree.StartYear = &year
+	}
+	if endYear.Valid {
+		year := int(endYear.Int64)
+		degree.EndYear = &year
+	}
+	return &degree, nil
+}
+
+// nonNil returns an empty list for a nil one, so it is stored and encoded as empty
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,343 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"stafind-backend/internal/models"
+)
+
+type employeeDuplicateRepository struct {
+	*BaseRepository
+}
+
+// NewEmployeeDuplicateRepository creates a new duplicate employee repository
+func NewEmployeeDuplicateRepository(db *sql.DB) (EmployeeDuplicateRepository, error) {
+	baseRepo, err := NewBaseRepository(db)
+	if err != nil {
+		return nil, err
+	}
+
+	return &employeeDuplicateRepository{BaseRepository: baseRepo}, nil
+}
+
+// ListIdentityProfiles retrieves every employee's name with its identifiers
+func (r *employeeDuplicateRepository) ListIdentityProfiles() ([]models.EmployeeIdentityProfile, error) {
+	query := r.MustGetQuery("list_employee_identity_profiles")
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get employee identities: %w", err)
+	}
+	defer rows.Close()
+
+	var profiles []models.EmployeeIdentityProfile
+	for rows.Next() {
+		var employeeID int
+		var name string
+		var kind, value sql.NullString
+		if err := rows.Scan(&employeeID, &name, &kind, &value); err != nil {
+			return nil, fmt.Errorf("failed to scan employee identity: %w", err)
+		}
+
+		if len(profiles) == 0 || profiles[len(profiles)-1].EmployeeID != employeeID {
+			profiles = append(profiles, models.EmployeeIdentityProfile{EmployeeID: employeeID, Name: name})
+		}
+		if kind.Valid {
+			profile := &profiles[len(profiles)-1]
+			profile.Identities = append(profile.Identities, models.EmployeeIdentity{Kind: kind.String, Value: value.String})
+		}
+	}
+
+	return profiles, rows.Err()
+}
+
+// AddIdentities adds identifiers to an employee, ignoring those already known
+func (r *employeeDuplicateRepository) AddIdentities(employeeID int, identities []models.EmployeeIdentity) error {
+	query := r.MustGetQuery("add_employee_identity")
+
+	for _, identity := range identities {
+		if _, err := r.db.Exec(query, employeeID, identity.Kind, identity.Value); err != nil {
+			return fmt.Errorf("failed to add employee identity: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// UpsertDuplicate flags a pair of employees, refreshing the score of a pending pair. A
+// dismissed pair stays dismissed.
+func (r *employeeDuplicateRepository) UpsertDuplicate(employeeID, duplicateID int, score float64, reasons []string) error {
+	if employeeID > duplicateID {
+		employeeID, duplicateID = duplicateID, employeeID
+	}
+
+	reasonsJSON, err := json.Marshal(reasons)
+	if err != nil {
+		return fmt.Errorf("failed to marshal duplicate reasons: %w", err)
+	}
+
+	query := r.MustGetQuery("upsert_employee_duplicate")
+	if _, err := r.db.Exec(query, employeeID, duplicateID, score, reasonsJSON); err != nil {
+		return fmt.Errorf("failed to flag duplicate employees: %w", err)
+	}
+
+	return nil
+}
+
+// ListDuplicates retrieves flagged pairs by status, most likely first
+func (r *employeeDuplicateRepository) ListDuplicates(status string) ([]models.EmployeeDuplicate, error) {
+	query := r.MustGetQuery("list_employee_duplicates")
+
+	rows, err := r.db.Query(query, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get duplicate employees: %w", err)
+	}
+	defer rows.Close()
+
+	duplicates := []models.EmployeeDuplicate{}
+	for rows.Next() {
+		duplicate, err := scanEmployeeDuplicate(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan duplicate employees: %w", err)
+		}
+		duplicates = append(duplicates, *duplicate)
+	}
+
+	return duplicates, rows.Err()
+}
+
+// GetDuplicate retrieves a flagged pair by ID
+func (r *employeeDuplicateRepository) GetDuplicate(id int) (*models.EmployeeDuplicate, error) {
+	query := r.MustGetQuery("get_employee_duplicate")
+
+	duplicate, err := scanEmployeeDuplicate(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("duplicate employees not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get duplicate employees: %w", err)
+	}
+
+	return duplicate, nil
+}
+
+// UpdateDuplicateStatus changes the status of a flagged pair
+func (r *employeeDuplicateRepository) UpdateDuplicateStatus(id int, status string) error {
+	query := r.MustGetQuery("update_employee_duplicate_status")
+
+	result, err := r.db.Exec(query, id, status)
+	if err != nil {
+		return fmt.Errorf("failed to update duplicate employees: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return fmt.Errorf("duplicate employees not found: %w", sql.ErrNoRows)
+	}
+
+	return nil
+}
+
+// Merge moves everything of the source employee to the target, including its extraction
+// history, and deletes the source, in one transaction. The target keeps its own details and
+// takes the source's only where it has none; it takes the source's extraction when that is
+// newer. merge carries the score, reasons and whether the merge is automatic, and is
+// completed with the record's ID and the source's name and email.
+func (r *employeeDuplicateRepository) Merge(targetID, sourceID int, merge *models.EmployeeMerge) (*models.EmployeeMergeResult, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result := &models.EmployeeMergeResult{MergedEmployeeID: sourceID}
+
+	moved, err := tx.Exec(r.MustGetQuery("merge_employee_skills"), targetID, sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge employee skills: %w", err)
+	}
+	result.SkillsMerged, _ = moved.RowsAffected()
+
+	if _, err := tx.Exec(r.MustGetQuery("merge_employee_identities"), targetID, sourceID); err != nil {
+		return nil, fmt.Errorf("failed to merge employee identities: %w", err)
+	}
+
+	if _, err := tx.Exec(r.MustGetQuery("merge_employee_languages"), targetID, sourceID); err != nil {
+		return nil, fmt.Errorf("failed to merge employee languages: %w", err)
+	}
+
+	if _, err := tx.Exec(r.MustGetQuery("merge_employee_certifications"), targetID, sourceID); err != nil {
+		return nil, fmt.Errorf("failed to merge employee certifications: %w", err)
+	}
+
+	for _, part := range []string{"positions", "education", "projects"} {
+		if _, err := tx.Exec(r.MustGetQuery("merge_employee_"+part), targetID, sourceID); err != nil {
+			return nil, fmt.Errorf("failed to merge employee %s: %w", part, err)
+		}
+	}
+
+	if _, err := tx.Exec(r.MustGetQuery("merge_employee_skill_terms"), targetID, sourceID); err != nil {
+		return nil, fmt.Errorf("failed to merge employee skill terms: %w", err)
+	}
+
+	moved, err = tx.Exec(r.MustGetQuery("merge_employee_matches"), targetID, sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to move employee matches: %w", err)
+	}
+	result.MatchesMoved, _ = moved.RowsAffected()
+
+	if _, err := tx.Exec(r.MustGetQuery("merge_employee_extraction_history"), targetID, sourceID); err != nil {
+		return nil, fmt.Errorf("failed to merge employee extraction history: %w", err)
+	}
+
+	moved, err = tx.Exec(r.MustGetQuery("merge_employee_drive_files"), targetID, sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to move employee drive files: %w", err)
+	}
+	result.FilesMoved, _ = moved.RowsAffected()
+
+	if _, err := tx.Exec(r.MustGetQuery("merge_employee_contact"), targetID, sourceID); err != nil {
+		return nil, fmt.Errorf("failed to merge employee contact details: %w", err)
+	}
+
+	var name string
+	var email, location, bio, currentProject, resumeURL, originalText, extractionSource, extractionStatus sql.NullString
+	var extractedData []byte
+	var extractionTimestamp sql.NullTime
+	err = tx.QueryRow(r.MustGetQuery("delete_merged_employee"), sourceID).Scan(
+		&name,
+		&email,
+		&location,
+		&bio,
+		&currentProject,
+		&resumeURL,
+		&originalText,
+		&extractedData,
+		&extractionTimestamp,
+		&extractionSource,
+		&extractionStatus,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("employee not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to delete merged employee: %w", err)
+	}
+
+	// The source is deleted first so its email is free for the target to take
+	details, err := tx.Exec(r.MustGetQuery("merge_employee_details"), targetID, email, location, bio, currentProject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge employee details: %w", err)
+	}
+	if rows, err := details.RowsAffected(); err == nil && rows == 0 {
+		return nil, fmt.Errorf("employee not found: %w", sql.ErrNoRows)
+	}
+
+	if extractionTimestamp.Valid {
+		extraction, err := tx.Exec(r.MustGetQuery("merge_employee_extraction"), targetID, resumeURL, originalText,
+			extractedData, extractionTimestamp, extractionSource, extractionStatus)
+		if err != nil {
+			return nil, fmt.Errorf("failed to merge employee extraction: %w", err)
+		}
+		rows, _ := extraction.RowsAffected()
+		result.ExtractionMoved = rows > 0
+	}
+
+	merge.EmployeeID = targetID
+	merge.MergedEmployeeID = sourceID
+	merge.MergedName = name
+	merge.MergedEmail = nil
+	if email.Valid {
+		merge.MergedEmail = &email.String
+	}
+	if merge.Reasons == nil {
+		merge.Reasons = []string{}
+	}
+	reasonsJSON, err := json.Marshal(merge.Reasons)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal merge reasons: %w", err)
+	}
+	err = tx.QueryRow(r.MustGetQuery("create_employee_merge"), targetID, sourceID, name, email, merge.Score,
+		reasonsJSON, merge.Automatic).Scan(&merge.ID, &merge.MergedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record employee merge: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit employee merge: %w", err)
+	}
+
+	return result, nil
+}
+
+// ListMerges retrieves the employees merged into an employee, newest first
+func (r *employeeDuplicateRepository) ListMerges(employeeID int) ([]models.EmployeeMerge, error) {
+	query := r.MustGetQuery("list_employee_merges")
+
+	rows, err := r.db.Query(query, employeeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get employee merges: %w", err)
+	}
+	defer rows.Close()
+
+	merges := []models.EmployeeMerge{}
+	for rows.Next() {
+		var merge models.EmployeeMerge
+		var email sql.NullString
+		var score sql.NullFloat64
+		var reasonsJSON []byte
+		err := rows.Scan(
+			&merge.ID,
+			&merge.EmployeeID,
+			&merge.MergedEmployeeID,
+			&merge.MergedName,
+			&email,
+			&score,
+			&reasonsJSON,
+			&merge.Automatic,
+			&merge.MergedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan employee merge: %w", err)
+		}
+		if email.Valid {
+			merge.MergedEmail = &email.String
+		}
+		if score.Valid {
+			merge.Score = &score.Float64
+		}
+		if err := json.Unmarshal(reasonsJSON, &merge.Reasons); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal merge reasons: %w", err)
+		}
+		merges = append(merges, merge)
+	}
+
+	return merges, rows.Err()
+}
+
+// scanEmployeeDuplicate scans a flagged pair from a row or rows
+func scanEmployeeDuplicate(row interface{ Scan(...interface{}) error }) (*models.EmployeeDuplicate, error) {
+	var duplicate models.EmployeeDuplicate
+	var reasonsJSON []byte
+	err := row.Scan(
+		&duplicate.ID,
+		&duplicate.EmployeeID,
+		&duplicate.EmployeeName,
+		&duplicate.EmployeeEmail,
+		&duplicate.DuplicateID,
+		&duplicate.DuplicateName,
+		&duplicate.DuplicateEmail,
+		&duplicate.Score,
+		&reasonsJSON,
+		&duplicate.Status,
+		&duplicate.CreatedAt,
+		&duplicate.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(reasonsJSON, &duplicate.Reasons); err != nil {
+		return nil, err
+	}
+
+	return &duplicate, nil
+}
@@ -0,0 +1,150 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"stafind-backend/internal/models"
+)
+
+type employeeExtractionRepository struct {
+	*BaseRepository
+}
+
+// NewEmployeeExtractionRepository creates a new employee extraction history repository
+func NewEmployeeExtractionRepository(db *sql.DB) (EmployeeExtractionRepository, error) {
+	baseRepo, err := NewBaseRepository(db)
+	if err != nil {
+		return nil, err
+	}
+
+	return &employeeExtractionRepository{BaseRepository: baseRepo}, nil
+}
+
+// Create records an extraction run as the employee's next version, filling in its ID, version
+// and creation time
+func (r *employeeExtractionRepository) Create(extraction *models.EmployeeExtraction) error {
+	query := r.MustGetQuery("create_employee_extraction")
+
+	var extractedData []byte
+	if extraction.ExtractedData != nil {
+		var err error
+		extractedData, err = json.Marshal(extraction.ExtractedData)
+		if err != nil {
+			return fmt.Errorf("failed to marshal extracted data: %w", err)
+		}
+	}
+
+	err := r.db.QueryRow(query,
+		extraction.EmployeeID,
+		extraction.Source,
+		extraction.ResumeURL,
+		extraction.ExtractorMethod,
+		extraction.ExtractorVersion,
+		extraction.OriginalText,
+		extractedData,
+		extraction.Status,
+	).Scan(&extraction.ID, &extraction.Version, &extraction.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record employee extraction: %w", err)
+	}
+
+	return nil
+}
+
+// ListByEmployee retrieves an employee's extraction runs without their text and data, newest
+// first
+func (r *employeeExtractionRepository) ListByEmployee(employeeID int) ([]models.EmployeeExtraction, error) {
+	query := r.MustGetQuery("list_employee_extractions")
+
+	rows, err := r.db.Query(query, employeeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get employee extractions: %w", err)
+	}
+	defer rows.Close()
+
+	extractions := []models.EmployeeExtraction{}
+	for rows.Next() {
+		var extraction models.EmployeeExtraction
+		var source, resumeURL, method sql.NullString
+		var extractorVersion sql.NullInt64
+		err := rows.Scan(
+			&extraction.ID,
+			&extraction.EmployeeID,
+			&extraction.Version,
+			&source,
+			&resumeURL,
+			&method,
+			&extractorVersion,
+			&extraction.Status,
+			&extraction.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan employee extraction: %w", err)
+		}
+		setExtractionColumns(&extraction, source, resumeURL, method, extractorVersion)
+		extractions = append(extractions, extraction)
+	}
+
+	return extractions, rows.Err()
+}
+
+// GetVersion retrieves one version of an employee's extraction
+func (r *employeeExtractionRepository) GetVersion(employeeID, version int) (*models.EmployeeExtraction, error) {
+	return r.get(r.MustGetQuery("get_employee_extraction"), employeeID, version)
+}
+
+// GetLatest retrieves an employee's latest extraction
+func (r *employeeExtractionRepository) GetLatest(employeeID int) (*models.EmployeeExtraction, error) {
+	return r.get(r.MustGetQuery("get_latest_employee_extraction"), employeeID)
+}
+
+// get retrieves one extraction with its text and data
+func (r *employeeExtractionRepository) get(query string, args ...interface{}) (*models.EmployeeExtraction, error) {
+	var extraction models.EmployeeExtraction
+	var source, resumeURL, method, originalText sql.NullString
+	var extractorVersion sql.NullInt64
+	var extractedData []byte
+	err := r.db.QueryRow(query, args...).Scan(
+		&extraction.ID,
+		&extraction.EmployeeID,
+		&extraction.Version,
+		&source,
+		&resumeURL,
+		&method,
+		&extractorVersion,
+		&extraction.Status,
+		&extraction.CreatedAt,
+		&originalText,
+		&extractedData,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("employee extraction not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get employee extraction: %w", err)
+	}
+	setExtractionColumns(&extraction, source, resumeURL, method, extractorVersion)
+	extraction.OriginalText = originalText.String
+
+	// Versions stored before the current resume format may not decode; they keep their text
+	if len(extractedData) > 0 {
+		var resume models.ProcessedResumeData
+		if err := json.Unmarshal(extractedData, &resume); err == nil {
+			extraction.ExtractedData = &resume
+		}
+	}
+
+	return &extraction, nil
+}
+
+// setExtractionColumns copies the nullable columns of an extraction
+func setExtractionColumns(extraction *models.EmployeeExtraction, source, resumeURL, method sql.NullString, extractorVersion sql.NullInt64) {
+	extraction.Source = source.String
+	extraction.ResumeURL = resumeURL.String
+	extraction.ExtractorMethod = method.String
+	if extractorVersion.Valid {
+		version := int(extractorVersion.Int64)
+		extraction.ExtractorVersion = &version
+	}
+}
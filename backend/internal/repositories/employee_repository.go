@@ -44,12 +44,15 @@ func (r *employeeRepository) GetAll() ([]models.Employee, error) {
 		var skillName sql.NullString
 		var proficiencyLevel sql.NullInt64
 		var yearsExperience sql.NullFloat64
+		var contact models.EmployeeContact
 
-		err := rows.Scan(
+		scanArgs := []interface{}{
 			&employeeID, &employeeName, &employeeEmail, &employeeDepartment,
 			&employeeLevel, &employeeLocation, &employeeBio, &currentProject, &resumeUrl,
-			&createdAt, &updatedAt, &skillID, &skillName, &proficiencyLevel, &yearsExperience,
-		)
+		}
+		scanArgs = append(scanArgs, contactColumns(&contact)...)
+		scanArgs = append(scanArgs, &createdAt, &updatedAt, &skillID, &skillName, &proficiencyLevel, &yearsExperience)
+		err := rows.Scan(scanArgs...)
 		if err != nil {
 			return nil, err
 		}
@@ -68,6 +71,8 @@ func (r *employeeRepository) GetAll() ([]models.Employee, error) {
 				CreatedAt:  createdAt,
 				UpdatedAt:  updatedAt,
 				Skills:     []models.Skill{},
+
+				EmployeeContact: contact,
 			}
 			if currentProject.Valid {
 				employee.CurrentProject = &currentProject.String
@@ -87,9 +92,25 @@ func (r *employeeRepository) GetAll() ([]models.Employee, error) {
 		}
 	}
 
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Attach spoken languages and certifications with one query each for all employees
+	languages, err := r.getAllLanguages()
+	if err != nil {
+		return nil, err
+	}
+	certifications, err := r.getAllCertifications()
+	if err != nil {
+		return nil, err
+	}
+
 	// Convert map to slice
 	var employees []models.Employee
 	for _, employee := range employeeMap {
+		employee.Languages = languages[employee.ID]
+		employee.Certifications = certifications[employee.ID]
 		employees = append(employees, *employee)
 	}
 
@@ -99,11 +120,13 @@ func (r *employeeRepository) GetAll() ([]models.Employee, error) {
 func (r *employeeRepository) GetByID(id int) (*models.Employee, error) {
 	query := r.MustGetQuery("get_employee_by_id")
 	var employee models.Employee
-	err := r.db.QueryRow(query, id).Scan(
+	scanArgs := []interface{}{
 		&employee.ID, &employee.Name, &employee.Email, &employee.Department,
 		&employee.Level, &employee.Location, &employee.Bio, &employee.CurrentProject, &employee.ResumeUrl,
-		&employee.CreatedAt, &employee.UpdatedAt,
-	)
+	}
+	scanArgs = append(scanArgs, contactColumns(&employee.EmployeeContact)...)
+	scanArgs = append(scanArgs, &employee.CreatedAt, &employee.UpdatedAt)
+	err := r.db.QueryRow(query, id).Scan(scanArgs...)
 	if err != nil {
 		return nil, err
 	}
@@ -115,6 +138,26 @@ func (r *employeeRepository) GetByID(id int) (*models.Employee, error) {
 	}
 	employee.Skills = skills
 
+	languages, err := r.GetLanguages(employee.ID)
+	if err != nil {
+		return nil, err
+	}
+	employee.Languages = languages
+
+	certifications, err := r.getCertifications(employee.ID)
+	if err != nil {
+		return nil, err
+	}
+	employee.Certifications = certifications
+
+	background, err := getEmployeeBackground(r.BaseRepository, employee.ID)
+	if err != nil {
+		return nil, err
+	}
+	employee.Positions = background.Positions
+	employee.Education = background.Education
+	employee.Projects = background.Projects
+
 	return &employee, nil
 }
 
@@ -125,12 +168,14 @@ func (r *employeeRepository) GetByEmail(email string) (*models.Employee, error)
 	var extractionTimestamp sql.NullTime
 	var extractedDataJSON sql.NullString
 
-	err := r.db.QueryRow(query, email).Scan(
+	scanArgs := []interface{}{
 		&employee.ID, &employee.Name, &employee.Email, &employee.Department,
 		&employee.Level, &employee.Location, &employee.Bio, &employee.CurrentProject, &employee.ResumeUrl,
 		&originalText, &extractedDataJSON, &extractionTimestamp, &extractionSource, &extractionStatus,
-		&employee.CreatedAt, &employee.UpdatedAt,
-	)
+	}
+	scanArgs = append(scanArgs, contactColumns(&employee.EmployeeContact)...)
+	scanArgs = append(scanArgs, &employee.CreatedAt, &employee.UpdatedAt)
+	err := r.db.QueryRow(query, email).Scan(scanArgs...)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, sql.ErrNoRows
@@ -165,6 +210,18 @@ func (r *employeeRepository) GetByEmail(email string) (*models.Employee, error)
 	}
 	employee.Skills = skills
 
+	languages, err := r.GetLanguages(employee.ID)
+	if err != nil {
+		return nil, err
+	}
+	employee.Languages = languages
+
+	certifications, err := r.getCertifications(employee.ID)
+	if err != nil {
+		return nil, err
+	}
+	employee.Certifications = certifications
+
 	return &employee, nil
 }
 
@@ -203,6 +260,16 @@ func (r *employeeRepository) Create(req *models.CreateEmployeeRequest) (*models.
 
 	fmt.Printf("DEBUG: Successfully created employee with ID: %d\n", employee.ID)
 
+	if err := r.updateContact(tx, employee.ID, &req.EmployeeContact); err != nil {
+		fmt.Printf("DEBUG: Failed to set employee contact details: %v\n", err)
+		return nil, err
+	}
+
+	if err := r.setLanguages(tx, employee.ID, req.Languages); err != nil {
+		fmt.Printf("DEBUG: Failed to set employee languages: %v\n", err)
+		return nil, err
+	}
+
 	employee.Name = req.Name
 	employee.Email = req.Email
 	employee.Department = req.Department
@@ -278,6 +345,16 @@ func (r *employeeRepository) CreateWithExtraction(req *models.CreateEmployeeRequ
 
 	fmt.Printf("DEBUG: Successfully created employee with extraction data, ID: %d\n", employee.ID)
 
+	if err := r.updateContact(tx, employee.ID, &req.EmployeeContact); err != nil {
+		fmt.Printf("DEBUG: Failed to set employee contact details: %v\n", err)
+		return nil, err
+	}
+
+	if err := r.setLanguages(tx, employee.ID, req.Languages); err != nil {
+		fmt.Printf("DEBUG: Failed to set employee languages: %v\n", err)
+		return nil, err
+	}
+
 	employee.Name = req.Name
 	employee.Email = req.Email
 	employee.Department = req.Department
@@ -341,6 +418,23 @@ func (r *employeeRepository) Update(id int, req *models.CreateEmployeeRequest) (
 		return nil, err
 	}
 
+	// Contact details are written only when the request sends some; the service has already
+	// filled the fields it left out with the stored ones
+	if len(req.ContactFields) > 0 {
+		if err := r.updateContact(tx, id, &req.EmployeeContact); err != nil {
+			fmt.Printf("DEBUG: Failed to update employee contact: %v\n", err)
+			return nil, err
+		}
+	}
+
+	// Languages are replaced only when the request sends them; an empty list clears them
+	if req.Languages != nil {
+		if err := r.setLanguages(tx, id, req.Languages); err != nil {
+			fmt.Printf("DEBUG: Failed to set employee languages: %v\n", err)
+			return nil, err
+		}
+	}
+
 	// Remove existing skills within transaction
 	fmt.Printf("DEBUG: Removing existing skills for employee %d\n", id)
 	removeSkillsQuery := r.MustGetQuery("remove_employee_skills")
@@ -400,6 +494,16 @@ func (r *employeeRepository) UpdateWithExtraction(id int, req *models.CreateEmpl
 		return nil, err
 	}
 
+	if err := r.updateContact(tx, id, &req.EmployeeContact); err != nil {
+		fmt.Printf("DEBUG: Failed to update employee contact details: %v\n", err)
+		return nil, err
+	}
+
+	if err := r.setLanguages(tx, id, req.Languages); err != nil {
+		fmt.Printf("DEBUG: Failed to set employee languages: %v\n", err)
+		return nil, err
+	}
+
 	// Remove existing skills within transaction
 	fmt.Printf("DEBUG: Removing existing skills for employee %d\n", id)
 	removeSkillsQuery := r.MustGetQuery("remove_employee_skills")
@@ -428,6 +532,116 @@ func (r *employeeRepository) UpdateWithExtraction(id int, req *models.CreateEmpl
 	return r.GetByID(id)
 }
 
+// updateContact sets an employee's contact details within a transaction
+func (r *employeeRepository) updateContact(tx *sql.Tx, employeeID int, contact *models.EmployeeContact) error {
+	query := r.MustGetQuery("update_employee_contact")
+	_, err := tx.Exec(query, employeeID, contact.Phone, contact.City, contact.Region, contact.Country,
+		contact.LinkedInURL, contact.GitHubURL, contact.GitLabURL, contact.StackOverflowURL, contact.WebsiteURL)
+	return err
+}
+
+// GetLanguages returns the spoken languages of an employee
+func (r *employeeRepository) GetLanguages(employeeID int) ([]models.SpokenLanguage, error) {
+	query := r.MustGetQuery("get_employee_languages")
+	rows, err := r.db.Query(query, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var languages []models.SpokenLanguage
+	for rows.Next() {
+		var language models.SpokenLanguage
+		if err := rows.Scan(&language.Code, &language.Name, &language.Level); err != nil {
+			return nil, err
+		}
+		languages = append(languages, language)
+	}
+	return languages, rows.Err()
+}
+
+// getAllLanguages returns the spoken languages of every employee, by employee ID
+func (r *employeeRepository) getAllLanguages() (map[int][]models.SpokenLanguage, error) {
+	query := r.MustGetQuery("get_all_employee_languages")
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	languages := make(map[int][]models.SpokenLanguage)
+	for rows.Next() {
+		var employeeID int
+		var language models.SpokenLanguage
+		if err := rows.Scan(&employeeID, &language.Code, &language.Name, &language.Level); err != nil {
+			return nil, err
+		}
+		languages[employeeID] = append(languages[employeeID], language)
+	}
+	return languages, rows.Err()
+}
+
+// getCertifications retrieves the certifications of an employee
+func (r *employeeRepository) getCertifications(employeeID int) ([]models.EmployeeCertification, error) {
+	rows, err := r.db.Query(r.MustGetQuery("get_employee_certifications"), employeeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var certifications []models.EmployeeCertification
+	for rows.Next() {
+		certification, err := scanEmployeeCertification(rows)
+		if err != nil {
+			return nil, err
+		}
+		certifications = append(certifications, *certification)
+	}
+	return certifications, rows.Err()
+}
+
+// getAllCertifications retrieves the certifications of every employee, by employee ID
+func (r *employeeRepository) getAllCertifications() (map[int][]models.EmployeeCertification, error) {
+	rows, err := r.db.Query(r.MustGetQuery("get_all_employee_certifications"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	certifications := make(map[int][]models.EmployeeCertification)
+	for rows.Next() {
+		certification, err := scanEmployeeCertification(rows)
+		if err != nil {
+			return nil, err
+		}
+		certifications[certification.EmployeeID] = append(certifications[certification.EmployeeID], *certification)
+	}
+	return certifications, rows.Err()
+}
+
+// setLanguages replaces an employee's spoken languages within a transaction
+func (r *employeeRepository) setLanguages(tx *sql.Tx, employeeID int, languages []models.SpokenLanguage) error {
+	if _, err := tx.Exec(r.MustGetQuery("remove_employee_languages"), employeeID); err != nil {
+		return err
+	}
+
+	query := r.MustGetQuery("add_employee_language")
+	for _, language := range languages {
+		if _, err := tx.Exec(query, employeeID, language.Code, language.Name, language.Level); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// contactColumns returns the scan destinations of the contact columns employee queries select
+func contactColumns(contact *models.EmployeeContact) []interface{} {
+	return []interface{}{
+		&contact.Phone, &contact.City, &contact.Region, &contact.Country,
+		&contact.LinkedInURL, &contact.GitHubURL, &contact.GitLabURL, &contact.StackOverflowURL, &contact.WebsiteURL,
+	}
+}
+
 func (r *employeeRepository) Delete(id int) error {
 	query := r.MustGetQuery("delete_employee")
 	_, err := r.db.Exec(query, id)
@@ -668,9 +882,21 @@ func (r *employeeRepository) GetEmployeesWithSkills(skillNames []string) ([]mode
 		})
 	}
 
+	// Attach spoken languages and certifications for the search filters
+	languages, err := r.getAllLanguages()
+	if err != nil {
+		return nil, err
+	}
+	certifications, err := r.getAllCertifications()
+	if err != nil {
+		return nil, err
+	}
+
 	// Convert map to slice
 	var employees []models.Employee
 	for _, employee := range employeeMap {
+		employee.Languages = languages[employee.ID]
+		employee.Certifications = certifications[employee.ID]
 		employees = append(employees, *employee)
 	}
 
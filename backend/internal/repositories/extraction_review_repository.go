@@ -0,0 +1,187 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"stafind-backend/internal/models"
+)
+
+type extractionReviewRepository struct {
+	*BaseRepository
+}
+
+// NewExtractionReviewRepository creates a new extraction review repository
+func NewExtractionReviewRepository(db *sql.DB) (ExtractionReviewRepository, error) {
+	baseRepo, err := NewBaseRepository(db)
+	if err != nil {
+		return nil, err
+	}
+
+	return &extractionReviewRepository{BaseRepository: baseRepo}, nil
+}
+
+// Create holds an extraction for review, filling in its ID, status and timestamps
+func (r *extractionReviewRepository) Create(review *models.ExtractionReview) error {
+	query := r.MustGetQuery("create_extraction_review")
+
+	reasonsJSON, err := json.Marshal(review.Reasons)
+	if err != nil {
+		return fmt.Errorf("failed to marshal review reasons: %w", err)
+	}
+	dataJSON, err := json.Marshal(review.ExtractedData)
+	if err != nil {
+		return fmt.Errorf("failed to marshal extracted data: %w", err)
+	}
+
+	err = r.db.QueryRow(query, reasonsJSON, review.ConfidenceScore, review.CandidateName, review.ExtractionSource,
+		review.ResumeURL, review.OriginalText, dataJSON).
+		Scan(&review.ID, &review.Status, &review.CreatedAt, &review.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create extraction review: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves held extractions by status without their text, oldest first
+func (r *extractionReviewRepository) List(status string) ([]models.ExtractionReview, error) {
+	query := r.MustGetQuery("list_extraction_reviews")
+
+	rows, err := r.db.Query(query, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get extraction reviews: %w", err)
+	}
+	defer rows.Close()
+
+	reviews := []models.ExtractionReview{}
+	for rows.Next() {
+		review, err := scanExtractionReview(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan extraction review: %w", err)
+		}
+		reviews = append(reviews, *review)
+	}
+
+	return reviews, rows.Err()
+}
+
+// GetByID retrieves a held extraction
+func (r *extractionReviewRepository) GetByID(id int) (*models.ExtractionReview, error) {
+	query := r.MustGetQuery("get_extraction_review")
+
+	review, err := scanExtractionReview(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("extraction review not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get extraction review: %w", err)
+	}
+
+	return review, nil
+}
+
+// UpdateData replaces the data of a pending extraction and reports whether it was pending
+func (r *extractionReviewRepository) UpdateData(id int, data *models.ProcessedResumeData) (bool, error) {
+	query := r.MustGetQuery("update_extraction_review_data")
+
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal extracted data: %w", err)
+	}
+
+	result, err := r.db.Exec(query, id, dataJSON, data.CandidateName)
+	if err != nil {
+		return false, fmt.Errorf("failed to update extraction review: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	return rows > 0, err
+}
+
+// Resolve approves or rejects a pending extraction and reports whether it was pending, so
+// that only one reviewer resolves it
+func (r *extractionReviewRepository) Resolve(id int, status, reviewedBy, notes string) (bool, error) {
+	query := r.MustGetQuery("resolve_extraction_review")
+
+	result, err := r.db.Exec(query, id, status, reviewedBy, notes)
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve extraction review: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	return rows > 0, err
+}
+
+// Reopen returns an extraction to the queue
+func (r *extractionReviewRepository) Reopen(id int) error {
+	query := r.MustGetQuery("reopen_extraction_review")
+
+	if _, err := r.db.Exec(query, id); err != nil {
+		return fmt.Errorf("failed to reopen extraction review: %w", err)
+	}
+
+	return nil
+}
+
+// SetEmployee links an approved extraction to the employee stored from it
+func (r *extractionReviewRepository) SetEmployee(id, employeeID int) error {
+	query := r.MustGetQuery("set_extraction_review_employee")
+
+	if _, err := r.db.Exec(query, id, employeeID); err != nil {
+		return fmt.Errorf("failed to link extraction review to employee: %w", err)
+	}
+
+	return nil
+}
+
+// scanExtractionReview scans a held extraction from a row or rows
+func scanExtractionReview(row interface{ Scan(...interface{}) error }) (*models.ExtractionReview, error) {
+	var review models.ExtractionReview
+	var reasonsJSON, dataJSON []byte
+	var confidence sql.NullFloat64
+	var candidateName, source, resumeURL, reviewedBy, notes, originalText sql.NullString
+	var employeeID sql.NullInt64
+	var reviewedAt sql.NullTime
+	err := row.Scan(
+		&review.ID,
+		&review.Status,
+		&reasonsJSON,
+		&confidence,
+		&candidateName,
+		&source,
+		&resumeURL,
+		&dataJSON,
+		&employeeID,
+		&reviewedBy,
+		&notes,
+		&reviewedAt,
+		&review.CreatedAt,
+		&review.UpdatedAt,
+		&originalText,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(reasonsJSON, &review.Reasons); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(dataJSON, &review.ExtractedData); err != nil {
+		return nil, err
+	}
+	review.ConfidenceScore = confidence.Float64
+	review.CandidateName = candidateName.String
+	review.ExtractionSource = source.String
+	review.ResumeURL = resumeURL.String
+	review.ReviewedBy = reviewedBy.String
+	review.ReviewNotes = notes.String
+	review.OriginalText = originalText.String
+	if employeeID.Valid {
+		id := int(employeeID.Int64)
+		review.EmployeeID = &id
+	}
+	if reviewedAt.Valid {
+		review.ReviewedAt = &reviewedAt.Time
+	}
+
+	return &review, nil
+}
@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"stafind-backend/internal/models"
+	"time"
+)
+
+type huggingFaceCacheRepository struct {
+	*BaseRepository
+}
+
+// NewHuggingFaceCacheRepository creates a new Hugging Face extraction cache repository
+func NewHuggingFaceCacheRepository(db *sql.DB) (HuggingFaceCacheRepository, error) {
+	baseRepo, err := NewBaseRepository(db)
+	if err != nil {
+		return nil, err
+	}
+
+	return &huggingFaceCacheRepository{BaseRepository: baseRepo}, nil
+}
+
+// Get retrieves an unexpired cached extraction by key
+func (r *huggingFaceCacheRepository) Get(key string) (*models.HuggingFaceCacheEntry, error) {
+	query := r.MustGetQuery("get_huggingface_cache_entry")
+
+	entry := &models.HuggingFaceCacheEntry{}
+	err := r.db.QueryRow(query, key).Scan(
+		&entry.CacheKey,
+		&entry.ModelName,
+		&entry.Response,
+		&entry.CreatedAt,
+		&entry.ExpiresAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("hugging face cache entry not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get hugging face cache entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+// Put stores an extraction, replacing an older one with the same key
+func (r *huggingFaceCacheRepository) Put(key, modelName string, response []byte, expiresAt time.Time) error {
+	query := r.MustGetQuery("upsert_huggingface_cache_entry")
+
+	_, err := r.db.Exec(query, key, modelName, response, expiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to store hugging face cache entry: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExpired removes expired entries and returns how many were deleted
+func (r *huggingFaceCacheRepository) DeleteExpired() (int64, error) {
+	query := r.MustGetQuery("delete_expired_huggingface_cache_entries")
+
+	result, err := r.db.Exec(query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired hugging face cache entries: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+// DeleteAll removes every entry and returns how many were deleted
+func (r *huggingFaceCacheRepository) DeleteAll() (int64, error) {
+	query := r.MustGetQuery("delete_all_huggingface_cache_entries")
+
+	result, err := r.db.Exec(query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete hugging face cache entries: %w", err)
+	}
+
+	return result.RowsAffected()
+}
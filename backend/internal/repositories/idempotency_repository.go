@@ -0,0 +1,122 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"stafind-backend/internal/models"
+	"time"
+)
+
+type idempotencyRepository struct {
+	*BaseRepository
+}
+
+// NewIdempotencyRepository creates a new idempotency key repository
+func NewIdempotencyRepository(db *sql.DB) (IdempotencyRepository, error) {
+	baseRepo, err := NewBaseRepository(db)
+	if err != nil {
+		return nil, err
+	}
+
+	return &idempotencyRepository{BaseRepository: baseRepo}, nil
+}
+
+// Reserve claims a key for a new request under token. It returns false when an unexpired record
+// already exists, unless that record is still processing and was reserved before staleBefore.
+func (r *idempotencyRepository) Reserve(key, scope, fingerprint, token string, expiresAt, staleBefore time.Time) (bool, error) {
+	query := r.MustGetQuery("reserve_idempotency_key")
+
+	var id int
+	err := r.db.QueryRow(query, key, scope, fingerprint, expiresAt, staleBefore, token).Scan(&id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+
+	return true, nil
+}
+
+// Get retrieves an idempotency record by key and scope
+func (r *idempotencyRepository) Get(key, scope string) (*models.IdempotencyRecord, error) {
+	query := r.MustGetQuery("get_idempotency_key")
+
+	record := &models.IdempotencyRecord{}
+	var responseStatus sql.NullInt64
+	var contentType sql.NullString
+	var completedAt sql.NullTime
+
+	err := r.db.QueryRow(query, key, scope).Scan(
+		&record.ID,
+		&record.IdempotencyKey,
+		&record.Scope,
+		&record.RequestFingerprint,
+		&record.Status,
+		&responseStatus,
+		&contentType,
+		&record.ResponseBody,
+		&record.CreatedAt,
+		&completedAt,
+		&record.ExpiresAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("idempotency key not found")
+		}
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+
+	if responseStatus.Valid {
+		status := int(responseStatus.Int64)
+		record.ResponseStatus = &status
+	}
+	if contentType.Valid {
+		record.ResponseContentType = &contentType.String
+	}
+	if completedAt.Valid {
+		record.CompletedAt = &completedAt.Time
+	}
+
+	return record, nil
+}
+
+// Complete stores the response for a key reserved under token. It returns false when the
+// reservation is no longer the key's, because a retry took the key over.
+func (r *idempotencyRepository) Complete(key, scope, token string, responseStatus int, contentType string, body []byte) (bool, error) {
+	query := r.MustGetQuery("complete_idempotency_key")
+
+	result, err := r.db.Exec(query, key, scope, token, responseStatus, contentType, body)
+	if err != nil {
+		return false, fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// Delete releases a key reserved under token. It returns false when the reservation is no
+// longer the key's.
+func (r *idempotencyRepository) Delete(key, scope, token string) (bool, error) {
+	query := r.MustGetQuery("delete_idempotency_key")
+
+	result, err := r.db.Exec(query, key, scope, token)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete idempotency key: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+// DeleteExpired removes expired records and returns how many were deleted
+func (r *idempotencyRepository) DeleteExpired() (int64, error) {
+	query := r.MustGetQuery("delete_expired_idempotency_keys")
+
+	result, err := r.db.Exec(query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+
+	return result.RowsAffected()
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"stafind-backend/internal/models"
+	"time"
 )
 
 // EmployeeRepository defines the interface for employee data operations
@@ -15,6 +16,7 @@ type EmployeeRepository interface {
 	UpdateWithExtraction(id int, req *models.CreateEmployeeRequest, originalText string, extractedData map[string]interface{}, extractionSource, extractionStatus, resumeURL string) (*models.Employee, error)
 	Delete(id int) error
 	GetSkills(employeeID int) ([]models.Skill, error)
+	GetLanguages(employeeID int) ([]models.SpokenLanguage, error)
 	AddSkill(employeeID int, skillReq *models.EmployeeSkillReq) error
 	RemoveSkills(employeeID int) error
 	GetEmployeesWithSkills(skillNames []string) ([]models.Employee, error)
@@ -57,6 +59,8 @@ type SkillRepository interface {
 	RemoveSkillFromCategory(skillID, categoryID int) error
 	GetSkillCategories(skillID int) ([]models.Category, error)
 	AssociateCategories(skillID int, categoryIDs []int) error
+	GetSkillAliases() (map[int][]string, error)
+	AddSkillAlias(skillID int, alias string) error
 }
 
 // MatchRepository defines the interface for match data operations
@@ -89,4 +93,107 @@ type APIKeyRepository interface {
 	Deactivate(id int) error
 	UpdateLastUsed(hash string) error
 	Delete(id int) error
+	SetSigningSecret(id int, secret string) error
+	GetSigningSecret(id int) (string, error)
+	HasSigningSecrets() (bool, error)
+}
+
+// IdempotencyRepository defines the interface for idempotency key data operations
+type IdempotencyRepository interface {
+	Reserve(key, scope, fingerprint, token string, expiresAt, staleBefore time.Time) (bool, error)
+	Get(key, scope string) (*models.IdempotencyRecord, error)
+	Complete(key, scope, token string, responseStatus int, contentType string, body []byte) (bool, error)
+	Delete(key, scope, token string) (bool, error)
+	DeleteExpired() (int64, error)
+}
+
+// DriveSyncRepository defines the interface for Google Drive sync state data operations
+type DriveSyncRepository interface {
+	GetFolder(folderID string) (*models.GoogleDriveSyncFolder, error)
+	UpsertFolder(folder *models.GoogleDriveSyncFolder) error
+	GetFile(fileID string) (*models.GoogleDriveSyncFile, error)
+	UpsertFile(file *models.GoogleDriveSyncFile) error
+}
+
+// HuggingFaceCacheRepository defines the interface for persisted Hugging Face extraction results
+type HuggingFaceCacheRepository interface {
+	Get(key string) (*models.HuggingFaceCacheEntry, error)
+	Put(key, modelName string, response []byte, expiresAt time.Time) error
+	DeleteExpired() (int64, error)
+	DeleteAll() (int64, error)
+}
+
+// EmployeeDuplicateRepository defines the interface for duplicate employee detection and merges
+type EmployeeDuplicateRepository interface {
+	ListIdentityProfiles() ([]models.EmployeeIdentityProfile, error)
+	AddIdentities(employeeID int, identities []models.EmployeeIdentity) error
+	UpsertDuplicate(employeeID, duplicateID int, score float64, reasons []string) error
+	ListDuplicates(status string) ([]models.EmployeeDuplicate, error)
+	GetDuplicate(id int) (*models.EmployeeDuplicate, error)
+	UpdateDuplicateStatus(id int, status string) error
+	Merge(targetID, sourceID int, merge *models.EmployeeMerge) (*models.EmployeeMergeResult, error)
+	ListMerges(employeeID int) ([]models.EmployeeMerge, error)
+}
+
+// EmployeeExtractionRepository defines the interface for the extraction history of employees
+type EmployeeExtractionRepository interface {
+	Create(extraction *models.EmployeeExtraction) error
+	ListByEmployee(employeeID int) ([]models.EmployeeExtraction, error)
+	GetVersion(employeeID, version int) (*models.EmployeeExtraction, error)
+	GetLatest(employeeID int) (*models.EmployeeExtraction, error)
+}
+
+// ExtractionReviewRepository defines the interface for extractions held for review
+type ExtractionReviewRepository interface {
+	Create(review *models.ExtractionReview) error
+	List(status string) ([]models.ExtractionReview, error)
+	GetByID(id int) (*models.ExtractionReview, error)
+	UpdateData(id int, data *models.ProcessedResumeData) (bool, error)
+	Resolve(id int, status, reviewedBy, notes string) (bool, error)
+	Reopen(id int) error
+	SetEmployee(id, employeeID int) error
+}
+
+// SkillDiscoveryRepository defines the interface for terms that look like skills but are not
+// in the skill catalog
+type SkillDiscoveryRepository interface {
+	RecordTerm(employeeID int, key string, term models.UnknownSkillTerm) error
+	ListTerms(status string, limit int) ([]models.DiscoveredSkillTerm, error)
+	GetTerm(id int) (*models.DiscoveredSkillTerm, error)
+	ResolveTerm(id int, status string, skillID *int, resolvedBy string) (bool, error)
+	ListTermEmployees(id int) ([]int, error)
+}
+
+// ReextractionRepository defines the interface for the employees and outcomes of re-extraction
+// runs
+type ReextractionRepository interface {
+	ListCandidates(filter models.ReextractionFilter, cvExtractID int) ([]int, error)
+	SaveResult(cvExtractID int, result *models.ReextractionResult) error
+	ListResults(cvExtractID int) ([]models.ReextractionResult, error)
+}
+
+// CertificationRepository defines the interface for the certification catalogue and the
+// certifications employees hold
+type CertificationRepository interface {
+	ListCatalog() ([]models.Certification, error)
+	GetCatalogEntry(id int) (*models.Certification, error)
+	CreateCatalogEntry(certification *models.Certification) error
+	UpdateCatalogEntry(certification *models.Certification) error
+	DeleteCatalogEntry(id int) (bool, error)
+	ListByEmployee(employeeID int) ([]models.EmployeeCertification, error)
+	Get(employeeID, id int) (*models.EmployeeCertification, error)
+	Create(certification *models.EmployeeCertification) error
+	Update(certification *models.EmployeeCertification) (bool, error)
+	Delete(employeeID, id int) (bool, error)
+	SyncResumeCertifications(employeeID int, certifications []models.EmployeeCertification) error
+	ClaimExpiring(days int) ([]models.ExpiringCertification, error)
+	ReleaseExpiring(ids []int) error
+}
+
+// EmployeeBackgroundRepository defines the interface for employees' work history, education and
+// projects
+type EmployeeBackgroundRepository interface {
+	Get(employeeID int) (*models.EmployeeBackground, error)
+	GetAllSearchable() (map[int]*models.EmployeeBackground, error)
+	Replace(employeeID int, background *models.EmployeeBackground) error
 }
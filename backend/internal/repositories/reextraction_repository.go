@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"stafind-backend/internal/models"
+)
+
+type reextractionRepository struct {
+	*BaseRepository
+}
+
+// NewReextractionRepository creates a new re-extraction run repository
+func NewReextractionRepository(db *sql.DB) (ReextractionRepository, error) {
+	baseRepo, err := NewBaseRepository(db)
+	if err != nil {
+		return nil, err
+	}
+
+	return &reextractionRepository{BaseRepository: baseRepo}, nil
+}
+
+// ListCandidates retrieves the IDs of the employees matching a filter that have no result in
+// the run yet
+func (r *reextractionRepository) ListCandidates(filter models.ReextractionFilter, cvExtractID int) ([]int, error) {
+	query := r.MustGetQuery("list_reextraction_candidates")
+
+	rows, err := r.db.Query(query, filter.Source, filter.ExtractorVersionBelow, filter.ExtractedAfter, filter.ExtractedBefore, cvExtractID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get re-extraction candidates: %w", err)
+	}
+	defer rows.Close()
+
+	var employeeIDs []int
+	for rows.Next() {
+		var employeeID int
+		if err := rows.Scan(&employeeID); err != nil {
+			return nil, fmt.Errorf("failed to scan re-extraction candidate: %w", err)
+		}
+		employeeIDs = append(employeeIDs, employeeID)
+	}
+
+	return employeeIDs, rows.Err()
+}
+
+// SaveResult stores the outcome of re-extracting an employee, replacing an earlier one
+func (r *reextractionRepository) SaveResult(cvExtractID int, result *models.ReextractionResult) error {
+	query := r.MustGetQuery("save_reextraction_result")
+
+	var changesJSON []byte
+	if result.Changes != nil {
+		var err error
+		changesJSON, err = json.Marshal(result.Changes)
+		if err != nil {
+			return fmt.Errorf("failed to marshal re-extraction changes: %w", err)
+		}
+	}
+
+	if _, err := r.db.Exec(query, cvExtractID, result.EmployeeID, result.Status, changesJSON, result.ErrorMessage); err != nil {
+		return fmt.Errorf("failed to save re-extraction result: %w", err)
+	}
+	return nil
+}
+
+// ListResults retrieves the outcomes of a run by employee
+func (r *reextractionRepository) ListResults(cvExtractID int) ([]models.ReextractionResult, error) {
+	query := r.MustGetQuery("list_reextraction_results")
+
+	rows, err := r.db.Query(query, cvExtractID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get re-extraction results: %w", err)
+	}
+	defer rows.Close()
+
+	results := []models.ReextractionResult{}
+	for rows.Next() {
+		var result models.ReextractionResult
+		var changesJSON []byte
+		var errorMessage sql.NullString
+		if err := rows.Scan(&result.EmployeeID, &result.EmployeeName, &result.Status, &changesJSON, &errorMessage, &result.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan re-extraction result: %w", err)
+		}
+		if changesJSON != nil {
+			if err := json.Unmarshal(changesJSON, &result.Changes); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal re-extraction changes: %w", err)
+			}
+		}
+		result.ErrorMessage = errorMessage.String
+		results = append(results, result)
+	}
+
+	return results, rows.Err()
+}
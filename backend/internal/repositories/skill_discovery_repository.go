@@ -0,0 +1,158 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"stafind-backend/internal/models"
+
+	"github.com/lib/pq"
+)
+
+type skillDiscoveryRepository struct {
+	*BaseRepository
+}
+
+// NewSkillDiscoveryRepository creates a new discovered skill term repository
+func NewSkillDiscoveryRepository(db *sql.DB) (SkillDiscoveryRepository, error) {
+	baseRepo, err := NewBaseRepository(db)
+	if err != nil {
+		return nil, err
+	}
+
+	return &skillDiscoveryRepository{BaseRepository: baseRepo}, nil
+}
+
+// RecordTerm counts a term found in an employee's resume under its normalized key and links
+// the term to the employee
+func (r *skillDiscoveryRepository) RecordTerm(employeeID int, key string, term models.UnknownSkillTerm) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var termID int
+	err = tx.QueryRow(r.MustGetQuery("record_discovered_skill_term"), term.Term, key, term.Source, term.Context).Scan(&termID)
+	if err != nil {
+		return fmt.Errorf("failed to record skill term: %w", err)
+	}
+
+	if _, err := tx.Exec(r.MustGetQuery("link_discovered_skill_term_employee"), termID, employeeID); err != nil {
+		return fmt.Errorf("failed to link skill term to employee: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// ListTerms retrieves discovered terms by status, most frequent first
+func (r *skillDiscoveryRepository) ListTerms(status string, limit int) ([]models.DiscoveredSkillTerm, error) {
+	query := r.MustGetQuery("list_discovered_skill_terms")
+
+	rows, err := r.db.Query(query, status, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get skill terms: %w", err)
+	}
+	defer rows.Close()
+
+	terms := []models.DiscoveredSkillTerm{}
+	for rows.Next() {
+		term, err := scanDiscoveredSkillTerm(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan skill term: %w", err)
+		}
+		terms = append(terms, *term)
+	}
+
+	return terms, rows.Err()
+}
+
+// GetTerm retrieves a discovered term
+func (r *skillDiscoveryRepository) GetTerm(id int) (*models.DiscoveredSkillTerm, error) {
+	query := r.MustGetQuery("get_discovered_skill_term")
+
+	term, err := scanDiscoveredSkillTerm(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("skill term not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get skill term: %w", err)
+	}
+
+	return term, nil
+}
+
+// ResolveTerm sets the status of a pending or ignored term, with the skill it became or maps
+// to, and reports whether the term could still be resolved
+func (r *skillDiscoveryRepository) ResolveTerm(id int, status string, skillID *int, resolvedBy string) (bool, error) {
+	query := r.MustGetQuery("resolve_discovered_skill_term")
+
+	result, err := r.db.Exec(query, id, status, skillID, resolvedBy)
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve skill term: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	return rows > 0, err
+}
+
+// ListTermEmployees retrieves the IDs of the employees whose resumes mention a term
+func (r *skillDiscoveryRepository) ListTermEmployees(id int) ([]int, error) {
+	query := r.MustGetQuery("list_discovered_skill_term_employees")
+
+	rows, err := r.db.Query(query, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get skill term employees: %w", err)
+	}
+	defer rows.Close()
+
+	var employeeIDs []int
+	for rows.Next() {
+		var employeeID int
+		if err := rows.Scan(&employeeID); err != nil {
+			return nil, fmt.Errorf("failed to scan skill term employee: %w", err)
+		}
+		employeeIDs = append(employeeIDs, employeeID)
+	}
+
+	return employeeIDs, rows.Err()
+}
+
+// scanDiscoveredSkillTerm scans a discovered term from a row or rows
+func scanDiscoveredSkillTerm(row interface{ Scan(...interface{}) error }) (*models.DiscoveredSkillTerm, error) {
+	var term models.DiscoveredSkillTerm
+	var examplesJSON []byte
+	var skillID sql.NullInt64
+	var resolvedBy sql.NullString
+	var resolvedAt sql.NullTime
+	err := row.Scan(
+		&term.ID,
+		&term.Term,
+		&term.Status,
+		&term.Occurrences,
+		&term.EmployeeCount,
+		pq.Array(&term.Sources),
+		&examplesJSON,
+		&skillID,
+		&resolvedBy,
+		&resolvedAt,
+		&term.FirstSeenAt,
+		&term.LastSeenAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(examplesJSON, &term.Examples); err != nil {
+		return nil, err
+	}
+	if skillID.Valid {
+		id := int(skillID.Int64)
+		term.SkillID = &id
+	}
+	term.ResolvedBy = resolvedBy.String
+	if resolvedAt.Valid {
+		term.ResolvedAt = &resolvedAt.Time
+	}
+
+	return &term, nil
+}
@@ -416,6 +416,35 @@ func (r *skillRepository) GetSkillsWithCategories() ([]models.Skill, error) {
 	return skills, nil
 }
 
+// GetSkillAliases retrieves the aliases of every skill that has any, by skill ID
+func (r *skillRepository) GetSkillAliases() (map[int][]string, error) {
+	query := r.MustGetQuery("get_skill_aliases")
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	aliases := make(map[int][]string)
+	for rows.Next() {
+		var skillID int
+		var alias string
+		if err := rows.Scan(&skillID, &alias); err != nil {
+			return nil, err
+		}
+		aliases[skillID] = append(aliases[skillID], alias)
+	}
+
+	return aliases, rows.Err()
+}
+
+// AddSkillAlias adds another name for a skill
+func (r *skillRepository) AddSkillAlias(skillID int, alias string) error {
+	query := r.MustGetQuery("add_skill_alias")
+	_, err := r.db.Exec(query, skillID, alias)
+	return err
+}
+
 func (r *skillRepository) AddSkillToCategory(skillID, categoryID int) error {
 	query := r.MustGetQuery("add_skill_to_category")
 	_, err := r.db.Exec(query, skillID, categoryID)
@@ -0,0 +1,196 @@
+package resumeparser
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"stafind-backend/internal/models"
+)
+
+var (
+	credentialPattern = regexp.MustCompile(`(?i)\b(?:credential(?:\s+id)?|certificate\s+(?:id|no\.?|number)|license\s+(?:id|no\.?|number)|cert(?:ificado)?\s*(?:id|#)|id\s+de\s+(?:la\s+)?credencial|n[ºo°.]*\s+de\s+(?:credencial|certificado))\s*[:#]?\s*([A-Za-z0-9][A-Za-z0-9\-_/.]{2,})`)
+	expiresPattern    = regexp.MustCompile(`(?i)\b(?:expires?|expired|expiration(?:\s+date)?|expiry|valid\s+(?:until|through|thru|to)|vence|vencimiento|caduca|caducidad|expira|v[aá]lid[oa]\s+hasta)\s*(?:on|:|el|en)?\s*(` + datePattern + `)`)
+	issuedPattern     = regexp.MustCompile(`(?i)\b(?:issued|obtained|earned|achieved|awarded|certified|emitid[oa]|expedid[oa]|obtenid[oa]|fecha\s+de\s+(?:emisi[oó]n|expedici[oó]n))\s*(?:on|in|:|el|en)?\s*(` + datePattern + `)`)
+	loneDatePattern   = regexp.MustCompile(`(?i)\b` + datePattern + `\b`)
+	noExpiryPattern   = regexp.MustCompile(`(?i)\b(?:no\s+expiration(?:\s+date)?|does\s+not\s+expire|sin\s+(?:fecha\s+de\s+)?(?:caducidad|vencimiento))\b`)
+	credentialLink    = regexp.MustCompile(`(?i)\b(?:see|show|view|ver|mostrar)\s+(?:credential|credencial)\b`)
+	parenthesized     = regexp.MustCompile(`\s*\(([^()]*)\)`)
+	certSeparators    = regexp.MustCompile(`\s*(?:\||·|•|,|;|\s[-–—]\s)\s*|\s+(?:by|from|por)\s+`)
+)
+
+// certificationIssuers are organisations that issue certifications, folded, as resumes write them
+var certificationIssuers = map[string]bool{
+	"amazon web services": true, "amazon web services aws": true, "aws": true, "amazon": true,
+	"microsoft": true, "google": true, "google cloud": true, "scrum org": true, "scrum alliance": true,
+	"project management institute": true, "pmi": true, "the linux foundation": true, "linux foundation": true,
+	"cncf": true, "cloud native computing foundation": true, "hashicorp": true, "oracle": true, "cisco": true,
+	"comptia": true, "isc": true, "isc2": true, "isc 2": true, "isaca": true, "axelos": true, "peoplecert": true,
+	"red hat": true, "salesforce": true, "databricks": true, "snowflake": true, "mongodb": true,
+	"scaled agile": true, "scaled agile inc": true, "istqb": true, "ibm": true, "vmware": true,
+	"atlassian": true, "coursera": true, "udemy": true, "edx": true, "platzi": true, "linkedin learning": true,
+}
+
+// certificationSkillLabels label the skills a certification covers, folded
+var certificationSkillLabels = map[string]bool{
+	"skills": true, "aptitudes": true, "habilidades": true, "competencias": true,
+}
+
+// certificationWords mark the name of a certification rather than of its issuer
+var certificationWords = map[string]bool{
+	"certified": true, "certificate": true, "certification": true, "certificado": true, "certificacion": true,
+	"associate": true, "professional": true, "practitioner": true, "specialist": true, "expert": true,
+	"master": true, "developer": true, "administrator": true, "engineer": true, "architect": true,
+}
+
+// issuerWords mark an organisation name, in English and Spanish
+var issuerWords = map[string]bool{
+	"institute": true, "university": true, "academy": true, "association": true,
+	"alliance": true, "council": true, "inc": true, "ltd": true, "llc": true, "fundacion": true,
+	"instituto": true, "universidad": true, "academia": true, "asociacion": true,
+}
+
+// parseCertifications reads the lines of a certifications section into certifications with their
+// issuer, credential ID and dates. Lines holding only an issuer, dates or a credential ID, as in
+// LinkedIn exports, complete the certification above them.
+func parseCertifications(lines []string, now time.Time) []models.ResumeCertification {
+	var certifications []models.ResumeCertification
+	for _, line := range lines {
+		text := stripBullet(line)
+		if text == "" || (credentialLink.MatchString(text) && len(strings.Fields(text)) <= 3) {
+			continue
+		}
+		// Skills listed under a certification, as in LinkedIn exports
+		if match := labelPattern.FindStringSubmatch(text); match != nil && certificationSkillLabels[normalizeText(match[1])] {
+			continue
+		}
+
+		certification := parseCertification(text, now)
+		if len(certifications) > 0 {
+			previous := &certifications[len(certifications)-1]
+			if certification.Name == "" {
+				completeCertification(previous, certification)
+				continue
+			}
+			if previous.Issuer == "" && isIssuer(certification.Name) {
+				previous.Issuer = certification.Name
+				certification.Issuer = ""
+				completeCertification(previous, certification)
+				continue
+			}
+		}
+		if certification.Name != "" && !isIssuer(certification.Name) {
+			certifications = append(certifications, certification)
+		}
+	}
+	return certifications
+}
+
+// parseCertification reads one line, such as "AWS Certified Developer – Associate, Amazon Web
+// Services (Mar 2022 - Mar 2025), Credential ID ABC123". The name is what remains once the
+// issuer, dates and credential ID are taken out.
+func parseCertification(text string, now time.Time) models.ResumeCertification {
+	var certification models.ResumeCertification
+
+	if match := credentialPattern.FindStringSubmatchIndex(text); match != nil {
+		certification.CredentialID = strings.TrimRight(text[match[2]:match[3]], ".")
+		text = text[:match[0]] + " " + text[match[1]:]
+	}
+	text = noExpiryPattern.ReplaceAllString(text, " ")
+	if match := expiresPattern.FindStringSubmatchIndex(text); match != nil {
+		certification.ExpiresOn = formatCertificationDate(text[match[2]:match[3]])
+		text = text[:match[0]] + " " + text[match[1]:]
+	}
+	if match := issuedPattern.FindStringSubmatchIndex(text); match != nil {
+		certification.IssuedOn = formatCertificationDate(text[match[2]:match[3]])
+		text = text[:match[0]] + " " + text[match[1]:]
+	}
+	if certification.IssuedOn == "" && certification.ExpiresOn == "" {
+		if r, found := FindDateRange(text, now); found {
+			certification.IssuedOn = r.FormatStart()
+			certification.ExpiresOn = r.FormatEnd()
+			text = strings.Replace(text, r.Text, " ", 1)
+		}
+	}
+	if certification.IssuedOn == "" {
+		if dates := loneDatePattern.FindAllStringIndex(text, -1); len(dates) > 0 {
+			last := dates[len(dates)-1]
+			certification.IssuedOn = formatCertificationDate(text[last[0]:last[1]])
+			text = text[:last[0]] + " " + text[last[1]:]
+		}
+	}
+	text = credentialLink.ReplaceAllString(text, " ")
+
+	// An issuer in parentheses, as in "Certified ScrumMaster (Scrum Alliance)"
+	text = parenthesized.ReplaceAllStringFunc(text, func(group string) string {
+		inner := strings.TrimSpace(strings.Trim(strings.TrimSpace(group), "()"))
+		switch {
+		case inner == "":
+			return ""
+		case certification.Issuer == "" && looksLikeIssuer(inner):
+			certification.Issuer = inner
+			return ""
+		}
+		return group
+	})
+
+	// The name comes first; an issuer may follow it after a separator
+	var name []string
+	for i, piece := range certSeparators.Split(text, -1) {
+		piece = trimSeparators(piece)
+		switch {
+		case piece == "":
+		case i > 0 && certification.Issuer == "" && looksLikeIssuer(piece):
+			certification.Issuer = piece
+		default:
+			name = append(name, piece)
+		}
+	}
+	certification.Name = strings.Join(name, " - ")
+
+	return certification
+}
+
+// completeCertification fills the fields of a certification that a following line gives
+func completeCertification(certification *models.ResumeCertification, more models.ResumeCertification) {
+	if certification.Issuer == "" {
+		certification.Issuer = more.Issuer
+	}
+	if certification.CredentialID == "" {
+		certification.CredentialID = more.CredentialID
+	}
+	if certification.IssuedOn == "" {
+		certification.IssuedOn = more.IssuedOn
+	}
+	if certification.ExpiresOn == "" {
+		certification.ExpiresOn = more.ExpiresOn
+	}
+}
+
+// isIssuer reports whether text is the name of a well-known certification issuer
+func isIssuer(text string) bool {
+	return certificationIssuers[strings.Join(strings.FieldsFunc(normalizeText(text), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	}), " ")]
+}
+
+// looksLikeIssuer reports whether text, found next to a certification name, names its issuer:
+// a well-known issuer or a short organisation name such as "Stanford University"
+func looksLikeIssuer(text string) bool {
+	if isIssuer(text) {
+		return true
+	}
+	return len(strings.Fields(text)) <= 6 && hasKeyword(text, issuerWords) && !hasKeyword(text, certificationWords)
+}
+
+// formatCertificationDate returns a date as YYYY-MM, or YYYY when it names no month
+func formatCertificationDate(text string) string {
+	date, hasMonth, ok := parseDate(text)
+	if !ok {
+		return ""
+	}
+	if hasMonth {
+		return date.Format("2006-01")
+	}
+	return date.Format("2006")
+}
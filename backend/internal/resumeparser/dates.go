@@ -0,0 +1,203 @@
+package resumeparser
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateRange is a period such as "Jan 2019 - Present" or "2017-2020"
+type DateRange struct {
+	Text    string    // The matched text as written in the resume
+	Start   time.Time // First day of the start month
+	End     time.Time // First day of the end month; the reference time for ongoing periods
+	Current bool      // The period runs to the present
+
+	monthly bool // The start (and the end, unless ongoing) names a month
+}
+
+// sameYearMonths is the length given to a whole-year range within one year, such as
+// "2019 - 2019": the work fell somewhere in that year, so it counts as half of it
+const sameYearMonths = 6
+
+// Months returns the length of the range in months. Ranges with months count both end
+// months, so "Jan 2019 - Dec 2019" is 12 months; "2017 - 2020" is 36 and "2019 - 2019" is
+// sameYearMonths.
+func (r DateRange) Months() int {
+	if !r.monthly && !r.Current && r.Start.Year() == r.End.Year() {
+		return sameYearMonths
+	}
+	months := (r.End.Year()-r.Start.Year())*12 + int(r.End.Month()) - int(r.Start.Month())
+	if r.monthly {
+		months++
+	}
+	return max(months, 0)
+}
+
+// Years returns the length of the range in years, rounded to one decimal
+func (r DateRange) Years() float64 {
+	return math.Round(float64(r.Months())/12*10) / 10
+}
+
+const (
+	monthPattern = `(?:jan(?:uary)?|feb(?:ruary)?|mar(?:ch)?|apr(?:il)?|may|june?|july?|aug(?:ust)?|sep(?:t(?:ember)?)?|oct(?:ober)?|nov(?:ember)?|dec(?:ember)?|ene(?:ro)?|febrero|marzo|abr(?:il)?|mayo|junio|julio|ago(?:sto)?|set(?:iembre)?|septiembre|octubre|noviembre|dic(?:iembre)?)\.?`
+	yearPattern  = `(?:19|20)\d{2}`
+	datePattern  = `(?:` + monthPattern + `\s*(?:de\s+|[/\-.,']\s*)?` + yearPattern +
+		`|(?:0?[1-9]|1[0-2])\s*[/\-.]\s*` + yearPattern +
+		`|` + yearPattern + `)`
+	presentPattern = `(?:present|current|currently|now|today|ongoing|presente|actualidad|actualmente|actual|hoy|la fecha)`
+)
+
+var (
+	dateRangePattern = regexp.MustCompile(`(?i)\b(` + datePattern + `)\s*(?:-|–|—|~|to|until|till|a|al|hasta)\s*(` + datePattern + `|` + presentPattern + `)\b`)
+	sinceDatePattern = regexp.MustCompile(`(?i)\b(?:since|desde)\s+(` + datePattern + `)\b`)
+	singleDate       = regexp.MustCompile(`(?i)^` + datePattern + `$`)
+	monthNamePattern = regexp.MustCompile(`(?i)^` + monthPattern)
+	yearOnlyPattern  = regexp.MustCompile(yearPattern)
+	numericMonth     = regexp.MustCompile(`^(0?[1-9]|1[0-2])\s*[/\-.]`)
+)
+
+// Month name prefixes in English and Spanish; the longest prefixes are checked first
+var monthPrefixes = []struct {
+	prefix string
+	month  time.Month
+}{
+	{"sept", time.September}, {"set", time.September},
+	{"jan", time.January}, {"ene", time.January},
+	{"feb", time.February},
+	{"mar", time.March},
+	{"apr", time.April}, {"abr", time.April},
+	{"may", time.May},
+	{"jun", time.June},
+	{"jul", time.July},
+	{"aug", time.August}, {"ago", time.August},
+	{"sep", time.September},
+	{"oct", time.October},
+	{"nov", time.November},
+	{"dec", time.December}, {"dic", time.December},
+}
+
+// FindDateRange returns the first date range in a line. Open ranges ("Since 2020",
+// "Desde marzo 2021") run to now.
+func FindDateRange(line string, now time.Time) (DateRange, bool) {
+	if match := dateRangePattern.FindStringSubmatchIndex(line); match != nil {
+		start, startHasMonth, ok := parseDate(line[match[2]:match[3]])
+		if !ok {
+			return DateRange{}, false
+		}
+
+		endText := line[match[4]:match[5]]
+		r := DateRange{Text: line[match[0]:match[1]], Start: start}
+
+		if singleDate.MatchString(strings.TrimSpace(endText)) {
+			end, endHasMonth, ok := parseDate(endText)
+			if !ok {
+				return DateRange{}, false
+			}
+			r.End = end
+			r.monthly = startHasMonth && endHasMonth
+			if !r.monthly {
+				// Whole-year ranges are compared year to year
+				r.Start = time.Date(r.Start.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+				r.End = time.Date(r.End.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+			}
+		} else {
+			r.Current = true
+			r.monthly = startHasMonth
+			r.End = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		}
+
+		if r.End.Before(r.Start) {
+			return DateRange{}, false
+		}
+		return r, true
+	}
+
+	if match := sinceDatePattern.FindStringSubmatchIndex(line); match != nil {
+		start, startHasMonth, ok := parseDate(line[match[2]:match[3]])
+		if !ok {
+			return DateRange{}, false
+		}
+		end := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		if end.Before(start) {
+			return DateRange{}, false
+		}
+		return DateRange{Text: line[match[0]:match[1]], Start: start, End: end, Current: true, monthly: startHasMonth}, true
+	}
+
+	return DateRange{}, false
+}
+
+// FormatStart returns the start as YYYY-MM, or YYYY for whole-year ranges
+func (r DateRange) FormatStart() string {
+	return r.format(r.Start)
+}
+
+// FormatEnd returns the end as YYYY-MM, or YYYY for whole-year ranges. It is empty for
+// ongoing periods.
+func (r DateRange) FormatEnd() string {
+	if r.Current {
+		return ""
+	}
+	return r.format(r.End)
+}
+
+func (r DateRange) format(t time.Time) string {
+	if r.monthly {
+		return t.Format("2006-01")
+	}
+	return t.Format("2006")
+}
+
+// LastYear returns the last four-digit year in a line, e.g. a graduation year
+func LastYear(line string) (int, bool) {
+	years := yearOnlyPattern.FindAllString(line, -1)
+	if len(years) == 0 {
+		return 0, false
+	}
+	year, err := strconv.Atoi(years[len(years)-1])
+	return year, err == nil
+}
+
+// parseDate parses "Jan 2019", "marzo de 2018", "03/2020" or "2017" to the first day of the
+// month (January when only the year is given) and reports whether a month was present
+func parseDate(text string) (time.Time, bool, bool) {
+	text = strings.TrimSpace(normalizeText(text))
+
+	yearText := yearOnlyPattern.FindString(text)
+	year, err := strconv.Atoi(yearText)
+	if err != nil {
+		return time.Time{}, false, false
+	}
+
+	month := time.Month(0)
+	if monthNamePattern.MatchString(text) {
+		for _, m := range monthPrefixes {
+			if strings.HasPrefix(text, m.prefix) {
+				month = m.month
+				break
+			}
+		}
+	} else if match := numericMonth.FindStringSubmatch(text); match != nil {
+		n, _ := strconv.Atoi(match[1])
+		month = time.Month(n)
+	}
+
+	if month == 0 {
+		return time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC), false, true
+	}
+	return time.Date(year, month, 1, 0, 0, 0, 0, time.UTC), true, true
+}
+
+// stripDateRange removes a matched date range and the separators around it from a line
+func stripDateRange(line string, r DateRange) string {
+	if r.Text == "" {
+		return strings.TrimSpace(line)
+	}
+	line = strings.Replace(line, r.Text, "", 1)
+	line = strings.ReplaceAll(line, "()", " ")
+	line = strings.ReplaceAll(line, "[]", " ")
+	return trimSeparators(line)
+}
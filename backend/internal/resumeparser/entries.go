@@ -0,0 +1,537 @@
+package resumeparser
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"stafind-backend/internal/models"
+)
+
+const (
+	maxHeaderWords  = 12 // Longer lines are descriptions
+	maxTitleWords   = 6  // Longer undated lines after a dated header are descriptions
+	maxEntryHeaders = 3  // Header lines of one entry, e.g. role, company and dates
+	maxTermWords    = 5  // Longer list items are sentences, not skill terms
+	maxTermLength   = 40
+)
+
+var (
+	bulletPattern    = regexp.MustCompile(`^(?:[-*•·▪●◦‣–—►✓✔➢>]|\d{1,2}[.)])\s*`)
+	labelPattern     = regexp.MustCompile(`^([^:]{1,30}):\s*(\S.*)$`)
+	headerSeparators = regexp.MustCompile(`\s+[|·•–—-]\s+|\s*\|\s*|,\s+|\t+`)
+	roleCompanySplit = regexp.MustCompile(`(?i)\s+(?:at|@|en)\s+`)
+	degreeFieldSplit = regexp.MustCompile(`(?i)\s+(?:in|en)\s+`)
+	degreeOfSplit    = regexp.MustCompile(`(?i)\s+(?:of|de)\s+`)
+	degreeAbbrev     = regexp.MustCompile(`(?i)^((?:b|m)\.?\s?(?:sc|s|a|eng|tech)\.?|ph\.?\s?d\.?|mba|bba)\s+(\S.*)$`)
+	listSeparators   = ",;|•·"
+)
+
+// Words that mark a job title, in English and Spanish (accents stripped)
+var roleKeywords = map[string]bool{
+	"engineer": true, "developer": true, "programmer": true, "analyst": true, "architect": true,
+	"manager": true, "lead": true, "consultant": true, "intern": true, "internship": true,
+	"designer": true, "scientist": true, "administrator": true, "specialist": true, "tester": true,
+	"qa": true, "devops": true, "sre": true, "director": true, "cto": true, "ceo": true, "cio": true,
+	"vp": true, "coordinator": true, "technician": true, "owner": true, "senior": true, "junior": true,
+	"sr": true, "jr": true, "trainee": true, "founder": true, "officer": true, "assistant": true,
+	"freelance": true, "freelancer": true, "contractor": true, "principal": true, "associate": true,
+	"teacher": true, "support": true, "head": true, "master": true,
+	"desarrollador": true, "desarrolladora": true, "programador": true, "programadora": true,
+	"ingeniero": true, "ingeniera": true, "analista": true, "arquitecto": true, "arquitecta": true,
+	"gerente": true, "lider": true, "consultor": true, "pasante": true, "practicante": true,
+	"becario": true, "becaria": true, "disenador": true, "disenadora": true, "administrador": true,
+	"administradora": true, "especialista": true, "coordinador": true, "coordinadora": true,
+	"tecnico": true, "tecnica": true, "fundador": true, "fundadora": true, "jefe": true, "jefa": true,
+	"responsable": true, "asistente": true, "soporte": true, "docente": true, "profesor": true,
+	"profesora": true, "ayudante": true, "directora": true,
+}
+
+// Words that mark an organisation name
+var companyKeywords = map[string]bool{
+	"inc": true, "llc": true, "ltd": true, "corp": true, "corporation": true, "sa": true, "srl": true,
+	"gmbh": true, "group": true, "grupo": true, "bank": true, "banco": true, "technologies": true,
+	"solutions": true, "labs": true, "software": true, "systems": true, "consulting": true,
+	"consultora": true, "company": true, "studio": true, "agency": true, "agencia": true,
+}
+
+// Words that mark an educational institution
+var institutionKeywords = []string{
+	"university", "universidad", "universitat", "college", "institute", "instituto", "school",
+	"escuela", "facultad", "academy", "academia", "colegio", "polytechnic", "politecnico",
+	"bootcamp", "campus",
+}
+
+// Words that mark a degree or qualification
+var degreeKeywords = []string{
+	"bachelor", "master", "msc", "bsc", "b.s.", "m.s.", "b.sc", "m.sc", "phd", "ph.d", "doctor",
+	"degree", "diploma", "associate", "mba", "licenciatura", "licenciado", "licenciada",
+	"ingenieria", "ingeniero", "ingeniera", "tecnicatura", "tecnico", "grado", "maestria",
+	"posgrado", "postgrado", "doctorado", "bachiller", "bachillerato", "especializacion",
+	"analista", "profesorado", "certificate",
+}
+
+// Words after "of" that belong to the name of a degree, as in "Bachelor of Science"
+var degreeTitleWords = map[string]bool{
+	"science": true, "sciences": true, "arts": true, "engineering": true, "technology": true,
+	"business": true, "laws": true, "philosophy": true, "education": true, "fine": true, "applied": true,
+}
+
+// Labels of project detail lines
+var (
+	projectRoleLabels = map[string]bool{"role": true, "rol": true, "position": true, "puesto": true, "cargo": true}
+	technologyLabels  = map[string]bool{
+		"technologies": true, "technology": true, "tech": true, "tech stack": true, "stack": true,
+		"tools": true, "built with": true, "tecnologias": true, "tecnologia": true, "herramientas": true,
+		"skills": true, "environment": true, "entorno": true,
+	}
+)
+
+// entry is a group of lines describing one position, degree or project
+type entry struct {
+	headers   []string
+	body      []string
+	dates     DateRange
+	hasDates  bool
+	datesOnly bool // Every header so far is only a date range
+}
+
+// groupEntries splits section lines into entries. Short non-bullet lines are headers (role,
+// company, dates); bullets and sentences are the body. A header after a body line, a second
+// date range, or a new title after a dated title starts the next entry. Once an entry has its
+// dates, an undated line is only a title when it starts the headers of a dated entry and reads
+// like a role or company; otherwise it describes the entry, as in "Developed REST APIs with
+// Python and Django".
+func groupEntries(lines []string, now time.Time) []*entry {
+	var (
+		entries []*entry
+		current *entry
+	)
+
+	for i, line := range lines {
+		bullet := bulletPattern.MatchString(line)
+		text := stripBullet(line)
+		if text == "" {
+			continue
+		}
+
+		r, hasDate := FindDateRange(text, now)
+		header := !bullet && isHeaderLike(text, r, hasDate)
+		if header && !hasDate && current != nil && current.hasDates && !current.datesOnly {
+			header = len(strings.Fields(text)) <= maxTitleWords && startsDatedEntry(text, lines[i+1:], now)
+		}
+		if !header {
+			if current == nil {
+				current = &entry{}
+				entries = append(entries, current)
+			}
+			current.body = append(current.body, text)
+			continue
+		}
+
+		if current == nil || len(current.body) > 0 || len(current.headers) >= maxEntryHeaders ||
+			(hasDate && current.hasDates) || (!hasDate && current.hasDates && !current.datesOnly) {
+			current = &entry{datesOnly: true}
+			entries = append(entries, current)
+		}
+
+		current.headers = append(current.headers, text)
+		if hasDate && !current.hasDates {
+			current.dates, current.hasDates = r, true
+		}
+		if !hasDate || stripDateRange(text, r) != "" {
+			current.datesOnly = false
+		}
+	}
+
+	return entries
+}
+
+// startsDatedEntry reports whether an undated title line starts the next entry: a date range
+// must follow in the header lines after it, and the title must name a role or company, unless
+// the date range stands on its own line after the title
+func startsDatedEntry(title string, next []string, now time.Time) bool {
+	for i := 0; i < len(next) && i < maxEntryHeaders-1; i++ {
+		if bulletPattern.MatchString(next[i]) {
+			return false
+		}
+		text := stripBullet(next[i])
+		if text == "" {
+			continue
+		}
+		r, hasDate := FindDateRange(text, now)
+		if !isHeaderLike(text, r, hasDate) {
+			return false
+		}
+		if hasDate {
+			return stripDateRange(text, r) == "" || hasKeyword(title, roleKeywords) || hasKeyword(title, companyKeywords)
+		}
+	}
+	return false
+}
+
+// isHeaderLike reports whether a line reads like an entry heading rather than a description
+func isHeaderLike(text string, r DateRange, hasDate bool) bool {
+	if len(strings.Fields(text)) > maxHeaderWords {
+		return false
+	}
+	// Spanish month names are written in lowercase: "marzo 2015 - diciembre 2018"
+	if hasDate && strings.HasPrefix(text, r.Text) {
+		return true
+	}
+	first, _ := utf8.DecodeRuneInString(text)
+	if unicode.IsLower(first) {
+		return false
+	}
+	if hasDate {
+		return true
+	}
+	if strings.HasSuffix(text, ".") {
+		return false
+	}
+	// "Technologies: Go, Kafka" lines belong to the entry above
+	return !labelPattern.MatchString(text)
+}
+
+// parseExperience turns an experience section into work experience entries
+func parseExperience(lines []string, now time.Time) []models.WorkExperience {
+	var experience []models.WorkExperience
+
+	for _, e := range groupEntries(lines, now) {
+		if len(e.headers) == 0 {
+			// Prose before the first position, e.g. a short introduction
+			continue
+		}
+
+		var pieces []string
+		role, company := "", ""
+		for _, header := range e.headers {
+			if r, ok := FindDateRange(header, now); ok {
+				header = stripDateRange(header, r)
+			}
+			if header == "" {
+				continue
+			}
+
+			for _, piece := range splitHeader(header) {
+				// "Senior Developer at Acme" names both
+				if parts := roleCompanySplit.Split(piece, 2); len(parts) == 2 && role == "" && company == "" &&
+					hasKeyword(parts[0], roleKeywords) {
+					role, company = trimSeparators(parts[0]), trimSeparators(parts[1])
+					continue
+				}
+				pieces = append(pieces, piece)
+			}
+		}
+
+		for _, piece := range pieces {
+			switch {
+			case role == "" && hasKeyword(piece, roleKeywords):
+				role = piece
+			case company == "" && !hasKeyword(piece, roleKeywords):
+				company = piece
+			}
+		}
+		// Without title words, "Title - Company" is the usual order
+		if role == "" && len(pieces) > 1 && company == pieces[0] && !hasKeyword(pieces[0], companyKeywords) {
+			role, company = pieces[0], pieces[1]
+		}
+
+		if role == "" && company == "" {
+			continue
+		}
+
+		position := models.WorkExperience{
+			Company:     company,
+			Role:        role,
+			Description: strings.Join(e.body, "\n"),
+		}
+		if e.hasDates {
+			position.Duration = e.dates.Text
+			position.Years = strconv.FormatFloat(e.dates.Years(), 'f', -1, 64)
+			position.StartDate = e.dates.FormatStart()
+			position.EndDate = e.dates.FormatEnd()
+			position.Current = e.dates.Current
+		}
+		experience = append(experience, position)
+	}
+
+	return experience
+}
+
+// parseEducation turns an education section into education entries. A line naming a second
+// institution or degree starts the next entry.
+func parseEducation(lines []string, now time.Time) []models.Education {
+	var (
+		education []models.Education
+		current   *models.Education
+	)
+
+	for _, line := range lines {
+		text := stripBullet(line)
+		if text == "" {
+			continue
+		}
+		// Bulleted sentences describe the entry above (thesis, coursework, honours)
+		if bulletPattern.MatchString(line) && !isHeaderLike(text, DateRange{}, false) {
+			continue
+		}
+
+		year, startYear := "", ""
+		if r, ok := FindDateRange(text, now); ok {
+			startYear = strconv.Itoa(r.Start.Year())
+			if !r.Current {
+				year = strconv.Itoa(r.End.Year())
+			}
+			text = stripDateRange(text, r)
+		} else if y, ok := LastYear(text); ok {
+			year = strconv.Itoa(y)
+			text = trimSeparators(yearOnlyPattern.ReplaceAllString(text, ""))
+		}
+
+		institution, degree := "", ""
+		for _, piece := range splitHeader(text) {
+			switch {
+			case institution == "" && containsAny(piece, institutionKeywords):
+				institution = piece
+			case degree == "" && containsAny(piece, degreeKeywords):
+				degree = piece
+			}
+		}
+		// "Licenciatura en Sistemas, Universidad de Buenos Aires" may come unsplit
+		if institution == "" && degree == "" && text != "" && current == nil {
+			degree = text
+		}
+
+		if current == nil || (institution != "" && current.Institution != "") || (degree != "" && current.Degree != "") {
+			if institution == "" && degree == "" && year == "" && startYear == "" {
+				continue
+			}
+			education = append(education, models.Education{})
+			current = &education[len(education)-1]
+		}
+
+		if institution != "" {
+			current.Institution = institution
+		}
+		if degree != "" {
+			current.Degree, current.Field = splitDegreeField(degree)
+		}
+		if startYear != "" && current.StartYear == "" {
+			current.StartYear = startYear
+		}
+		if year != "" && current.Year == "" {
+			current.Year = year
+		}
+	}
+
+	return education
+}
+
+// splitDegreeField splits the field of study from a degree: "Bachelor of Science in Computer
+// Science", "BSc Computer Science", "Bachelor of Computer Science" and "Grado en Ingeniería
+// Informática" all name one. The degree is returned whole when it names none.
+func splitDegreeField(degree string) (string, string) {
+	if parts := degreeFieldSplit.Split(degree, 2); len(parts) == 2 && containsAny(parts[0], degreeKeywords) {
+		return trimSeparators(parts[0]), trimSeparators(parts[1])
+	}
+	if match := degreeAbbrev.FindStringSubmatch(degree); match != nil {
+		return match[1], trimSeparators(match[2])
+	}
+	if parts := degreeOfSplit.Split(degree, 2); len(parts) == 2 && containsAny(parts[0], degreeKeywords) {
+		if words := strings.Fields(normalizeText(parts[1])); len(words) > 0 && !degreeTitleWords[words[0]] {
+			return trimSeparators(parts[0]), trimSeparators(parts[1])
+		}
+	}
+	return degree, ""
+}
+
+// parseProjects turns a projects section into project entries
+func parseProjects(lines []string, now time.Time) []models.Project {
+	var projects []models.Project
+
+	for _, e := range groupEntries(lines, now) {
+		if len(e.headers) == 0 {
+			continue
+		}
+
+		project := models.Project{}
+		if e.hasDates {
+			project.Duration = e.dates.Text
+		}
+
+		for _, header := range e.headers {
+			if r, ok := FindDateRange(header, now); ok {
+				header = stripDateRange(header, r)
+			}
+			if header == "" {
+				continue
+			}
+
+			pieces := splitHeader(header)
+			if project.Name == "" {
+				project.Name = pieces[0]
+				pieces = pieces[1:]
+			}
+			for _, piece := range pieces {
+				if project.Role == "" && hasKeyword(piece, roleKeywords) {
+					project.Role = piece
+				}
+			}
+		}
+
+		var description []string
+		for _, line := range e.body {
+			if match := labelPattern.FindStringSubmatch(line); match != nil {
+				label := normalizeHeading(match[1])
+				switch {
+				case projectRoleLabels[label]:
+					project.Role = strings.TrimSpace(match[2])
+					continue
+				case technologyLabels[label]:
+					project.Technologies = append(project.Technologies, splitList(match[2])...)
+					continue
+				}
+			}
+			description = append(description, line)
+		}
+		project.Description = strings.Join(description, "\n")
+
+		if project.Name != "" {
+			projects = append(projects, project)
+		}
+	}
+
+	return projects
+}
+
+// parseListItems returns one item per line, e.g. certifications
+func parseListItems(lines []string) []string {
+	var items []string
+	for _, line := range lines {
+		text := stripBullet(line)
+		if text == "" || singleDate.MatchString(text) {
+			continue
+		}
+		items = append(items, text)
+	}
+	return items
+}
+
+// parseSeparatedItems splits lines on list separators, e.g. "English (C1), Spanish (native)"
+func parseSeparatedItems(lines []string) []string {
+	var items []string
+	for _, line := range lines {
+		items = append(items, splitList(stripBullet(line))...)
+	}
+	return items
+}
+
+// parseSkillTerms returns the short terms listed in a skills section. Group labels such as
+// "Languages:" or "Cloud:" are dropped.
+func parseSkillTerms(lines []string) []string {
+	var terms []string
+	seen := make(map[string]bool)
+
+	for _, line := range lines {
+		text := stripBullet(line)
+		if match := labelPattern.FindStringSubmatch(text); match != nil {
+			text = match[2]
+		}
+
+		for _, term := range splitList(text) {
+			key := normalizeText(term)
+			if seen[key] || len(strings.Fields(term)) > maxTermWords || utf8.RuneCountInString(term) > maxTermLength {
+				continue
+			}
+			seen[key] = true
+			terms = append(terms, term)
+		}
+	}
+
+	return terms
+}
+
+// splitHeader splits a header line on the separators between role, company and location
+func splitHeader(header string) []string {
+	var pieces []string
+	for _, piece := range headerSeparators.Split(header, -1) {
+		if piece = trimSeparators(piece); piece != "" {
+			pieces = append(pieces, piece)
+		}
+	}
+	if len(pieces) == 0 {
+		return []string{header}
+	}
+	return pieces
+}
+
+// splitList splits a line on commas, semicolons, pipes and bullets outside parentheses
+func splitList(line string) []string {
+	var (
+		items []string
+		item  strings.Builder
+		depth int
+	)
+
+	flush := func() {
+		if text := trimSeparators(item.String()); text != "" {
+			items = append(items, text)
+		}
+		item.Reset()
+	}
+
+	for _, r := range line {
+		switch {
+		case r == '(' || r == '[':
+			depth++
+		case (r == ')' || r == ']') && depth > 0:
+			depth--
+		case depth == 0 && strings.ContainsRune(listSeparators, r):
+			flush()
+			continue
+		}
+		item.WriteRune(r)
+	}
+	flush()
+
+	return items
+}
+
+// hasKeyword reports whether any word of text is in keywords
+func hasKeyword(text string, keywords map[string]bool) bool {
+	for _, word := range strings.FieldsFunc(normalizeText(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if keywords[word] {
+			return true
+		}
+	}
+	return false
+}
+
+// containsAny reports whether text contains any of the keywords
+func containsAny(text string, keywords []string) bool {
+	normalized := normalizeText(text)
+	for _, keyword := range keywords {
+		if strings.Contains(normalized, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+func stripBullet(line string) string {
+	return strings.TrimSpace(bulletPattern.ReplaceAllString(strings.TrimSpace(line), ""))
+}
+
+// trimSeparators trims whitespace and dangling separators from both ends
+func trimSeparators(text string) string {
+	text = multiSpacePattern.ReplaceAllString(text, " ")
+	return strings.TrimFunc(text, func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune("-–—|,;:·•/", r)
+	})
+}
@@ -0,0 +1,130 @@
+package resumeparser
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+// position is the part of a parsed position the tests compare
+type position struct {
+	role, company string
+}
+
+func parsePositions(t *testing.T, text string) []position {
+	t.Helper()
+	var positions []position
+	for _, p := range parseExperience(strings.Split(text, "\n"), testNow) {
+		positions = append(positions, position{p.Role, p.Company})
+	}
+	return positions
+}
+
+func TestParseExperience(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []position
+	}{
+		{
+			name: "role, company and dates on one line",
+			text: `Senior Developer | Acme Corp | Jan 2020 - Present
+- Built the billing service in Go
+Backend Engineer | Globex | 2017 - 2019
+- Maintained the search API`,
+			want: []position{{"Senior Developer", "Acme Corp"}, {"Backend Engineer", "Globex"}},
+		},
+		{
+			name: "headers on separate lines",
+			text: `Backend Engineer
+Mercado Libre
+March 2018 - December 2021
+- Payments platform
+Software Developer
+Globant
+2015 - 2018`,
+			want: []position{{"Backend Engineer", "Mercado Libre"}, {"Software Developer", "Globant"}},
+		},
+		{
+			name: "capitalised description lines after a dated header",
+			text: `Senior Developer at Acme Corp, 2019 - 2023
+Developed REST APIs with Python and Django; PostgreSQL
+Led the migration to Kubernetes
+Backend Engineer | Globex | 2016 - 2019`,
+			want: []position{{"Senior Developer", "Acme Corp"}, {"Backend Engineer", "Globex"}},
+		},
+		{
+			name: "description lines straight after the next title",
+			text: `Data Engineer | Initech | 2020 - 2022
+Designed Airflow pipelines on AWS
+Reduced batch runtimes by half
+Analista de Datos
+Banco Galicia
+2018 - 2020`,
+			want: []position{{"Data Engineer", "Initech"}, {"Analista de Datos", "Banco Galicia"}},
+		},
+		{
+			name: "dates first",
+			text: `2019 - 2021
+Senior Developer | Acme Corp
+- Led a team of five`,
+			want: []position{{"Senior Developer", "Acme Corp"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parsePositions(t, tt.text)
+			if len(got) != len(tt.want) {
+				t.Fatalf("positions = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("position %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseExperienceKeepsDescriptions(t *testing.T) {
+	text := `Senior Developer | Acme Corp | 2019 - 2023
+Developed REST APIs with Python and Django; PostgreSQL
+Led the migration to Kubernetes`
+
+	positions := parseExperience(strings.Split(text, "\n"), testNow)
+	if len(positions) != 1 {
+		t.Fatalf("got %d positions, want 1: %+v", len(positions), positions)
+	}
+	want := "Developed REST APIs with Python and Django; PostgreSQL\nLed the migration to Kubernetes"
+	if positions[0].Description != want {
+		t.Errorf("description = %q, want %q", positions[0].Description, want)
+	}
+	if positions[0].StartDate == "" || positions[0].Current {
+		t.Errorf("dates = %q to %q (current %v), want 2019 to 2023", positions[0].StartDate, positions[0].EndDate, positions[0].Current)
+	}
+}
+
+func TestParseProjects(t *testing.T) {
+	text := `Stafind | 2023 - Present
+Matched employees to job requests with a skill graph
+Technologies: Go, PostgreSQL, React
+Resume Parser | 2021 - 2022
+- Parsed PDF resumes`
+
+	projects := parseProjects(strings.Split(text, "\n"), testNow)
+	if len(projects) != 2 {
+		t.Fatalf("got %d projects, want 2: %+v", len(projects), projects)
+	}
+	if projects[0].Name != "Stafind" || projects[1].Name != "Resume Parser" {
+		t.Errorf("names = %q, %q, want Stafind, Resume Parser", projects[0].Name, projects[1].Name)
+	}
+	if strings.Join(projects[0].Technologies, ",") != "Go,PostgreSQL,React" {
+		t.Errorf("technologies = %v, want Go, PostgreSQL, React", projects[0].Technologies)
+	}
+	if projects[0].Description != "Matched employees to job requests with a skill graph" {
+		t.Errorf("description = %q", projects[0].Description)
+	}
+}
@@ -0,0 +1,141 @@
+package resumeparser
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"stafind-backend/internal/models"
+)
+
+// Period is a stretch of work history. End is exclusive: the first day after the last month
+// worked, so adjacent positions do not overlap.
+type Period struct {
+	Start time.Time
+	End   time.Time
+}
+
+// PositionPeriod returns the period of a parsed position from its StartDate, EndDate and
+// Current fields. Whole-year dates count like DateRange does: "2017 - 2020" is 36 months and
+// "2019 - 2019" is sameYearMonths.
+func PositionPeriod(position models.WorkExperience, now time.Time) (Period, bool) {
+	start, monthly, ok := parsePositionDate(position.StartDate)
+	if !ok {
+		return Period{}, false
+	}
+
+	var end time.Time
+	if position.Current || position.EndDate == "" {
+		end = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	} else if end, _, ok = parsePositionDate(position.EndDate); !ok {
+		return Period{}, false
+	}
+	if monthly {
+		end = end.AddDate(0, 1, 0)
+	} else if !position.Current && position.EndDate != "" && end.Year() == start.Year() {
+		end = start.AddDate(0, sameYearMonths, 0)
+	}
+
+	if !end.After(start) {
+		return Period{}, false
+	}
+	return Period{Start: start, End: end}, true
+}
+
+// parsePositionDate parses the YYYY-MM or YYYY dates stored on WorkExperience
+func parsePositionDate(value string) (time.Time, bool, bool) {
+	if t, err := time.Parse("2006-01", value); err == nil {
+		return t, true, true
+	}
+	if t, err := time.Parse("2006", value); err == nil {
+		return t, false, true
+	}
+	return time.Time{}, false, false
+}
+
+// TotalYears returns the years covered by periods, counting overlapping months once, rounded
+// to one decimal
+func TotalYears(periods []Period) float64 {
+	if len(periods) == 0 {
+		return 0
+	}
+
+	sorted := append([]Period(nil), periods...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Start.Before(sorted[j].Start)
+	})
+
+	months := 0
+	current := sorted[0]
+	for _, p := range sorted[1:] {
+		if !p.Start.After(current.End) {
+			if p.End.After(current.End) {
+				current.End = p.End
+			}
+			continue
+		}
+		months += monthsBetween(current.Start, current.End)
+		current = p
+	}
+	months += monthsBetween(current.Start, current.End)
+
+	return math.Round(float64(months)/12*10) / 10
+}
+
+func monthsBetween(start, end time.Time) int {
+	return (end.Year()-start.Year())*12 + int(end.Month()) - int(start.Month())
+}
+
+// ExperienceYears returns the total years of dated work history, counting concurrent
+// positions once
+func ExperienceYears(positions []models.WorkExperience, now time.Time) float64 {
+	var periods []Period
+	for _, position := range positions {
+		if period, ok := PositionPeriod(position, now); ok {
+			periods = append(periods, period)
+		}
+	}
+	return TotalYears(periods)
+}
+
+// SkillYears attributes each skill to the dated positions whose role or description mention
+// it, by name or by one of its aliases, and returns the years covered by those positions.
+// Aliases are keyed by the lowercased skill name. Skills no dated position mentions are left
+// out.
+func SkillYears(positions []models.WorkExperience, skills []string, aliases map[string][]string, now time.Time) map[string]float64 {
+	type datedPosition struct {
+		period Period
+		text   string
+	}
+
+	var dated []datedPosition
+	for _, position := range positions {
+		if period, ok := PositionPeriod(position, now); ok {
+			dated = append(dated, datedPosition{period: period, text: position.Role + "\n" + position.Description})
+		}
+	}
+
+	years := make(map[string]float64)
+	if len(dated) == 0 {
+		return years
+	}
+
+	for _, skill := range skills {
+		var periods []Period
+		terms := append([]string{skill}, aliases[strings.ToLower(skill)]...)
+		for _, position := range dated {
+			for _, term := range terms {
+				if MentionsSkill(position.text, term) {
+					periods = append(periods, position.period)
+					break
+				}
+			}
+		}
+		if total := TotalYears(periods); total > 0 {
+			years[skill] = total
+		}
+	}
+
+	return years
+}
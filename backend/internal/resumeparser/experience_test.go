@@ -0,0 +1,68 @@
+package resumeparser
+
+import (
+	"testing"
+	"time"
+
+	"stafind-backend/internal/models"
+)
+
+func TestExperienceYearsSameYearRange(t *testing.T) {
+	now := time.Date(2024, time.June, 15, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		positions []models.WorkExperience
+		want      float64
+	}{
+		{"same year", []models.WorkExperience{{StartDate: "2019", EndDate: "2019"}}, 0.5},
+		{"whole years", []models.WorkExperience{{StartDate: "2017", EndDate: "2020"}}, 3},
+		{"same month", []models.WorkExperience{{StartDate: "2019-03", EndDate: "2019-03"}}, 0.1},
+		{
+			name: "same year next to a longer position",
+			positions: []models.WorkExperience{
+				{StartDate: "2016", EndDate: "2018"},
+				{StartDate: "2018", EndDate: "2018"},
+			},
+			want: 2.5,
+		},
+	}
+
+	for _, tt := range tests {
+		if got := ExperienceYears(tt.positions, now); got != tt.want {
+			t.Errorf("%s: ExperienceYears() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	r, ok := FindDateRange("Backend Engineer | Globex | 2019 - 2019", now)
+	if !ok || r.Years() != 0.5 {
+		t.Errorf("FindDateRange(2019 - 2019) = %v years (found %v), want 0.5", r.Years(), ok)
+	}
+}
+
+func TestSkillYearsResolvesAliases(t *testing.T) {
+	now := time.Date(2024, time.June, 15, 0, 0, 0, 0, time.UTC)
+	positions := []models.WorkExperience{
+		{Role: "Backend Engineer", StartDate: "2016-01", EndDate: "2018-12", Description: "Built services in Golang on K8s"},
+		{Role: "Go Developer", StartDate: "2019-01", EndDate: "2020-12"},
+	}
+	aliases := map[string][]string{
+		"go":         {"Golang"},
+		"kubernetes": {"K8s"},
+	}
+
+	years := SkillYears(positions, []string{"Go", "Kubernetes", "Rust"}, aliases, now)
+	want := map[string]float64{"Go": 5, "Kubernetes": 3}
+	if len(years) != len(want) {
+		t.Errorf("SkillYears() = %v, want %v", years, want)
+	}
+	for skill, total := range want {
+		if years[skill] != total {
+			t.Errorf("%s = %v years, want %v", skill, years[skill], total)
+		}
+	}
+
+	// Without aliases only the position naming the skill counts
+	if years := SkillYears(positions, []string{"Go"}, nil, now); years["Go"] != 2 {
+		t.Errorf("Go without aliases = %v years, want 2", years["Go"])
+	}
+}
@@ -0,0 +1,46 @@
+package resumeparser
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"stafind-backend/internal/skillmatch"
+)
+
+// shortSkillLength is the length up to which skill names match case-sensitively, so that
+// "Go" is not found in "go to market"
+const shortSkillLength = 2
+
+// Mention is the byte span of a skill mention in a text
+type Mention struct {
+	Start int
+	End   int
+}
+
+// SkillMentions returns the spans where text mentions a skill as a whole term. Neighbouring
+// letters and digits rule a match out, so "Java" is not found in "JavaScript". Case is folded
+// rune by rune, so characters whose lowercase has another length, such as "İ", do not shift
+// the spans or stop other skills from matching.
+func SkillMentions(text, skill string) []Mention {
+	skill = strings.TrimSpace(skill)
+	if skill == "" {
+		return nil
+	}
+
+	matcher := skillmatch.New([]skillmatch.Pattern{{
+		Term:          skill,
+		Value:         skill,
+		CaseSensitive: utf8.RuneCountInString(skill) <= shortSkillLength,
+	}})
+
+	var mentions []Mention
+	for _, match := range matcher.FindAll(text) {
+		mentions = append(mentions, Mention{Start: match.Start, End: match.End})
+	}
+	return mentions
+}
+
+// MentionsSkill reports whether text mentions a skill as a whole term
+func MentionsSkill(text, skill string) bool {
+	return len(SkillMentions(text, skill)) > 0
+}
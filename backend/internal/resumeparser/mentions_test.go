@@ -0,0 +1,54 @@
+package resumeparser
+
+import (
+	"testing"
+	"time"
+
+	"stafind-backend/internal/models"
+)
+
+func TestSkillMentions(t *testing.T) {
+	tests := []struct {
+		text  string
+		skill string
+		want  []string
+	}{
+		{"Python and python scripts", "Python", []string{"Python", "python"}},
+		{"JavaScript, not Java", "Java", []string{"Java"}},
+		{"Go services; go to market", "Go", []string{"Go"}},
+		// "İ" lowercases to three bytes, which used to stop every longer skill from matching
+		{"İstanbul: Kubernetes and Python", "Kubernetes", []string{"Kubernetes"}},
+		{"Team lead in İzmir, PYTHON daily", "Python", []string{"PYTHON"}},
+		{"Ömer Çelik, Go developer", "Go", []string{"Go"}},
+	}
+
+	for _, tt := range tests {
+		mentions := SkillMentions(tt.text, tt.skill)
+		var got []string
+		for _, mention := range mentions {
+			got = append(got, tt.text[mention.Start:mention.End])
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("SkillMentions(%q, %q) = %q, want %q", tt.text, tt.skill, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("SkillMentions(%q, %q) = %q, want %q", tt.text, tt.skill, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestSkillYearsWithTurkishNames(t *testing.T) {
+	now := time.Date(2024, time.June, 15, 0, 0, 0, 0, time.UTC)
+	positions := []models.WorkExperience{
+		{Role: "Backend Engineer at İş Bankası", StartDate: "2020-01", EndDate: "2021-12", Description: "Python and Kubernetes"},
+	}
+
+	years := SkillYears(positions, []string{"Python", "Kubernetes"}, nil, now)
+	if years["Python"] != 2 || years["Kubernetes"] != 2 {
+		t.Errorf("SkillYears() = %v, want 2 years of each", years)
+	}
+}
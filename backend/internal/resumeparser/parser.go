@@ -0,0 +1,96 @@
+// Package resumeparser splits resume text into sections and parses work history, education,
+// projects, certifications and languages. Headings are recognised in English and Spanish.
+package resumeparser
+
+import (
+	"strings"
+	"time"
+
+	"stafind-backend/internal/models"
+)
+
+// minSummaryWords is the shortest header paragraph used as a summary when a resume has no
+// summary section
+const minSummaryWords = 12
+
+// Document is the structured content of a resume
+type Document struct {
+	Sections       []Section
+	Summary        string
+	Experience     []models.WorkExperience // In the order written, usually most recent first
+	Education      []models.Education
+	Projects       []models.Project
+	Certifications []string
+	Languages      []string // Spoken languages as written, e.g. "English (C1)"
+	SkillTerms     []string // Terms listed in skills sections, whether or not they are known skills
+
+	CertificationDetails []models.ResumeCertification // Certifications with their issuer, credential ID and dates
+}
+
+// Parse segments resume text and parses each section
+func Parse(text string) *Document {
+	return parse(text, time.Now())
+}
+
+// parse parses resume text; ongoing periods end at now
+func parse(text string, now time.Time) *Document {
+	doc := &Document{Sections: Segment(text)}
+
+	var summary []string
+	for _, section := range doc.Sections {
+		switch section.Kind {
+		case SectionSummary:
+			summary = append(summary, section.Lines...)
+		case SectionExperience:
+			doc.Experience = append(doc.Experience, parseExperience(section.Lines, now)...)
+		case SectionEducation:
+			doc.Education = append(doc.Education, parseEducation(section.Lines, now)...)
+		case SectionProjects:
+			doc.Projects = append(doc.Projects, parseProjects(section.Lines, now)...)
+		case SectionCertifications:
+			doc.Certifications = append(doc.Certifications, parseListItems(section.Lines)...)
+			doc.CertificationDetails = append(doc.CertificationDetails, parseCertifications(section.Lines, now)...)
+		case SectionLanguages:
+			doc.Languages = append(doc.Languages, parseSeparatedItems(section.Lines)...)
+		case SectionSkills:
+			doc.SkillTerms = append(doc.SkillTerms, parseSkillTerms(section.Lines)...)
+		}
+	}
+
+	if len(summary) == 0 {
+		summary = headerParagraphs(doc.Sections)
+	}
+	doc.Summary = strings.Join(summary, " ")
+
+	return doc
+}
+
+// headerParagraphs returns the long lines of the header section, which hold the introduction
+// of resumes that have no summary heading
+func headerParagraphs(sections []Section) []string {
+	if len(sections) == 0 || sections[0].Kind != SectionHeader {
+		return nil
+	}
+
+	var paragraphs []string
+	for _, line := range sections[0].Lines {
+		if len(strings.Fields(line)) >= minSummaryWords {
+			paragraphs = append(paragraphs, line)
+		}
+	}
+	return paragraphs
+}
+
+// CurrentPosition returns the ongoing position, or the first one listed when none is marked
+// as current
+func (d *Document) CurrentPosition() (models.WorkExperience, bool) {
+	for _, position := range d.Experience {
+		if position.Current {
+			return position, true
+		}
+	}
+	if len(d.Experience) > 0 {
+		return d.Experience[0], true
+	}
+	return models.WorkExperience{}, false
+}
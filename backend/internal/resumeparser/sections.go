@@ -0,0 +1,279 @@
+package resumeparser
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SectionKind identifies the kind of a resume section
+type SectionKind string
+
+// Section kinds
+const (
+	SectionHeader         SectionKind = "header" // Text before the first heading: name, contact details
+	SectionSummary        SectionKind = "summary"
+	SectionExperience     SectionKind = "experience"
+	SectionEducation      SectionKind = "education"
+	SectionSkills         SectionKind = "skills"
+	SectionCertifications SectionKind = "certifications"
+	SectionProjects       SectionKind = "projects"
+	SectionLanguages      SectionKind = "languages"
+	SectionOther          SectionKind = "other" // Recognised headings whose content is not parsed (references, hobbies, ...)
+)
+
+// Section is a block of resume lines under one heading
+type Section struct {
+	Kind    SectionKind `json:"kind"`
+	Heading string      `json:"heading"`
+	Lines   []string    `json:"lines"`
+}
+
+// maxHeadingLength is the longest line still considered a heading
+const maxHeadingLength = 60
+
+// sectionHeadings maps normalized headings in English and Spanish to section kinds
+var sectionHeadings = map[string]SectionKind{
+	// Summary
+	"summary":              SectionSummary,
+	"professional summary": SectionSummary,
+	"career summary":       SectionSummary,
+	"executive summary":    SectionSummary,
+	"profile":              SectionSummary,
+	"professional profile": SectionSummary,
+	"about":                SectionSummary,
+	"about me":             SectionSummary,
+	"objective":            SectionSummary,
+	"career objective":     SectionSummary,
+	"resumen":              SectionSummary,
+	"resumen profesional":  SectionSummary,
+	"perfil":               SectionSummary,
+	"perfil profesional":   SectionSummary,
+	"sobre mi":             SectionSummary,
+	"acerca de mi":         SectionSummary,
+	"objetivo":             SectionSummary,
+	"objetivo profesional": SectionSummary,
+	"extracto":             SectionSummary,
+
+	// Experience
+	"experience":                 SectionExperience,
+	"work experience":            SectionExperience,
+	"professional experience":    SectionExperience,
+	"relevant experience":        SectionExperience,
+	"employment":                 SectionExperience,
+	"employment history":         SectionExperience,
+	"work history":               SectionExperience,
+	"career history":             SectionExperience,
+	"experiencia":                SectionExperience,
+	"experiencia laboral":        SectionExperience,
+	"experiencia profesional":    SectionExperience,
+	"historial laboral":          SectionExperience,
+	"trayectoria profesional":    SectionExperience,
+	"trayectoria laboral":        SectionExperience,
+	"antecedentes laborales":     SectionExperience,
+	"experiencia de trabajo":     SectionExperience,
+	"experiencia laboral previa": SectionExperience,
+
+	// Education
+	"education":               SectionEducation,
+	"academic background":     SectionEducation,
+	"academic history":        SectionEducation,
+	"education and training":  SectionEducation,
+	"educacion":               SectionEducation,
+	"formacion":               SectionEducation,
+	"formacion academica":     SectionEducation,
+	"formacion profesional":   SectionEducation,
+	"estudios":                SectionEducation,
+	"estudios realizados":     SectionEducation,
+	"antecedentes academicos": SectionEducation,
+
+	// Skills
+	"skills":                 SectionSkills,
+	"technical skills":       SectionSkills,
+	"core skills":            SectionSkills,
+	"key skills":             SectionSkills,
+	"skills and tools":       SectionSkills,
+	"competencies":           SectionSkills,
+	"core competencies":      SectionSkills,
+	"technologies":           SectionSkills,
+	"tech stack":             SectionSkills,
+	"tools and technologies": SectionSkills,
+	"habilidades":            SectionSkills,
+	"habilidades tecnicas":   SectionSkills,
+	"competencias":           SectionSkills,
+	"competencias tecnicas":  SectionSkills,
+	"conocimientos":          SectionSkills,
+	"conocimientos tecnicos": SectionSkills,
+	"tecnologias":            SectionSkills,
+	"aptitudes":              SectionSkills,
+	"herramientas":           SectionSkills,
+
+	// Certifications
+	"certifications":              SectionCertifications,
+	"certification":               SectionCertifications,
+	"certificates":                SectionCertifications,
+	"licenses and certifications": SectionCertifications,
+	"courses":                     SectionCertifications,
+	"courses and certifications":  SectionCertifications,
+	"certifications and courses":  SectionCertifications,
+	"certificaciones":             SectionCertifications,
+	"certificados":                SectionCertifications,
+	"cursos":                      SectionCertifications,
+	"cursos y certificaciones":    SectionCertifications,
+	"certificaciones y cursos":    SectionCertifications,
+
+	// Projects
+	"projects":             SectionProjects,
+	"personal projects":    SectionProjects,
+	"key projects":         SectionProjects,
+	"selected projects":    SectionProjects,
+	"side projects":        SectionProjects,
+	"proyectos":            SectionProjects,
+	"proyectos destacados": SectionProjects,
+	"proyectos personales": SectionProjects,
+
+	// Languages
+	"languages":        SectionLanguages,
+	"spoken languages": SectionLanguages,
+	"language skills":  SectionLanguages,
+	"idiomas":          SectionLanguages,
+	"lenguas":          SectionLanguages,
+
+	// Not parsed, but must not bleed into the previous section
+	"references":             SectionOther,
+	"referencias":            SectionOther,
+	"interests":              SectionOther,
+	"hobbies":                SectionOther,
+	"intereses":              SectionOther,
+	"awards":                 SectionOther,
+	"premios":                SectionOther,
+	"publications":           SectionOther,
+	"publicaciones":          SectionOther,
+	"volunteering":           SectionOther,
+	"volunteer experience":   SectionOther,
+	"voluntariado":           SectionOther,
+	"additional information": SectionOther,
+	"informacion adicional":  SectionOther,
+	"contact":                SectionOther,
+	"contacto":               SectionOther,
+	"personal information":   SectionOther,
+	"informacion personal":   SectionOther,
+	"datos personales":       SectionOther,
+}
+
+var (
+	diacriticReplacer = strings.NewReplacer(
+		"á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ü", "u", "ñ", "n",
+		"à", "a", "è", "e", "ì", "i", "ò", "o", "ù", "u", "ç", "c",
+	)
+	multiSpacePattern = regexp.MustCompile(`\s+`)
+)
+
+// Segment splits resume text into sections. Text before the first recognised heading forms
+// a SectionHeader section; empty sections are dropped.
+func Segment(text string) []Section {
+	current := Section{Kind: SectionHeader}
+	var sections []Section
+
+	flush := func() {
+		if len(current.Lines) > 0 {
+			sections = append(sections, current)
+		}
+	}
+
+	for _, raw := range strings.Split(text, "\n") {
+		line := strings.TrimSpace(raw)
+		if line == "" {
+			continue
+		}
+
+		if kind, ok := headingKind(line); ok {
+			flush()
+			current = Section{Kind: kind, Heading: line}
+			continue
+		}
+
+		// "Skills: Go, Python" starts a section and carries its first line
+		if kind, heading, rest, ok := inlineHeading(line, current.Kind); ok {
+			flush()
+			current = Section{Kind: kind, Heading: heading, Lines: []string{rest}}
+			continue
+		}
+
+		current.Lines = append(current.Lines, line)
+	}
+	flush()
+
+	return sections
+}
+
+// headingKind reports whether a whole line is a section heading
+func headingKind(line string) (SectionKind, bool) {
+	if utf8.RuneCountInString(line) > maxHeadingLength {
+		return "", false
+	}
+	kind, ok := sectionHeadings[normalizeHeading(line)]
+	return kind, ok
+}
+
+// inlineHeading splits "Heading: content" lines. Labels that describe the current section,
+// such as "Languages:" among skills, stay part of it.
+func inlineHeading(line string, currentKind SectionKind) (SectionKind, string, string, bool) {
+	idx := strings.Index(line, ":")
+	if idx <= 0 {
+		return "", "", "", false
+	}
+
+	heading := strings.TrimSpace(line[:idx])
+	rest := strings.TrimSpace(line[idx+1:])
+	if rest == "" {
+		return "", "", "", false
+	}
+
+	kind, ok := headingKind(heading)
+	if !ok || kind == SectionOther {
+		return "", "", "", false
+	}
+	if kind == SectionLanguages && currentKind == SectionSkills {
+		return "", "", "", false
+	}
+	// "Technologies: Go, Kafka" under a position or project lists what it used
+	if kind == SectionSkills && (currentKind == SectionExperience || currentKind == SectionProjects) {
+		return "", "", "", false
+	}
+	return kind, heading, rest, true
+}
+
+// normalizeHeading lowercases a heading, strips accents, decoration and letter spacing
+func normalizeHeading(line string) string {
+	s := strings.ToLower(line)
+	s = diacriticReplacer.Replace(s)
+	s = strings.ReplaceAll(s, "&", " and ")
+	s = strings.TrimFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	s = multiSpacePattern.ReplaceAllString(s, " ")
+
+	// "E X P E R I E N C E" from letter-spaced headings
+	fields := strings.Fields(s)
+	if len(fields) > 3 {
+		single := true
+		for _, field := range fields {
+			if utf8.RuneCountInString(field) != 1 {
+				single = false
+				break
+			}
+		}
+		if single {
+			s = strings.Join(fields, "")
+		}
+	}
+
+	return s
+}
+
+// normalizeText lowercases text and strips accents for keyword matching
+func normalizeText(text string) string {
+	return diacriticReplacer.Replace(strings.ToLower(text))
+}
@@ -0,0 +1,67 @@
+package resumewatcher
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// manifest remembers the content hashes imported from a directory, so the same resume
+// dropped again is not imported twice
+type manifest struct {
+	Files map[string]manifestEntry `json:"files"`
+}
+
+type manifestEntry struct {
+	FileName   string    `json:"file_name"`
+	Status     string    `json:"status"`
+	EmployeeID int       `json:"employee_id,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func loadManifest(dir string) (*manifest, error) {
+	m := &manifest{Files: make(map[string]manifestEntry)}
+
+	data, err := os.ReadFile(filepath.Join(dir, manifestFileName))
+	if os.IsNotExist(err) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+	if m.Files == nil {
+		m.Files = make(map[string]manifestEntry)
+	}
+	return m, nil
+}
+
+func (m *manifest) record(result FileResult) {
+	m.Files[result.Hash] = manifestEntry{
+		FileName:   filepath.Base(result.Path),
+		Status:     result.Status,
+		EmployeeID: result.EmployeeID,
+		Error:      result.Error,
+		UpdatedAt:  time.Now(),
+	}
+}
+
+// save writes the manifest through a temporary file so a crash never leaves it half written
+func (m *manifest) save(dir string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(dir, manifestFileName)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
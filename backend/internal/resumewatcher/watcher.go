@@ -0,0 +1,342 @@
+// Package resumewatcher imports resumes dropped into local directories
+package resumewatcher
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"stafind-backend/internal/models"
+	"stafind-backend/internal/services"
+	"stafind-backend/internal/textextract"
+
+	"github.com/google/uuid"
+)
+
+// Defaults for Config fields left empty
+const (
+	DefaultInterval     = 30 * time.Second
+	DefaultStableFor    = 5 * time.Second
+	DefaultProcessedDir = "processed"
+	DefaultFailedDir    = "failed"
+
+	manifestFileName = ".resume-watcher.json"
+	maxFileSize      = 25 << 20
+)
+
+// Supported resume extensions
+var supportedExtensions = map[string]bool{
+	".pdf":  true,
+	".docx": true,
+	".txt":  true,
+}
+
+// Config controls which directories are watched and where imported files go
+type Config struct {
+	Directories  []string
+	Interval     time.Duration // Time between polls
+	StableFor    time.Duration // Files modified more recently than this are still being written and wait for the next poll
+	ProcessedDir string        // Subfolder for imported files
+	FailedDir    string        // Subfolder for files that could not be imported
+}
+
+// Watcher polls directories for new resumes and imports them
+type Watcher struct {
+	config           Config
+	importer         *services.ResumeImporter
+	cvExtractService services.CVExtractService
+}
+
+// FileResult describes the outcome of one file in a poll
+type FileResult struct {
+	Path       string
+	Hash       string
+	Status     string // processed, failed or duplicate
+	EmployeeID int
+	Action     string
+	Error      string
+}
+
+// Status values of FileResult
+const (
+	FileStatusProcessed = "processed"
+	FileStatusFailed    = "failed"
+	FileStatusDuplicate = "duplicate"
+)
+
+// New creates a watcher
+func New(config Config, importer *services.ResumeImporter, cvExtractService services.CVExtractService) *Watcher {
+	if config.Interval <= 0 {
+		config.Interval = DefaultInterval
+	}
+	if config.StableFor < 0 {
+		config.StableFor = 0
+	}
+	if config.ProcessedDir == "" {
+		config.ProcessedDir = DefaultProcessedDir
+	}
+	if config.FailedDir == "" {
+		config.FailedDir = DefaultFailedDir
+	}
+
+	return &Watcher{
+		config:           config,
+		importer:         importer,
+		cvExtractService: cvExtractService,
+	}
+}
+
+// Run polls every configured directory until the context is cancelled
+func (w *Watcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(w.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		w.PollAll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// PollAll polls every configured directory once
+func (w *Watcher) PollAll(ctx context.Context) []FileResult {
+	var results []FileResult
+	for _, dir := range w.config.Directories {
+		if ctx.Err() != nil {
+			break
+		}
+
+		dirResults, err := w.Poll(ctx, dir)
+		if err != nil {
+			log.Printf("Resume watcher: failed to poll %s: %v", dir, err)
+			continue
+		}
+		results = append(results, dirResults...)
+	}
+	return results
+}
+
+// Poll imports the resumes currently in a directory. All files found in one poll form a
+// batch tracked as a single CV extract record, which is marked completed once every file
+// was handled and failed when the context is cancelled first.
+func (w *Watcher) Poll(ctx context.Context, dir string) ([]FileResult, error) {
+	files, err := w.readyFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	manifest, err := loadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	extractRequestID := w.startBatch(dir, len(files))
+	batchStart := time.Now()
+
+	results := make([]FileResult, 0, len(files))
+	for i, path := range files {
+		if ctx.Err() != nil {
+			// The files left wait in the directory for the next poll, in a new batch
+			if extractRequestID != "" {
+				message := fmt.Sprintf("watcher stopped after %d of %d files", i, len(files))
+				if _, err := w.cvExtractService.MarkExtractFailed(extractRequestID, message); err != nil {
+					log.Printf("Resume watcher: failed to mark CV extract as stopped: %v", err)
+				}
+			}
+			return results, nil
+		}
+
+		result := w.processFile(path, manifest)
+		results = append(results, result)
+
+		destination := w.config.ProcessedDir
+		if result.Status == FileStatusFailed {
+			destination = w.config.FailedDir
+		}
+		target, err := moveFile(path, filepath.Join(dir, destination), result.Hash)
+		if err != nil {
+			log.Printf("Resume watcher: failed to move %s: %v", path, err)
+		} else if result.Status == FileStatusFailed {
+			writeErrorNote(target, result.Error)
+		}
+
+		if result.Hash != "" && result.Status != FileStatusDuplicate {
+			manifest.record(result)
+			if err := manifest.save(dir); err != nil {
+				log.Printf("Resume watcher: failed to save manifest for %s: %v", dir, err)
+			}
+		}
+
+		if extractRequestID != "" {
+			fileStatus := models.CVExtractFileStatusProcessed
+			if result.Status == FileStatusFailed {
+				fileStatus = models.CVExtractFileStatusFailed
+			}
+			if _, err := w.cvExtractService.UpdateFileProgress(extractRequestID, i+1, fileStatus); err != nil {
+				log.Printf("Resume watcher: failed to update CV extract progress: %v", err)
+			}
+		}
+
+		log.Printf("Resume watcher: %s %s", result.Status, path)
+	}
+
+	if extractRequestID != "" {
+		if _, err := w.cvExtractService.MarkExtractSuccess(extractRequestID, time.Since(batchStart).Milliseconds()); err != nil {
+			log.Printf("Resume watcher: failed to mark CV extract as completed: %v", err)
+		}
+	}
+
+	return results, nil
+}
+
+// readyFiles lists supported files that are no longer being written, oldest first
+func (w *Watcher) readyFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	type candidate struct {
+		path    string
+		modTime time.Time
+	}
+	var candidates []candidate
+	cutoff := time.Now().Add(-w.config.StableFor)
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") || !supportedExtensions[strings.ToLower(filepath.Ext(name))] {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil || !info.Mode().IsRegular() || info.ModTime().After(cutoff) {
+			continue
+		}
+		candidates = append(candidates, candidate{path: filepath.Join(dir, name), modTime: info.ModTime()})
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].modTime.Before(candidates[j].modTime)
+	})
+
+	files := make([]string, len(candidates))
+	for i, c := range candidates {
+		files[i] = c.path
+	}
+	return files, nil
+}
+
+// processFile imports a single file unless its content was already imported
+func (w *Watcher) processFile(path string, manifest *manifest) FileResult {
+	result := FileResult{Path: path, Status: FileStatusFailed}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if info.Size() > maxFileSize {
+		result.Error = fmt.Sprintf("file is larger than %d bytes", maxFileSize)
+		return result
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	sum := sha256.Sum256(data)
+	result.Hash = hex.EncodeToString(sum[:])
+
+	if previous, ok := manifest.Files[result.Hash]; ok && previous.Status == FileStatusProcessed {
+		result.Status = FileStatusDuplicate
+		result.EmployeeID = previous.EmployeeID
+		return result
+	}
+
+	text, err := textextract.Extract(data, "", path)
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to extract text: %v", err)
+		return result
+	}
+
+	// Local paths are not reachable from the web app, so no resume URL is stored
+	imported, err := w.importer.Import(text, "local_directory", "", map[string]interface{}{
+		"file_name":    filepath.Base(path),
+		"content_hash": result.Hash,
+	})
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Status = FileStatusProcessed
+	result.EmployeeID = imported.Candidate.EmployeeID
+	result.Action = imported.Candidate.Action
+	return result
+}
+
+// startBatch creates the CV extract record for a poll and returns its request ID
+func (w *Watcher) startBatch(dir string, numFiles int) string {
+	extractRequestID := fmt.Sprintf("watch-%s-%s", filepath.Base(dir), uuid.NewString())
+
+	metadata, _ := json.Marshal(map[string]interface{}{
+		"source":    "local_directory",
+		"directory": dir,
+	})
+	metadataStr := string(metadata)
+
+	if _, err := w.cvExtractService.CreateOrUpdateExtract(extractRequestID, models.CVExtractStatusProcessing, numFiles, 0, &metadataStr); err != nil {
+		log.Printf("Resume watcher: failed to create CV extract record: %v", err)
+		return ""
+	}
+	return extractRequestID
+}
+
+// moveFile moves a file into a subfolder, adding part of its hash to the name if the target exists.
+// It returns the new path.
+func moveFile(path, targetDir, hash string) (string, error) {
+	if err := os.MkdirAll(targetDir, 0o755); err != nil {
+		return "", err
+	}
+
+	name := filepath.Base(path)
+	target := filepath.Join(targetDir, name)
+	if _, err := os.Stat(target); err == nil {
+		ext := filepath.Ext(name)
+		suffix := time.Now().Format("20060102150405")
+		if len(hash) >= 8 {
+			suffix = hash[:8]
+		}
+		target = filepath.Join(targetDir, strings.TrimSuffix(name, ext)+"-"+suffix+ext)
+	}
+
+	if err := os.Rename(path, target); err != nil {
+		return "", err
+	}
+	return target, nil
+}
+
+// writeErrorNote stores the failure reason next to a failed file
+func writeErrorNote(path, message string) {
+	if err := os.WriteFile(path+".error.txt", []byte(message+"\n"), 0o644); err != nil {
+		log.Printf("Resume watcher: failed to write error note for %s: %v", path, err)
+	}
+}
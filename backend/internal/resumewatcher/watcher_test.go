@@ -0,0 +1,114 @@
+package resumewatcher
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"stafind-backend/internal/models"
+	"stafind-backend/internal/services"
+)
+
+// fakeCVExtractService records how each batch ends
+type fakeCVExtractService struct {
+	services.CVExtractService
+	created   []string
+	succeeded []string
+	failed    map[string]string
+}
+
+func (f *fakeCVExtractService) CreateOrUpdateExtract(requestID string, status string, numFiles int, fileNumber int, metadata *string) (*models.CVExtract, error) {
+	f.created = append(f.created, requestID)
+	return &models.CVExtract{}, nil
+}
+
+func (f *fakeCVExtractService) UpdateFileProgress(requestID string, fileNumber int, fileStatus string) (*models.CVExtract, error) {
+	return &models.CVExtract{}, nil
+}
+
+func (f *fakeCVExtractService) MarkExtractSuccess(requestID string, totalTimeMs int64) (*models.CVExtract, error) {
+	f.succeeded = append(f.succeeded, requestID)
+	return &models.CVExtract{}, nil
+}
+
+func (f *fakeCVExtractService) MarkExtractFailed(requestID string, errorMessage string) (*models.CVExtract, error) {
+	if f.failed == nil {
+		f.failed = make(map[string]string)
+	}
+	f.failed[requestID] = errorMessage
+	return &models.CVExtract{}, nil
+}
+
+// writeImportedResume drops a resume whose content the manifest already lists as imported,
+// so polling it needs no importer
+func writeImportedResume(t *testing.T, dir, name string) {
+	t.Helper()
+	content := []byte("Resume of " + name)
+	if err := os.WriteFile(filepath.Join(dir, name), content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := loadManifest(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(content)
+	m.record(FileResult{Path: name, Hash: hex.EncodeToString(sum[:]), Status: FileStatusProcessed, EmployeeID: 1})
+	if err := m.save(dir); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPollMarksBatchCompleted(t *testing.T) {
+	dir := t.TempDir()
+	extracts := &fakeCVExtractService{}
+	watcher := New(Config{Directories: []string{dir}}, nil, extracts)
+
+	for _, name := range []string{"ana.txt", "luis.txt"} {
+		writeImportedResume(t, dir, name)
+		results, err := watcher.Poll(context.Background(), dir)
+		if err != nil || len(results) != 1 || results[0].Status != FileStatusDuplicate {
+			t.Fatalf("Poll() = %+v, %v; want one duplicate", results, err)
+		}
+	}
+
+	// Batches of the same directory within one second get their own IDs
+	if len(extracts.created) != 2 || extracts.created[0] == extracts.created[1] {
+		t.Errorf("batch IDs = %v, want two distinct", extracts.created)
+	}
+	if !strings.HasPrefix(extracts.created[0], "watch-"+filepath.Base(dir)+"-") {
+		t.Errorf("batch ID = %q, want the directory in it", extracts.created[0])
+	}
+	if len(extracts.succeeded) != 2 || len(extracts.failed) != 0 {
+		t.Errorf("succeeded %v, failed %v; want both batches completed", extracts.succeeded, extracts.failed)
+	}
+}
+
+// A batch cut short by shutdown is not reported as completed, and its files stay for the next poll
+func TestPollMarksCancelledBatchFailed(t *testing.T) {
+	dir := t.TempDir()
+	writeImportedResume(t, dir, "ana.txt")
+	extracts := &fakeCVExtractService{}
+	watcher := New(Config{Directories: []string{dir}}, nil, extracts)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results, err := watcher.Poll(ctx, dir)
+	if err != nil || len(results) != 0 {
+		t.Fatalf("Poll() = %+v, %v; want no files handled", results, err)
+	}
+
+	if len(extracts.succeeded) != 0 {
+		t.Errorf("cancelled batch marked completed: %v", extracts.succeeded)
+	}
+	if message := extracts.failed[extracts.created[0]]; message != "watcher stopped after 0 of 1 files" {
+		t.Errorf("failure message = %q", message)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "ana.txt")); err != nil {
+		t.Errorf("unhandled file was moved: %v", err)
+	}
+}
@@ -13,12 +13,14 @@ import (
 type aiAgentService struct {
 	aiAgentRepo         repositories.AIAgentRepository
 	employeeRepo        repositories.EmployeeRepository
+	backgroundRepo      repositories.EmployeeBackgroundRepository
 	skillRepo           repositories.SkillRepository
 	categoryRepo        repositories.CategoryRepository
 	matchRepo           repositories.MatchRepository
 	matchEngine         *matching.MatchEngine
 	notificationService NotificationService
 	nerService          *NERService
+	jobRequestParser    *JobRequestParser
 	skillNormalization  map[string]string // Cache for skill normalization
 }
 
@@ -26,20 +28,24 @@ type aiAgentService struct {
 func NewAIAgentService(
 	aiAgentRepo repositories.AIAgentRepository,
 	employeeRepo repositories.EmployeeRepository,
+	backgroundRepo repositories.EmployeeBackgroundRepository,
 	skillRepo repositories.SkillRepository,
 	categoryRepo repositories.CategoryRepository,
 	matchRepo repositories.MatchRepository,
 	notificationService NotificationService,
+	nerService *NERService,
 ) AIAgentService {
 	return &aiAgentService{
 		aiAgentRepo:         aiAgentRepo,
 		employeeRepo:        employeeRepo,
+		backgroundRepo:      backgroundRepo,
 		skillRepo:           skillRepo,
 		categoryRepo:        categoryRepo,
 		matchRepo:           matchRepo,
 		matchEngine:         matching.NewMatchEngine(),
 		notificationService: notificationService,
-		nerService:          NewNERService(skillRepo, categoryRepo),
+		nerService:          nerService,
+		jobRequestParser:    NewJobRequestParser(nerService),
 		skillNormalization:  constants.SkillNormalizationMap, // Cache normalization map
 	}
 }
@@ -162,8 +168,20 @@ func (s *aiAgentService) ProcessAIAgentRequest(id int) (*models.AIAgentResponse,
 		return nil, err
 	}
 
+	// Parse the request into a structured search; fall back to the flat skill list when
+	// the parser finds no skills so matching behaves as before
+	searchReq := &models.SearchRequest{RequiredSkills: skills}
+	parsedRequest, err := s.jobRequestParser.Parse(extractedText)
+	if err != nil {
+		s.notificationService.LogError(id, fmt.Sprintf("Job request parsing failed: %v", err))
+		parsedRequest = nil
+	} else if len(parsedRequest.SearchRequest.RequiredSkills)+len(parsedRequest.SearchRequest.PreferredSkills) > 0 {
+		parsed := parsedRequest.SearchRequest
+		searchReq = &parsed
+	}
+
 	// Find matching employees
-	matches, err := s.findMatchingEmployees(skills)
+	matches, err := s.searchEmployees(searchReq)
 	if err != nil {
 		request.Status = "failed"
 		errorMsg := fmt.Sprintf("Employee matching failed: %v", err)
@@ -194,6 +212,7 @@ func (s *aiAgentService) ProcessAIAgentRequest(id int) (*models.AIAgentResponse,
 		Summary:        s.generateSummary(aiMatches, skills),
 		ProcessingTime: time.Since(startTime).Milliseconds(),
 		Status:         "completed",
+		ParsedRequest:  parsedRequest,
 	}
 
 	// Save response to database
@@ -230,6 +249,11 @@ func (s *aiAgentService) ExtractSkillsFromText(text string) (*models.SkillExtrac
 	}, nil
 }
 
+// ParseJobRequest interprets a free-text job request without running a search
+func (s *aiAgentService) ParseJobRequest(text string) (*models.ParsedJobRequest, error) {
+	return s.jobRequestParser.Parse(text)
+}
+
 func (s *aiAgentService) GetAIAgentRequests(limit int, offset int) ([]models.AIAgentRequest, error) {
 	return s.aiAgentRepo.GetAll(limit, offset)
 }
@@ -263,6 +287,12 @@ func (s *aiAgentService) extractTextFromAttachment(url string) (string, error) {
 
 // findMatchingEmployees finds employees matching the extracted skills
 func (s *aiAgentService) findMatchingEmployees(skills []string) ([]models.Match, error) {
+	return s.searchEmployees(&models.SearchRequest{RequiredSkills: skills})
+}
+
+// searchEmployees finds and ranks employees for a structured search request
+func (s *aiAgentService) searchEmployees(searchReq *models.SearchRequest) ([]models.Match, error) {
+	skills := append(append([]string{}, searchReq.RequiredSkills...), searchReq.PreferredSkills...)
 	if len(skills) == 0 {
 		return []models.Match{}, nil
 	}
@@ -276,10 +306,12 @@ func (s *aiAgentService) findMatchingEmployees(skills []string) ([]models.Match,
 		return nil, fmt.Errorf("failed to find employees: %w", err)
 	}
 
-	// Create optimized search request
-	searchReq := &models.SearchRequest{
-		RequiredSkills: skills,
-		MinMatchScore:  0.1,
+	if err := loadSearchBackgrounds(s.backgroundRepo, searchReq, employees); err != nil {
+		return nil, fmt.Errorf("failed to load work history: %w", err)
+	}
+
+	if searchReq.MinMatchScore == 0 {
+		searchReq.MinMatchScore = 0.1
 	}
 
 	// Use matching engine to score and rank results
@@ -166,6 +166,13 @@ func (s *apiKeyService) RotateAPIKey(oldKeyID int) (*models.APIKeyResponse, erro
 		return nil, fmt.Errorf("failed to create new API key: %v", err)
 	}
 
+	// Carry the signing secret over so signed integrations only need the new key ID
+	if secret, err := s.apiKeyRepo.GetSigningSecret(oldKeyID); err == nil && secret != "" {
+		if err := s.apiKeyRepo.SetSigningSecret(createdKey.ID, secret); err != nil {
+			return nil, fmt.Errorf("failed to copy signing secret: %v", err)
+		}
+	}
+
 	// Return the new key (only time it's returned)
 	return &models.APIKeyResponse{
 		ID:          createdKey.ID,
@@ -179,6 +186,66 @@ func (s *apiKeyService) RotateAPIKey(oldKeyID int) (*models.APIKeyResponse, erro
 	}, nil
 }
 
+// CreateSigningSecret issues a new HMAC signing secret for an API key, replacing any previous one
+func (s *apiKeyService) CreateSigningSecret(id int) (*models.SigningSecretResponse, error) {
+	apiKey, err := s.apiKeyRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !apiKey.IsActive {
+		return nil, fmt.Errorf("API key is deactivated")
+	}
+
+	// Generate 32 random bytes
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return nil, fmt.Errorf("failed to generate signing secret: %v", err)
+	}
+	secret := "whsec_" + hex.EncodeToString(bytes)
+
+	if err := s.apiKeyRepo.SetSigningSecret(id, secret); err != nil {
+		return nil, err
+	}
+
+	return &models.SigningSecretResponse{
+		KeyID:         apiKey.ID,
+		ServiceName:   apiKey.ServiceName,
+		SigningSecret: secret, // Only returned once!
+	}, nil
+}
+
+// GetSigningSecret returns the signing secret of an active, unexpired API key
+func (s *apiKeyService) GetSigningSecret(id int) (string, error) {
+	apiKey, err := s.apiKeyRepo.GetByID(id)
+	if err != nil {
+		return "", err
+	}
+
+	if !apiKey.IsActive {
+		return "", fmt.Errorf("API key is deactivated")
+	}
+
+	if apiKey.ExpiresAt != nil && time.Now().After(*apiKey.ExpiresAt) {
+		return "", fmt.Errorf("API key has expired")
+	}
+
+	secret, err := s.apiKeyRepo.GetSigningSecret(id)
+	if err != nil {
+		return "", err
+	}
+	if secret == "" {
+		return "", fmt.Errorf("API key has no signing secret")
+	}
+
+	return secret, nil
+}
+
+// HasSigningSecrets reports whether any active API key has a signing secret
+func (s *apiKeyService) HasSigningSecrets() (bool, error) {
+	return s.apiKeyRepo.HasSigningSecrets()
+}
+
 // GetEnvironmentAPIKey gets API key from environment variables (fallback)
 func GetEnvironmentAPIKey() string {
 	return os.Getenv(constants.EnvExternalAPIKey)
@@ -2,111 +2,307 @@ package services
 
 import (
 	"database/sql"
-	"encoding/json"
 	"fmt"
+	"log"
+	"os"
+	"stafind-backend/internal/constants"
+	"stafind-backend/internal/identity"
 	"stafind-backend/internal/models"
 	"stafind-backend/internal/repositories"
+	"stafind-backend/internal/resumeparser"
+	"strconv"
 	"strings"
 	"time"
 )
 
 // CandidateStorageService handles candidate extraction and storage
 type CandidateStorageService struct {
-	employeeRepo repositories.EmployeeRepository
-	skillRepo    repositories.SkillRepository
+	employeeRepo     repositories.EmployeeRepository
+	skillRepo        repositories.SkillRepository
+	duplicateService *DuplicateService
+	historyService   *ExtractionHistoryService
+	reviewRepo       repositories.ExtractionReviewRepository
+	discoveryRepo    repositories.SkillDiscoveryRepository
+	certifications   *CertificationService
+	backgroundRepo   repositories.EmployeeBackgroundRepository
+	reviewThreshold  float64
 }
 
-// NewCandidateStorageService creates a new candidate storage service
-func NewCandidateStorageService(employeeRepo repositories.EmployeeRepository, skillRepo repositories.SkillRepository) *CandidateStorageService {
-	return &CandidateStorageService{
-		employeeRepo: employeeRepo,
-		skillRepo:    skillRepo,
+// NewCandidateStorageService creates a new candidate storage service. Extractions below
+// REVIEW_CONFIDENCE_THRESHOLD are held for review; terms that look like unknown skills are
+// recorded for skill discovery, certifications are matched to the catalogue, and the work
+// history, education and projects are stored in their own tables.
+func NewCandidateStorageService(
+	employeeRepo repositories.EmployeeRepository,
+	skillRepo repositories.SkillRepository,
+	duplicateService *DuplicateService,
+	historyService *ExtractionHistoryService,
+	reviewRepo repositories.ExtractionReviewRepository,
+	discoveryRepo repositories.SkillDiscoveryRepository,
+	certificationService *CertificationService,
+	backgroundRepo repositories.EmployeeBackgroundRepository,
+) *CandidateStorageService {
+	service := &CandidateStorageService{
+		employeeRepo:     employeeRepo,
+		skillRepo:        skillRepo,
+		duplicateService: duplicateService,
+		historyService:   historyService,
+		reviewRepo:       reviewRepo,
+		discoveryRepo:    discoveryRepo,
+		certifications:   certificationService,
+		backgroundRepo:   backgroundRepo,
+		reviewThreshold:  constants.DefaultReviewConfidenceThreshold,
 	}
+
+	if value, err := strconv.ParseFloat(os.Getenv(constants.EnvReviewConfidenceThreshold), 64); err == nil && value >= 0 && value <= 1 {
+		service.reviewThreshold = value
+	}
+
+	return service
 }
 
-// ProcessCandidateExtraction processes candidate extraction and stores/updates employee data
+// ProcessCandidateExtraction processes candidate extraction and stores/updates employee data.
+// Extractions with a low confidence or an unlikely candidate name are held for review
+// instead; the result then has the "pending_review" action and the review ID.
 func (s *CandidateStorageService) ProcessCandidateExtraction(
 	originalText string,
-	extractedData map[string]interface{},
+	resume *models.ProcessedResumeData,
+	extractionSource string,
+	resumeURL string,
+) (*models.CandidateExtractionResult, error) {
+	if reasons := s.reviewReasons(resume); len(reasons) > 0 {
+		return s.holdForReview(originalText, resume, extractionSource, resumeURL, reasons)
+	}
+
+	return s.StoreCandidate(originalText, resume, extractionSource, resumeURL)
+}
+
+// reviewReasons explains why an extraction should be reviewed before it is stored
+func (s *CandidateStorageService) reviewReasons(resume *models.ProcessedResumeData) []string {
+	var reasons []string
+	if problem := identity.NameProblem(resume.CandidateName); problem != "" {
+		reasons = append(reasons, problem)
+	}
+	if resume.ConfidenceScore < s.reviewThreshold {
+		reasons = append(reasons, fmt.Sprintf("confidence %.2f is below %.2f", resume.ConfidenceScore, s.reviewThreshold))
+	}
+	return reasons
+}
+
+// holdForReview stores an extraction in the review queue instead of as an employee
+func (s *CandidateStorageService) holdForReview(
+	originalText string,
+	resume *models.ProcessedResumeData,
+	extractionSource string,
+	resumeURL string,
+	reasons []string,
+) (*models.CandidateExtractionResult, error) {
+	startTime := time.Now()
+
+	review := &models.ExtractionReview{
+		Reasons:          reasons,
+		ConfidenceScore:  resume.ConfidenceScore,
+		CandidateName:    resume.CandidateName,
+		ExtractionSource: extractionSource,
+		ResumeURL:        resumeURL,
+		OriginalText:     originalText,
+		ExtractedData:    resume,
+	}
+	if err := s.reviewRepo.Create(review); err != nil {
+		return &models.CandidateExtractionResult{
+			Status:         "failed",
+			Message:        fmt.Sprintf("Failed to hold extraction for review: %v", err),
+			ProcessingTime: time.Since(startTime),
+		}, err
+	}
+	log.Printf("Extraction of %q held for review %d: %s", resume.CandidateName, review.ID, strings.Join(reasons, "; "))
+
+	return &models.CandidateExtractionResult{
+		Action:          "pending_review",
+		ExtractedData:   resume,
+		ChangesDetected: false,
+		ChangesSummary:  reasons,
+		ProcessingTime:  time.Since(startTime),
+		Status:          "pending_review",
+		Message:         "Extraction held for review: " + strings.Join(reasons, "; "),
+		ReviewID:        review.ID,
+	}, nil
+}
+
+// StoreCandidate stores extracted data as an employee without the review check, for
+// extractions a reviewer approved. The resume belongs to the employee with the same email
+// or, failing that, to the employee it matches at or above the auto-merge threshold;
+// otherwise a new employee is created and the employees it may duplicate are flagged for
+// review.
+func (s *CandidateStorageService) StoreCandidate(
+	originalText string,
+	resume *models.ProcessedResumeData,
 	extractionSource string,
 	resumeURL string,
 ) (*models.CandidateExtractionResult, error) {
 	startTime := time.Now()
 
 	// Extract candidate name and email from extracted data
-	candidateName, _ := extractedData["candidate_name"].(string)
-	candidateEmail, _ := extractedData["contact_info"].(map[string]interface{})["email"].(string)
+	candidateName := resume.CandidateName
+	candidateEmail := resumeEmail(resume)
 
-	if candidateName == "" || candidateEmail == "" {
+	if candidateName == "" {
 		return &models.CandidateExtractionResult{
 			Status:         "failed",
-			Message:        "Candidate name and email are required",
+			Message:        "Candidate name is required",
 			ProcessingTime: time.Since(startTime),
-		}, fmt.Errorf("candidate name and email are required")
+		}, fmt.Errorf("candidate name is required")
 	}
 
+	profile := ResumeProfile(originalText, resume)
+
 	// Check if employee already exists by email
-	fmt.Printf("DEBUG: Checking for existing employee with email: %s\n", candidateEmail)
-	existingEmployee, err := s.employeeRepo.GetByEmail(candidateEmail)
+	if candidateEmail != "" {
+		existingEmployee, err := s.employeeRepo.GetByEmail(candidateEmail)
+		if err == nil {
+			result, err := s.updateExistingEmployee(existingEmployee, originalText, resume, extractionSource, startTime, resumeURL)
+			return s.recordFindings(result, err, resume, profile, nil)
+		}
+		if err != sql.ErrNoRows {
+			return &models.CandidateExtractionResult{
+				Status:         "failed",
+				Message:        fmt.Sprintf("Database error while checking for existing employee: %v", err),
+				ProcessingTime: time.Since(startTime),
+			}, err
+		}
+	}
+
+	// Otherwise look for the same person under another email, or none
+	candidates, err := s.duplicateService.FindCandidates(profile, 0)
 	if err != nil {
-		fmt.Printf("DEBUG: GetByEmail error: %v\n", err)
-		// Check if it's a "no rows" error (employee doesn't exist)
-		if err == sql.ErrNoRows {
-			fmt.Printf("DEBUG: Employee not found, creating new one\n")
-			// Employee doesn't exist, create new one
-			return s.createNewEmployee(originalText, extractedData, extractionSource, startTime, resumeURL)
+		log.Printf("Duplicate check failed for %s, creating a new employee: %v", candidateName, err)
+		candidates = nil
+	}
+	if len(candidates) > 0 && candidates[0].Score >= s.duplicateService.AutoMergeThreshold() {
+		match := candidates[0]
+		existingEmployee, err := s.employeeRepo.GetByID(match.EmployeeID)
+		if err != nil {
+			return &models.CandidateExtractionResult{
+				Status:         "failed",
+				Message:        fmt.Sprintf("Database error while loading matched employee: %v", err),
+				ProcessingTime: time.Since(startTime),
+			}, err
 		}
-		// Some other database error occurred
-		fmt.Printf("DEBUG: Database error: %v\n", err)
+		log.Printf("Resume of %s matched employee %d (score %.2f: %s)", candidateName, match.EmployeeID, match.Score, strings.Join(match.Reasons, ", "))
+
+		result, err := s.updateExistingEmployee(existingEmployee, originalText, resume, extractionSource, startTime, resumeURL)
+		if err == nil {
+			result.ChangesSummary = append(result.ChangesSummary, "Matched existing employee: "+strings.Join(match.Reasons, ", "))
+		}
+		return s.recordFindings(result, err, resume, profile, candidates[1:])
+	}
+
+	// Employee doesn't exist, create new one
+	result, err := s.createNewEmployee(originalText, resume, extractionSource, startTime, resumeURL)
+	if err == nil {
+		result.PossibleDuplicates = candidates
+	}
+	return s.recordFindings(result, err, resume, profile, candidates)
+}
+
+// ReextractEmployee stores data extracted again from an employee's resume on that employee,
+// without the review check or matching the resume to an employee
+func (s *CandidateStorageService) ReextractEmployee(
+	employeeID int,
+	originalText string,
+	resume *models.ProcessedResumeData,
+	extractionSource string,
+	resumeURL string,
+) (*models.CandidateExtractionResult, error) {
+	startTime := time.Now()
+
+	employee, err := s.employeeRepo.GetByID(employeeID)
+	if err != nil {
 		return &models.CandidateExtractionResult{
+			EmployeeID:     employeeID,
 			Status:         "failed",
-			Message:        fmt.Sprintf("Database error while checking for existing employee: %v", err),
+			Message:        fmt.Sprintf("Database error while loading employee: %v", err),
 			ProcessingTime: time.Since(startTime),
 		}, err
 	}
-	fmt.Printf("DEBUG: Found existing employee: %s\n", existingEmployee.Name)
 
-	// Employee exists, check for changes
-	return s.updateExistingEmployee(existingEmployee, originalText, extractedData, extractionSource, startTime, resumeURL)
+	result, err := s.updateExistingEmployee(employee, originalText, resume, extractionSource, startTime, resumeURL)
+	return s.recordFindings(result, err, resume, ResumeProfile(originalText, resume), nil)
+}
+
+// recordFindings stores, for the employee of a successful result, the resume's identifiers,
+// certifications, work history, education and projects, and the terms that look like unknown
+// skills, and flags the other candidates for review. Failing to record them does not fail the
+// extraction.
+func (s *CandidateStorageService) recordFindings(
+	result *models.CandidateExtractionResult,
+	err error,
+	resume *models.ProcessedResumeData,
+	profile identity.Profile,
+	candidates []models.DuplicateCandidate,
+) (*models.CandidateExtractionResult, error) {
+	if err != nil || result.EmployeeID == 0 {
+		return result, err
+	}
+
+	if recordErr := s.duplicateService.Record(result.EmployeeID, profile, candidates); recordErr != nil {
+		log.Printf("Failed to record identities of employee %d: %v", result.EmployeeID, recordErr)
+	}
+	for _, term := range resume.UnknownSkills {
+		if recordErr := s.discoveryRepo.RecordTerm(result.EmployeeID, normalizeSkillName(term.Term), term); recordErr != nil {
+			log.Printf("Failed to record skill term %q of employee %d: %v", term.Term, result.EmployeeID, recordErr)
+		}
+	}
+	if recordErr := s.certifications.StoreResumeCertifications(result.EmployeeID, resume.CertificationDetails); recordErr != nil {
+		log.Printf("Failed to store certifications of employee %d: %v", result.EmployeeID, recordErr)
+	}
+	if recordErr := s.backgroundRepo.Replace(result.EmployeeID, resumeBackground(resume)); recordErr != nil {
+		log.Printf("Failed to store work history, education and projects of employee %d: %v", result.EmployeeID, recordErr)
+	}
+	return result, err
 }
 
 // createNewEmployee creates a new employee from extracted data
 func (s *CandidateStorageService) createNewEmployee(
 	originalText string,
-	extractedData map[string]interface{},
+	resume *models.ProcessedResumeData,
 	extractionSource string,
 	startTime time.Time,
 	resumeURL string,
 ) (*models.CandidateExtractionResult, error) {
-	// Extract basic information
-	candidateName, _ := extractedData["candidate_name"].(string)
-	contactInfo, _ := extractedData["contact_info"].(map[string]interface{})
-	email, _ := contactInfo["email"].(string)
-	location, _ := contactInfo["location"].(string)
-
-	summary, _ := extractedData["summary"].(string)
+	extractedData, err := resumeDataToMap(resume)
+	if err != nil {
+		return &models.CandidateExtractionResult{
+			Status:         "failed",
+			Message:        fmt.Sprintf("Failed to encode extracted data: %v", err),
+			ProcessingTime: time.Since(startTime),
+		}, err
+	}
 
 	// Extract and normalize seniority level using improved extraction
-	seniorityLevel := s.extractAndNormalizeSeniorityLevel(extractedData, originalText)
+	seniorityLevel := s.extractAndNormalizeSeniorityLevel(resume, originalText)
 
 	// Extract last project from work experience
-	lastProject := s.extractLastProject(originalText)
+	lastProject := s.extractLastProjectFromResume(resume, originalText)
 
 	// Create employee request
 	createReq := &models.CreateEmployeeRequest{
-		Name:           candidateName,
-		Email:          email,
+		Name:           resume.CandidateName,
+		Email:          resumeEmail(resume),
 		Department:     s.mapSeniorityToDepartment(seniorityLevel),
 		Level:          seniorityLevel, // Seniority level (e.g., "Senior", "Mid", "Junior")
-		Location:       location,
-		Bio:            summary,
+		Location:       resume.ContactInfo.Location,
+		Bio:            resume.ProfessionalSummary,
 		CurrentProject: lastProject, // Last project they worked on
 		ResumeUrl:      resumeURL,   // Resume URL from the extraction request
-		Skills:         s.extractSkillsFromData(extractedData),
+		Skills:         s.extractSkillsFromData(resume),
+
+		Languages:       resume.SpokenLanguages,
+		EmployeeContact: resumeContact(resume, nil),
 	}
 
 	// Create employee with extraction data
-	fmt.Printf("DEBUG: Creating new employee with extraction data, email: %s\n", email)
+	fmt.Printf("DEBUG: Creating new employee with extraction data, email: %s\n", createReq.Email)
 	employee, err := s.employeeRepo.CreateWithExtraction(
 		createReq,
 		originalText,
@@ -125,16 +321,20 @@ func (s *CandidateStorageService) createNewEmployee(
 	}
 	fmt.Printf("DEBUG: Successfully created employee with extraction data, ID: %d\n", employee.ID)
 
+	run := newExtractionRun(employee.ID, originalText, resume, extractionSource, resumeURL)
+	diff := DiffExtractions(nil, run)
+
 	return &models.CandidateExtractionResult{
-		EmployeeID:      employee.ID,
-		Action:          "created",
-		Employee:        employee,
-		ExtractedData:   extractedData,
-		ChangesDetected: true,
-		ChangesSummary:  []string{"New employee created"},
-		ProcessingTime:  time.Since(startTime),
-		Status:          "completed",
-		Message:         "Employee created successfully",
+		EmployeeID:        employee.ID,
+		Action:            "created",
+		Employee:          employee,
+		ExtractedData:     resume,
+		ChangesDetected:   true,
+		ChangesSummary:    append([]string{"New employee created"}, diff.Summary...),
+		ProcessingTime:    time.Since(startTime),
+		Status:            "completed",
+		Message:           "Employee created successfully",
+		ExtractionVersion: s.recordExtraction(run),
 	}, nil
 }
 
@@ -142,45 +342,44 @@ func (s *CandidateStorageService) createNewEmployee(
 func (s *CandidateStorageService) updateExistingEmployee(
 	existingEmployee *models.Employee,
 	originalText string,
-	extractedData map[string]interface{},
+	resume *models.ProcessedResumeData,
 	extractionSource string,
 	startTime time.Time,
 	resumeURL string,
 ) (*models.CandidateExtractionResult, error) {
-	changesDetected := false
-	changesSummary := []string{}
-
-	// Check if original text has changed
-	if existingEmployee.OriginalText == nil || *existingEmployee.OriginalText != originalText {
-		changesDetected = true
-		changesSummary = append(changesSummary, "Original text updated")
-	}
-
-	// Check for changes in extracted data
-	if existingEmployee.ExtractedData != nil {
-		existingDataJSON, _ := json.Marshal(existingEmployee.ExtractedData)
-		newDataJSON, _ := json.Marshal(extractedData)
-		if string(existingDataJSON) != string(newDataJSON) {
-			changesDetected = true
-			changesSummary = append(changesSummary, "Extracted data updated")
-		}
-	} else {
-		changesDetected = true
-		changesSummary = append(changesSummary, "Extracted data added")
+	extractedData, err := resumeDataToMap(resume)
+	if err != nil {
+		return &models.CandidateExtractionResult{
+			EmployeeID:     existingEmployee.ID,
+			Status:         "failed",
+			Message:        fmt.Sprintf("Failed to encode extracted data: %v", err),
+			ProcessingTime: time.Since(startTime),
+		}, err
 	}
 
+	// Changes are what differs from the employee's latest extraction
+	run := newExtractionRun(existingEmployee.ID, originalText, resume, extractionSource, resumeURL)
+	previous, err := s.historyService.Latest(existingEmployee.ID)
+	if err != nil {
+		log.Printf("Failed to get latest extraction of employee %d, comparing with none: %v", existingEmployee.ID, err)
+	}
+	diff := DiffExtractions(previous, run)
+	changesDetected := diff.HasChanges()
+	changesSummary := diff.Summary
+
 	// If no changes detected, return without updating
 	if !changesDetected {
 		return &models.CandidateExtractionResult{
-			EmployeeID:      existingEmployee.ID,
-			Action:          "no_changes",
-			Employee:        existingEmployee,
-			ExtractedData:   extractedData,
-			ChangesDetected: false,
-			ChangesSummary:  []string{"No changes detected"},
-			ProcessingTime:  time.Since(startTime),
-			Status:          "completed",
-			Message:         "No changes detected, employee data is up to date",
+			EmployeeID:        existingEmployee.ID,
+			Action:            "no_changes",
+			Employee:          existingEmployee,
+			ExtractedData:     resume,
+			ChangesDetected:   false,
+			ChangesSummary:    []string{"No changes detected"},
+			ProcessingTime:    time.Since(startTime),
+			Status:            "completed",
+			Message:           "No changes detected, employee data is up to date",
+			ExtractionVersion: s.recordExtraction(run),
 		}, nil
 	}
 
@@ -193,25 +392,26 @@ func (s *CandidateStorageService) updateExistingEmployee(
 	status := "completed"
 	existingEmployee.ExtractionStatus = &status
 
-	// Extract updated information
-	contactInfo, _ := extractedData["contact_info"].(map[string]interface{})
-	location, _ := contactInfo["location"].(string)
-	summary, _ := extractedData["summary"].(string)
-
 	// Extract and normalize seniority level using improved extraction
-	seniorityLevel := s.extractAndNormalizeSeniorityLevel(extractedData, originalText)
+	seniorityLevel := s.extractAndNormalizeSeniorityLevel(resume, originalText)
 
 	// Extract last project from work experience
-	lastProject := s.extractLastProject(originalText)
+	lastProject := s.extractLastProjectFromResume(resume, originalText)
+
+	// Employees created from a resume without an email take the first one seen
+	email := existingEmployee.Email
+	if email == "" {
+		email = resumeEmail(resume)
+	}
 
 	// Update basic information with extraction data
 	updateReq := &models.CreateEmployeeRequest{
 		Name:       existingEmployee.Name,
-		Email:      existingEmployee.Email,
+		Email:      email,
 		Department: s.mapSeniorityToDepartment(seniorityLevel),
 		Level:      seniorityLevel, // Seniority level (e.g., "Senior", "Mid", "Junior")
-		Location:   location,
-		Bio:        summary,
+		Location:   resume.ContactInfo.Location,
+		Bio:        resume.ProfessionalSummary,
 		CurrentProject: func() string {
 			// Use extracted project if available, otherwise keep existing
 			if lastProject != "" {
@@ -223,7 +423,16 @@ func (s *CandidateStorageService) updateExistingEmployee(
 			return ""
 		}(), // Last project they worked on or existing project
 		ResumeUrl: resumeURL, // Resume URL from the extraction request
-		Skills:    s.extractSkillsFromData(extractedData),
+		Skills:    s.extractSkillsFromData(resume),
+
+		Languages: func() []models.SpokenLanguage {
+			// A resume that names no languages keeps the ones stored
+			if len(resume.SpokenLanguages) == 0 {
+				return existingEmployee.Languages
+			}
+			return resume.SpokenLanguages
+		}(),
+		EmployeeContact: resumeContact(resume, &existingEmployee.EmployeeContact),
 	}
 
 	updatedEmployee, err := s.employeeRepo.UpdateWithExtraction(
@@ -240,7 +449,7 @@ func (s *CandidateStorageService) updateExistingEmployee(
 			EmployeeID:      existingEmployee.ID,
 			Action:          "failed",
 			Employee:        existingEmployee,
-			ExtractedData:   extractedData,
+			ExtractedData:   resume,
 			ChangesDetected: changesDetected,
 			ChangesSummary:  changesSummary,
 			ProcessingTime:  time.Since(startTime),
@@ -250,23 +459,97 @@ func (s *CandidateStorageService) updateExistingEmployee(
 	}
 
 	return &models.CandidateExtractionResult{
-		EmployeeID:      updatedEmployee.ID,
-		Action:          "updated",
-		Employee:        updatedEmployee,
-		ExtractedData:   extractedData,
-		ChangesDetected: changesDetected,
-		ChangesSummary:  changesSummary,
-		ProcessingTime:  time.Since(startTime),
-		Status:          "completed",
-		Message:         "Employee updated successfully",
+		EmployeeID:        updatedEmployee.ID,
+		Action:            "updated",
+		Employee:          updatedEmployee,
+		ExtractedData:     resume,
+		ChangesDetected:   changesDetected,
+		ChangesSummary:    changesSummary,
+		ProcessingTime:    time.Since(startTime),
+		Status:            "completed",
+		Message:           "Employee updated successfully",
+		ExtractionVersion: s.recordExtraction(run),
 	}, nil
 }
 
+// resumeEmail returns the resume's email, or "" when it has none or only a placeholder made
+// up by the extractor
+func resumeEmail(resume *models.ProcessedResumeData) string {
+	if identity.NormalizeEmail(resume.ContactInfo.Email) == "" {
+		return ""
+	}
+	return strings.TrimSpace(resume.ContactInfo.Email)
+}
+
+// resumeContact returns the contact details of a resume, keeping the existing ones for details
+// the resume lacks. Phone numbers are only stored in E.164.
+func resumeContact(resume *models.ProcessedResumeData, existing *models.EmployeeContact) models.EmployeeContact {
+	info := resume.ContactInfo
+	contact := models.EmployeeContact{
+		City:             info.City,
+		Region:           info.Region,
+		Country:          info.Country,
+		LinkedInURL:      info.LinkedInURL,
+		GitHubURL:        info.GitHubURL,
+		GitLabURL:        info.GitLabURL,
+		StackOverflowURL: info.StackOverflowURL,
+		WebsiteURL:       info.WebsiteURL,
+	}
+	if strings.HasPrefix(info.Phone, "+") {
+		contact.Phone = info.Phone
+	}
+	if existing == nil {
+		return contact
+	}
+
+	keep := func(value *string, existing string) {
+		if *value == "" {
+			*value = existing
+		}
+	}
+	keep(&contact.Phone, existing.Phone)
+	// City, region and country describe one place, so they are kept together
+	if contact.City == "" && contact.Country == "" {
+		contact.City, contact.Region, contact.Country = existing.City, existing.Region, existing.Country
+	}
+	keep(&contact.LinkedInURL, existing.LinkedInURL)
+	keep(&contact.GitHubURL, existing.GitHubURL)
+	keep(&contact.GitLabURL, existing.GitLabURL)
+	keep(&contact.StackOverflowURL, existing.StackOverflowURL)
+	keep(&contact.WebsiteURL, existing.WebsiteURL)
+	return contact
+}
+
+// newExtractionRun describes an extraction run for the employee's history
+func newExtractionRun(employeeID int, originalText string, resume *models.ProcessedResumeData, extractionSource, resumeURL string) *models.EmployeeExtraction {
+	extractorVersion := constants.ExtractorVersion
+	return &models.EmployeeExtraction{
+		EmployeeID:       employeeID,
+		Source:           extractionSource,
+		ResumeURL:        resumeURL,
+		ExtractorMethod:  resume.ExtractionMethod,
+		ExtractorVersion: &extractorVersion,
+		OriginalText:     originalText,
+		ExtractedData:    resume,
+		Status:           "completed",
+	}
+}
+
+// recordExtraction adds a run to the employee's extraction history and returns its version.
+// The employee is already stored, so a failure is logged rather than failing the extraction.
+func (s *CandidateStorageService) recordExtraction(run *models.EmployeeExtraction) int {
+	if err := s.historyService.Record(run); err != nil {
+		log.Printf("Failed to record extraction history of employee %d: %v", run.EmployeeID, err)
+		return 0
+	}
+	return run.Version
+}
+
 // extractAndNormalizeSeniorityLevel extracts and normalizes seniority level from various formats
-func (s *CandidateStorageService) extractAndNormalizeSeniorityLevel(extractedData map[string]interface{}, originalText string) string {
+func (s *CandidateStorageService) extractAndNormalizeSeniorityLevel(resume *models.ProcessedResumeData, originalText string) string {
 	// First try to get from extracted data
-	if seniorityLevel, ok := extractedData["seniority_level"].(string); ok && seniorityLevel != "" {
-		return s.normalizeSeniorityLevel(seniorityLevel)
+	if resume.SeniorityLevel != "" {
+		return s.normalizeSeniorityLevel(resume.SeniorityLevel)
 	}
 
 	// If not found in extracted data, try to extract from original text
@@ -383,71 +666,100 @@ func (s *CandidateStorageService) mapSeniorityToDepartment(seniorityLevel string
 	}
 }
 
-// extractSkillsFromData extracts skills from extracted data (NER format)
-func (s *CandidateStorageService) extractSkillsFromData(extractedData map[string]interface{}) []models.EmployeeSkillReq {
+// extractSkillsFromData extracts skills from the taxonomy categories of the extracted data.
+// Years come from the dated positions that mention each skill or one of its aliases. Proficiency is the level
+// stated in the text ("expert in Go"), else derived from those years, else based on the
+// skill's category.
+func (s *CandidateStorageService) extractSkillsFromData(resume *models.ProcessedResumeData) []models.EmployeeSkillReq {
 	var skills []models.EmployeeSkillReq
 
-	// Extract skills from NER format - skills is directly the Categories map
-	if categories, ok := extractedData["skills"].(map[string]interface{}); ok {
-		// Handle the NER service format: map[string][]string
-		for categoryName, skillList := range categories {
-			if skillArray, ok := skillList.([]interface{}); ok {
-				for _, skill := range skillArray {
-					if skillName, ok := skill.(string); ok {
-						skills = append(skills, models.EmployeeSkillReq{
-							SkillName:        skillName,
-							ProficiencyLevel: s.getProficiencyLevelForCategory(categoryName),
-							YearsExperience:  s.getYearsExperienceForCategory(categoryName),
-						})
-					}
-				}
+	skillYears := resumeparser.SkillYears(resume.Experience, resumeSkillNames(resume), s.skillAliasesByName(), time.Now())
+
+	for categoryName, skillList := range resume.SkillCategories {
+		for _, skillName := range skillList {
+			proficiency := s.getProficiencyLevelForCategory(categoryName)
+			years, ok := skillYears[skillName]
+			if stated, found := resume.SkillProficiency[skillName]; found {
+				proficiency = stated.Level
+			} else if ok {
+				proficiency = s.getProficiencyLevelForYears(years)
 			}
+
+			skills = append(skills, models.EmployeeSkillReq{
+				SkillName:        skillName,
+				ProficiencyLevel: proficiency,
+				YearsExperience:  years,
+			})
 		}
 	}
 
 	return skills
 }
 
-// getProficiencyLevelForCategory returns appropriate proficiency level based on skill category
+// skillAliasesByName returns the aliases of the catalog skills keyed by lowercased skill
+// name. Without them years are still found for mentions of the skill names.
+func (s *CandidateStorageService) skillAliasesByName() map[string][]string {
+	aliases, err := s.skillRepo.GetSkillAliases()
+	if err != nil {
+		log.Printf("Failed to load skill aliases, matching skill names only: %v", err)
+		return nil
+	}
+	if len(aliases) == 0 {
+		return nil
+	}
+
+	skills, err := s.skillRepo.GetAll()
+	if err != nil {
+		log.Printf("Failed to load skills for their aliases, matching skill names only: %v", err)
+		return nil
+	}
+
+	byName := make(map[string][]string, len(aliases))
+	for _, skill := range skills {
+		if skillAliases, ok := aliases[skill.ID]; ok {
+			byName[strings.ToLower(skill.Name)] = skillAliases
+		}
+	}
+	return byName
+}
+
+// getProficiencyLevelForCategory returns the proficiency level for skills without dated
+// experience. Listing a skill alone shows working knowledge, not expertise.
 func (s *CandidateStorageService) getProficiencyLevelForCategory(categoryName string) int {
 	category := strings.ToLower(categoryName)
 
-	// Higher proficiency for core technical skills
 	switch {
-	case strings.Contains(category, "programming") || strings.Contains(category, "language"):
-		return 4
-	case strings.Contains(category, "framework") || strings.Contains(category, "library"):
-		return 3
-	case strings.Contains(category, "tool") || strings.Contains(category, "software"):
-		return 3
-	case strings.Contains(category, "database") || strings.Contains(category, "cloud"):
-		return 3
 	case strings.Contains(category, "methodology") || strings.Contains(category, "process"):
 		return 2
 	default:
-		return 3 // Default proficiency level
+		return 3
 	}
 }
 
-// getYearsExperienceForCategory returns appropriate years of experience based on skill category
-func (s *CandidateStorageService) getYearsExperienceForCategory(categoryName string) float64 {
-	category := strings.ToLower(categoryName)
-
-	// More experience typically needed for advanced categories
+// getProficiencyLevelForYears returns the proficiency level for years of dated experience
+// with a skill
+func (s *CandidateStorageService) getProficiencyLevelForYears(years float64) int {
 	switch {
-	case strings.Contains(category, "programming") || strings.Contains(category, "language"):
-		return 2.0
-	case strings.Contains(category, "framework") || strings.Contains(category, "library"):
-		return 1.5
-	case strings.Contains(category, "tool") || strings.Contains(category, "software"):
-		return 1.0
-	case strings.Contains(category, "database") || strings.Contains(category, "cloud"):
-		return 1.5
-	case strings.Contains(category, "methodology") || strings.Contains(category, "process"):
-		return 1.0
+	case years >= 7:
+		return 5
+	case years >= 4:
+		return 4
+	case years >= 2:
+		return 3
+	case years >= 1:
+		return 2
 	default:
-		return 1.0 // Default years of experience
+		return 1
+	}
+}
+
+// extractLastProjectFromResume returns the first project of the projects section, falling back
+// to scanning the resume text
+func (s *CandidateStorageService) extractLastProjectFromResume(resume *models.ProcessedResumeData, originalText string) string {
+	if len(resume.Projects) > 0 && resume.Projects[0].Name != "" {
+		return resume.Projects[0].Name
 	}
+	return s.extractLastProject(originalText)
 }
 
 // extractLastProject extracts the last project from work experience text
@@ -10,15 +10,23 @@ import (
 
 type categoryService struct {
 	categoryRepo repositories.CategoryRepository
+	events       *SkillCatalogEvents
 }
 
-// NewCategoryService creates a new category service
-func NewCategoryService(categoryRepo repositories.CategoryRepository) CategoryService {
+// NewCategoryService creates a new category service. Changes are published to events, which
+// may be nil.
+func NewCategoryService(categoryRepo repositories.CategoryRepository, events *SkillCatalogEvents) CategoryService {
 	return &categoryService{
 		categoryRepo: categoryRepo,
+		events:       events,
 	}
 }
 
+// publish announces a change to categories
+func (s *categoryService) publish(action string, ids ...int) {
+	s.events.Publish(SkillCatalogEvent{Action: action, Resource: SkillCatalogCategory, IDs: ids})
+}
+
 func (s *categoryService) GetAllCategories() ([]models.Category, error) {
 	return s.categoryRepo.GetAll()
 }
@@ -51,7 +59,12 @@ func (s *categoryService) CreateCategory(category *models.Category) (*models.Cat
 		return nil, &ConflictError{Resource: "category", Message: "Category already exists"}
 	}
 
-	return s.categoryRepo.Create(category)
+	created, err := s.categoryRepo.Create(category)
+	if err != nil {
+		return nil, err
+	}
+	s.publish(SkillCatalogCreated, created.ID)
+	return created, nil
 }
 
 func (s *categoryService) CreateCategoriesBatch(categories []models.Category) ([]models.Category, error) {
@@ -70,7 +83,16 @@ func (s *categoryService) CreateCategoriesBatch(categories []models.Category) ([
 		}
 	}
 
-	return s.categoryRepo.CreateBatch(categories)
+	created, err := s.categoryRepo.CreateBatch(categories)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int, 0, len(created))
+	for _, category := range created {
+		ids = append(ids, category.ID)
+	}
+	s.publish(SkillCatalogCreated, ids...)
+	return created, nil
 }
 
 func (s *categoryService) UpdateCategory(id int, category *models.Category) (*models.Category, error) {
@@ -94,7 +116,12 @@ func (s *categoryService) UpdateCategory(id int, category *models.Category) (*mo
 		return nil, &ConflictError{Resource: "category", Message: "Another category with this name already exists"}
 	}
 
-	return s.categoryRepo.Update(id, category)
+	updated, err := s.categoryRepo.Update(id, category)
+	if err != nil {
+		return nil, err
+	}
+	s.publish(SkillCatalogUpdated, id)
+	return updated, nil
 }
 
 func (s *categoryService) DeleteCategory(id int) error {
@@ -107,7 +134,11 @@ func (s *categoryService) DeleteCategory(id int) error {
 		return err
 	}
 
-	return s.categoryRepo.Delete(id)
+	if err := s.categoryRepo.Delete(id); err != nil {
+		return err
+	}
+	s.publish(SkillCatalogDeleted, id)
+	return nil
 }
 
 func (s *categoryService) DeleteCategoriesBatch(ids []int) error {
@@ -129,7 +160,11 @@ func (s *categoryService) DeleteCategoriesBatch(ids []int) error {
 		}
 	}
 
-	return s.categoryRepo.DeleteBatch(validIDs)
+	if err := s.categoryRepo.DeleteBatch(validIDs); err != nil {
+		return err
+	}
+	s.publish(SkillCatalogDeleted, validIDs...)
+	return nil
 }
 
 func (s *categoryService) GetCategoryStats() (*models.SkillStats, error) {
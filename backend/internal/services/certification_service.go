@@ -0,0 +1,452 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"stafind-backend/internal/constants"
+	"stafind-backend/internal/identity"
+	"stafind-backend/internal/models"
+	"stafind-backend/internal/repositories"
+
+	"github.com/lib/pq"
+)
+
+// dateLayout is the layout of certification dates
+const dateLayout = "2006-01-02"
+
+// CertificationService manages the certification catalogue and the certifications employees
+// hold, matches the certifications found in resumes to the catalogue, and announces the
+// certifications about to expire
+type CertificationService struct {
+	certificationRepo   repositories.CertificationRepository
+	employeeRepo        repositories.EmployeeRepository
+	notificationService NotificationService
+	noticeDays          int
+}
+
+// NewCertificationService creates a new certification service. Expiries are announced
+// CERTIFICATION_EXPIRY_NOTICE_DAYS ahead.
+func NewCertificationService(
+	certificationRepo repositories.CertificationRepository,
+	employeeRepo repositories.EmployeeRepository,
+	notificationService NotificationService,
+) *CertificationService {
+	service := &CertificationService{
+		certificationRepo:   certificationRepo,
+		employeeRepo:        employeeRepo,
+		notificationService: notificationService,
+		noticeDays:          constants.DefaultCertificationExpiryNoticeDays,
+	}
+
+	if value, err := strconv.Atoi(os.Getenv(constants.EnvCertificationExpiryNoticeDays)); err == nil && value >= 0 {
+		service.noticeDays = value
+	}
+
+	return service
+}
+
+// ListCatalog returns the certification catalogue
+func (s *CertificationService) ListCatalog() ([]models.Certification, error) {
+	return s.certificationRepo.ListCatalog()
+}
+
+// GetCatalogEntry returns a catalogue certification
+func (s *CertificationService) GetCatalogEntry(id int) (*models.Certification, error) {
+	certification, err := s.certificationRepo.GetCatalogEntry(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, &NotFoundError{Resource: "certification", ID: id}
+	}
+	return certification, err
+}
+
+// CreateCatalogEntry adds a certification to the catalogue
+func (s *CertificationService) CreateCatalogEntry(req *models.CertificationRequest) (*models.Certification, error) {
+	certification, err := catalogEntry(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.certificationRepo.CreateCatalogEntry(certification); err != nil {
+		return nil, catalogConflict(err)
+	}
+	return certification, nil
+}
+
+// UpdateCatalogEntry updates a catalogue certification. Employee certifications already matched
+// to it keep their dates.
+func (s *CertificationService) UpdateCatalogEntry(id int, req *models.CertificationRequest) (*models.Certification, error) {
+	certification, err := catalogEntry(req)
+	if err != nil {
+		return nil, err
+	}
+	certification.ID = id
+
+	if err := s.certificationRepo.UpdateCatalogEntry(certification); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &NotFoundError{Resource: "certification", ID: id}
+		}
+		return nil, catalogConflict(err)
+	}
+	return certification, nil
+}
+
+// DeleteCatalogEntry removes a certification from the catalogue. Employees keep the
+// certifications matched to it, unmatched.
+func (s *CertificationService) DeleteCatalogEntry(id int) error {
+	deleted, err := s.certificationRepo.DeleteCatalogEntry(id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return &NotFoundError{Resource: "certification", ID: id}
+	}
+	return nil
+}
+
+// ListEmployeeCertifications returns the certifications of an employee, soonest to expire first
+func (s *CertificationService) ListEmployeeCertifications(employeeID int) ([]models.EmployeeCertification, error) {
+	if err := s.checkEmployee(employeeID); err != nil {
+		return nil, err
+	}
+	return s.certificationRepo.ListByEmployee(employeeID)
+}
+
+// AddEmployeeCertification adds a certification to an employee by hand
+func (s *CertificationService) AddEmployeeCertification(employeeID int, req *models.EmployeeCertificationRequest) (*models.EmployeeCertification, error) {
+	if err := s.checkEmployee(employeeID); err != nil {
+		return nil, err
+	}
+
+	certification, err := s.employeeCertification(req)
+	if err != nil {
+		return nil, err
+	}
+	certification.EmployeeID = employeeID
+	certification.Source = models.CertificationSourceManual
+
+	if err := s.certificationRepo.Create(certification); err != nil {
+		return nil, employeeCertificationConflict(err)
+	}
+	return s.certificationRepo.Get(employeeID, certification.ID)
+}
+
+// UpdateEmployeeCertification replaces a certification of an employee. Once edited, it is kept
+// as it is when the employee's resume is extracted again.
+func (s *CertificationService) UpdateEmployeeCertification(employeeID, id int, req *models.EmployeeCertificationRequest) (*models.EmployeeCertification, error) {
+	certification, err := s.employeeCertification(req)
+	if err != nil {
+		return nil, err
+	}
+	certification.ID = id
+	certification.EmployeeID = employeeID
+
+	updated, err := s.certificationRepo.Update(certification)
+	if err != nil {
+		return nil, employeeCertificationConflict(err)
+	}
+	if !updated {
+		return nil, &NotFoundError{Resource: "employee certification", ID: id}
+	}
+	return s.certificationRepo.Get(employeeID, id)
+}
+
+// DeleteEmployeeCertification removes a certification from an employee
+func (s *CertificationService) DeleteEmployeeCertification(employeeID, id int) error {
+	deleted, err := s.certificationRepo.Delete(employeeID, id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return &NotFoundError{Resource: "employee certification", ID: id}
+	}
+	return nil
+}
+
+// StoreResumeCertifications stores the certifications found in an employee's resume, matched
+// to the catalogue, in place of those found in an earlier version of it. A resume without
+// certifications leaves the employee's as they are.
+func (s *CertificationService) StoreResumeCertifications(employeeID int, found []models.ResumeCertification) error {
+	if len(found) == 0 {
+		return nil
+	}
+
+	catalog, err := s.certificationRepo.ListCatalog()
+	if err != nil {
+		return err
+	}
+
+	var certifications []models.EmployeeCertification
+	seen := make(map[string]bool)
+	for _, resumeCertification := range found {
+		key := strings.ToLower(strings.TrimSpace(resumeCertification.Name))
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		certification := models.EmployeeCertification{
+			EmployeeID:   employeeID,
+			Name:         strings.TrimSpace(resumeCertification.Name),
+			Issuer:       resumeCertification.Issuer,
+			CredentialID: resumeCertification.CredentialID,
+			IssuedOn:     resumeDate(resumeCertification.IssuedOn, false),
+			ExpiresOn:    resumeDate(resumeCertification.ExpiresOn, true),
+			Source:       models.CertificationSourceResume,
+		}
+		if entry := matchCatalog(certification.Name, catalog); entry != nil {
+			certification.CertificationID = &entry.ID
+			if certification.Issuer == "" {
+				certification.Issuer = entry.Issuer
+			}
+			// Only an issue month dates the expiry closely enough to announce it
+			if certification.ExpiresOn == "" && len(resumeCertification.IssuedOn) == len("2006-01") {
+				certification.ExpiresOn = expiryAfter(certification.IssuedOn, entry.ValidityMonths)
+			}
+		}
+		certifications = append(certifications, certification)
+	}
+
+	return s.certificationRepo.SyncResumeCertifications(employeeID, certifications)
+}
+
+// NoticeDays returns how many days ahead certification expiries are announced; 0 when the
+// notices are turned off
+func (s *CertificationService) NoticeDays() int {
+	return s.noticeDays
+}
+
+// NotifyExpiring announces, on Teams and to the admin, the certifications expiring within the
+// notice period that were not announced yet. Each expiry is announced once; certifications
+// whose announcement failed are announced on the next run.
+func (s *CertificationService) NotifyExpiring() (*models.CertificationExpiryNotice, error) {
+	notice := &models.CertificationExpiryNotice{Days: s.noticeDays, Certifications: []models.ExpiringCertification{}}
+	if s.noticeDays == 0 {
+		return notice, nil
+	}
+
+	expiring, err := s.certificationRepo.ClaimExpiring(s.noticeDays)
+	if err != nil {
+		return nil, err
+	}
+	if len(expiring) == 0 {
+		return notice, nil
+	}
+
+	title := fmt.Sprintf("Certifications expiring within %d days", s.noticeDays)
+	message := expiryMessage(expiring)
+	teamsErr := s.notificationService.SendTeamsNotification(title, message)
+	emailErr := s.notificationService.SendAdminEmail(title, message)
+	if teamsErr != nil && emailErr != nil {
+		ids := make([]int, len(expiring))
+		for i, certification := range expiring {
+			ids[i] = certification.ID
+		}
+		if err := s.certificationRepo.ReleaseExpiring(ids); err != nil {
+			log.Printf("Failed to release %d expiring certifications for the next run: %v", len(ids), err)
+		}
+		return nil, fmt.Errorf("failed to announce expiring certifications: %w", teamsErr)
+	}
+	if teamsErr != nil {
+		log.Printf("Expiring certifications not announced on Teams: %v", teamsErr)
+	}
+
+	notice.Certifications = expiring
+	return notice, nil
+}
+
+// checkEmployee returns a NotFoundError when an employee does not exist
+func (s *CertificationService) checkEmployee(employeeID int) error {
+	if _, err := s.employeeRepo.GetByID(employeeID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &NotFoundError{Resource: "employee", ID: employeeID}
+		}
+		return err
+	}
+	return nil
+}
+
+// employeeCertification validates a request for an employee certification and completes it
+// from the catalogue: the certification asked for by ID, or else the one its name matches
+func (s *CertificationService) employeeCertification(req *models.EmployeeCertificationRequest) (*models.EmployeeCertification, error) {
+	certification := &models.EmployeeCertification{
+		CertificationID: req.CertificationID,
+		Name:            strings.TrimSpace(req.Name),
+		Issuer:          strings.TrimSpace(req.Issuer),
+		CredentialID:    strings.TrimSpace(req.CredentialID),
+		IssuedOn:        strings.TrimSpace(req.IssuedOn),
+		ExpiresOn:       strings.TrimSpace(req.ExpiresOn),
+	}
+
+	for field, value := range map[string]string{"issued_on": certification.IssuedOn, "expires_on": certification.ExpiresOn} {
+		if _, err := time.Parse(dateLayout, value); value != "" && err != nil {
+			return nil, &ValidationError{Field: field, Message: "must be a date such as 2025-03-31"}
+		}
+	}
+	if certification.IssuedOn != "" && certification.ExpiresOn != "" && certification.ExpiresOn < certification.IssuedOn {
+		return nil, &ValidationError{Field: "expires_on", Message: "must not be before issued_on"}
+	}
+
+	var entry *models.Certification
+	if req.CertificationID != nil {
+		found, err := s.certificationRepo.GetCatalogEntry(*req.CertificationID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, &ValidationError{Field: "certification_id", Message: "no such certification in the catalogue"}
+			}
+			return nil, err
+		}
+		entry = found
+	} else if certification.Name != "" {
+		catalog, err := s.certificationRepo.ListCatalog()
+		if err != nil {
+			return nil, err
+		}
+		entry = matchCatalog(certification.Name, catalog)
+	}
+
+	if certification.Name == "" {
+		if entry == nil {
+			return nil, &ValidationError{Field: "name", Message: "name or certification_id is required"}
+		}
+		certification.Name = entry.Name
+	}
+	if entry != nil {
+		certification.CertificationID = &entry.ID
+		if certification.Issuer == "" {
+			certification.Issuer = entry.Issuer
+		}
+		if certification.ExpiresOn == "" && certification.IssuedOn != "" {
+			certification.ExpiresOn = expiryAfter(certification.IssuedOn, entry.ValidityMonths)
+		}
+	}
+
+	return certification, nil
+}
+
+// catalogEntry validates a request for a catalogue certification
+func catalogEntry(req *models.CertificationRequest) (*models.Certification, error) {
+	certification := &models.Certification{
+		Name:           strings.TrimSpace(req.Name),
+		Issuer:         strings.TrimSpace(req.Issuer),
+		Aliases:        []string{},
+		ValidityMonths: req.ValidityMonths,
+	}
+	if certification.Name == "" {
+		return nil, &ValidationError{Field: "name", Message: "is required"}
+	}
+	if certification.Issuer == "" {
+		return nil, &ValidationError{Field: "issuer", Message: "is required"}
+	}
+	if req.ValidityMonths != nil && *req.ValidityMonths <= 0 {
+		return nil, &ValidationError{Field: "validity_months", Message: "must be positive; leave it out for certifications that do not expire"}
+	}
+	for _, alias := range req.Aliases {
+		if alias = strings.TrimSpace(alias); alias != "" {
+			certification.Aliases = append(certification.Aliases, alias)
+		}
+	}
+	return certification, nil
+}
+
+// catalogConflict reports a catalogue certification whose name is taken as a ConflictError
+func catalogConflict(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return &ConflictError{Resource: "certification", Message: "A certification with this name already exists"}
+	}
+	return err
+}
+
+// employeeCertificationConflict reports a certification the employee already holds as a
+// ConflictError
+func employeeCertificationConflict(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return &ConflictError{Resource: "employee certification", Message: "The employee already holds a certification with this name"}
+	}
+	return err
+}
+
+// matchCatalog returns the catalogue certification a name refers to: the one it names exactly,
+// by name or alias, or else the one with the longest name or alias within it, as "AWS Certified
+// Solutions Architect - Associate" within "AWS Certified Solutions Architect - Associate
+// (SAA-C03)". Nil when there is none.
+func matchCatalog(name string, catalog []models.Certification) *models.Certification {
+	normalized := identity.NormalizeName(name)
+	if normalized == "" {
+		return nil
+	}
+
+	var best *models.Certification
+	bestLength := 0
+	for i := range catalog {
+		for _, term := range append([]string{catalog[i].Name}, catalog[i].Aliases...) {
+			term = identity.NormalizeName(term)
+			if term == "" {
+				continue
+			}
+			if term == normalized {
+				return &catalog[i]
+			}
+			if len(term) > bestLength && strings.Contains(" "+normalized+" ", " "+term+" ") {
+				best, bestLength = &catalog[i], len(term)
+			}
+		}
+	}
+	return best
+}
+
+// resumeDate turns a resume date, YYYY-MM or YYYY, into a date: the first day of the period
+// for issue and start dates and, with end, the last for expiry and end dates
+func resumeDate(value string, end bool) string {
+	for _, layout := range []string{"2006-01", "2006"} {
+		date, err := time.Parse(layout, value)
+		if err != nil {
+			continue
+		}
+		if end && layout == "2006-01" {
+			date = date.AddDate(0, 1, -1)
+		} else if end {
+			date = date.AddDate(1, 0, -1)
+		}
+		return date.Format(dateLayout)
+	}
+	return ""
+}
+
+// expiryAfter returns the date a certification issued on a date expires after a number of
+// months, "" when it does not expire. A day the expiry month lacks becomes its last day, so
+// one month after January 31 is February 28 or 29, not early March.
+func expiryAfter(issuedOn string, validityMonths *int) string {
+	issued, err := time.Parse(dateLayout, issuedOn)
+	if err != nil || validityMonths == nil {
+		return ""
+	}
+	month := time.Date(issued.Year(), issued.Month()+time.Month(*validityMonths), 1, 0, 0, 0, 0, time.UTC)
+	lastDay := month.AddDate(0, 1, -1).Day()
+	return month.AddDate(0, 0, min(issued.Day(), lastDay)-1).Format(dateLayout)
+}
+
+// expiryMessage lists expiring certifications, soonest first, with their holders
+func expiryMessage(expiring []models.ExpiringCertification) string {
+	sort.SliceStable(expiring, func(i, j int) bool { return expiring[i].ExpiresOn < expiring[j].ExpiresOn })
+
+	lines := make([]string, 0, len(expiring))
+	for _, certification := range expiring {
+		name := certification.Name
+		if certification.CatalogName != "" {
+			name = certification.CatalogName
+		}
+		lines = append(lines, fmt.Sprintf("- %s (%s): %s, expires on %s",
+			certification.EmployeeName, certification.EmployeeEmail, name, certification.ExpiresOn))
+	}
+	return strings.Join(lines, "\n\n")
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"strconv"
+	"testing"
+
+	"stafind-backend/internal/models"
+)
+
+func TestResumeDate(t *testing.T) {
+	tests := []struct {
+		value string
+		end   bool
+		want  string
+	}{
+		{"2023-04", false, "2023-04-01"},
+		{"2023-04", true, "2023-04-30"},
+		{"2023-12", true, "2023-12-31"},
+		// Month ends follow the calendar, leap years included
+		{"2024-02", true, "2024-02-29"},
+		{"2023-02", true, "2023-02-28"},
+		// A year alone covers the whole year
+		{"2021", false, "2021-01-01"},
+		{"2021", true, "2021-12-31"},
+		{"", false, ""},
+		{"", true, ""},
+		{"April 2023", false, ""},
+		{"2023-13", true, ""},
+		{"2023-04-15", false, ""},
+	}
+
+	for _, tt := range tests {
+		if got := resumeDate(tt.value, tt.end); got != tt.want {
+			t.Errorf("resumeDate(%q, %v) = %q, want %q", tt.value, tt.end, got, tt.want)
+		}
+	}
+}
+
+func TestExpiryAfter(t *testing.T) {
+	months := func(n int) *int { return &n }
+
+	tests := []struct {
+		issuedOn string
+		validity *int
+		want     string
+	}{
+		{"2023-04-01", months(36), "2026-04-01"},
+		{"2023-04-15", months(12), "2024-04-15"},
+		{"2023-11-20", months(3), "2024-02-20"},
+		{"2023-04-01", months(0), "2023-04-01"},
+		// A day the expiry month lacks becomes its last day
+		{"2024-01-31", months(1), "2024-02-29"},
+		{"2023-01-31", months(1), "2023-02-28"},
+		{"2023-08-31", months(6), "2024-02-29"},
+		{"2023-05-31", months(1), "2023-06-30"},
+		{"2024-02-29", months(12), "2025-02-28"},
+		{"2024-02-29", months(48), "2028-02-29"},
+		{"2023-12-31", months(2), "2024-02-29"},
+		// No validity: the certification does not expire
+		{"2023-04-01", nil, ""},
+		{"", months(12), ""},
+		{"2023-04", months(12), ""},
+	}
+
+	for _, tt := range tests {
+		validity := "nil"
+		if tt.validity != nil {
+			validity = strconv.Itoa(*tt.validity)
+		}
+		if got := expiryAfter(tt.issuedOn, tt.validity); got != tt.want {
+			t.Errorf("expiryAfter(%q, %s) = %q, want %q", tt.issuedOn, validity, got, tt.want)
+		}
+	}
+}
+
+func TestMatchCatalog(t *testing.T) {
+	catalog := []models.Certification{
+		{ID: 1, Name: "AWS Certified Solutions Architect - Associate", Aliases: []string{"SAA-C03"}},
+		{ID: 2, Name: "AWS Certified Solutions Architect - Professional", Aliases: []string{"SAP-C02"}},
+		{ID: 3, Name: "AWS Certified Cloud Practitioner"},
+		{ID: 4, Name: "Certified Kubernetes Administrator", Aliases: []string{"CKA"}},
+		{ID: 5, Name: "Scrum Master"},
+		{ID: 6, Name: "Professional Scrum Master", Aliases: []string{"PSM I"}},
+	}
+
+	tests := []struct {
+		name string
+		want int // Catalogue ID, 0 for no match
+	}{
+		{"AWS Certified Solutions Architect - Associate", 1},
+		// Case, accents and punctuation do not matter
+		{"aws certified solutions architect associate", 1},
+		{"CKA", 4},
+		{"cka", 4},
+		// The longest name within wins
+		{"AWS Certified Solutions Architect - Associate (SAA-C03)", 1},
+		{"AWS Certified Solutions Architect - Professional, 2023", 2},
+		{"Professional Scrum Master I (PSM I)", 6},
+		{"Scrum Master certification", 5},
+		// An exact alias beats a longer name mentioned within
+		{"PSM I", 6},
+		{"Kubernetes CKA exam", 4},
+		// Whole words only
+		{"CKAD", 0},
+		{"Certified Kubernetes Application Developer", 0},
+		{"AWS Certified", 0},
+		{"", 0},
+	}
+
+	for _, tt := range tests {
+		got := 0
+		if entry := matchCatalog(tt.name, catalog); entry != nil {
+			got = entry.ID
+		}
+		if got != tt.want {
+			t.Errorf("matchCatalog(%q) = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
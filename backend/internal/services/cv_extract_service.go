@@ -15,7 +15,7 @@ type CVExtractService interface {
 	UpdateExtractProgress(requestID string, filesProcessed int, filesFailed int) (*models.CVExtract, error)
 	MarkExtractSuccess(requestID string, totalTimeMs int64) (*models.CVExtract, error)
 	MarkExtractFailed(requestID string, errorMessage string) (*models.CVExtract, error)
-	UpdateFileProgress(requestID string, currentFile int, filesProcessed int, filesFailed int) (*models.CVExtract, error)
+	UpdateFileProgress(requestID string, fileNumber int, fileStatus string) (*models.CVExtract, error)
 	CompleteExtract(requestID string, totalTimeMs int64, errorMessage *string) (*models.CVExtract, error)
 	GetExtractStats(filters repositories.CVExtractStatsFilters) (*models.CVExtractStats, error)
 	ListExtracts(filters repositories.CVExtractFilters) ([]models.CVExtract, int64, error)
@@ -245,21 +245,21 @@ func (s *cvExtractService) MarkExtractFailed(requestID string, errorMessage stri
 	return updatedExtract, nil
 }
 
-// UpdateFileProgress updates the progress of file processing
-func (s *cvExtractService) UpdateFileProgress(requestID string, currentFile int, filesProcessed int, filesFailed int) (*models.CVExtract, error) {
+// UpdateFileProgress records the outcome of one file (models.CVExtractFileStatus*) and returns the
+// extract with recalculated counts. Calling it again for the same file number is safe: the file is
+// counted once, with its latest outcome.
+func (s *cvExtractService) UpdateFileProgress(requestID string, fileNumber int, fileStatus string) (*models.CVExtract, error) {
+	if fileStatus != models.CVExtractFileStatusProcessed && fileStatus != models.CVExtractFileStatusFailed {
+		return nil, fmt.Errorf("invalid file status: %s", fileStatus)
+	}
+
 	// Get existing extract
 	extract, err := s.extractRepo.GetByRequestID(requestID)
 	if err != nil {
 		return nil, fmt.Errorf("CV extract not found: %w", err)
 	}
 
-	// Update progress
-	updateData := models.CVExtractUpdate{
-		FilesProcessed: &filesProcessed,
-		FilesFailed:    &filesFailed,
-	}
-
-	updatedExtract, err := s.extractRepo.Update(extract.ID, updateData)
+	updatedExtract, err := s.extractRepo.RecordFileResult(extract.ID, fileNumber, fileStatus)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update CV extract progress: %w", err)
 	}
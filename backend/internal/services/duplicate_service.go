@@ -0,0 +1,314 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+
+	"stafind-backend/internal/constants"
+	"stafind-backend/internal/identity"
+	"stafind-backend/internal/models"
+	"stafind-backend/internal/repositories"
+)
+
+// DuplicateService recognises employees that are probably the same person. Each employee
+// keeps the identifiers of every resume stored for it (email, phone, LinkedIn and GitHub
+// profiles, text fingerprints); a new resume or a scan compares those and the names.
+//
+// Pairs scoring at or above the review threshold are flagged for review. A resume scoring
+// at or above the auto-merge threshold against an employee updates that employee, and a scan
+// asked to auto-merge merges pairs at or above it that share an email address. Pairs
+// dismissed as different people are never flagged or merged again.
+type DuplicateService struct {
+	duplicateRepo      repositories.EmployeeDuplicateRepository
+	employeeRepo       repositories.EmployeeRepository
+	reviewThreshold    float64
+	autoMergeThreshold float64
+}
+
+// NewDuplicateService creates a duplicate service. Thresholds come from
+// DUPLICATE_REVIEW_THRESHOLD and DUPLICATE_AUTO_MERGE_THRESHOLD when set.
+func NewDuplicateService(duplicateRepo repositories.EmployeeDuplicateRepository, employeeRepo repositories.EmployeeRepository) *DuplicateService {
+	service := &DuplicateService{
+		duplicateRepo:      duplicateRepo,
+		employeeRepo:       employeeRepo,
+		reviewThreshold:    constants.DefaultDuplicateReviewThreshold,
+		autoMergeThreshold: constants.DefaultDuplicateAutoMergeThreshold,
+	}
+
+	if value, err := strconv.ParseFloat(os.Getenv(constants.EnvDuplicateReviewThreshold), 64); err == nil && value > 0 && value <= 1 {
+		service.reviewThreshold = value
+	}
+	if value, err := strconv.ParseFloat(os.Getenv(constants.EnvDuplicateAutoMergeThreshold), 64); err == nil && value > 0 && value <= 1 {
+		service.autoMergeThreshold = value
+	}
+	if service.autoMergeThreshold < service.reviewThreshold {
+		log.Printf("Duplicate auto-merge threshold %.2f is below the review threshold %.2f; using %.2f for both",
+			service.autoMergeThreshold, service.reviewThreshold, service.reviewThreshold)
+		service.autoMergeThreshold = service.reviewThreshold
+	}
+
+	return service
+}
+
+// AutoMergeThreshold is the lowest score at which a resume updates the matched employee
+func (s *DuplicateService) AutoMergeThreshold() float64 {
+	return s.autoMergeThreshold
+}
+
+// ResumeProfile collects the name and identifiers of a resume
+func ResumeProfile(originalText string, resume *models.ProcessedResumeData) identity.Profile {
+	profile := identity.Profile{Name: resume.CandidateName}
+	profile.Add(identity.KindEmail, identity.NormalizeEmail(resume.ContactInfo.Email))
+	profile.Add(identity.KindPhone, identity.NormalizePhone(resume.ContactInfo.Phone))
+	for _, handle := range identity.ProfileHandles(originalText) {
+		profile.Add(handle.Kind, handle.Value)
+	}
+	profile.Add(identity.KindFingerprint, identity.Fingerprint(originalText))
+	return profile
+}
+
+// FindCandidates returns the employees at or above the review threshold for a profile, most
+// likely first. excludeID leaves out the employee the profile belongs to, if any.
+func (s *DuplicateService) FindCandidates(profile identity.Profile, excludeID int) ([]models.DuplicateCandidate, error) {
+	profiles, err := s.duplicateRepo.ListIdentityProfiles()
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := []models.DuplicateCandidate{}
+	for _, stored := range profiles {
+		if stored.EmployeeID == excludeID {
+			continue
+		}
+		score, reasons := identity.Compare(profile, storedProfile(stored))
+		if score >= s.reviewThreshold {
+			candidates = append(candidates, models.DuplicateCandidate{
+				EmployeeID: stored.EmployeeID,
+				Name:       stored.Name,
+				Score:      score,
+				Reasons:    reasons,
+			})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+	return candidates, nil
+}
+
+// Record stores a resume's identifiers for an employee and flags the candidates found for
+// it for review
+func (s *DuplicateService) Record(employeeID int, profile identity.Profile, candidates []models.DuplicateCandidate) error {
+	identities := make([]models.EmployeeIdentity, 0, len(profile.Identifiers))
+	for _, identifier := range profile.Identifiers {
+		identities = append(identities, models.EmployeeIdentity{Kind: identifier.Kind, Value: identifier.Value})
+	}
+	if err := s.duplicateRepo.AddIdentities(employeeID, identities); err != nil {
+		return err
+	}
+
+	for _, candidate := range candidates {
+		if candidate.EmployeeID == employeeID {
+			continue
+		}
+		if err := s.duplicateRepo.UpsertDuplicate(employeeID, candidate.EmployeeID, candidate.Score, candidate.Reasons); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CandidatesFor returns the employees that may be the same person as an employee
+func (s *DuplicateService) CandidatesFor(employeeID int) ([]models.DuplicateCandidate, error) {
+	profiles, err := s.duplicateRepo.ListIdentityProfiles()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, stored := range profiles {
+		if stored.EmployeeID == employeeID {
+			return s.FindCandidates(storedProfile(stored), employeeID)
+		}
+	}
+	return nil, NewNotFoundError("employee not found")
+}
+
+// scanPair is a pair of employees compared during a scan
+type scanPair struct {
+	employeeID, duplicateID int
+	score                   float64
+	reasons                 []string
+	sameEmail               bool
+}
+
+// Scan compares every pair of employees and flags those at or above the review threshold.
+// With autoMerge, pairs at or above the auto-merge threshold that share an email address are
+// merged instead, most likely first, keeping the older employee. Dismissed pairs are skipped.
+func (s *DuplicateService) Scan(autoMerge bool) (*models.DuplicateScanResult, error) {
+	profiles, err := s.duplicateRepo.ListIdentityProfiles()
+	if err != nil {
+		return nil, err
+	}
+
+	dismissed, err := s.duplicateRepo.ListDuplicates(models.DuplicateStatusDismissed)
+	if err != nil {
+		return nil, err
+	}
+	different := make(map[[2]int]bool, len(dismissed))
+	for _, pair := range dismissed {
+		different[pairKey(pair.EmployeeID, pair.DuplicateID)] = true
+	}
+
+	var pairs []scanPair
+	for i := range profiles {
+		a := storedProfile(profiles[i])
+		for j := i + 1; j < len(profiles); j++ {
+			if different[pairKey(profiles[i].EmployeeID, profiles[j].EmployeeID)] {
+				continue
+			}
+			b := storedProfile(profiles[j])
+			score, reasons := identity.Compare(a, b)
+			if score >= s.reviewThreshold {
+				pairs = append(pairs, scanPair{profiles[i].EmployeeID, profiles[j].EmployeeID, score, reasons,
+					identity.Shares(a, b, identity.KindEmail)})
+			}
+		}
+	}
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return pairs[i].score > pairs[j].score
+	})
+
+	result := &models.DuplicateScanResult{EmployeesScanned: len(profiles)}
+	merged := make(map[int]bool)
+	for _, pair := range pairs {
+		if merged[pair.employeeID] || merged[pair.duplicateID] {
+			continue
+		}
+
+		// Merging deletes an employee, so it takes an address only that person uses
+		if autoMerge && pair.sameEmail && pair.score >= s.autoMergeThreshold {
+			targetID, sourceID := min(pair.employeeID, pair.duplicateID), max(pair.employeeID, pair.duplicateID)
+			score := pair.score
+			_, err := s.duplicateRepo.Merge(targetID, sourceID, &models.EmployeeMerge{
+				Score:     &score,
+				Reasons:   pair.reasons,
+				Automatic: true,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to merge employee %d into %d: %w", sourceID, targetID, err)
+			}
+			log.Printf("Merged employee %d into %d (score %.2f: %v)", sourceID, targetID, pair.score, pair.reasons)
+			merged[sourceID] = true
+			result.PairsMerged++
+			continue
+		}
+
+		if err := s.duplicateRepo.UpsertDuplicate(pair.employeeID, pair.duplicateID, pair.score, pair.reasons); err != nil {
+			return nil, err
+		}
+		result.PairsFlagged++
+	}
+
+	result.Duplicates, err = s.duplicateRepo.ListDuplicates(models.DuplicateStatusPending)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ListDuplicates returns the flagged pairs with a status, pending by default
+func (s *DuplicateService) ListDuplicates(status string) ([]models.EmployeeDuplicate, error) {
+	switch status {
+	case "":
+		status = models.DuplicateStatusPending
+	case models.DuplicateStatusPending, models.DuplicateStatusDismissed:
+	default:
+		return nil, NewValidationError("status must be pending or dismissed")
+	}
+
+	return s.duplicateRepo.ListDuplicates(status)
+}
+
+// Dismiss marks a flagged pair as different people, so later resumes and scans leave it alone
+func (s *DuplicateService) Dismiss(id int) (*models.EmployeeDuplicate, error) {
+	if _, err := s.duplicateRepo.GetDuplicate(id); err != nil {
+		return nil, NewNotFoundError("duplicate employees not found")
+	}
+
+	if err := s.duplicateRepo.UpdateDuplicateStatus(id, models.DuplicateStatusDismissed); err != nil {
+		return nil, err
+	}
+
+	return s.duplicateRepo.GetDuplicate(id)
+}
+
+// Merge merges the source employee into the target: skills, identifiers, matches, Google
+// Drive files and the newer extraction move to the target, and the source is deleted
+func (s *DuplicateService) Merge(targetID, sourceID int, automatic bool) (*models.EmployeeMergeResult, error) {
+	if targetID == sourceID {
+		return nil, NewValidationError("an employee cannot be merged into itself")
+	}
+
+	profiles, err := s.duplicateRepo.ListIdentityProfiles()
+	if err != nil {
+		return nil, err
+	}
+	var target, source *models.EmployeeIdentityProfile
+	for i := range profiles {
+		switch profiles[i].EmployeeID {
+		case targetID:
+			target = &profiles[i]
+		case sourceID:
+			source = &profiles[i]
+		}
+	}
+	if target == nil || source == nil {
+		return nil, NewNotFoundError("employee not found")
+	}
+
+	score, reasons := identity.Compare(storedProfile(*target), storedProfile(*source))
+	result, err := s.duplicateRepo.Merge(targetID, sourceID, &models.EmployeeMerge{
+		Score:     &score,
+		Reasons:   reasons,
+		Automatic: automatic,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Employee, err = s.employeeRepo.GetByID(targetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merged employee: %w", err)
+	}
+
+	return result, nil
+}
+
+// ListMerges returns the employees merged into an employee
+func (s *DuplicateService) ListMerges(employeeID int) ([]models.EmployeeMerge, error) {
+	if _, err := s.employeeRepo.GetByID(employeeID); err != nil {
+		return nil, NewNotFoundError("employee not found")
+	}
+
+	return s.duplicateRepo.ListMerges(employeeID)
+}
+
+// pairKey identifies a pair of employees regardless of order
+func pairKey(a, b int) [2]int {
+	return [2]int{min(a, b), max(a, b)}
+}
+
+// storedProfile converts a stored employee profile for matching
+func storedProfile(stored models.EmployeeIdentityProfile) identity.Profile {
+	profile := identity.Profile{Name: stored.Name}
+	for _, identityValue := range stored.Identities {
+		profile.Add(identityValue.Kind, identityValue.Value)
+	}
+	return profile
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"testing"
+
+	"stafind-backend/internal/identity"
+	"stafind-backend/internal/models"
+	"stafind-backend/internal/repositories"
+)
+
+// fakeDuplicateRepo keeps identity profiles and flagged pairs in memory
+type fakeDuplicateRepo struct {
+	repositories.EmployeeDuplicateRepository
+	profiles  []models.EmployeeIdentityProfile
+	dismissed []models.EmployeeDuplicate
+	flagged   [][2]int
+	merged    [][2]int
+}
+
+func (r *fakeDuplicateRepo) ListIdentityProfiles() ([]models.EmployeeIdentityProfile, error) {
+	return r.profiles, nil
+}
+
+func (r *fakeDuplicateRepo) ListDuplicates(status string) ([]models.EmployeeDuplicate, error) {
+	if status == models.DuplicateStatusDismissed {
+		return r.dismissed, nil
+	}
+	return nil, nil
+}
+
+func (r *fakeDuplicateRepo) UpsertDuplicate(employeeID, duplicateID int, score float64, reasons []string) error {
+	r.flagged = append(r.flagged, [2]int{employeeID, duplicateID})
+	return nil
+}
+
+func (r *fakeDuplicateRepo) Merge(targetID, sourceID int, merge *models.EmployeeMerge) (*models.EmployeeMergeResult, error) {
+	r.merged = append(r.merged, [2]int{targetID, sourceID})
+	return &models.EmployeeMergeResult{}, nil
+}
+
+// identityProfile builds a stored profile from kind/value pairs
+func identityProfile(employeeID int, name string, identifiers ...string) models.EmployeeIdentityProfile {
+	profile := models.EmployeeIdentityProfile{EmployeeID: employeeID, Name: name}
+	for i := 0; i+1 < len(identifiers); i += 2 {
+		profile.Identities = append(profile.Identities, models.EmployeeIdentity{Kind: identifiers[i], Value: identifiers[i+1]})
+	}
+	return profile
+}
+
+func TestScanAutoMerge(t *testing.T) {
+	repo := &fakeDuplicateRepo{
+		profiles: []models.EmployeeIdentityProfile{
+			// Same address: merged
+			identityProfile(1, "Ana García", identity.KindEmail, "ana@acme.com"),
+			identityProfile(2, "Ana García López", identity.KindEmail, "ana@acme.com"),
+			// Same phone and name, no shared address: flagged only
+			identityProfile(3, "Pedro Martínez", identity.KindPhone, "3001234567"),
+			identityProfile(4, "Pedro Martinez", identity.KindPhone, "3001234567"),
+			// Same address but dismissed as different people: left alone
+			identityProfile(5, "Luis Gómez", identity.KindEmail, "info@gomez.com"),
+			identityProfile(6, "Luisa Gómez", identity.KindEmail, "info@gomez.com"),
+		},
+		dismissed: []models.EmployeeDuplicate{{EmployeeID: 5, DuplicateID: 6, Status: models.DuplicateStatusDismissed}},
+	}
+	service := NewDuplicateService(repo, nil)
+
+	result, err := service.Scan(true)
+	if err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+
+	if len(repo.merged) != 1 || repo.merged[0] != [2]int{1, 2} {
+		t.Errorf("merged = %v, want only employee 2 into 1", repo.merged)
+	}
+	if len(repo.flagged) != 1 || repo.flagged[0] != [2]int{3, 4} {
+		t.Errorf("flagged = %v, want only employees 3 and 4", repo.flagged)
+	}
+	if result.PairsMerged != 1 || result.PairsFlagged != 1 {
+		t.Errorf("result = %d merged, %d flagged, want 1 and 1", result.PairsMerged, result.PairsFlagged)
+	}
+}
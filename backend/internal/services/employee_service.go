@@ -1,8 +1,10 @@
 package services
 
 import (
+	"stafind-backend/internal/contactinfo"
 	"stafind-backend/internal/models"
 	"stafind-backend/internal/repositories"
+	"stafind-backend/internal/spokenlang"
 )
 
 type employeeService struct {
@@ -67,6 +69,13 @@ func (s *employeeService) CreateEmployee(req *models.CreateEmployeeRequest) (*mo
 		}
 	}
 
+	if err := normalizeContact(&req.EmployeeContact); err != nil {
+		return nil, err
+	}
+	if err := normalizeLanguages(req.Languages); err != nil {
+		return nil, err
+	}
+
 	return s.employeeRepo.Create(req)
 }
 
@@ -113,9 +122,69 @@ func (s *employeeService) UpdateEmployee(id int, req *models.CreateEmployeeReque
 		}
 	}
 
+	// Contact fields the body leaves out keep their stored, usually extracted, values
+	if len(req.ContactFields) > 0 {
+		existing, err := s.employeeRepo.GetByID(id)
+		if err != nil {
+			return nil, err
+		}
+		req.MergeContact(existing.EmployeeContact)
+	}
+	if err := normalizeContact(&req.EmployeeContact); err != nil {
+		return nil, err
+	}
+	if err := normalizeLanguages(req.Languages); err != nil {
+		return nil, err
+	}
+
 	return s.employeeRepo.Update(id, req)
 }
 
+// normalizeContact stores the country as its ISO code and the phone in E.164
+func normalizeContact(contact *models.EmployeeContact) error {
+	if contact.Country != "" {
+		country := contactinfo.CountryCode(contact.Country)
+		if country == "" {
+			return &ValidationError{Field: "country", Message: "Unknown country: " + contact.Country}
+		}
+		contact.Country = country
+	}
+	if contact.Phone != "" {
+		phone, _ := contactinfo.NormalizePhone(contact.Phone, contact.Country)
+		if phone == "" {
+			return &ValidationError{Field: "phone", Message: "Phone must include a country code or the employee's country"}
+		}
+		contact.Phone = phone
+	}
+	return nil
+}
+
+// normalizeLanguages identifies each spoken language by its code and states its level in CEFR
+// terms, so "Inglés" at "fluent" is stored as en at C1
+func normalizeLanguages(languages []models.SpokenLanguage) error {
+	for i := range languages {
+		language := &languages[i]
+		name := language.Code
+		if name == "" {
+			name = language.Name
+		}
+		code := spokenlang.Code(name)
+		if code == "" {
+			return &ValidationError{Field: "languages", Message: "Unknown language: " + name}
+		}
+		language.Code, language.Name = code, spokenlang.Name(code)
+
+		if language.Level != "" {
+			level := spokenlang.ParseLevel(language.Level)
+			if level == "" {
+				return &ValidationError{Field: "languages", Message: "Unknown language level: " + language.Level}
+			}
+			language.Level = level
+		}
+	}
+	return nil
+}
+
 func (s *employeeService) DeleteEmployee(id int) error {
 	return s.employeeRepo.Delete(id)
 }
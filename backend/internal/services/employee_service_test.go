@@ -0,0 +1,106 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"stafind-backend/internal/models"
+	"stafind-backend/internal/repositories"
+)
+
+// fakeEmployeeRepo keeps one employee and records the last update
+type fakeEmployeeRepo struct {
+	repositories.EmployeeRepository
+	employee models.Employee
+	updated  *models.CreateEmployeeRequest
+}
+
+func (r *fakeEmployeeRepo) GetByID(id int) (*models.Employee, error) {
+	employee := r.employee
+	return &employee, nil
+}
+
+func (r *fakeEmployeeRepo) Update(id int, req *models.CreateEmployeeRequest) (*models.Employee, error) {
+	r.updated = req
+	return &r.employee, nil
+}
+
+func TestUpdateEmployeeContact(t *testing.T) {
+	extracted := models.EmployeeContact{
+		Phone:       "+34612345678",
+		City:        "Madrid",
+		Country:     "ES",
+		LinkedInURL: "https://www.linkedin.com/in/ana",
+		GitHubURL:   "https://github.com/ana",
+	}
+
+	tests := []struct {
+		name        string
+		body        string
+		wantWritten bool
+		want        models.EmployeeContact
+	}{
+		{
+			name: "no contact fields keeps the extracted ones",
+			body: `{"name": "Ana", "email": "ana@acme.com"}`,
+		},
+		{
+			name:        "sent fields replace the extracted ones",
+			body:        `{"name": "Ana", "email": "ana@acme.com", "phone": "+34 699 111 222", "city": "Sevilla"}`,
+			wantWritten: true,
+			want: models.EmployeeContact{
+				Phone:       "+34699111222",
+				City:        "Sevilla",
+				Country:     "ES",
+				LinkedInURL: "https://www.linkedin.com/in/ana",
+				GitHubURL:   "https://github.com/ana",
+			},
+		},
+		{
+			name:        "an empty string clears a field",
+			body:        `{"name": "Ana", "email": "ana@acme.com", "github_url": ""}`,
+			wantWritten: true,
+			want: models.EmployeeContact{
+				Phone:       "+34612345678",
+				City:        "Madrid",
+				Country:     "ES",
+				LinkedInURL: "https://www.linkedin.com/in/ana",
+			},
+		},
+		{
+			name:        "a national phone uses the stored country",
+			body:        `{"name": "Ana", "email": "ana@acme.com", "phone": "699 111 222"}`,
+			wantWritten: true,
+			want: models.EmployeeContact{
+				Phone:       "+34699111222",
+				City:        "Madrid",
+				Country:     "ES",
+				LinkedInURL: "https://www.linkedin.com/in/ana",
+				GitHubURL:   "https://github.com/ana",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeEmployeeRepo{employee: models.Employee{ID: 7, EmployeeContact: extracted}}
+			service := NewEmployeeService(repo)
+
+			var req models.CreateEmployeeRequest
+			if err := json.Unmarshal([]byte(tt.body), &req); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if _, err := service.UpdateEmployee(7, &req); err != nil {
+				t.Fatalf("UpdateEmployee() error = %v", err)
+			}
+
+			written := len(repo.updated.ContactFields) > 0
+			if written != tt.wantWritten {
+				t.Fatalf("contact written = %v, want %v", written, tt.wantWritten)
+			}
+			if written && repo.updated.EmployeeContact != tt.want {
+				t.Errorf("contact = %+v, want %+v", repo.updated.EmployeeContact, tt.want)
+			}
+		})
+	}
+}
@@ -4,9 +4,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"os"
 	"regexp"
+	"stafind-backend/internal/constants"
+	"stafind-backend/internal/contactinfo"
 	"stafind-backend/internal/models"
-	"stafind-backend/internal/repositories"
+	"stafind-backend/internal/resumeparser"
+	"stafind-backend/internal/spokenlang"
 	"strconv"
 	"strings"
 	"time"
@@ -14,15 +18,15 @@ import (
 
 // CandidateExtractService handles intelligent extraction from resumes and job descriptions
 type CandidateExtractService struct {
-	nerService *NERService
+	nerService   *NERService
+	phoneCountry string // Country of national phone numbers when the resume has no location
 }
 
-// NewCandidateExtractionService creates a new extraction service using pure NER
-func NewCandidateExtractionService(skillRepo repositories.SkillRepository, categoryRepo repositories.CategoryRepository) *CandidateExtractService {
-	log.Printf("Initializing Candidate Extraction Service with pure NER (Prose library)")
-
+// NewCandidateExtractionService creates a new extraction service on a shared NER service
+func NewCandidateExtractionService(nerService *NERService) *CandidateExtractService {
 	return &CandidateExtractService{
-		nerService: NewNERService(skillRepo, categoryRepo),
+		nerService:   nerService,
+		phoneCountry: contactinfo.CountryCode(os.Getenv(constants.EnvDefaultPhoneCountry)),
 	}
 }
 
@@ -31,7 +35,7 @@ func (s *CandidateExtractService) ProcessText(request *models.ExtractProcessRequ
 	startTime := time.Now()
 
 	// Process with pure NER
-	processedContent, err := s.processWithNER(request.ProcessingType, request.Text, request.Metadata)
+	processedContent, resume, err := s.processWithNER(request.ProcessingType, request.Text, request.Metadata)
 	if err != nil {
 		return nil, fmt.Errorf("failed to process text with NER: %w", err)
 	}
@@ -46,13 +50,15 @@ func (s *CandidateExtractService) ProcessText(request *models.ExtractProcessRequ
 		ProcessingType:   request.ProcessingType,
 		Metadata:         request.Metadata,
 		Timestamp:        time.Now(),
+		Resume:           resume,
 	}
 
 	return response, nil
 }
 
-// processWithNER processes text using pure NER extraction
-func (s *CandidateExtractService) processWithNER(processingType, text string, metadata map[string]interface{}) (string, error) {
+// processWithNER processes text using pure NER extraction. Candidate extraction also returns
+// the typed resume data.
+func (s *CandidateExtractService) processWithNER(processingType, text string, metadata map[string]interface{}) (string, *models.ProcessedResumeData, error) {
 	log.Printf("Processing with pure NER (Prose library)")
 	log.Printf("Processing type: %s, Text length: %d characters", processingType, len(text))
 
@@ -64,57 +70,98 @@ func (s *CandidateExtractService) processWithNER(processingType, text string, me
 
 	case "search_analysis":
 		log.Printf("Analyzing search request using NER...")
-		return s.analyzeSearchWithNER(text)
+		content, err := s.analyzeSearchWithNER(text)
+		return content, nil, err
 
 	case "candidate_matching":
 		log.Printf("Matching candidate with NER analysis...")
-		return s.matchCandidateWithNER(text)
+		content, err := s.matchCandidateWithNER(text)
+		return content, nil, err
 
 	default:
 		log.Printf("Processing generic text with NER...")
-		return s.processGenericWithNER(text)
+		content, err := s.processGenericWithNER(text)
+		return content, nil, err
 	}
 }
 
-// extractCandidateWithNER uses pure NER to extract candidate information
-func (s *CandidateExtractService) extractCandidateWithNER(text string) (string, error) {
-	// Use NER service to extract all information
-	nerResult, err := s.nerService.ExtractSkillsFromText(text)
+// extractCandidateWithNER uses pure NER to extract candidate information and returns it as JSON
+// together with the typed data
+func (s *CandidateExtractService) extractCandidateWithNER(text string) (string, *models.ProcessedResumeData, error) {
+	resume, err := s.ExtractResume(text)
 	if err != nil {
-		return "", fmt.Errorf("NER extraction failed: %w", err)
+		return "", nil, err
 	}
 
-	// Extract contact information using NER entities
-	contactInfo := s.extractContactInfoWithNER(text)
-
-	// Build complete candidate info using pure NER
-	candidateInfo := map[string]interface{}{
-		"candidate_name":   s.extractNameWithNER(text),
-		"contact_info":     contactInfo,
-		"skills":           nerResult.Skills.Categories,
-		"years_experience": nerResult.Skills.YearsOfExperience,
-		"seniority_level":  s.extractAndNormalizeSeniorityLevelNER(text, nerResult.Skills.YearsOfExperience),
-		"current_position": s.extractCurrentPosition(text),
-		"education": map[string]interface{}{
-			"level":        nerResult.Skills.EducationLevel,
-			"institutions": s.extractInstitutionsWithNER(text),
-		},
-		"languages":         nerResult.Skills.LanguagesDetected,
-		"summary":           nerResult.Skills.Summary,
-		"total_skills":      nerResult.TotalSkillsFound,
-		"confidence":        nerResult.Skills.ConfidenceScore,
-		"extraction_method": "Pure NER (Prose)",
-		"processing_time":   nerResult.ProcessingTime,
+	jsonData, err := json.MarshalIndent(resume, "", "  ")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to marshal candidate info: %w", err)
 	}
 
-	jsonData, err := json.MarshalIndent(candidateInfo, "", "  ")
+	return string(jsonData), resume, nil
+}
+
+// ExtractResume extracts candidate information from resume text. The resume parser splits the
+// text into sections for work history, education, projects, certifications and languages;
+// skills come from NER against the skills taxonomy.
+func (s *CandidateExtractService) ExtractResume(text string) (*models.ProcessedResumeData, error) {
+	nerResult, err := s.nerService.ExtractSkillsFromText(text)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal candidate info: %w", err)
+		return nil, fmt.Errorf("NER extraction failed: %w", err)
 	}
 
-	log.Printf("Successfully extracted candidate info using pure NER (confidence: %.2f, skills: %d)",
-		nerResult.Skills.ConfidenceScore, nerResult.TotalSkillsFound)
-	return string(jsonData), nil
+	doc := resumeparser.Parse(text)
+	contactInfo := s.extractContactInfoWithNER(text)
+
+	currentRole := s.extractCurrentPosition(text)
+	if position, ok := doc.CurrentPosition(); ok && position.Role != "" {
+		currentRole = position.Role
+	}
+
+	// Dated work history beats "N years of experience" claims; concurrent positions count once
+	yearsExperience := ""
+	if years := resumeparser.ExperienceYears(doc.Experience, time.Now()); years > 0 {
+		yearsExperience = strconv.FormatFloat(years, 'f', -1, 64)
+	} else if years := s.getMaxYearsFromStrings(nerResult.Skills.YearsOfExperience); years > 0 {
+		yearsExperience = strconv.Itoa(years)
+	}
+
+	resume := &models.ProcessedResumeData{
+		CandidateName:   s.extractNameWithNER(text),
+		ContactInfo:     contactInfo,
+		SeniorityLevel:  s.extractAndNormalizeSeniorityLevelNER(text, nerResult.Skills.YearsOfExperience),
+		YearsExperience: yearsExperience,
+		CurrentRole:     currentRole,
+		Skills: models.ResumeSkills{
+			Technical:  []string{},
+			Soft:       []string{},
+			Languages:  []string{},
+			Tools:      []string{},
+			Frameworks: []string{},
+		},
+		Experience:          emptyIfNil(doc.Experience),
+		Projects:            emptyIfNil(doc.Projects),
+		Education:           emptyIfNil(doc.Education),
+		Certifications:      emptyIfNil(doc.Certifications),
+		Languages:           emptyIfNil(doc.Languages),
+		SpokenLanguages:     emptyIfNil(spokenlang.Extract(doc.Languages, text)),
+		ProfessionalSummary: doc.Summary,
+		ProcessingTimestamp: time.Now().Format(time.RFC3339),
+		SkillProficiency:    nerResult.Skills.Proficiency,
+		SkillEvidence:       emptyIfNil(nerResult.Evidence),
+		ConfidenceScore:     nerResult.Skills.ConfidenceScore,
+		ExtractionMethod:    "Pure NER (Prose)",
+	}
+	MergeResumeSkills(resume, nerResult.Skills.Categories)
+	resume.CertificationDetails = emptyIfNil(doc.CertificationDetails)
+	resume.UnknownSkills = UnknownSkillTerms(text, doc.SkillTerms, models.SkillTermSourceSkillsSection, func(term string) bool {
+		_, known := s.nerService.CanonicalSkill(term)
+		return known
+	})
+
+	log.Printf("Successfully extracted candidate info using pure NER (confidence: %.2f, skills: %d, positions: %d, education: %d)",
+		nerResult.Skills.ConfidenceScore, nerResult.TotalSkillsFound, len(resume.Experience), len(resume.Education))
+	return resume, nil
 }
 
 // analyzeSearchWithNER uses pure NER to analyze search requests
@@ -263,12 +310,37 @@ func (s *CandidateExtractService) extractNameWithNER(text string) string {
 	return "Name not found"
 }
 
-func (s *CandidateExtractService) extractContactInfoWithNER(text string) map[string]string {
-	contactInfo := map[string]string{
-		"email":    s.findEmail(text),
-		"phone":    s.findPhone(text),
-		"location": s.findLocation(text),
+// extractContactInfoWithNER finds the candidate's contact details: the phone number in E.164
+// when its country can be told, from the number itself, the candidate's location or
+// DEFAULT_PHONE_COUNTRY, the location split into city, region and country, and profile links
+func (s *CandidateExtractService) extractContactInfoWithNER(text string) models.ContactInfo {
+	contactInfo := models.ContactInfo{
+		Email:    s.findEmail(text),
+		Phone:    s.findPhone(text),
+		Location: s.findLocation(text),
 	}
+
+	location := contactinfo.ParseLocation(contactInfo.Location)
+	contactInfo.City, contactInfo.Region, contactInfo.Country = location.City, location.Region, location.Country
+
+	phoneCountry := location.Country
+	if phoneCountry == "" {
+		phoneCountry = s.phoneCountry
+	}
+	if phone, country := contactinfo.NormalizePhone(contactInfo.Phone, phoneCountry); phone != "" {
+		contactInfo.Phone = phone
+		// A number with a country code places a candidate who did not write their country
+		if contactInfo.Country == "" {
+			contactInfo.Country = country
+		}
+	}
+
+	links := contactinfo.FindLinks(text)
+	contactInfo.LinkedInURL = links.LinkedIn
+	contactInfo.GitHubURL = links.GitHub
+	contactInfo.GitLabURL = links.GitLab
+	contactInfo.StackOverflowURL = links.StackOverflow
+	contactInfo.WebsiteURL = links.Website
 	return contactInfo
 }
 
@@ -316,26 +388,8 @@ func (s *CandidateExtractService) findEmail(text string) string {
 }
 
 func (s *CandidateExtractService) findPhone(text string) string {
-	// Look for phone patterns
-	lines := strings.Split(text, "\n")
-	for _, line := range lines {
-		lower := strings.ToLower(line)
-		if strings.Contains(lower, "phone") || strings.Contains(lower, "tel") || strings.Contains(lower, "móvil") {
-			// Extract the number part
-			words := strings.Fields(line)
-			for _, word := range words {
-				if strings.ContainsAny(word, "0123456789") && len(word) >= 9 {
-					return word
-				}
-			}
-		}
-	}
-	// Look for standalone numbers
-	words := strings.Fields(text)
-	for _, word := range words {
-		if strings.HasPrefix(word, "+") && len(word) > 10 {
-			return word
-		}
+	if phone := contactinfo.FindPhone(text); phone != "" {
+		return phone
 	}
 	return "Not provided"
 }
@@ -0,0 +1,282 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"stafind-backend/internal/models"
+	"stafind-backend/internal/repositories"
+)
+
+// ExtractionHistoryService keeps every extraction run of an employee as a numbered version and
+// compares versions
+type ExtractionHistoryService struct {
+	extractionRepo repositories.EmployeeExtractionRepository
+	employeeRepo   repositories.EmployeeRepository
+}
+
+// NewExtractionHistoryService creates a new extraction history service
+func NewExtractionHistoryService(extractionRepo repositories.EmployeeExtractionRepository, employeeRepo repositories.EmployeeRepository) *ExtractionHistoryService {
+	return &ExtractionHistoryService{
+		extractionRepo: extractionRepo,
+		employeeRepo:   employeeRepo,
+	}
+}
+
+// Record stores an extraction run as the employee's next version
+func (s *ExtractionHistoryService) Record(extraction *models.EmployeeExtraction) error {
+	return s.extractionRepo.Create(extraction)
+}
+
+// Latest returns an employee's latest extraction, or nil when it has none
+func (s *ExtractionHistoryService) Latest(employeeID int) (*models.EmployeeExtraction, error) {
+	extraction, err := s.extractionRepo.GetLatest(employeeID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return extraction, err
+}
+
+// List returns an employee's extraction runs, newest first, without their text and data
+func (s *ExtractionHistoryService) List(employeeID int) ([]models.EmployeeExtraction, error) {
+	if _, err := s.employeeRepo.GetByID(employeeID); err != nil {
+		return nil, NewNotFoundError("employee not found")
+	}
+
+	return s.extractionRepo.ListByEmployee(employeeID)
+}
+
+// Get returns one version of an employee's extraction
+func (s *ExtractionHistoryService) Get(employeeID, version int) (*models.EmployeeExtraction, error) {
+	extraction, err := s.extractionRepo.GetVersion(employeeID, version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, NewNotFoundError(fmt.Sprintf("extraction version %d not found", version))
+	}
+	return extraction, err
+}
+
+// Diff compares two versions of an employee's extraction. A zero to is the latest version and
+// a zero from the version before to.
+func (s *ExtractionHistoryService) Diff(employeeID, from, to int) (*models.ExtractionDiff, error) {
+	if from < 0 || to < 0 {
+		return nil, NewValidationError("versions must be positive")
+	}
+
+	var toExtraction *models.EmployeeExtraction
+	var err error
+	if to == 0 {
+		toExtraction, err = s.Latest(employeeID)
+		if err == nil && toExtraction == nil {
+			return nil, NewNotFoundError("employee has no extractions")
+		}
+	} else {
+		toExtraction, err = s.Get(employeeID, to)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if from == 0 {
+		from = toExtraction.Version - 1
+	}
+	var fromExtraction *models.EmployeeExtraction
+	if from > 0 {
+		if fromExtraction, err = s.Get(employeeID, from); err != nil {
+			return nil, err
+		}
+	}
+
+	diff := DiffExtractions(fromExtraction, toExtraction)
+	diff.EmployeeID = employeeID
+	return diff, nil
+}
+
+// diffedFields are the resume fields compared between extractions, with their labels
+var diffedFields = []struct {
+	name  string
+	label string
+	value func(*models.ProcessedResumeData) string
+}{
+	{"candidate_name", "Name", func(r *models.ProcessedResumeData) string { return r.CandidateName }},
+	{"email", "Email", func(r *models.ProcessedResumeData) string { return r.ContactInfo.Email }},
+	{"phone", "Phone", func(r *models.ProcessedResumeData) string { return r.ContactInfo.Phone }},
+	{"location", "Location", func(r *models.ProcessedResumeData) string { return r.ContactInfo.Location }},
+	{"city", "City", func(r *models.ProcessedResumeData) string { return r.ContactInfo.City }},
+	{"country", "Country", func(r *models.ProcessedResumeData) string { return r.ContactInfo.Country }},
+	{"linkedin_url", "LinkedIn", func(r *models.ProcessedResumeData) string { return r.ContactInfo.LinkedInURL }},
+	{"github_url", "GitHub", func(r *models.ProcessedResumeData) string { return r.ContactInfo.GitHubURL }},
+	{"gitlab_url", "GitLab", func(r *models.ProcessedResumeData) string { return r.ContactInfo.GitLabURL }},
+	{"stackoverflow_url", "Stack Overflow", func(r *models.ProcessedResumeData) string { return r.ContactInfo.StackOverflowURL }},
+	{"website_url", "Website", func(r *models.ProcessedResumeData) string { return r.ContactInfo.WebsiteURL }},
+	{"spoken_languages", "Languages", func(r *models.ProcessedResumeData) string { return formatSpokenLanguages(r.SpokenLanguages) }},
+	{"certifications", "Certifications", func(r *models.ProcessedResumeData) string { return formatCertifications(r.CertificationDetails) }},
+	{"current_role", "Current role", func(r *models.ProcessedResumeData) string { return r.CurrentRole }},
+	{"seniority_level", "Seniority level", func(r *models.ProcessedResumeData) string { return r.SeniorityLevel }},
+	{"years_experience", "Years of experience", func(r *models.ProcessedResumeData) string { return r.YearsExperience }},
+}
+
+// formatSpokenLanguages lists spoken languages with their levels, as in "English (C1), Spanish (native)"
+func formatSpokenLanguages(languages []models.SpokenLanguage) string {
+	formatted := make([]string, len(languages))
+	for i, language := range languages {
+		formatted[i] = language.Name
+		if language.Level != "" {
+			formatted[i] += " (" + language.Level + ")"
+		}
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// formatCertifications lists certifications with their expiry, as in "CKA (expires 2026-05), PMP"
+func formatCertifications(certifications []models.ResumeCertification) string {
+	formatted := make([]string, len(certifications))
+	for i, certification := range certifications {
+		formatted[i] = certification.Name
+		if certification.ExpiresOn != "" {
+			formatted[i] += " (expires " + certification.ExpiresOn + ")"
+		}
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// DiffExtractions compares two extractions: skills and positions added or removed, changed
+// fields such as the current role, and whether the resume text changed. from may be nil for
+// an employee's first extraction.
+func DiffExtractions(from, to *models.EmployeeExtraction) *models.ExtractionDiff {
+	diff := &models.ExtractionDiff{
+		EmployeeID:       to.EmployeeID,
+		ToVersion:        to.Version,
+		SkillsAdded:      []string{},
+		SkillsRemoved:    []string{},
+		PositionsAdded:   []string{},
+		PositionsRemoved: []string{},
+		FieldChanges:     []models.ExtractionFieldChange{},
+		Summary:          []string{},
+	}
+
+	// A first extraction is compared with an empty one
+	if from == nil {
+		from = &models.EmployeeExtraction{}
+		diff.TextChanged = true
+	} else {
+		diff.FromVersion = from.Version
+		diff.TextChanged = from.OriginalText != to.OriginalText
+	}
+
+	fromData, toData := from.ExtractedData, to.ExtractedData
+	if fromData == nil {
+		fromData = &models.ProcessedResumeData{}
+	}
+	if toData == nil {
+		toData = &models.ProcessedResumeData{}
+	}
+
+	diff.SkillsAdded, diff.SkillsRemoved = setDifference(resumeSkillNames(fromData), resumeSkillNames(toData))
+	diff.PositionsAdded, diff.PositionsRemoved = setDifference(resumePositions(fromData), resumePositions(toData))
+	for _, field := range diffedFields {
+		before, after := strings.TrimSpace(field.value(fromData)), strings.TrimSpace(field.value(toData))
+		if !strings.EqualFold(before, after) {
+			diff.FieldChanges = append(diff.FieldChanges, models.ExtractionFieldChange{Field: field.name, From: before, To: after})
+		}
+	}
+
+	if len(diff.SkillsAdded) > 0 {
+		diff.Summary = append(diff.Summary, "Skills added: "+strings.Join(diff.SkillsAdded, ", "))
+	}
+	if len(diff.SkillsRemoved) > 0 {
+		diff.Summary = append(diff.Summary, "Skills removed: "+strings.Join(diff.SkillsRemoved, ", "))
+	}
+	for _, change := range diff.FieldChanges {
+		diff.Summary = append(diff.Summary, fieldChangeSummary(change))
+	}
+	for _, position := range diff.PositionsAdded {
+		diff.Summary = append(diff.Summary, "Position added: "+position)
+	}
+	for _, position := range diff.PositionsRemoved {
+		diff.Summary = append(diff.Summary, "Position removed: "+position)
+	}
+	if diff.TextChanged && len(diff.Summary) == 0 {
+		if diff.FromVersion == 0 {
+			diff.Summary = append(diff.Summary, "Extracted data added")
+		} else {
+			diff.Summary = append(diff.Summary, "Resume text updated")
+		}
+	}
+
+	return diff
+}
+
+// fieldChangeSummary describes a changed field, e.g. "Current role changed from Developer to
+// Tech Lead"
+func fieldChangeSummary(change models.ExtractionFieldChange) string {
+	label := change.Field
+	for _, field := range diffedFields {
+		if field.name == change.Field {
+			label = field.label
+		}
+	}
+
+	switch {
+	case change.From == "":
+		return fmt.Sprintf("%s set to %s", label, change.To)
+	case change.To == "":
+		return fmt.Sprintf("%s removed (was %s)", label, change.From)
+	default:
+		return fmt.Sprintf("%s changed from %s to %s", label, change.From, change.To)
+	}
+}
+
+// resumePositions returns the work experience of resume data as "Role at Company", once each
+func resumePositions(resume *models.ProcessedResumeData) []string {
+	seen := make(map[string]bool)
+	var positions []string
+	for _, experience := range resume.Experience {
+		role, company := strings.TrimSpace(experience.Role), strings.TrimSpace(experience.Company)
+		var position string
+		switch {
+		case role != "" && company != "":
+			position = role + " at " + company
+		case role != "":
+			position = role
+		case company != "":
+			position = company
+		default:
+			continue
+		}
+		if key := strings.ToLower(position); !seen[key] {
+			seen[key] = true
+			positions = append(positions, position)
+		}
+	}
+	return positions
+}
+
+// setDifference returns the items only in after and only in before, ignoring case, each sorted
+func setDifference(before, after []string) (added, removed []string) {
+	inBefore := make(map[string]bool, len(before))
+	for _, item := range before {
+		inBefore[strings.ToLower(item)] = true
+	}
+	inAfter := make(map[string]bool, len(after))
+	for _, item := range after {
+		inAfter[strings.ToLower(item)] = true
+	}
+
+	added, removed = []string{}, []string{}
+	for _, item := range after {
+		if !inBefore[strings.ToLower(item)] {
+			added = append(added, item)
+		}
+	}
+	for _, item := range before {
+		if !inAfter[strings.ToLower(item)] {
+			removed = append(removed, item)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
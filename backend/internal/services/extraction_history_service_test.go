@@ -0,0 +1,208 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"stafind-backend/internal/models"
+)
+
+func TestSetDifference(t *testing.T) {
+	tests := []struct {
+		name        string
+		before      []string
+		after       []string
+		wantAdded   []string
+		wantRemoved []string
+	}{
+		{"both empty", nil, nil, []string{}, []string{}},
+		{"all added", nil, []string{"React", "Go"}, []string{"Go", "React"}, []string{}},
+		{"all removed", []string{"Java"}, nil, []string{}, []string{"Java"}},
+		{"overlap", []string{"Go", "Java", "SQL"}, []string{"SQL", "Go", "Rust", "Docker"}, []string{"Docker", "Rust"}, []string{"Java"}},
+		{"case only", []string{"PostgreSQL", "go"}, []string{"postgresql", "Go"}, []string{}, []string{}},
+		{"same items", []string{"Go"}, []string{"Go"}, []string{}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			added, removed := setDifference(tt.before, tt.after)
+			if !reflect.DeepEqual(added, tt.wantAdded) {
+				t.Errorf("added = %q, want %q", added, tt.wantAdded)
+			}
+			if !reflect.DeepEqual(removed, tt.wantRemoved) {
+				t.Errorf("removed = %q, want %q", removed, tt.wantRemoved)
+			}
+		})
+	}
+}
+
+// extraction builds a version of an employee's extraction
+func extraction(version int, text string, data *models.ProcessedResumeData) *models.EmployeeExtraction {
+	return &models.EmployeeExtraction{EmployeeID: 7, Version: version, OriginalText: text, ExtractedData: data}
+}
+
+func TestDiffExtractions(t *testing.T) {
+	first := &models.ProcessedResumeData{
+		CandidateName:   "Ana García",
+		CurrentRole:     "Developer",
+		SeniorityLevel:  "mid",
+		SkillCategories: map[string][]string{"Programming Languages": {"Go", "Java"}, "Databases": {"PostgreSQL"}},
+		Experience:      []models.WorkExperience{{Role: "Developer", Company: "Acme"}},
+		ContactInfo:     models.ContactInfo{Email: "ana@acme.com"},
+	}
+	second := &models.ProcessedResumeData{
+		CandidateName:   "Ana García",
+		CurrentRole:     "Tech Lead",
+		SkillCategories: map[string][]string{"Programming Languages": {"Go", "Rust"}, "Databases": {"postgresql"}},
+		Experience:      []models.WorkExperience{{Role: "Tech Lead", Company: "Globex"}, {Role: "Developer", Company: "Acme"}},
+		ContactInfo:     models.ContactInfo{Email: "ana@acme.com", GitHubURL: "https://github.com/ana"},
+	}
+	// Differs from first only in case and spacing
+	recased := &models.ProcessedResumeData{
+		CandidateName:   " ana garcía ",
+		CurrentRole:     "developer",
+		SeniorityLevel:  "Mid",
+		SkillCategories: map[string][]string{"Backend": {"go", "JAVA", "postgresql"}},
+		Experience:      []models.WorkExperience{{Role: "developer", Company: "ACME"}},
+		ContactInfo:     models.ContactInfo{Email: "Ana@Acme.com"},
+	}
+
+	tests := []struct {
+		name string
+		from *models.EmployeeExtraction
+		to   *models.EmployeeExtraction
+		want models.ExtractionDiff
+	}{
+		{
+			name: "skills, positions and fields changed",
+			from: extraction(1, "resume v1", first),
+			to:   extraction(2, "resume v2", second),
+			want: models.ExtractionDiff{
+				EmployeeID:       7,
+				FromVersion:      1,
+				ToVersion:        2,
+				SkillsAdded:      []string{"Rust"},
+				SkillsRemoved:    []string{"Java"},
+				PositionsAdded:   []string{"Tech Lead at Globex"},
+				PositionsRemoved: []string{},
+				FieldChanges: []models.ExtractionFieldChange{
+					{Field: "github_url", From: "", To: "https://github.com/ana"},
+					{Field: "current_role", From: "Developer", To: "Tech Lead"},
+					{Field: "seniority_level", From: "mid", To: ""},
+				},
+				TextChanged: true,
+				Summary: []string{
+					"Skills added: Rust",
+					"Skills removed: Java",
+					"GitHub set to https://github.com/ana",
+					"Current role changed from Developer to Tech Lead",
+					"Seniority level removed (was mid)",
+					"Position added: Tech Lead at Globex",
+				},
+			},
+		},
+		{
+			name: "first extraction",
+			to: extraction(1, "resume v1", &models.ProcessedResumeData{
+				CurrentRole:     "Developer",
+				SkillCategories: map[string][]string{"Programming Languages": {"Go"}},
+				Experience:      []models.WorkExperience{{Company: "Acme"}},
+			}),
+			want: models.ExtractionDiff{
+				EmployeeID:       7,
+				ToVersion:        1,
+				SkillsAdded:      []string{"Go"},
+				SkillsRemoved:    []string{},
+				PositionsAdded:   []string{"Acme"},
+				PositionsRemoved: []string{},
+				FieldChanges:     []models.ExtractionFieldChange{{Field: "current_role", From: "", To: "Developer"}},
+				TextChanged:      true,
+				Summary: []string{
+					"Skills added: Go",
+					"Current role set to Developer",
+					"Position added: Acme",
+				},
+			},
+		},
+		{
+			name: "first extraction without data",
+			to:   extraction(1, "resume v1", nil),
+			want: models.ExtractionDiff{
+				EmployeeID:       7,
+				ToVersion:        1,
+				SkillsAdded:      []string{},
+				SkillsRemoved:    []string{},
+				PositionsAdded:   []string{},
+				PositionsRemoved: []string{},
+				FieldChanges:     []models.ExtractionFieldChange{},
+				TextChanged:      true,
+				Summary:          []string{"Extracted data added"},
+			},
+		},
+		{
+			name: "only case and spacing changed",
+			from: extraction(1, "resume", first),
+			to:   extraction(2, "resume", recased),
+			want: models.ExtractionDiff{
+				EmployeeID:       7,
+				FromVersion:      1,
+				ToVersion:        2,
+				SkillsAdded:      []string{},
+				SkillsRemoved:    []string{},
+				PositionsAdded:   []string{},
+				PositionsRemoved: []string{},
+				FieldChanges:     []models.ExtractionFieldChange{},
+				Summary:          []string{},
+			},
+		},
+		{
+			name: "only the text changed",
+			from: extraction(3, "resume", first),
+			to:   extraction(4, "resume, reformatted", first),
+			want: models.ExtractionDiff{
+				EmployeeID:       7,
+				FromVersion:      3,
+				ToVersion:        4,
+				SkillsAdded:      []string{},
+				SkillsRemoved:    []string{},
+				PositionsAdded:   []string{},
+				PositionsRemoved: []string{},
+				FieldChanges:     []models.ExtractionFieldChange{},
+				TextChanged:      true,
+				Summary:          []string{"Resume text updated"},
+			},
+		},
+		{
+			name: "data removed",
+			from: extraction(1, "resume", &models.ProcessedResumeData{
+				SkillCategories: map[string][]string{"Programming Languages": {"Go"}},
+				Experience:      []models.WorkExperience{{Role: "Developer"}},
+			}),
+			to: extraction(2, "resume", nil),
+			want: models.ExtractionDiff{
+				EmployeeID:       7,
+				FromVersion:      1,
+				ToVersion:        2,
+				SkillsAdded:      []string{},
+				SkillsRemoved:    []string{"Go"},
+				PositionsAdded:   []string{},
+				PositionsRemoved: []string{"Developer"},
+				FieldChanges:     []models.ExtractionFieldChange{},
+				Summary:          []string{"Skills removed: Go", "Position removed: Developer"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DiffExtractions(tt.from, tt.to)
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("DiffExtractions() = %+v\nwant %+v", *got, tt.want)
+			}
+			// Every change is summarized
+			if wantChanges := len(tt.want.Summary) > 0; got.HasChanges() != wantChanges {
+				t.Errorf("HasChanges() = %v, want %v", got.HasChanges(), wantChanges)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"fmt"
+	"log"
+
+	"stafind-backend/internal/models"
+	"stafind-backend/internal/repositories"
+)
+
+// ExtractionReviewService lets reviewers correct, approve or reject extractions held because
+// of a low confidence or an unlikely candidate name
+type ExtractionReviewService struct {
+	reviewRepo     repositories.ExtractionReviewRepository
+	storageService *CandidateStorageService
+}
+
+// NewExtractionReviewService creates a new extraction review service
+func NewExtractionReviewService(reviewRepo repositories.ExtractionReviewRepository, storageService *CandidateStorageService) *ExtractionReviewService {
+	return &ExtractionReviewService{
+		reviewRepo:     reviewRepo,
+		storageService: storageService,
+	}
+}
+
+// List returns held extractions by status, pending unless approved or rejected is asked for
+func (s *ExtractionReviewService) List(status string) ([]models.ExtractionReview, error) {
+	switch status {
+	case "":
+		status = models.ReviewStatusPending
+	case models.ReviewStatusPending, models.ReviewStatusApproved, models.ReviewStatusRejected:
+	default:
+		return nil, NewValidationError("status must be pending, approved or rejected")
+	}
+
+	return s.reviewRepo.List(status)
+}
+
+// Get returns a held extraction with its resume text
+func (s *ExtractionReviewService) Get(id int) (*models.ExtractionReview, error) {
+	review, err := s.reviewRepo.GetByID(id)
+	if err != nil {
+		return nil, NewNotFoundError("extraction review not found")
+	}
+	return review, nil
+}
+
+// Update replaces the data of a pending extraction with a reviewer's corrections
+func (s *ExtractionReviewService) Update(id int, data *models.ProcessedResumeData) (*models.ExtractionReview, error) {
+	if data == nil {
+		return nil, NewValidationError("extracted_data is required")
+	}
+	if err := s.updateData(id, data); err != nil {
+		return nil, err
+	}
+
+	return s.reviewRepo.GetByID(id)
+}
+
+// Approve stores a pending extraction as an employee, with the reviewer's corrections when
+// data is given. The extraction goes back to the queue when it cannot be stored.
+func (s *ExtractionReviewService) Approve(id int, data *models.ProcessedResumeData, reviewedBy, notes string) (*models.ExtractionReviewResult, error) {
+	if data != nil {
+		if err := s.updateData(id, data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.resolve(id, models.ReviewStatusApproved, reviewedBy, notes); err != nil {
+		return nil, err
+	}
+	review, err := s.reviewRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	candidate, err := s.storageService.StoreCandidate(review.OriginalText, review.ExtractedData, review.ExtractionSource, review.ResumeURL)
+	if err == nil && candidate.Status == "failed" {
+		err = NewValidationError(candidate.Message)
+	}
+	if err != nil {
+		if reopenErr := s.reviewRepo.Reopen(id); reopenErr != nil {
+			log.Printf("Failed to reopen extraction review %d: %v", id, reopenErr)
+		}
+		return nil, err
+	}
+
+	if err := s.reviewRepo.SetEmployee(id, candidate.EmployeeID); err != nil {
+		log.Printf("Failed to link extraction review %d to employee %d: %v", id, candidate.EmployeeID, err)
+	}
+	review.EmployeeID = &candidate.EmployeeID
+
+	return &models.ExtractionReviewResult{Review: review, Candidate: candidate}, nil
+}
+
+// Reject discards a pending extraction without storing it
+func (s *ExtractionReviewService) Reject(id int, reviewedBy, notes string) (*models.ExtractionReviewResult, error) {
+	if err := s.resolve(id, models.ReviewStatusRejected, reviewedBy, notes); err != nil {
+		return nil, err
+	}
+
+	review, err := s.reviewRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ExtractionReviewResult{Review: review}, nil
+}
+
+// updateData replaces the data of a pending extraction
+func (s *ExtractionReviewService) updateData(id int, data *models.ProcessedResumeData) error {
+	updated, err := s.reviewRepo.UpdateData(id, data)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return s.notPending(id)
+	}
+	return nil
+}
+
+// resolve claims a pending extraction for one reviewer
+func (s *ExtractionReviewService) resolve(id int, status, reviewedBy, notes string) error {
+	resolved, err := s.reviewRepo.Resolve(id, status, reviewedBy, notes)
+	if err != nil {
+		return err
+	}
+	if !resolved {
+		return s.notPending(id)
+	}
+	return nil
+}
+
+// notPending explains why an extraction could not be changed: it does not exist or it was
+// already resolved
+func (s *ExtractionReviewService) notPending(id int) error {
+	review, err := s.reviewRepo.GetByID(id)
+	if err != nil {
+		return NewNotFoundError("extraction review not found")
+	}
+	return NewConflictError(fmt.Sprintf("extraction review was already %s", review.Status))
+}
@@ -0,0 +1,427 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"stafind-backend/internal/googledrive"
+	"stafind-backend/internal/models"
+	"stafind-backend/internal/repositories"
+	"stafind-backend/internal/textextract"
+)
+
+// ErrGoogleDriveNotConfigured is returned when no Drive credentials are configured
+var ErrGoogleDriveNotConfigured = errors.New("google drive is not configured")
+
+// Resume formats scanned when the request does not list file types
+var defaultDriveFileTypes = []string{
+	textextract.MimeTypePDF,
+	textextract.MimeTypeDOCX,
+	textextract.MimeTypePlain,
+	googledrive.MimeTypeGoogleDoc,
+}
+
+// changeTokenPrecision is the resolution of stored timestamps; tokens stay below pending files by this much
+const changeTokenPrecision = time.Microsecond
+
+type googleDriveService struct {
+	client           *googledrive.Client
+	driveSyncRepo    repositories.DriveSyncRepository
+	importer         *ResumeImporter
+	cvExtractService CVExtractService
+
+	mu      sync.Mutex
+	running map[string]bool
+}
+
+// NewGoogleDriveService creates a new Google Drive sync service. A nil client leaves the
+// service unconfigured; scans then fail with ErrGoogleDriveNotConfigured.
+func NewGoogleDriveService(
+	client *googledrive.Client,
+	driveSyncRepo repositories.DriveSyncRepository,
+	extractionService *CandidateExtractService,
+	candidateStorageService *CandidateStorageService,
+	cvExtractService CVExtractService,
+) GoogleDriveService {
+	return &googleDriveService{
+		client:           client,
+		driveSyncRepo:    driveSyncRepo,
+		importer:         NewResumeImporter(extractionService, candidateStorageService),
+		cvExtractService: cvExtractService,
+		running:          make(map[string]bool),
+	}
+}
+
+// ScanFolder lists a folder, processes new and changed resumes through the candidate pipeline
+// and records the results. Files modified after the stored change token are listed; files
+// whose recorded revision was already processed are skipped unless ProcessExisting is set.
+func (s *googleDriveService) ScanFolder(ctx context.Context, req *models.GoogleDriveScanRequest) (*models.GoogleDriveScanResponse, error) {
+	if s.client == nil {
+		return nil, ErrGoogleDriveNotConfigured
+	}
+	if strings.TrimSpace(req.FolderID) == "" {
+		return nil, NewValidationError("folder_id is required")
+	}
+	if req.MaxFiles < 0 {
+		return nil, NewValidationError("max_files must not be negative")
+	}
+
+	if !s.startScan(req.FolderID) {
+		return nil, NewConflictError(fmt.Sprintf("a scan of folder %s is already running", req.FolderID))
+	}
+	defer s.finishScan(req.FolderID)
+
+	startTime := time.Now()
+
+	folder, err := s.client.GetFolder(ctx, req.FolderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get folder: %w", err)
+	}
+
+	var previousToken time.Time
+	state, err := s.driveSyncRepo.GetFolder(req.FolderID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if state != nil && state.ChangeToken != nil && !req.ProcessExisting {
+		previousToken, err = parseChangeToken(*state.ChangeToken)
+		if err != nil {
+			log.Printf("Ignoring invalid change token for folder %s: %v", req.FolderID, err)
+		}
+	}
+
+	files, err := s.listFiles(ctx, req, previousToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// File results reference the folder, so make sure it is recorded before processing
+	syncState := &models.GoogleDriveSyncFolder{
+		FolderID:   req.FolderID,
+		FolderName: &folder.Name,
+		Recursive:  req.Recursive,
+	}
+	if state != nil {
+		syncState.ChangeToken = state.ChangeToken
+	}
+	if err := s.driveSyncRepo.UpsertFolder(syncState); err != nil {
+		return nil, err
+	}
+
+	response := &models.GoogleDriveScanResponse{
+		FolderID:   folder.ID,
+		FolderName: folder.Name,
+		TotalFiles: len(files),
+		Files:      make([]models.GoogleDriveFileProcess, 0, len(files)),
+	}
+
+	// Oldest first, so a capped scan resumes where it stopped
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModifiedTime.Before(files[j].ModifiedTime)
+	})
+
+	var pending []models.GoogleDriveFile
+	for _, file := range files {
+		previous, err := s.driveSyncRepo.GetFile(file.ID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+
+		if !req.ProcessExisting && previous != nil &&
+			previous.Status == models.GoogleDriveFileStatusProcessed &&
+			previous.ModifiedTime.Equal(file.ModifiedTime.UTC().Truncate(changeTokenPrecision)) {
+			response.SkippedFiles++
+			response.Files = append(response.Files, models.GoogleDriveFileProcess{
+				FileID:       file.ID,
+				FileName:     file.Name,
+				FileSize:     file.Size,
+				MimeType:     file.MimeType,
+				ModifiedTime: file.ModifiedTime,
+				Status:       models.GoogleDriveFileStatusSkipped,
+				ProcessedAt:  previous.ProcessedAt,
+			})
+			continue
+		}
+		pending = append(pending, file)
+	}
+
+	var deferred []models.GoogleDriveFile
+	if req.MaxFiles > 0 && len(pending) > req.MaxFiles {
+		deferred = pending[req.MaxFiles:]
+		pending = pending[:req.MaxFiles]
+		response.Errors = append(response.Errors, fmt.Sprintf("%d files left for the next scan (max_files=%d)", len(deferred), req.MaxFiles))
+	}
+
+	extractRequestID := ""
+	if len(pending) > 0 {
+		extractRequestID = s.startTracking(req, folder, len(pending))
+		response.ExtractRequestID = extractRequestID
+	}
+
+	skillsSeen := make(map[string]bool)
+	for i, file := range pending {
+		if err := ctx.Err(); err != nil {
+			// Stop early; unprocessed files are picked up by the next scan
+			deferred = append(deferred, pending[i:]...)
+			response.Errors = append(response.Errors, "scan cancelled: "+err.Error())
+			break
+		}
+
+		result := s.processFile(ctx, req, file)
+		response.Files = append(response.Files, result)
+
+		if result.Status == models.GoogleDriveFileStatusProcessed {
+			response.ProcessedFiles++
+			if req.ExtractSkills {
+				for _, skill := range result.ExtractedSkills {
+					key := strings.ToLower(skill.Name)
+					if !skillsSeen[key] {
+						skillsSeen[key] = true
+						response.ExtractedSkills = append(response.ExtractedSkills, skill)
+					}
+				}
+			}
+		} else {
+			response.FailedFiles++
+			response.Errors = append(response.Errors, fmt.Sprintf("%s: %s", file.Name, result.Error))
+		}
+
+		s.recordFile(req.FolderID, file, result)
+		if extractRequestID != "" {
+			fileStatus := models.CVExtractFileStatusProcessed
+			if result.Status != models.GoogleDriveFileStatusProcessed {
+				fileStatus = models.CVExtractFileStatusFailed
+			}
+			if _, err := s.cvExtractService.UpdateFileProgress(extractRequestID, i+1, fileStatus); err != nil {
+				log.Printf("Warning: Failed to update CV extract progress: %v", err)
+			}
+		}
+	}
+
+	response.ProcessingTime = time.Since(startTime)
+	if extractRequestID != "" {
+		if _, err := s.cvExtractService.MarkExtractSuccess(extractRequestID, response.ProcessingTime.Milliseconds()); err != nil {
+			log.Printf("Warning: Failed to mark CV extract as completed: %v", err)
+		}
+	}
+
+	changeToken := nextChangeToken(previousToken, files, deferred)
+	response.ChangeToken = formatChangeToken(changeToken)
+
+	syncState.ChangeToken = nil
+	if !changeToken.IsZero() {
+		syncState.ChangeToken = &response.ChangeToken
+	}
+	if extractRequestID != "" {
+		syncState.LastExtractRequestID = &extractRequestID
+	}
+	if err := s.driveSyncRepo.UpsertFolder(syncState); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// listFiles collects the supported files of a folder and, when recursive, of its subfolders
+func (s *googleDriveService) listFiles(ctx context.Context, req *models.GoogleDriveScanRequest, modifiedAfter time.Time) ([]models.GoogleDriveFile, error) {
+	fileTypes := req.FileTypes
+	if len(fileTypes) == 0 {
+		fileTypes = defaultDriveFileTypes
+	}
+	allowed := make(map[string]bool, len(fileTypes))
+	for _, fileType := range fileTypes {
+		allowed[strings.ToLower(fileType)] = true
+	}
+
+	var files []models.GoogleDriveFile
+	visited := map[string]bool{req.FolderID: true}
+	queue := []string{req.FolderID}
+
+	for len(queue) > 0 {
+		folderID := queue[0]
+		queue = queue[1:]
+
+		children, err := s.client.ListFiles(ctx, folderID, modifiedAfter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list folder %s: %w", folderID, err)
+		}
+		for _, file := range children {
+			if allowed[strings.ToLower(file.MimeType)] {
+				files = append(files, file)
+			}
+		}
+
+		if !req.Recursive {
+			break
+		}
+
+		subfolders, err := s.client.ListSubfolders(ctx, folderID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list subfolders of %s: %w", folderID, err)
+		}
+		for _, subfolder := range subfolders {
+			// Drive folders can have several parents, so the same folder may be reached twice
+			if !visited[subfolder.ID] {
+				visited[subfolder.ID] = true
+				queue = append(queue, subfolder.ID)
+			}
+		}
+	}
+
+	return files, nil
+}
+
+// processFile downloads a file, extracts its text and runs the candidate pipeline
+func (s *googleDriveService) processFile(ctx context.Context, req *models.GoogleDriveScanRequest, file models.GoogleDriveFile) models.GoogleDriveFileProcess {
+	result := models.GoogleDriveFileProcess{
+		FileID:       file.ID,
+		FileName:     file.Name,
+		FileSize:     file.Size,
+		MimeType:     file.MimeType,
+		ModifiedTime: file.ModifiedTime,
+		Status:       models.GoogleDriveFileStatusFailed,
+		ProcessedAt:  time.Now(),
+	}
+
+	data, mimeType, err := s.client.Download(ctx, file)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	text, err := textextract.Extract(data, mimeType, file.Name)
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to extract text: %v", err)
+		return result
+	}
+
+	imported, err := s.importer.Import(text, "google_drive", file.WebViewLink, map[string]interface{}{
+		"file_id":   file.ID,
+		"file_name": file.Name,
+		"folder_id": req.FolderID,
+	})
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Status = models.GoogleDriveFileStatusProcessed
+	result.EmployeeID = imported.Candidate.EmployeeID
+	result.Action = imported.Candidate.Action
+	if req.ExtractSkills {
+		result.ExtractedSkills = extractedSkillList(imported.Resume)
+	}
+	return result
+}
+
+// startTracking creates the CV extract record that tracks the files of this scan
+func (s *googleDriveService) startTracking(req *models.GoogleDriveScanRequest, folder *models.GoogleDriveFolder, numFiles int) string {
+	extractRequestID := fmt.Sprintf("drive-%s-%d", req.FolderID, time.Now().Unix())
+
+	metadata, _ := json.Marshal(map[string]interface{}{
+		"source":      "google_drive",
+		"folder_id":   req.FolderID,
+		"folder_name": folder.Name,
+		"recursive":   req.Recursive,
+	})
+	metadataStr := string(metadata)
+
+	if _, err := s.cvExtractService.CreateOrUpdateExtract(extractRequestID, models.CVExtractStatusProcessing, numFiles, 0, &metadataStr); err != nil {
+		log.Printf("Warning: Failed to create CV extract record for Drive scan: %v", err)
+		return ""
+	}
+	return extractRequestID
+}
+
+func (s *googleDriveService) recordFile(folderID string, file models.GoogleDriveFile, result models.GoogleDriveFileProcess) {
+	record := &models.GoogleDriveSyncFile{
+		FileID:       file.ID,
+		FolderID:     folderID,
+		FileName:     file.Name,
+		MimeType:     &file.MimeType,
+		FileSize:     &file.Size,
+		ModifiedTime: file.ModifiedTime.UTC().Truncate(changeTokenPrecision),
+		Status:       result.Status,
+	}
+	if result.EmployeeID != 0 {
+		record.EmployeeID = &result.EmployeeID
+	}
+	if result.Error != "" {
+		record.ErrorMessage = &result.Error
+	}
+
+	if err := s.driveSyncRepo.UpsertFile(record); err != nil {
+		log.Printf("Warning: Failed to record Drive file %s: %v", file.ID, err)
+	}
+}
+
+func (s *googleDriveService) startScan(folderID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running[folderID] {
+		return false
+	}
+	s.running[folderID] = true
+	return true
+}
+
+func (s *googleDriveService) finishScan(folderID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.running, folderID)
+}
+
+// nextChangeToken advances the token past every listed file, but stops just before the oldest
+// file that was not reached so the next scan lists it again. Failed files are recorded with
+// their error and passed: a file that can never be read would otherwise hold the token back
+// for good. A new revision of the file, or a scan with process_existing, retries it.
+func nextChangeToken(previous time.Time, listed, deferred []models.GoogleDriveFile) time.Time {
+	var oldestPending time.Time
+	for _, file := range deferred {
+		if oldestPending.IsZero() || file.ModifiedTime.Before(oldestPending) {
+			oldestPending = file.ModifiedTime
+		}
+	}
+	if !oldestPending.IsZero() {
+		return oldestPending.UTC().Add(-changeTokenPrecision)
+	}
+
+	token := previous
+	for _, file := range listed {
+		if file.ModifiedTime.After(token) {
+			token = file.ModifiedTime.UTC()
+		}
+	}
+	return token
+}
+
+func parseChangeToken(token string) (time.Time, error) {
+	return time.Parse(time.RFC3339Nano, token)
+}
+
+func formatChangeToken(token time.Time) string {
+	if token.IsZero() {
+		return ""
+	}
+	return token.UTC().Format(time.RFC3339Nano)
+}
+
+// extractedSkillList flattens the categorized skills of a candidate extraction
+func extractedSkillList(resume *models.ProcessedResumeData) []models.Skill {
+	names := resumeSkillNames(resume)
+	skills := make([]models.Skill, 0, len(names))
+	for _, name := range names {
+		skills = append(skills, models.Skill{Name: name})
+	}
+	return skills
+}
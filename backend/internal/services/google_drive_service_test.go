@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"stafind-backend/internal/googledrive"
+	"stafind-backend/internal/models"
+)
+
+// fakeDrive serves a folder of files from memory and fails every download, so no file
+// reaches the candidate pipeline
+type fakeDrive struct {
+	folderID string
+	files    []models.GoogleDriveFile
+}
+
+func (d *fakeDrive) Do(req *http.Request) (*http.Response, error) {
+	recorder := httptest.NewRecorder()
+	d.serve(recorder, req)
+	return recorder.Result(), nil
+}
+
+func (d *fakeDrive) serve(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == "/drive/v3/files/"+d.folderID:
+		json.NewEncoder(w).Encode(map[string]string{"id": d.folderID, "name": "Resumes", "mimeType": googledrive.MimeTypeFolder})
+
+	case r.URL.Path == "/drive/v3/files":
+		query := r.URL.Query().Get("q")
+		files := []map[string]interface{}{}
+		if !strings.Contains(query, "mimeType = ") {
+			var after time.Time
+			if i := strings.Index(query, "modifiedTime > '"); i >= 0 {
+				after, _ = time.Parse(time.RFC3339Nano, strings.TrimSuffix(query[i+len("modifiedTime > '"):], "'"))
+			}
+			for _, file := range d.files {
+				if file.ModifiedTime.After(after) {
+					files = append(files, map[string]interface{}{"id": file.ID, "name": file.Name, "mimeType": file.MimeType, "modifiedTime": file.ModifiedTime})
+				}
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"files": files})
+
+	default:
+		http.Error(w, "backend error", http.StatusInternalServerError)
+	}
+}
+
+type fakeDriveSyncRepo struct {
+	folders map[string]models.GoogleDriveSyncFolder
+	files   map[string]models.GoogleDriveSyncFile
+}
+
+func newFakeDriveSyncRepo() *fakeDriveSyncRepo {
+	return &fakeDriveSyncRepo{
+		folders: make(map[string]models.GoogleDriveSyncFolder),
+		files:   make(map[string]models.GoogleDriveSyncFile),
+	}
+}
+
+func (r *fakeDriveSyncRepo) GetFolder(folderID string) (*models.GoogleDriveSyncFolder, error) {
+	folder, ok := r.folders[folderID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return &folder, nil
+}
+
+func (r *fakeDriveSyncRepo) UpsertFolder(folder *models.GoogleDriveSyncFolder) error {
+	r.folders[folder.FolderID] = *folder
+	return nil
+}
+
+func (r *fakeDriveSyncRepo) GetFile(fileID string) (*models.GoogleDriveSyncFile, error) {
+	file, ok := r.files[fileID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return &file, nil
+}
+
+func (r *fakeDriveSyncRepo) UpsertFile(file *models.GoogleDriveSyncFile) error {
+	r.files[file.FileID] = *file
+	return nil
+}
+
+type fakeCVExtractService struct {
+	CVExtractService
+}
+
+func (fakeCVExtractService) CreateOrUpdateExtract(requestID string, status string, numFiles int, fileNumber int, metadata *string) (*models.CVExtract, error) {
+	return &models.CVExtract{}, nil
+}
+
+func (fakeCVExtractService) UpdateFileProgress(requestID string, fileNumber int, fileStatus string) (*models.CVExtract, error) {
+	return &models.CVExtract{}, nil
+}
+
+func (fakeCVExtractService) MarkExtractSuccess(requestID string, totalTimeMs int64) (*models.CVExtract, error) {
+	return &models.CVExtract{}, nil
+}
+
+func newDriveTestService(drive *fakeDrive, repo *fakeDriveSyncRepo) GoogleDriveService {
+	client := googledrive.NewClient("https://drive.test", drive, googledrive.StaticToken("test-token"))
+	return NewGoogleDriveService(client, repo, nil, nil, fakeCVExtractService{})
+}
+
+func driveTestFiles() []models.GoogleDriveFile {
+	base := time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+	return []models.GoogleDriveFile{
+		{ID: "f1", Name: "ana.pdf", MimeType: "application/pdf", ModifiedTime: base},
+		{ID: "f2", Name: "luis.pdf", MimeType: "application/pdf", ModifiedTime: base.Add(time.Hour)},
+	}
+}
+
+// A file that always fails must not hold the change token back, or every later scan would
+// list it and everything after it again
+func TestScanFolderAdvancesPastFailedFiles(t *testing.T) {
+	drive := &fakeDrive{folderID: "folder1", files: driveTestFiles()}
+	repo := newFakeDriveSyncRepo()
+	service := newDriveTestService(drive, repo)
+
+	response, err := service.ScanFolder(context.Background(), &models.GoogleDriveScanRequest{FolderID: "folder1"})
+	if err != nil {
+		t.Fatalf("ScanFolder() error = %v", err)
+	}
+	if response.FailedFiles != 2 {
+		t.Fatalf("failed files = %d, want 2", response.FailedFiles)
+	}
+	for _, file := range drive.files {
+		record, ok := repo.files[file.ID]
+		if !ok || record.Status != models.GoogleDriveFileStatusFailed || record.ErrorMessage == nil {
+			t.Errorf("file %s recorded as %+v, want failed with its error", file.ID, record)
+		}
+	}
+	if want := formatChangeToken(drive.files[1].ModifiedTime); response.ChangeToken != want {
+		t.Errorf("change token = %q, want %q", response.ChangeToken, want)
+	}
+
+	response, err = service.ScanFolder(context.Background(), &models.GoogleDriveScanRequest{FolderID: "folder1"})
+	if err != nil {
+		t.Fatalf("second ScanFolder() error = %v", err)
+	}
+	if response.TotalFiles != 0 {
+		t.Errorf("second scan listed %d files, want 0", response.TotalFiles)
+	}
+
+	response, err = service.ScanFolder(context.Background(), &models.GoogleDriveScanRequest{FolderID: "folder1", ProcessExisting: true})
+	if err != nil {
+		t.Fatalf("ScanFolder() with process_existing error = %v", err)
+	}
+	if response.FailedFiles != 2 {
+		t.Errorf("process_existing retried %d files, want 2", response.FailedFiles)
+	}
+}
+
+func TestScanFolderHoldsTokenBeforeDeferredFiles(t *testing.T) {
+	drive := &fakeDrive{folderID: "folder1", files: driveTestFiles()}
+	repo := newFakeDriveSyncRepo()
+	service := newDriveTestService(drive, repo)
+
+	response, err := service.ScanFolder(context.Background(), &models.GoogleDriveScanRequest{FolderID: "folder1", MaxFiles: 1})
+	if err != nil {
+		t.Fatalf("ScanFolder() error = %v", err)
+	}
+	if response.FailedFiles != 1 {
+		t.Fatalf("failed files = %d, want 1", response.FailedFiles)
+	}
+
+	response, err = service.ScanFolder(context.Background(), &models.GoogleDriveScanRequest{FolderID: "folder1"})
+	if err != nil {
+		t.Fatalf("second ScanFolder() error = %v", err)
+	}
+	if response.TotalFiles != 1 || response.Files[0].FileID != "f2" {
+		t.Errorf("second scan listed %+v, want only the deferred file f2", response.Files)
+	}
+}
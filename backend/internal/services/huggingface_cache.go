@@ -0,0 +1,219 @@
+package services
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"stafind-backend/internal/models"
+	"stafind-backend/internal/repositories"
+)
+
+// extractionCache is a size-bounded LRU of Hugging Face extraction results whose entries
+// expire after a TTL. With a repository, results are also stored in the database so they
+// survive restarts and are shared by every instance.
+type extractionCache struct {
+	mutex    sync.Mutex
+	capacity int
+	ttl      time.Duration
+	entries  map[string]*list.Element
+	order    *list.List // Most recently used at the front
+	repo     repositories.HuggingFaceCacheRepository
+	stats    models.HuggingFaceCacheStats
+}
+
+type extractionCacheEntry struct {
+	key       string
+	response  *models.HuggingFaceSkillExtractionResponse
+	expiresAt time.Time
+}
+
+// newExtractionCache creates a cache; repo may be nil to keep results in memory only
+func newExtractionCache(capacity int, ttl time.Duration, repo repositories.HuggingFaceCacheRepository) *extractionCache {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &extractionCache{
+		capacity: capacity,
+		ttl:      ttl,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+		repo:     repo,
+	}
+}
+
+// extractionCacheKey identifies the model output for a text. The redacted text is what the
+// model saw, so changing the redaction settings or rules gives new keys; the original text
+// is kept because result offsets are mapped back to it. Request options applied after the
+// model call, such as category filters, are not part of it.
+func extractionCacheKey(text, redactedText, modelName string, confidenceThreshold float64) string {
+	hash := sha256.New()
+	hash.Write([]byte(text))
+	hash.Write([]byte{0})
+	hash.Write([]byte(redactedText))
+	hash.Write([]byte{0})
+	hash.Write([]byte(modelName))
+	hash.Write([]byte{0})
+	hash.Write([]byte(strconv.FormatFloat(confidenceThreshold, 'g', -1, 64)))
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// get returns an unexpired result from memory, or from the database after a memory miss
+func (c *extractionCache) get(key string) (*models.HuggingFaceSkillExtractionResponse, bool) {
+	c.mutex.Lock()
+	if element, exists := c.entries[key]; exists {
+		entry := element.Value.(*extractionCacheEntry)
+		if time.Now().Before(entry.expiresAt) {
+			c.order.MoveToFront(element)
+			c.stats.Hits++
+			c.mutex.Unlock()
+			return entry.response, true
+		}
+		c.remove(element)
+		c.stats.Expirations++
+	}
+	c.mutex.Unlock()
+
+	if response, expiresAt, found := c.load(key); found {
+		c.mutex.Lock()
+		c.add(key, response, expiresAt)
+		c.stats.PersistentHits++
+		c.mutex.Unlock()
+		return response, true
+	}
+
+	c.mutex.Lock()
+	c.stats.Misses++
+	c.mutex.Unlock()
+	return nil, false
+}
+
+// set stores a result in memory and, when persistent, in the database
+func (c *extractionCache) set(key string, response *models.HuggingFaceSkillExtractionResponse) {
+	expiresAt := time.Now().Add(c.ttl)
+
+	c.mutex.Lock()
+	c.add(key, response, expiresAt)
+	c.mutex.Unlock()
+
+	if c.repo == nil {
+		return
+	}
+	data, err := json.Marshal(response)
+	if err != nil {
+		log.Printf("Failed to encode Hugging Face cache entry: %v", err)
+		return
+	}
+	if err := c.repo.Put(key, response.ModelUsed, data, expiresAt); err != nil {
+		log.Printf("Failed to persist Hugging Face cache entry: %v", err)
+	}
+}
+
+// load reads a result from the database. Database errors count as misses; the cache must
+// never fail an extraction.
+func (c *extractionCache) load(key string) (*models.HuggingFaceSkillExtractionResponse, time.Time, bool) {
+	if c.repo == nil {
+		return nil, time.Time{}, false
+	}
+
+	entry, err := c.repo.Get(key)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("Failed to read Hugging Face cache entry: %v", err)
+		}
+		return nil, time.Time{}, false
+	}
+
+	response := &models.HuggingFaceSkillExtractionResponse{}
+	if err := json.Unmarshal(entry.Response, response); err != nil {
+		log.Printf("Failed to decode Hugging Face cache entry: %v", err)
+		return nil, time.Time{}, false
+	}
+	return response, entry.ExpiresAt, true
+}
+
+// add inserts or refreshes an entry, evicting the least recently used beyond capacity.
+// The caller holds the mutex.
+func (c *extractionCache) add(key string, response *models.HuggingFaceSkillExtractionResponse, expiresAt time.Time) {
+	if element, exists := c.entries[key]; exists {
+		entry := element.Value.(*extractionCacheEntry)
+		entry.response = response
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&extractionCacheEntry{key: key, response: response, expiresAt: expiresAt})
+	for c.order.Len() > c.capacity {
+		c.remove(c.order.Back())
+		c.stats.Evictions++
+	}
+}
+
+// remove drops an entry. The caller holds the mutex.
+func (c *extractionCache) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*extractionCacheEntry).key)
+}
+
+// purgeExpired drops expired entries from memory and the database
+func (c *extractionCache) purgeExpired() (int64, error) {
+	now := time.Now()
+
+	c.mutex.Lock()
+	var purged int64
+	for element := c.order.Back(); element != nil; {
+		previous := element.Prev()
+		if !now.Before(element.Value.(*extractionCacheEntry).expiresAt) {
+			c.remove(element)
+			c.stats.Expirations++
+			purged++
+		}
+		element = previous
+	}
+	c.mutex.Unlock()
+
+	if c.repo == nil {
+		return purged, nil
+	}
+	deleted, err := c.repo.DeleteExpired()
+	return purged + deleted, err
+}
+
+// purge drops every entry from memory and the database
+func (c *extractionCache) purge() (*models.HuggingFaceCachePurgeResult, error) {
+	c.mutex.Lock()
+	result := &models.HuggingFaceCachePurgeResult{MemoryEntries: c.order.Len()}
+	c.entries = make(map[string]*list.Element)
+	c.order.Init()
+	c.mutex.Unlock()
+
+	if c.repo == nil {
+		return result, nil
+	}
+	deleted, err := c.repo.DeleteAll()
+	if err != nil {
+		return result, err
+	}
+	result.PersistentEntries = deleted
+	return result, nil
+}
+
+// snapshot returns the cache statistics
+func (c *extractionCache) snapshot() models.HuggingFaceCacheStats {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	stats := c.stats
+	stats.Entries = c.order.Len()
+	stats.Capacity = c.capacity
+	stats.Persistent = c.repo != nil
+	return stats
+}
@@ -0,0 +1,197 @@
+package services
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"stafind-backend/internal/models"
+	"stafind-backend/internal/repositories"
+)
+
+// fakeCacheRepo keeps persisted entries in memory and, like the query, hides expired ones
+type fakeCacheRepo struct {
+	repositories.HuggingFaceCacheRepository
+	entries map[string]models.HuggingFaceCacheEntry
+	expired int64 // Rows DeleteExpired reports
+}
+
+func newFakeCacheRepo() *fakeCacheRepo {
+	return &fakeCacheRepo{entries: make(map[string]models.HuggingFaceCacheEntry)}
+}
+
+func (r *fakeCacheRepo) Get(key string) (*models.HuggingFaceCacheEntry, error) {
+	entry, exists := r.entries[key]
+	if !exists || !time.Now().Before(entry.ExpiresAt) {
+		return nil, sql.ErrNoRows
+	}
+	return &entry, nil
+}
+
+func (r *fakeCacheRepo) Put(key, modelName string, response []byte, expiresAt time.Time) error {
+	r.entries[key] = models.HuggingFaceCacheEntry{CacheKey: key, ModelName: modelName, Response: response, ExpiresAt: expiresAt}
+	return nil
+}
+
+func (r *fakeCacheRepo) DeleteExpired() (int64, error) {
+	return r.expired, nil
+}
+
+// cachedResponse is a model result told apart by the model name
+func cachedResponse(modelName string) *models.HuggingFaceSkillExtractionResponse {
+	return &models.HuggingFaceSkillExtractionResponse{Success: true, ModelUsed: modelName}
+}
+
+func TestExtractionCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := newExtractionCache(2, time.Hour, nil)
+	cache.set("a", cachedResponse("a"))
+	cache.set("b", cachedResponse("b"))
+
+	// Reading a makes b the least recently used
+	if _, found := cache.get("a"); !found {
+		t.Fatal("a missing before eviction")
+	}
+	cache.set("c", cachedResponse("c"))
+
+	for key, want := range map[string]bool{"a": true, "b": false, "c": true} {
+		if _, found := cache.get(key); found != want {
+			t.Errorf("get(%q) found = %v, want %v", key, found, want)
+		}
+	}
+	stats := cache.snapshot()
+	if stats.Evictions != 1 || stats.Entries != 2 || stats.Capacity != 2 {
+		t.Errorf("stats = %+v, want 1 eviction and 2 of 2 entries", stats)
+	}
+}
+
+func TestExtractionCacheRefreshKeepsEntries(t *testing.T) {
+	cache := newExtractionCache(2, time.Hour, nil)
+	cache.set("a", cachedResponse("old"))
+	cache.set("b", cachedResponse("b"))
+	// Setting a again refreshes it instead of adding a third entry, and makes b the oldest
+	cache.set("a", cachedResponse("new"))
+	cache.set("c", cachedResponse("c"))
+
+	response, found := cache.get("a")
+	if !found || response.ModelUsed != "new" {
+		t.Fatalf("get(a) = %+v, %v, want the refreshed response", response, found)
+	}
+	if _, found := cache.get("b"); found {
+		t.Error("b survived although it was the least recently used")
+	}
+}
+
+func TestExtractionCacheCapacityIsAtLeastOne(t *testing.T) {
+	cache := newExtractionCache(0, time.Hour, nil)
+	cache.set("a", cachedResponse("a"))
+	if _, found := cache.get("a"); !found {
+		t.Error("a cache of capacity 0 keeps nothing")
+	}
+}
+
+func TestExtractionCacheExpiry(t *testing.T) {
+	cache := newExtractionCache(10, time.Hour, nil)
+	cache.mutex.Lock()
+	cache.add("expired", cachedResponse("expired"), time.Now().Add(-time.Second))
+	cache.mutex.Unlock()
+
+	if _, found := cache.get("expired"); found {
+		t.Fatal("expired entry returned")
+	}
+	stats := cache.snapshot()
+	if stats.Expirations != 1 || stats.Misses != 1 || stats.Entries != 0 {
+		t.Errorf("stats = %+v, want the entry dropped as 1 expiration and 1 miss", stats)
+	}
+}
+
+func TestExtractionCachePurgeExpired(t *testing.T) {
+	repo := newFakeCacheRepo()
+	repo.expired = 3
+	cache := newExtractionCache(10, time.Hour, repo)
+
+	cache.mutex.Lock()
+	cache.add("old", cachedResponse("old"), time.Now().Add(-time.Minute))
+	cache.add("fresh", cachedResponse("fresh"), time.Now().Add(time.Minute))
+	cache.add("older", cachedResponse("older"), time.Now().Add(-time.Hour))
+	cache.mutex.Unlock()
+
+	purged, err := cache.purgeExpired()
+	if err != nil {
+		t.Fatalf("purgeExpired() error = %v", err)
+	}
+	if purged != 5 {
+		t.Errorf("purged = %d, want 2 from memory and 3 from the database", purged)
+	}
+	if _, found := cache.get("fresh"); !found {
+		t.Error("unexpired entry purged")
+	}
+	if stats := cache.snapshot(); stats.Entries != 1 || stats.Expirations != 2 {
+		t.Errorf("stats = %+v, want 1 entry left and 2 expirations", stats)
+	}
+}
+
+func TestExtractionCachePersistence(t *testing.T) {
+	repo := newFakeCacheRepo()
+	writer := newExtractionCache(10, time.Hour, repo)
+	writer.set("a", cachedResponse("model-a"))
+	if _, stored := repo.entries["a"]; !stored {
+		t.Fatal("set did not persist the entry")
+	}
+
+	// Another instance, or this one after a restart, finds it in the database
+	reader := newExtractionCache(10, time.Hour, repo)
+	response, found := reader.get("a")
+	if !found || response.ModelUsed != "model-a" {
+		t.Fatalf("get(a) = %+v, %v, want the persisted response", response, found)
+	}
+	// The loaded entry is now in memory
+	if _, found := reader.get("a"); !found {
+		t.Fatal("loaded entry not kept in memory")
+	}
+	if stats := reader.snapshot(); stats.PersistentHits != 1 || stats.Hits != 1 || !stats.Persistent {
+		t.Errorf("stats = %+v, want 1 persistent hit then 1 memory hit", stats)
+	}
+
+	// The loaded entry keeps the stored expiry instead of starting a new TTL
+	reader.mutex.Lock()
+	expiresAt := reader.entries["a"].Value.(*extractionCacheEntry).expiresAt
+	reader.mutex.Unlock()
+	if !expiresAt.Equal(repo.entries["a"].ExpiresAt) {
+		t.Errorf("loaded expiry = %v, want the stored %v", expiresAt, repo.entries["a"].ExpiresAt)
+	}
+}
+
+func TestExtractionCacheIgnoresBadPersistedEntries(t *testing.T) {
+	repo := newFakeCacheRepo()
+	repo.entries["corrupt"] = models.HuggingFaceCacheEntry{Response: []byte("{"), ExpiresAt: time.Now().Add(time.Hour)}
+	repo.entries["expired"] = models.HuggingFaceCacheEntry{Response: []byte("{}"), ExpiresAt: time.Now().Add(-time.Hour)}
+	cache := newExtractionCache(10, time.Hour, repo)
+
+	for _, key := range []string{"corrupt", "expired", "missing"} {
+		if _, found := cache.get(key); found {
+			t.Errorf("get(%q) found an entry", key)
+		}
+	}
+	if stats := cache.snapshot(); stats.Misses != 3 || stats.Entries != 0 {
+		t.Errorf("stats = %+v, want 3 misses and nothing in memory", stats)
+	}
+}
+
+func TestExtractionCacheKey(t *testing.T) {
+	base := extractionCacheKey("Ana, ana@acme.com, Go", "Ana, [EMAIL], Go", "model", 0.5)
+	if again := extractionCacheKey("Ana, ana@acme.com, Go", "Ana, [EMAIL], Go", "model", 0.5); again != base {
+		t.Errorf("same inputs give different keys")
+	}
+
+	others := map[string]string{
+		"text":          extractionCacheKey("Bea, ana@acme.com, Go", "Ana, [EMAIL], Go", "model", 0.5),
+		"redacted text": extractionCacheKey("Ana, ana@acme.com, Go", "Ana, ana@acme.com, Go", "model", 0.5),
+		"model":         extractionCacheKey("Ana, ana@acme.com, Go", "Ana, [EMAIL], Go", "other", 0.5),
+		"threshold":     extractionCacheKey("Ana, ana@acme.com, Go", "Ana, [EMAIL], Go", "model", 0.6),
+	}
+	for changed, key := range others {
+		if key == base {
+			t.Errorf("changing the %s keeps the key", changed)
+		}
+	}
+}
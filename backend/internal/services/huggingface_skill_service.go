@@ -1,58 +1,77 @@
 package services
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
+	"log"
+	"math"
+	"os"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"stafind-backend/internal/constants"
+	"stafind-backend/internal/huggingface"
 	"stafind-backend/internal/models"
+	"stafind-backend/internal/redact"
+	"stafind-backend/internal/repositories"
 )
 
 // HuggingFaceSkillService implements skill extraction using Hugging Face models
 type HuggingFaceSkillService struct {
-	apiKey          string
-	baseURL         string
+	client          *huggingface.Client
 	config          *models.SkillExtractionConfig
-	httpClient      *http.Client
 	stats           *models.SkillExtractionStats
 	statsMutex      sync.RWMutex
-	cache           map[string]*models.HuggingFaceSkillExtractionResponse
-	cacheMutex      sync.RWMutex
+	cache           *extractionCache
 	skillCategories map[string][]string
 	categoryMutex   sync.RWMutex
 }
 
-// NewHuggingFaceSkillService creates a new Hugging Face skill extraction service
-func NewHuggingFaceSkillService(apiKey string) *HuggingFaceSkillService {
+// NewHuggingFaceSkillService creates a new Hugging Face skill extraction service. Results are
+// cached in memory, and in the database too when cacheRepo is not nil.
+func NewHuggingFaceSkillService(client *huggingface.Client, cacheRepo repositories.HuggingFaceCacheRepository) *HuggingFaceSkillService {
+	cacheSize := constants.DefaultHuggingFaceCacheSize
+	if value := os.Getenv(constants.EnvHuggingFaceCacheSize); value != "" {
+		if size, err := strconv.Atoi(value); err == nil && size > 0 {
+			cacheSize = size
+		}
+	}
+	cacheTTLMinutes := constants.DefaultHuggingFaceCacheTTLMinutes
+	if value := os.Getenv(constants.EnvHuggingFaceCacheTTLMinutes); value != "" {
+		if minutes, err := strconv.Atoi(value); err == nil && minutes > 0 {
+			cacheTTLMinutes = minutes
+		}
+	}
+
 	config := &models.SkillExtractionConfig{
 		DefaultModel:        "dbmdz/bert-large-cased-finetuned-conll03-english",
 		FallbackModel:       "dslim/bert-base-NER",
 		ConfidenceThreshold: 0.5,
 		MaxSkillsPerText:    50,
 		EnableCaching:       true,
-		CacheExpiry:         30 * time.Minute,
+		CacheExpiry:         time.Duration(cacheTTLMinutes) * time.Minute,
+		MaxCacheEntries:     cacheSize,
+		Redaction:           redact.ParseConfig(os.Getenv(constants.EnvHuggingFaceRedact)),
 		Models: map[string]models.HuggingFaceModelConfig{
 			"dbmdz/bert-large-cased-finetuned-conll03-english": {
 				ModelName:           "dbmdz/bert-large-cased-finetuned-conll03-english",
-				APIEndpoint:         "https://api-inference.huggingface.co/models/dbmdz/bert-large-cased-finetuned-conll03-english",
+				APIEndpoint:         client.ModelURL("dbmdz/bert-large-cased-finetuned-conll03-english"),
 				ConfidenceThreshold: 0.5,
 				MaxTokens:           512,
 			},
 			"dslim/bert-base-NER": {
 				ModelName:           "dslim/bert-base-NER",
-				APIEndpoint:         "https://api-inference.huggingface.co/models/dslim/bert-base-NER",
+				APIEndpoint:         client.ModelURL("dslim/bert-base-NER"),
 				ConfidenceThreshold: 0.4,
 				MaxTokens:           512,
 			},
 			"microsoft/DialoGPT-medium": {
 				ModelName:           "microsoft/DialoGPT-medium",
-				APIEndpoint:         "https://api-inference.huggingface.co/models/microsoft/DialoGPT-medium",
+				APIEndpoint:         client.ModelURL("microsoft/DialoGPT-medium"),
 				ConfidenceThreshold: 0.3,
 				MaxTokens:           256,
 			},
@@ -70,12 +89,10 @@ func NewHuggingFaceSkillService(apiKey string) *HuggingFaceSkillService {
 	}
 
 	service := &HuggingFaceSkillService{
-		apiKey:          apiKey,
-		baseURL:         "https://api-inference.huggingface.co",
+		client:          client,
 		config:          config,
-		httpClient:      &http.Client{Timeout: 30 * time.Second},
 		stats:           &models.SkillExtractionStats{},
-		cache:           make(map[string]*models.HuggingFaceSkillExtractionResponse),
+		cache:           newExtractionCache(config.MaxCacheEntries, config.CacheExpiry, cacheRepo),
 		skillCategories: make(map[string][]string),
 	}
 
@@ -85,20 +102,14 @@ func NewHuggingFaceSkillService(apiKey string) *HuggingFaceSkillService {
 	return service
 }
 
-// ExtractSkills extracts skills from text using Hugging Face models
-func (h *HuggingFaceSkillService) ExtractSkills(request *models.HuggingFaceSkillExtractionRequest) (*models.HuggingFaceSkillExtractionResponse, error) {
+// ExtractSkills extracts skills from text using Hugging Face models. Model calls stop when
+// ctx is cancelled.
+func (h *HuggingFaceSkillService) ExtractSkills(ctx context.Context, request *models.HuggingFaceSkillExtractionRequest) (*models.HuggingFaceSkillExtractionResponse, error) {
 	startTime := time.Now()
 
 	// Update stats
 	h.updateStats(true, startTime)
 
-	// Check cache if enabled
-	if h.config.EnableCaching {
-		if cached, found := h.getFromCache(request.Text); found {
-			return cached, nil
-		}
-	}
-
 	// Validate request
 	if err := h.validateRequest(request); err != nil {
 		h.updateStats(false, startTime)
@@ -114,12 +125,24 @@ func (h *HuggingFaceSkillService) ExtractSkills(request *models.HuggingFaceSkill
 		modelName = h.config.DefaultModel
 	}
 
+	// Personal data never leaves for the model; offsets in its results are mapped back
+	redacted := redact.Redact(request.Text, h.config.Redaction)
+
+	// The cache holds model output; the request's filters are applied to a copy on every hit
+	cacheKey := extractionCacheKey(request.Text, redacted.Text, modelName, request.ConfidenceThreshold)
+	if h.config.EnableCaching {
+		if cached, found := h.cache.get(cacheKey); found {
+			response := *cached
+			return h.processSkills(&response, request, time.Since(startTime)), nil
+		}
+	}
+
 	// Extract skills using the specified model
-	response, err := h.extractSkillsWithModel(request.Text, modelName, request.ConfidenceThreshold)
+	response, err := h.extractSkillsWithModel(ctx, request.Text, redacted, modelName, request.ConfidenceThreshold)
 	if err != nil {
-		// Try fallback model if primary fails
-		if modelName != h.config.FallbackModel {
-			response, err = h.extractSkillsWithModel(request.Text, h.config.FallbackModel, request.ConfidenceThreshold)
+		// Try fallback model if primary fails, unless the caller has given up
+		if modelName != h.config.FallbackModel && ctx.Err() == nil {
+			response, err = h.extractSkillsWithModel(ctx, request.Text, redacted, h.config.FallbackModel, request.ConfidenceThreshold)
 		}
 		if err != nil {
 			h.updateStats(false, startTime)
@@ -130,27 +153,30 @@ func (h *HuggingFaceSkillService) ExtractSkills(request *models.HuggingFaceSkill
 		}
 	}
 
-	// Process and categorize skills
-	processedResponse := h.processSkills(response, request, time.Since(startTime))
-
-	// Cache the result if enabled
+	// Cache the model output if enabled. Fallback results are not cached for the requested
+	// model, so it is asked again once it recovers.
 	if h.config.EnableCaching {
-		h.setCache(request.Text, processedResponse)
+		cached := *response
+		if response.ModelUsed != modelName {
+			cacheKey = extractionCacheKey(request.Text, redacted.Text, response.ModelUsed, request.ConfidenceThreshold)
+		}
+		h.cache.set(cacheKey, &cached)
 	}
 
-	return processedResponse, nil
+	// Process and categorize skills
+	return h.processSkills(response, request, time.Since(startTime)), nil
 }
 
 // ExtractSkillsFromText is a convenience method for simple text extraction
-func (h *HuggingFaceSkillService) ExtractSkillsFromText(text string) (*models.HuggingFaceSkillExtractionResponse, error) {
+func (h *HuggingFaceSkillService) ExtractSkillsFromText(ctx context.Context, text string) (*models.HuggingFaceSkillExtractionResponse, error) {
 	request := &models.HuggingFaceSkillExtractionRequest{
 		Text: text,
 	}
-	return h.ExtractSkills(request)
+	return h.ExtractSkills(ctx, request)
 }
 
-// extractSkillsWithModel calls the Hugging Face API for a specific model
-func (h *HuggingFaceSkillService) extractSkillsWithModel(text, modelName string, confidenceThreshold float64) (*models.HuggingFaceSkillExtractionResponse, error) {
+// extractSkillsWithModel calls the Hugging Face API for a specific model with the redacted text
+func (h *HuggingFaceSkillService) extractSkillsWithModel(ctx context.Context, text string, redacted *redact.Result, modelName string, confidenceThreshold float64) (*models.HuggingFaceSkillExtractionResponse, error) {
 	modelConfig, exists := h.config.Models[modelName]
 	if !exists {
 		return nil, fmt.Errorf("model %s not found in configuration", modelName)
@@ -158,52 +184,16 @@ func (h *HuggingFaceSkillService) extractSkillsWithModel(text, modelName string,
 
 	// Prepare the request payload
 	payload := map[string]interface{}{
-		"inputs": text,
+		"inputs": redacted.Text,
 		"parameters": map[string]interface{}{
 			"aggregation_strategy": "simple", // For NER models, use aggregation_strategy instead
 		},
 	}
 
-	jsonPayload, err := json.Marshal(payload)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request payload: %w", err)
-	}
-
-	// Create HTTP request
-	req, err := http.NewRequest("POST", modelConfig.APIEndpoint, bytes.NewBuffer(jsonPayload))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+h.apiKey)
-
-	// Make the request
-	resp, err := h.httpClient.Do(req)
+	// Retries, backoff and the circuit breaker live in the client
+	body, err := h.client.Infer(ctx, modelConfig.ModelName, payload)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request to Hugging Face API: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Read response
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	// Check for API errors
-	if resp.StatusCode != http.StatusOK {
-		var apiError map[string]interface{}
-		if err := json.Unmarshal(body, &apiError); err == nil {
-			if errorMsg, ok := apiError["error"].(string); ok {
-				// Check for specific permission errors
-				if strings.Contains(errorMsg, "sufficient permissions") || strings.Contains(errorMsg, "authentication method") {
-					return nil, fmt.Errorf("hugging Face API permission error: %s. Please check your API key has Inference API permissions enabled", errorMsg)
-				}
-				return nil, fmt.Errorf("hugging Face API error: %s", errorMsg)
-			}
-		}
-		return nil, fmt.Errorf("hugging Face API returned status %d: %s", resp.StatusCode, string(body))
+		return nil, err
 	}
 
 	// Parse the NER results - NER models return a different format
@@ -214,15 +204,20 @@ func (h *HuggingFaceSkillService) extractSkillsWithModel(text, modelName string,
 		return nil, fmt.Errorf("failed to parse NER results: %w", err)
 	}
 
-	// Convert to our internal format
+	// Convert to our internal format, in offsets of the original text. Entities found in
+	// placeholders are personal data, not skills.
 	var nerResults []models.HuggingFaceNERResult
 	for _, resp := range nerResponses {
+		start, end, ok := redacted.OriginalSpan(resp.Start, resp.End)
+		if !ok {
+			continue
+		}
 		nerResults = append(nerResults, models.HuggingFaceNERResult{
 			Entity: resp.EntityGroup,
 			Score:  resp.Score,
 			Word:   resp.Word,
-			Start:  resp.Start,
-			End:    resp.End,
+			Start:  start,
+			End:    end,
 			Label:  resp.EntityGroup,
 		})
 	}
@@ -239,6 +234,9 @@ func (h *HuggingFaceSkillService) extractSkillsWithModel(text, modelName string,
 		ModelUsed:   modelName,
 		RawResponse: nerResults,
 	}
+	if len(redacted.Replacements) > 0 {
+		response.Redactions = redacted.Counts()
+	}
 
 	// Convert NER results to skills
 	skills := h.convertNERResultsToSkills(nerResults, text, confidenceThreshold)
@@ -257,7 +255,7 @@ func (h *HuggingFaceSkillService) extractSkillsWithModel(text, modelName string,
 // convertNERResultsToSkills converts Hugging Face NER results to skill objects
 func (h *HuggingFaceSkillService) convertNERResultsToSkills(nerResults []models.HuggingFaceNERResult, text string, confidenceThreshold float64) []models.HuggingFaceSkill {
 	var skills []models.HuggingFaceSkill
-	seenSkills := make(map[string]bool)
+	seenSkills := make(map[string]int)
 
 	for _, result := range nerResults {
 		// Filter by confidence threshold
@@ -272,7 +270,15 @@ func (h *HuggingFaceSkillService) convertNERResultsToSkills(nerResults []models.
 
 		// Normalize skill name
 		normalizedName := h.normalizeSkillName(result.Word)
-		if normalizedName == "" || seenSkills[normalizedName] {
+		if normalizedName == "" {
+			continue
+		}
+
+		mention := h.mentionAt(text, result)
+		if i, seen := seenSkills[normalizedName]; seen {
+			// Later mentions of a skill add evidence; the best score wins
+			skills[i].Mentions = append(skills[i].Mentions, mention)
+			skills[i].Confidence = math.Max(skills[i].Confidence, result.Score)
 			continue
 		}
 
@@ -288,10 +294,11 @@ func (h *HuggingFaceSkillService) convertNERResultsToSkills(nerResults []models.
 			Context:        context,
 			NormalizedName: normalizedName,
 			Synonyms:       h.generateSynonyms(normalizedName),
+			Mentions:       []models.SkillMention{mention},
 		}
 
+		seenSkills[normalizedName] = len(skills)
 		skills = append(skills, skill)
-		seenSkills[normalizedName] = true
 	}
 
 	// Sort by confidence score (highest first)
@@ -331,6 +338,9 @@ func (h *HuggingFaceSkillService) processSkills(response *models.HuggingFaceSkil
 	// Calculate overall confidence score
 	response.ConfidenceScore = h.calculateOverallConfidence(response.Skills)
 
+	// Per-skill evidence in the same shape as the database extractor's
+	response.Evidence = huggingFaceEvidence(response.Skills)
+
 	// Set processing time
 	response.ProcessingTime = processingTime
 
@@ -353,6 +363,18 @@ func (h *HuggingFaceSkillService) categorizeSkills(skills []models.HuggingFaceSk
 
 // Helper methods
 
+// mentionAt returns the text a NER result covers. Hugging Face offsets count characters, so
+// they are applied to runes; the model's word is used when they do not fit the text.
+func (h *HuggingFaceSkillService) mentionAt(text string, result models.HuggingFaceNERResult) models.SkillMention {
+	mention := models.SkillMention{Start: result.Start, End: result.End, Text: result.Word}
+
+	runes := []rune(text)
+	if result.Start >= 0 && result.Start < result.End && result.End <= len(runes) {
+		mention.Text = string(runes[result.Start:result.End])
+	}
+	return mention
+}
+
 func (h *HuggingFaceSkillService) validateRequest(request *models.HuggingFaceSkillExtractionRequest) error {
 	if request.Text == "" {
 		return fmt.Errorf("text is required")
@@ -584,19 +606,15 @@ func (h *HuggingFaceSkillService) initializeSkillCategories() {
 }
 
 // Cache methods
-func (h *HuggingFaceSkillService) getFromCache(text string) (*models.HuggingFaceSkillExtractionResponse, bool) {
-	h.cacheMutex.RLock()
-	defer h.cacheMutex.RUnlock()
 
-	response, exists := h.cache[text]
-	return response, exists
+// PurgeCache removes every cached extraction, in memory and in the database
+func (h *HuggingFaceSkillService) PurgeCache() (*models.HuggingFaceCachePurgeResult, error) {
+	return h.cache.purge()
 }
 
-func (h *HuggingFaceSkillService) setCache(text string, response *models.HuggingFaceSkillExtractionResponse) {
-	h.cacheMutex.Lock()
-	defer h.cacheMutex.Unlock()
-
-	h.cache[text] = response
+// PurgeExpiredCache removes cached extractions whose TTL has passed
+func (h *HuggingFaceSkillService) PurgeExpiredCache() (int64, error) {
+	return h.cache.purgeExpired()
 }
 
 // Stats methods
@@ -639,18 +657,66 @@ func (h *HuggingFaceSkillService) GetStats() (*models.SkillExtractionStats, erro
 
 	// Create a copy to avoid race conditions
 	stats := *h.stats
+	stats.Cache = h.cache.snapshot()
+	stats.ModelHealth = h.client.Health(h.config.DefaultModel, h.config.FallbackModel)
 	return &stats, nil
 }
 
+// GetHealth reports the circuit breaker state of the models. It reflects the calls made so
+// far and makes none itself.
+func (h *HuggingFaceSkillService) GetHealth() *models.HuggingFaceHealth {
+	health := &models.HuggingFaceHealth{
+		Status: "healthy",
+		Models: h.client.Health(h.config.DefaultModel, h.config.FallbackModel),
+	}
+
+	open := 0
+	for _, model := range health.Models {
+		if model.State == huggingface.StateOpen {
+			open++
+		}
+	}
+	switch {
+	case !h.client.Configured() || open == len(health.Models):
+		health.Status = "unhealthy"
+	case open > 0:
+		health.Status = "degraded"
+	}
+	return health
+}
+
 func (h *HuggingFaceSkillService) HealthCheck() error {
-	// Test with a simple request
-	_, err := h.ExtractSkillsFromText("test")
-	if err != nil {
-		return fmt.Errorf("health check failed: %w", err)
+	if !h.client.Configured() {
+		return fmt.Errorf("health check failed: %s is not set", constants.EnvHuggingFaceAPIKey)
+	}
+	if health := h.GetHealth(); health.Status == "unhealthy" {
+		return fmt.Errorf("health check failed: every model's circuit breaker is open")
 	}
 	return nil
 }
 
+// WarmUp asks the API to load the default and fallback models, which answer 503 for a while
+// after a period without use
+func (h *HuggingFaceSkillService) WarmUp(ctx context.Context) {
+	if !h.client.Configured() {
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, model := range []string{h.config.DefaultModel, h.config.FallbackModel} {
+		wg.Add(1)
+		go func(model string) {
+			defer wg.Done()
+			if err := h.client.Warm(ctx, model); err != nil {
+				log.Printf("Failed to warm up Hugging Face model %s: %v", model, err)
+				return
+			}
+			log.Printf("Hugging Face model %s is ready", model)
+		}(model)
+	}
+	wg.Wait()
+}
+
 // Utility function
 func containsString(slice []string, item string) bool {
 	for _, s := range slice {
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"stafind-backend/internal/constants"
+	"stafind-backend/internal/models"
+	"stafind-backend/internal/repositories"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type idempotencyService struct {
+	idempotencyRepo repositories.IdempotencyRepository
+	ttl             time.Duration
+	lease           time.Duration
+}
+
+// NewIdempotencyService creates a new idempotency service. Stored responses are kept
+// for IDEMPOTENCY_TTL_HOURS (24 by default). A request that has not finished within
+// IDEMPOTENCY_LEASE_MINUTES (5 by default) loses its key, so a crashed or hung request
+// does not block retries until the TTL passes.
+func NewIdempotencyService(idempotencyRepo repositories.IdempotencyRepository) IdempotencyService {
+	ttlHours := constants.DefaultIdempotencyTTLHours
+	if value := os.Getenv(constants.EnvIdempotencyTTLHours); value != "" {
+		if hours, err := strconv.Atoi(value); err == nil && hours > 0 {
+			ttlHours = hours
+		}
+	}
+	leaseMinutes := constants.DefaultIdempotencyLeaseMinutes
+	if value := os.Getenv(constants.EnvIdempotencyLeaseMinutes); value != "" {
+		if minutes, err := strconv.Atoi(value); err == nil && minutes > 0 {
+			leaseMinutes = minutes
+		}
+	}
+
+	return &idempotencyService{
+		idempotencyRepo: idempotencyRepo,
+		ttl:             time.Duration(ttlHours) * time.Hour,
+		lease:           time.Duration(leaseMinutes) * time.Minute,
+	}
+}
+
+// Begin reserves a key for a new request and returns the token of the reservation, which
+// Complete and Release need. When the key is already taken it returns the existing record and
+// no token, so the caller can replay or reject the request.
+func (s *idempotencyService) Begin(key, scope, fingerprint string) (*models.IdempotencyRecord, string, error) {
+	if key == "" || scope == "" {
+		return nil, "", NewValidationError("idempotency key and scope are required")
+	}
+
+	// The existing record may be released between the two calls, so try twice
+	for attempt := 0; attempt < 2; attempt++ {
+		now := time.Now()
+		token := uuid.NewString()
+		reserved, err := s.idempotencyRepo.Reserve(key, scope, fingerprint, token, now.Add(s.ttl), now.Add(-s.lease))
+		if err != nil {
+			return nil, "", err
+		}
+		if reserved {
+			return nil, token, nil
+		}
+
+		record, err := s.idempotencyRepo.Get(key, scope)
+		if err == nil {
+			return record, "", nil
+		}
+	}
+
+	return nil, "", fmt.Errorf("failed to reserve idempotency key %q", key)
+}
+
+// Complete stores the response of a request so that retries can replay it. It returns false
+// when the request outlived its lease and a retry has taken the key over; the retry's
+// response is kept.
+func (s *idempotencyService) Complete(key, scope, token string, responseStatus int, contentType string, body []byte) (bool, error) {
+	return s.idempotencyRepo.Complete(key, scope, token, responseStatus, contentType, body)
+}
+
+// Release frees a reserved key so the request can be retried. It returns false when a retry
+// has taken the key over, which is then left alone.
+func (s *idempotencyService) Release(key, scope, token string) (bool, error) {
+	return s.idempotencyRepo.Delete(key, scope, token)
+}
+
+// PurgeExpired removes stored responses whose TTL has passed
+func (s *idempotencyService) PurgeExpired() (int64, error) {
+	return s.idempotencyRepo.DeleteExpired()
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"stafind-backend/internal/constants"
+	"stafind-backend/internal/models"
+	"stafind-backend/internal/repositories"
+)
+
+// fakeIdempotencyRepo keeps one key the way the idempotency_keys queries do
+type fakeIdempotencyRepo struct {
+	repositories.IdempotencyRepository
+	record     *models.IdempotencyRecord
+	token      string
+	reservedAt time.Time
+}
+
+func (r *fakeIdempotencyRepo) Reserve(key, scope, fingerprint, token string, expiresAt, staleBefore time.Time) (bool, error) {
+	if r.record != nil && !(r.record.Status == models.IdempotencyStatusProcessing && r.reservedAt.Before(staleBefore)) {
+		return false, nil
+	}
+	r.record = &models.IdempotencyRecord{IdempotencyKey: key, Scope: scope, RequestFingerprint: fingerprint, Status: models.IdempotencyStatusProcessing}
+	r.token = token
+	r.reservedAt = time.Now()
+	return true, nil
+}
+
+func (r *fakeIdempotencyRepo) Get(key, scope string) (*models.IdempotencyRecord, error) {
+	return r.record, nil
+}
+
+func (r *fakeIdempotencyRepo) Complete(key, scope, token string, responseStatus int, contentType string, body []byte) (bool, error) {
+	if r.record == nil || r.token != token || r.record.Status != models.IdempotencyStatusProcessing {
+		return false, nil
+	}
+	r.record.Status = models.IdempotencyStatusCompleted
+	r.record.ResponseStatus = &responseStatus
+	r.record.ResponseBody = body
+	return true, nil
+}
+
+func (r *fakeIdempotencyRepo) Delete(key, scope, token string) (bool, error) {
+	if r.record == nil || r.token != token || r.record.Status != models.IdempotencyStatusProcessing {
+		return false, nil
+	}
+	r.record = nil
+	return true, nil
+}
+
+// A request that never finished holds its key only for the lease, not the whole TTL
+func TestIdempotencyBeginTakesOverStaleKeys(t *testing.T) {
+	t.Setenv(constants.EnvIdempotencyLeaseMinutes, "10")
+
+	repo := &fakeIdempotencyRepo{}
+	service := NewIdempotencyService(repo)
+	if _, token, err := service.Begin("job-1", "scope", "body"); err != nil || token == "" {
+		t.Fatalf("Begin() = token %q, %v; want the key reserved", token, err)
+	}
+
+	repo.reservedAt = time.Now().Add(-5 * time.Minute)
+	if _, token, err := service.Begin("job-1", "scope", "body"); err != nil || token != "" {
+		t.Fatalf("Begin() within the lease = token %q, %v; want the key still held", token, err)
+	}
+
+	repo.reservedAt = time.Now().Add(-11 * time.Minute)
+	if _, token, err := service.Begin("job-1", "scope", "body"); err != nil || token == "" {
+		t.Fatalf("Begin() after the lease = token %q, %v; want the key taken over", token, err)
+	}
+}
+
+// The slow request that lost its key to a retry can neither overwrite the retry's response
+// nor release its reservation
+func TestIdempotencyLateRequestAfterTakeover(t *testing.T) {
+	t.Setenv(constants.EnvIdempotencyLeaseMinutes, "10")
+
+	repo := &fakeIdempotencyRepo{}
+	service := NewIdempotencyService(repo)
+	_, slow, _ := service.Begin("job-1", "scope", "body")
+
+	repo.reservedAt = time.Now().Add(-11 * time.Minute)
+	_, retry, _ := service.Begin("job-1", "scope", "body")
+	if retry == "" || retry == slow {
+		t.Fatalf("retry token = %q, want a new reservation", retry)
+	}
+
+	if released, err := service.Release("job-1", "scope", slow); err != nil || released {
+		t.Errorf("Release() by the slow request = %v, %v; want false", released, err)
+	}
+	if stored, err := service.Complete("job-1", "scope", slow, 500, "text/plain", []byte("late")); err != nil || stored {
+		t.Errorf("Complete() by the slow request = %v, %v; want false", stored, err)
+	}
+	if repo.record == nil || repo.record.Status != models.IdempotencyStatusProcessing {
+		t.Fatalf("record = %+v, want the retry's reservation untouched", repo.record)
+	}
+
+	if stored, err := service.Complete("job-1", "scope", retry, 201, "application/json", []byte("{}")); err != nil || !stored {
+		t.Errorf("Complete() by the retry = %v, %v; want true", stored, err)
+	}
+	if stored, _ := service.Complete("job-1", "scope", slow, 500, "text/plain", []byte("late")); stored || *repo.record.ResponseStatus != 201 {
+		t.Errorf("late Complete() replaced the stored response with %d", *repo.record.ResponseStatus)
+	}
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"stafind-backend/internal/models"
 )
 
@@ -59,6 +60,8 @@ type SkillService interface {
 	GetSkillCategories(skillID int) ([]models.Category, error)
 	GetSkillsByEmployeeID(employeeID int) ([]models.Skill, error)
 	GetSkillsByEmployeeIDs(employeeIDs []int) (map[int][]models.Skill, error)
+	SuggestSkillCategories(name string) ([]string, error)
+	AddSkillAlias(skillID int, alias string) error
 }
 
 // DashboardService defines the interface for dashboard business logic
@@ -80,6 +83,7 @@ type AIAgentService interface {
 	UpdateAIAgentStatus(id int, status string) error
 	ProcessAIAgentRequest(id int) (*models.AIAgentResponse, error)
 	ExtractSkillsFromText(text string) (*models.SkillExtractResponse, error)
+	ParseJobRequest(text string) (*models.ParsedJobRequest, error)
 	GetAIAgentRequests(limit int, offset int) ([]models.AIAgentRequest, error)
 	GetAIAgentResponse(requestID int) (*models.AIAgentResponse, error)
 	FindMatchingEmployees(skills []string) ([]models.Match, error)
@@ -92,6 +96,7 @@ type AIAgentService interface {
 // NotificationService defines the interface for notification business logic
 type NotificationService interface {
 	SendTeamsMessage(channelID string, message string) error
+	SendTeamsNotification(title string, message string) error
 	SendAdminEmail(subject string, body string) error
 	LogError(requestID int, error string) error
 }
@@ -104,6 +109,9 @@ type APIKeyService interface {
 	DeactivateAPIKey(id int) error
 	UpdateLastUsed(key string) error
 	RotateAPIKey(oldKeyID int) (*models.APIKeyResponse, error)
+	CreateSigningSecret(id int) (*models.SigningSecretResponse, error)
+	GetSigningSecret(id int) (string, error)
+	HasSigningSecrets() (bool, error)
 }
 
 // ExtractionService defines the interface for candidate and resume extraction using NER
@@ -122,10 +130,25 @@ type ModernNERService interface {
 
 // HuggingFaceSkillExtractionService defines the interface for Hugging Face skill extraction
 type HuggingFaceSkillExtractionService interface {
-	ExtractSkills(request *models.HuggingFaceSkillExtractionRequest) (*models.HuggingFaceSkillExtractionResponse, error)
-	ExtractSkillsFromText(text string) (*models.HuggingFaceSkillExtractionResponse, error)
+	ExtractSkills(ctx context.Context, request *models.HuggingFaceSkillExtractionRequest) (*models.HuggingFaceSkillExtractionResponse, error)
+	ExtractSkillsFromText(ctx context.Context, text string) (*models.HuggingFaceSkillExtractionResponse, error)
 	GetAvailableModels() ([]string, error)
 	GetModelConfig(modelName string) (*models.HuggingFaceModelConfig, error)
 	GetStats() (*models.SkillExtractionStats, error)
+	GetHealth() *models.HuggingFaceHealth
+	PurgeCache() (*models.HuggingFaceCachePurgeResult, error)
 	HealthCheck() error
 }
+
+// IdempotencyService defines the interface for storing and replaying responses of idempotent requests
+type IdempotencyService interface {
+	Begin(key, scope, fingerprint string) (*models.IdempotencyRecord, string, error)
+	Complete(key, scope, token string, responseStatus int, contentType string, body []byte) (bool, error)
+	Release(key, scope, token string) (bool, error)
+	PurgeExpired() (int64, error)
+}
+
+// GoogleDriveService defines the interface for syncing resumes from Google Drive folders
+type GoogleDriveService interface {
+	ScanFolder(ctx context.Context, req *models.GoogleDriveScanRequest) (*models.GoogleDriveScanResponse, error)
+}
@@ -0,0 +1,690 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"stafind-backend/internal/constants"
+	"stafind-backend/internal/models"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	// yearsPattern matches "3 years", "3+ years", "3-5 años", "at least three years", "más de 2 años"
+	yearsPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}])(?:(?:at least|minimum|min\.?|more than|over|al menos|mínimo|minimo|más de|mas de)\s+)?(\d+(?:[.,]\d+)?|one|two|three|four|five|six|seven|eight|nine|ten|un|uno|dos|tres|cuatro|cinco|seis|siete|ocho|nueve|diez)(?:\s*(?:-|–|to|a)\s*(?:\d+(?:[.,]\d+)?))?\s*\+?\s*(?:years?|yrs?|años|anos|año)(?:$|[^\p{L}\p{N}])`)
+
+	// locationPattern matches a capitalized place name after "in", "based in", "en", "ubicado en", ...
+	locationPattern = regexp.MustCompile(`(?:^|[^\p{L}])(?i:based in|located in|in|from|ubicad[oa] en|radicad[oa] en|en|desde)\s+(\p{Lu}[\p{L}]+(?:[ -](?:de |del )?\p{Lu}[\p{L}]+)*)`)
+)
+
+// locationStopWords are capitalized words that follow "in"/"en" but are not places
+var locationStopWords = map[string]bool{
+	"english": true, "spanish": true, "inglés": true, "ingles": true, "español": true, "espanol": true,
+	"january": true, "february": true, "march": true, "april": true, "may": true, "june": true,
+	"july": true, "august": true, "september": true, "october": true, "november": true, "december": true,
+	"enero": true, "febrero": true, "marzo": true, "abril": true, "mayo": true, "junio": true,
+	"julio": true, "agosto": true, "septiembre": true, "octubre": true, "noviembre": true, "diciembre": true,
+}
+
+// locationSkillContext are words that, right before "in"/"en", mean the phrase is a skill and not a place
+var locationSkillContext = []string{
+	"experience", "experiencia", "expert", "experto", "experta", "skilled", "proficient",
+	"knowledge", "conocimiento", "conocimientos", "background", "specialized", "especializado",
+	"especializada", "certified", "certificado", "certificada", "fluent",
+}
+
+// ambiguousVocabularyTerms are vocabulary entries that are also common words and
+// only count as skills when written with their usual capitalization
+var ambiguousVocabularyTerms = map[string]string{
+	"go": "Go",
+}
+
+// JobRequestParser turns a free-text job request (English or Spanish) into a structured search
+type JobRequestParser struct {
+	nerService *NERService
+	vocabulary []string
+}
+
+// jobRequestClause is a fragment of the request with its required/preferred classification
+type jobRequestClause struct {
+	text      string
+	preferred bool
+}
+
+// NewJobRequestParser creates a new job request parser. The NER service is optional; when it is nil
+// or fails, skills are detected with the built-in normalization vocabulary only.
+func NewJobRequestParser(nerService *NERService) *JobRequestParser {
+	vocabulary := make([]string, 0, len(constants.SkillNormalizationMap))
+	for term := range constants.SkillNormalizationMap {
+		vocabulary = append(vocabulary, term)
+	}
+	// Longer terms first so "spring boot" is evidence before "spring"
+	sort.Slice(vocabulary, func(i, j int) bool {
+		if len(vocabulary[i]) != len(vocabulary[j]) {
+			return len(vocabulary[i]) > len(vocabulary[j])
+		}
+		return vocabulary[i] < vocabulary[j]
+	})
+
+	return &JobRequestParser{
+		nerService: nerService,
+		vocabulary: vocabulary,
+	}
+}
+
+// Parse interprets a job request and returns the search it describes together with
+// the evidence for every value, so the requester can review and correct it
+func (p *JobRequestParser) Parse(text string) (*models.ParsedJobRequest, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, NewValidationError("job request text is required")
+	}
+
+	lower := strings.ToLower(text)
+	result := &models.ParsedJobRequest{
+		OriginalText:    text,
+		Language:        p.detectLanguage(lower),
+		Interpretations: []models.JobRequestInterpretation{},
+	}
+
+	required, preferred := p.extractSkills(text, result)
+
+	experienceLevel := p.extractExperienceLevel(lower, result)
+	minYears := p.extractMinYears(lower, result)
+	if experienceLevel == "" && minYears > 0 {
+		experienceLevel = experienceLevelFromYears(minYears)
+		result.Interpretations = append(result.Interpretations, models.JobRequestInterpretation{
+			Field:    "experience_level",
+			Value:    experienceLevel,
+			Evidence: fmt.Sprintf("inferred from %s years of experience", formatYears(minYears)),
+		})
+	}
+
+	location := p.extractLocation(text, lower, append(append([]string{}, required...), preferred...), result)
+	department := p.extractDepartment(lower, result)
+	priority := p.extractPriority(lower, result)
+
+	result.SearchRequest = models.SearchRequest{
+		RequiredSkills:     required,
+		PreferredSkills:    preferred,
+		Department:         department,
+		ExperienceLevel:    experienceLevel,
+		Location:           location,
+		MinYearsExperience: minYears,
+	}
+
+	primarySkill := ""
+	if len(required) > 0 {
+		primarySkill = required[0]
+	} else if len(preferred) > 0 {
+		primarySkill = preferred[0]
+	}
+
+	yearsMin := ""
+	if minYears > 0 {
+		yearsMin = formatYears(minYears)
+	}
+
+	result.SearchCriteria = models.SearchCriteria{
+		Intent:             constants.JobRequestIntentSearch,
+		SkillsRequired:     required,
+		ExperienceLevel:    experienceLevel,
+		YearsExperienceMin: yearsMin,
+		Language:           result.Language,
+		OriginalMessage:    text,
+		SearchCriteria: models.DetailedCriteria{
+			PrimarySkill:    primarySkill,
+			SecondarySkills: preferred,
+			ExperienceFocus: department,
+			PriorityLevel:   priority,
+		},
+		ResponseSuggestion: p.describe(result.Language, &result.SearchRequest),
+	}
+
+	return result, nil
+}
+
+// extractSkills finds skills clause by clause and splits them into required and preferred.
+// A skill mentioned both ways is treated as required.
+func (p *JobRequestParser) extractSkills(text string, result *models.ParsedJobRequest) ([]string, []string) {
+	var required, preferred []string
+	seen := make(map[string]int) // normalized skill -> index in required (>=0) or -1 for preferred
+
+	for _, clause := range splitJobRequestClauses(text) {
+		for _, skill := range p.findSkills(clause.text) {
+			key := canonicalSkillKey(skill)
+			position, exists := seen[key]
+
+			switch {
+			case !exists && !clause.preferred:
+				seen[key] = len(required)
+				required = append(required, skill)
+			case !exists && clause.preferred:
+				seen[key] = -1
+				preferred = append(preferred, skill)
+			case exists && position == -1 && !clause.preferred:
+				preferred = removeSkill(preferred, key)
+				seen[key] = len(required)
+				required = append(required, skill)
+			default:
+				continue
+			}
+
+			field := "required_skills"
+			if clause.preferred {
+				field = "preferred_skills"
+			}
+			result.Interpretations = append(result.Interpretations, models.JobRequestInterpretation{
+				Field:    field,
+				Value:    skill,
+				Evidence: clause.text,
+			})
+		}
+	}
+
+	if required == nil {
+		required = []string{}
+	}
+	if preferred == nil {
+		preferred = []string{}
+	}
+	return required, preferred
+}
+
+// findSkills returns the skills mentioned in a clause, preferring database names over vocabulary terms
+func (p *JobRequestParser) findSkills(clause string) []string {
+	var skills []string
+	seen := make(map[string]bool)
+
+	add := func(skill string) {
+		key := canonicalSkillKey(skill)
+		if key == "" || seen[key] {
+			return
+		}
+		seen[key] = true
+		skills = append(skills, skill)
+	}
+
+	if p.nerService != nil {
+		nerResult, err := p.nerService.ExtractSkillsFromText(clause)
+		if err != nil {
+			log.Printf("Job request parser: NER extraction failed, using vocabulary only: %v", err)
+		} else if nerResult != nil {
+			var found []string
+			for _, skillList := range nerResult.Skills.Categories {
+				found = append(found, skillList...)
+			}
+			// Keep output stable across map iteration orders
+			sort.Strings(found)
+			for _, skill := range found {
+				add(skill)
+			}
+		}
+	}
+
+	// Match longer vocabulary terms first and blank them out, so "js" is not found inside "node.js"
+	type vocabularyMatch struct {
+		skill string
+		index int
+	}
+	var matches []vocabularyMatch
+	masked := strings.ToLower(clause)
+	for _, term := range p.vocabulary {
+		index := -1
+		if casedTerm, ambiguous := ambiguousVocabularyTerms[term]; ambiguous {
+			index = indexTerm(clause, casedTerm)
+		} else {
+			masked, index = maskTerm(masked, term)
+		}
+		if index >= 0 {
+			matches = append(matches, vocabularyMatch{skill: constants.SkillNormalizationMap[term], index: index})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].index < matches[j].index })
+	for _, match := range matches {
+		add(match.skill)
+	}
+
+	return skills
+}
+
+// extractExperienceLevel returns the ExperienceLevelMap key for the earliest seniority term in the text
+func (p *JobRequestParser) extractExperienceLevel(lower string, result *models.ParsedJobRequest) string {
+	bestIndex := -1
+	bestTerm := ""
+	for term := range constants.JobRequestSeniorityTerms {
+		index := indexTerm(lower, term)
+		if index < 0 {
+			continue
+		}
+		if bestIndex < 0 || index < bestIndex || (index == bestIndex && len(term) > len(bestTerm)) {
+			bestIndex = index
+			bestTerm = term
+		}
+	}
+
+	if bestTerm == "" {
+		return ""
+	}
+
+	level := constants.JobRequestSeniorityTerms[bestTerm]
+	result.Interpretations = append(result.Interpretations, models.JobRequestInterpretation{
+		Field:    "experience_level",
+		Value:    level,
+		Evidence: bestTerm,
+	})
+	return level
+}
+
+// extractMinYears returns the largest minimum number of years mentioned in the text
+func (p *JobRequestParser) extractMinYears(lower string, result *models.ParsedJobRequest) float64 {
+	var minYears float64
+	evidence := ""
+
+	for _, match := range yearsPattern.FindAllStringSubmatch(lower, -1) {
+		years := parseJobRequestNumber(match[1])
+		if years > minYears {
+			minYears = years
+			evidence = strings.TrimFunc(match[0], func(r rune) bool {
+				return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+'
+			})
+		}
+	}
+
+	if minYears > 0 {
+		result.Interpretations = append(result.Interpretations, models.JobRequestInterpretation{
+			Field:    "min_years_experience",
+			Value:    formatYears(minYears),
+			Evidence: evidence,
+		})
+	}
+	return minYears
+}
+
+// extractLocation finds a city after a location preposition, falling back to remote work
+func (p *JobRequestParser) extractLocation(text, lower string, skills []string, result *models.ParsedJobRequest) string {
+	skillKeys := make(map[string]bool, len(skills))
+	for _, skill := range skills {
+		skillKeys[canonicalSkillKey(skill)] = true
+	}
+
+	for _, match := range locationPattern.FindAllStringSubmatchIndex(text, -1) {
+		place := text[match[2]:match[3]]
+		if !p.isLikelyPlace(place, text[:match[0]], skillKeys) {
+			continue
+		}
+
+		result.Interpretations = append(result.Interpretations, models.JobRequestInterpretation{
+			Field:    "location",
+			Value:    place,
+			Evidence: strings.TrimSpace(text[match[0]:match[1]]),
+		})
+		return place
+	}
+
+	for _, term := range []string{"remote", "remotely", "remoto", "remota", "teletrabajo"} {
+		if indexTerm(lower, term) >= 0 {
+			result.Interpretations = append(result.Interpretations, models.JobRequestInterpretation{
+				Field:    "location",
+				Value:    constants.JobRequestLocationRemote,
+				Evidence: term,
+			})
+			return constants.JobRequestLocationRemote
+		}
+	}
+
+	return ""
+}
+
+// isLikelyPlace rejects captured phrases that are skills, languages, months or role keywords
+func (p *JobRequestParser) isLikelyPlace(place, before string, skillKeys map[string]bool) bool {
+	lowerPlace := strings.ToLower(place)
+	if skillKeys[canonicalSkillKey(place)] || locationStopWords[lowerPlace] {
+		return false
+	}
+	if _, isSkill := constants.SkillNormalizationMap[lowerPlace]; isSkill {
+		return false
+	}
+	if _, isDepartment := constants.JobRequestDepartmentTerms[lowerPlace]; isDepartment {
+		return false
+	}
+
+	words := strings.Fields(strings.ToLower(before))
+	if len(words) > 0 {
+		previous := strings.TrimFunc(words[len(words)-1], func(r rune) bool { return !unicode.IsLetter(r) })
+		for _, context := range locationSkillContext {
+			if previous == context {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// extractDepartment maps the earliest role keyword in the text to a department category
+func (p *JobRequestParser) extractDepartment(lower string, result *models.ParsedJobRequest) string {
+	bestIndex := -1
+	bestTerm := ""
+	for term := range constants.JobRequestDepartmentTerms {
+		index := indexTerm(lower, term)
+		if index < 0 {
+			continue
+		}
+		if bestIndex < 0 || index < bestIndex || (index == bestIndex && len(term) > len(bestTerm)) {
+			bestIndex = index
+			bestTerm = term
+		}
+	}
+
+	if bestTerm == "" {
+		return ""
+	}
+
+	department := constants.JobRequestDepartmentTerms[bestTerm]
+	result.Interpretations = append(result.Interpretations, models.JobRequestInterpretation{
+		Field:    "department",
+		Value:    department,
+		Evidence: bestTerm,
+	})
+	return department
+}
+
+// extractPriority returns high priority when the request uses urgency words
+func (p *JobRequestParser) extractPriority(lower string, result *models.ParsedJobRequest) string {
+	for _, term := range constants.JobRequestUrgencyTerms {
+		if indexTerm(lower, term) >= 0 {
+			result.Interpretations = append(result.Interpretations, models.JobRequestInterpretation{
+				Field:    "priority_level",
+				Value:    constants.JobRequestPriorityHigh,
+				Evidence: term,
+			})
+			return constants.JobRequestPriorityHigh
+		}
+	}
+	return constants.JobRequestPriorityNormal
+}
+
+// detectLanguage guesses whether the request is written in Spanish or English
+func (p *JobRequestParser) detectLanguage(lower string) string {
+	words := strings.FieldsFunc(lower, func(r rune) bool { return !unicode.IsLetter(r) })
+	spanish, english := 0, 0
+	for _, word := range words {
+		if contains(constants.JobRequestSpanishHints, word) {
+			spanish++
+		}
+		if contains(constants.JobRequestEnglishHints, word) {
+			english++
+		}
+	}
+
+	if spanish > english {
+		return constants.JobRequestLanguageSpanish
+	}
+	return constants.JobRequestLanguageEnglish
+}
+
+// describe renders the parsed search back to the requester in their language
+func (p *JobRequestParser) describe(language string, req *models.SearchRequest) string {
+	spanish := language == constants.JobRequestLanguageSpanish
+
+	var summary strings.Builder
+	if spanish {
+		summary.WriteString("Buscando perfil")
+		if req.ExperienceLevel != "" {
+			summary.WriteString(" " + req.ExperienceLevel)
+		}
+	} else {
+		summary.WriteString("Searching for a")
+		if req.ExperienceLevel != "" {
+			summary.WriteString(" " + req.ExperienceLevel)
+		}
+		summary.WriteString(" candidate")
+	}
+	if req.Department != "" {
+		summary.WriteString(" (" + req.Department + ")")
+	}
+
+	if len(req.RequiredSkills) > 0 {
+		if spanish {
+			summary.WriteString(" con ")
+		} else {
+			summary.WriteString(" with ")
+		}
+		summary.WriteString(strings.Join(req.RequiredSkills, ", "))
+	}
+
+	switch {
+	case req.Location == constants.JobRequestLocationRemote && spanish:
+		summary.WriteString(", remoto")
+	case req.Location == constants.JobRequestLocationRemote:
+		summary.WriteString(", working remotely")
+	case req.Location != "" && spanish:
+		summary.WriteString(" en " + req.Location)
+	case req.Location != "":
+		summary.WriteString(" in " + req.Location)
+	}
+
+	if req.MinYearsExperience > 0 {
+		if spanish {
+			summary.WriteString(", " + formatYears(req.MinYearsExperience) + "+ años de experiencia")
+		} else {
+			summary.WriteString(", " + formatYears(req.MinYearsExperience) + "+ years of experience")
+		}
+	}
+
+	if len(req.PreferredSkills) > 0 {
+		if spanish {
+			summary.WriteString("; deseable: ")
+		} else {
+			summary.WriteString("; nice to have: ")
+		}
+		summary.WriteString(strings.Join(req.PreferredSkills, ", "))
+	}
+
+	summary.WriteString(".")
+	return summary.String()
+}
+
+// splitJobRequestClauses splits a request into clauses and marks the ones that describe
+// optional skills. A marker followed by ":" ("Nice to have: Spring, Docker") applies to the
+// rest of the list until the next sentence or header.
+func splitJobRequestClauses(text string) []jobRequestClause {
+	var clauses []jobRequestClause
+	stickyPreferred := false
+
+	for _, sentence := range splitJobRequestSentences(text) {
+		if !sentence.continuesList {
+			stickyPreferred = false
+		}
+
+		for _, part := range strings.Split(sentence.text, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			lowerPart := strings.ToLower(part)
+
+			preferred := stickyPreferred
+			if marker, isHeader := findJobRequestMarker(lowerPart, constants.JobRequestPreferredMarkers); marker {
+				preferred = true
+				if isHeader {
+					stickyPreferred = true
+				}
+			} else if marker, isHeader := findJobRequestMarker(lowerPart, constants.JobRequestRequiredMarkers); marker {
+				preferred = false
+				if isHeader {
+					stickyPreferred = false
+				}
+			}
+
+			clauses = append(clauses, jobRequestClause{text: part, preferred: preferred})
+		}
+	}
+
+	return clauses
+}
+
+// jobRequestSentence is a sentence or line of the request
+type jobRequestSentence struct {
+	text          string
+	continuesList bool // true when the previous sentence ended with a line break or a ":" header
+}
+
+// splitJobRequestSentences splits on line breaks, ";", "!", "?" and on "." when it ends a
+// sentence, so names such as "Node.js" or "VB.NET" stay intact
+func splitJobRequestSentences(text string) []jobRequestSentence {
+	var sentences []jobRequestSentence
+	runes := []rune(text)
+	start := 0
+	continuesList := false
+
+	flush := func(end int, nextContinues bool) {
+		part := strings.TrimSpace(string(runes[start:end]))
+		if part != "" {
+			sentences = append(sentences, jobRequestSentence{text: part, continuesList: continuesList})
+			continuesList = nextContinues || strings.HasSuffix(part, ":")
+		}
+		start = end + 1
+	}
+
+	for i, r := range runes {
+		switch {
+		case r == '\n':
+			flush(i, true)
+		case r == ';' || r == '!' || r == '?':
+			flush(i, false)
+		case r == '.' && (i+1 == len(runes) || unicode.IsSpace(runes[i+1])):
+			flush(i, false)
+		}
+	}
+	if start < len(runes) {
+		flush(len(runes), false)
+	}
+
+	return sentences
+}
+
+// findJobRequestMarker reports whether a clause contains one of the markers and whether
+// the marker is used as a list header (followed by ":")
+func findJobRequestMarker(lowerClause string, markers []string) (bool, bool) {
+	for _, marker := range markers {
+		index := indexTerm(lowerClause, marker)
+		if index < 0 {
+			continue
+		}
+		rest := strings.TrimSpace(lowerClause[index+len(marker):])
+		return true, strings.HasPrefix(rest, ":") || strings.HasSuffix(marker, ":")
+	}
+	return false, false
+}
+
+// indexTerm returns the byte index of the first whole-word occurrence of term in text, or -1
+func indexTerm(text, term string) int {
+	if term == "" {
+		return -1
+	}
+
+	offset := 0
+	for {
+		index := strings.Index(text[offset:], term)
+		if index < 0 {
+			return -1
+		}
+		start := offset + index
+		end := start + len(term)
+
+		if isTermBoundary(text, start, true) && isTermBoundary(text, end, false) {
+			return start
+		}
+		offset = start + 1
+		if offset >= len(text) {
+			return -1
+		}
+	}
+}
+
+// maskTerm blanks out every whole-word occurrence of term and returns the index of the first one, or -1
+func maskTerm(text, term string) (string, int) {
+	first := indexTerm(text, term)
+	if first < 0 {
+		return text, -1
+	}
+
+	blank := strings.Repeat(" ", len(term))
+	for index := first; index >= 0; index = indexTerm(text, term) {
+		text = text[:index] + blank + text[index+len(term):]
+	}
+	return text, first
+}
+
+// isTermBoundary reports whether position is a word boundary on the given side
+func isTermBoundary(text string, position int, before bool) bool {
+	var r rune
+	if before {
+		if position == 0 {
+			return true
+		}
+		r, _ = utf8.DecodeLastRuneInString(text[:position])
+	} else {
+		if position >= len(text) {
+			return true
+		}
+		r, _ = utf8.DecodeRuneInString(text[position:])
+	}
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '+' && r != '#'
+}
+
+// canonicalSkillKey normalizes a skill name so vocabulary terms and database names compare equal
+func canonicalSkillKey(skill string) string {
+	lower := strings.ToLower(strings.TrimSpace(skill))
+	if mapped, exists := constants.SkillNormalizationMap[lower]; exists {
+		lower = mapped
+	}
+	return normalizeSkillName(lower)
+}
+
+// removeSkill removes the skill with the given canonical key from a list
+func removeSkill(skills []string, key string) []string {
+	filtered := skills[:0]
+	for _, skill := range skills {
+		if canonicalSkillKey(skill) != key {
+			filtered = append(filtered, skill)
+		}
+	}
+	return filtered
+}
+
+// parseJobRequestNumber parses digits or spelled-out numbers
+func parseJobRequestNumber(value string) float64 {
+	if number, exists := constants.JobRequestNumberWords[value]; exists {
+		return number
+	}
+	number, err := strconv.ParseFloat(strings.ReplaceAll(value, ",", "."), 64)
+	if err != nil {
+		return 0
+	}
+	return number
+}
+
+// experienceLevelFromYears maps a minimum number of years to an ExperienceLevelMap key
+func experienceLevelFromYears(years float64) string {
+	switch {
+	case years >= 7:
+		return "senior"
+	case years >= 3:
+		return "mid"
+	default:
+		return "junior"
+	}
+}
+
+// formatYears renders years without a trailing ".0"
+func formatYears(years float64) string {
+	return strconv.FormatFloat(years, 'f', -1, 64)
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestJobRequestParserMinYears(t *testing.T) {
+	tests := []struct {
+		text     string
+		years    float64
+		level    string
+		evidence string
+	}{
+		{"Need a Go developer with 3+ years of experience", 3, "mid", "3+ years"},
+		{"Backend engineer, at least five years with Python", 5, "mid", "at least five years"},
+		{"Buscamos desarrollador Java con 3-5 años de experiencia", 3, "mid", "3-5 años"},
+		{"Desarrollador React con más de 2 años", 2, "junior", "más de 2 años"},
+		{"Senior Kotlin developer, 8 yrs", 8, "senior", "8 yrs"},
+		{"Python developer with 2.5 years in Django", 2.5, "junior", "2.5 years"},
+		// A stated seniority is kept over the one the years suggest
+		{"Junior Go developer, 4 years", 4, "junior", "4 years"},
+		{"Go developer for a project starting in 2025", 0, "", ""},
+		{"React developer", 0, "", ""},
+	}
+
+	parser := NewJobRequestParser(nil)
+	for _, tt := range tests {
+		parsed, err := parser.Parse(tt.text)
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", tt.text, err)
+		}
+
+		search := parsed.SearchRequest
+		if search.MinYearsExperience != tt.years || search.ExperienceLevel != tt.level {
+			t.Errorf("Parse(%q) = %v years, level %q, want %v years, level %q",
+				tt.text, search.MinYearsExperience, search.ExperienceLevel, tt.years, tt.level)
+		}
+
+		evidence := ""
+		for _, interpretation := range parsed.Interpretations {
+			if interpretation.Field == "min_years_experience" {
+				evidence = interpretation.Evidence
+			}
+		}
+		if evidence != tt.evidence {
+			t.Errorf("Parse(%q) years evidence = %q, want %q", tt.text, evidence, tt.evidence)
+		}
+	}
+}
+
+func TestJobRequestParserDescribesYears(t *testing.T) {
+	parsed, err := NewJobRequestParser(nil).Parse("Go developer with 3+ years of experience")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if parsed.SearchCriteria.YearsExperienceMin != "3" {
+		t.Errorf("YearsExperienceMin = %q, want 3", parsed.SearchCriteria.YearsExperienceMin)
+	}
+	if !strings.Contains(parsed.SearchCriteria.ResponseSuggestion, "3+ years of experience") {
+		t.Errorf("summary = %q, want the years", parsed.SearchCriteria.ResponseSuggestion)
+	}
+}
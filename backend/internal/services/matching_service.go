@@ -11,15 +11,17 @@ import (
 
 // MatchingService handles candidate matching based on skills and requirements
 type MatchingService struct {
-	employeeRepo repositories.EmployeeRepository
-	skillRepo    repositories.SkillRepository
+	employeeRepo     repositories.EmployeeRepository
+	skillRepo        repositories.SkillRepository
+	jobRequestParser *JobRequestParser
 }
 
-// NewMatchingService creates a new matching service
-func NewMatchingService(employeeRepo repositories.EmployeeRepository, skillRepo repositories.SkillRepository) *MatchingService {
+// NewMatchingService creates a new matching service on a shared NER service
+func NewMatchingService(employeeRepo repositories.EmployeeRepository, skillRepo repositories.SkillRepository, nerService *NERService) *MatchingService {
 	return &MatchingService{
-		employeeRepo: employeeRepo,
-		skillRepo:    skillRepo,
+		employeeRepo:     employeeRepo,
+		skillRepo:        skillRepo,
+		jobRequestParser: NewJobRequestParser(nerService),
 	}
 }
 
@@ -70,41 +72,19 @@ func (s *MatchingService) MatchCandidatesToRequirements(requirements string, ext
 	}, nil
 }
 
-// extractRequiredSkills extracts skills from job requirements text
+// extractRequiredSkills extracts skills from job requirements text. Preferred skills are used
+// only when the requirements name no required ones.
 func (s *MatchingService) extractRequiredSkills(requirements string) []string {
-	var skills []string
-	words := strings.Fields(strings.ToLower(requirements))
-
-	// Common technical skills to look for
-	skillKeywords := map[string][]string{
-		"programming": {"python", "java", "javascript", "typescript", "go", "rust", "c++", "c#", "php", "ruby", "swift", "kotlin"},
-		"web":         {"react", "angular", "vue", "html", "css", "nodejs", "express", "django", "flask", "spring", "laravel"},
-		"database":    {"postgresql", "mysql", "mongodb", "redis", "elasticsearch", "sqlite", "oracle", "sql server"},
-		"cloud":       {"aws", "azure", "gcp", "docker", "kubernetes", "terraform", "jenkins", "ci/cd"},
-		"tools":       {"git", "github", "gitlab", "jira", "confluence", "slack", "figma", "postman"},
-		"frameworks":  {"django", "flask", "spring", "express", "rails", "laravel", "symfony", "asp.net"},
-		"mobile":      {"react native", "flutter", "ios", "android", "xamarin", "ionic"},
-		"data":        {"pandas", "numpy", "tensorflow", "pytorch", "scikit-learn", "spark", "hadoop"},
+	parsed, err := s.jobRequestParser.Parse(requirements)
+	if err != nil {
+		log.Printf("Failed to parse job requirements: %v", err)
+		return []string{}
 	}
 
-	for _, word := range words {
-		// Clean the word
-		cleanWord := strings.Trim(word, ".,!?;:")
-
-		// Check against skill keywords
-		for _, skillList := range skillKeywords {
-			for _, skill := range skillList {
-				if strings.Contains(cleanWord, skill) {
-					// Add the skill if not already present
-					if !s.containsSkill(skills, skill) {
-						skills = append(skills, skill)
-					}
-				}
-			}
-		}
+	if len(parsed.SearchRequest.RequiredSkills) > 0 {
+		return parsed.SearchRequest.RequiredSkills
 	}
-
-	return skills
+	return parsed.SearchRequest.PreferredSkills
 }
 
 // calculateMatch calculates how well an employee matches the requirements
@@ -2,14 +2,13 @@ package services
 
 import (
 	"fmt"
-	"regexp"
 	"strings"
 	"sync"
 	"time"
 
+	"stafind-backend/internal/models"
 	"stafind-backend/internal/repositories"
-
-	"github.com/jdkato/prose/v2"
+	"stafind-backend/internal/skillmatch"
 )
 
 // DatabaseSkillExtractor provides skill extraction using database categories and skills
@@ -18,6 +17,7 @@ type DatabaseSkillExtractor struct {
 	categoryRepo    repositories.CategoryRepository
 	skillsCache     map[string]SkillInfo
 	categoriesCache map[string]CategoryInfo
+	matcher         *skillmatch.Matcher // Skill names and synonyms; values are skillsCache keys
 	cacheMutex      sync.RWMutex
 	lastCacheUpdate time.Time
 	cacheExpiry     time.Duration
@@ -40,22 +40,26 @@ type CategoryInfo struct {
 
 // ExtractedSkills represents the structured skills extracted from text using dynamic categories
 type ExtractedSkills struct {
-	Categories        map[string][]string `json:"categories"`          // Dynamic categories from database
-	EducationLevel    []string            `json:"education_level"`     // Still needed for NER
-	LanguagesDetected []string            `json:"languages_detected"`  // Still needed for NER
-	YearsOfExperience []string            `json:"years_of_experience"` // Still needed for NER
-	Summary           string              `json:"summary"`
-	ConfidenceScore   float64             `json:"confidence_score"`
+	Categories        map[string][]string                `json:"categories"`          // Dynamic categories from database
+	Proficiency       map[string]models.SkillProficiency `json:"proficiency"`         // Stated proficiency by skill name
+	EducationLevel    []string                           `json:"education_level"`     // Still needed for NER
+	LanguagesDetected []string                           `json:"languages_detected"`  // Still needed for NER
+	YearsOfExperience []string                           `json:"years_of_experience"` // Still needed for NER
+	Summary           string                             `json:"summary"`
+	ConfidenceScore   float64                            `json:"confidence_score"`
+
+	hits map[string]*skillHits // Stages and surface forms each skill was found by
 }
 
 // SkillExtractionResult represents the complete result of skill extraction
 type SkillExtractionResult struct {
-	Skills           ExtractedSkills `json:"skills"`
-	TotalSkillsFound int             `json:"total_skills_found"`
-	ExtractionMethod string          `json:"extraction_method"`
-	AIConfidence     string          `json:"ai_confidence"`
-	RawText          string          `json:"raw_text"`
-	ProcessingTime   string          `json:"processing_time"`
+	Skills           ExtractedSkills        `json:"skills"`
+	Evidence         []models.SkillEvidence `json:"evidence"`
+	TotalSkillsFound int                    `json:"total_skills_found"`
+	ExtractionMethod string                 `json:"extraction_method"`
+	AIConfidence     string                 `json:"ai_confidence"`
+	RawText          string                 `json:"raw_text"`
+	ProcessingTime   string                 `json:"processing_time"`
 }
 
 // NERService provides Named Entity Recognition capabilities for skill extraction
@@ -75,6 +79,19 @@ func (n *NERService) ExtractSkillsFromText(text string) (*SkillExtractionResult,
 	return n.databaseExtractor.ExtractSkillsFromText(text)
 }
 
+// CanonicalSkill maps a skill name reported by another extractor to the skill catalog
+func (n *NERService) CanonicalSkill(name string) (SkillInfo, bool) {
+	return n.databaseExtractor.CanonicalSkill(name)
+}
+
+// WatchSkillCatalog reloads the skill cache on the next extraction after any skill catalog
+// change, instead of waiting for the cache to expire
+func (n *NERService) WatchSkillCatalog(events *SkillCatalogEvents) {
+	events.Subscribe(func(SkillCatalogEvent) {
+		n.databaseExtractor.Invalidate()
+	})
+}
+
 // NewDatabaseSkillExtractor creates a new database-backed skill extractor
 func NewDatabaseSkillExtractor(skillRepo repositories.SkillRepository, categoryRepo repositories.CategoryRepository) *DatabaseSkillExtractor {
 	return &DatabaseSkillExtractor{
@@ -82,6 +99,7 @@ func NewDatabaseSkillExtractor(skillRepo repositories.SkillRepository, categoryR
 		categoryRepo:    categoryRepo,
 		skillsCache:     make(map[string]SkillInfo),
 		categoriesCache: make(map[string]CategoryInfo),
+		matcher:         skillmatch.New(nil),
 		cacheExpiry:     30 * time.Minute, // Cache for 30 minutes
 	}
 }
@@ -101,6 +119,10 @@ func (d *DatabaseSkillExtractor) LoadSkillsFromDB() error {
 	if err != nil {
 		return fmt.Errorf("failed to load skills from database: %w", err)
 	}
+	aliases, err := d.skillRepo.GetSkillAliases()
+	if err != nil {
+		return fmt.Errorf("failed to load skill aliases from database: %w", err)
+	}
 
 	// Clear existing cache
 	d.skillsCache = make(map[string]SkillInfo)
@@ -114,6 +136,15 @@ func (d *DatabaseSkillExtractor) LoadSkillsFromDB() error {
 			Synonyms:   generateSynonyms(skill.Name),
 		}
 
+		// Aliases approved from discovered skill terms match like the skill's name
+		for _, alias := range aliases[skill.ID] {
+			for _, synonym := range generateSynonyms(alias) {
+				if !contains(skillInfo.Synonyms, synonym) {
+					skillInfo.Synonyms = append(skillInfo.Synonyms, synonym)
+				}
+			}
+		}
+
 		// Add categories
 		for i, category := range skill.Categories {
 			skillInfo.Categories[i] = category.Name
@@ -129,6 +160,8 @@ func (d *DatabaseSkillExtractor) LoadSkillsFromDB() error {
 		}
 	}
 
+	d.matcher = buildSkillMatcher(d.skillsCache)
+
 	// Load categories
 	categories, err := d.categoryRepo.GetAll()
 	if err != nil {
@@ -150,105 +183,109 @@ func (d *DatabaseSkillExtractor) LoadSkillsFromDB() error {
 	return nil
 }
 
-// ExtractSkillsFromText extracts skills from text using database data
-func (d *DatabaseSkillExtractor) ExtractSkillsFromText(text string) (*SkillExtractionResult, error) {
-	// Ensure skills are loaded from database
-	if err := d.LoadSkillsFromDB(); err != nil {
-		return nil, fmt.Errorf("failed to load skills from database: %w", err)
-	}
+// Invalidate marks the cache stale so the next extraction reloads it. The stale cache keeps
+// serving extractions that are already running.
+func (d *DatabaseSkillExtractor) Invalidate() {
+	d.cacheMutex.Lock()
+	defer d.cacheMutex.Unlock()
+	d.lastCacheUpdate = time.Time{}
+}
 
-	// Use Prose for initial NER
-	doc, err := prose.NewDocument(text)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create prose document: %w", err)
+// CanonicalSkill returns the catalog skill a name stands for. The whole name must be the
+// skill's name or one of its synonyms; a name that merely mentions a skill does not count.
+func (d *DatabaseSkillExtractor) CanonicalSkill(name string) (SkillInfo, bool) {
+	if err := d.LoadSkillsFromDB(); err != nil {
+		return SkillInfo{}, false
 	}
 
-	// Extract entities using Prose
-	entities := doc.Entities()
-	tokens := doc.Tokens()
+	d.cacheMutex.RLock()
+	defer d.cacheMutex.RUnlock()
 
-	// Process text for skill extraction
-	extractedSkills := &ExtractedSkills{
-		Categories: make(map[string][]string),
+	if skillInfo, exists := d.skillsCache[normalizeSkillName(name)]; exists {
+		return skillInfo, true
 	}
 
-	// Combine entities and tokens for comprehensive analysis
-	allTextParts := make([]string, 0, len(entities)+len(tokens))
-
-	// Add entities
-	for _, entity := range entities {
-		allTextParts = append(allTextParts, entity.Text)
+	name = strings.TrimSpace(name)
+	for _, match := range d.matcher.FindAll(name) {
+		if match.Start == 0 && match.End == len(name) {
+			skillInfo, exists := d.skillsCache[match.Value]
+			return skillInfo, exists
+		}
 	}
+	return SkillInfo{}, false
+}
 
-	// Add tokens that might be skills
-	for _, token := range tokens {
-		if d.isLikelySkill(token.Text) {
-			allTextParts = append(allTextParts, token.Text)
-		}
+// ExtractSkillsFromText extracts skills from text using database data
+func (d *DatabaseSkillExtractor) ExtractSkillsFromText(text string) (*SkillExtractionResult, error) {
+	// Ensure skills are loaded from database
+	if err := d.LoadSkillsFromDB(); err != nil {
+		return nil, fmt.Errorf("failed to load skills from database: %w", err)
 	}
 
-	// Extract skills using database lookup
-	d.extractSkillsFromTextParts(allTextParts, extractedSkills)
+	// Process text for skill extraction
+	extractedSkills := &ExtractedSkills{
+		Categories:  make(map[string][]string),
+		Proficiency: make(map[string]models.SkillProficiency),
+		hits:        make(map[string]*skillHits),
+	}
 
-	// Also search for skills in the full text using regex patterns
-	d.extractSkillsWithRegex(text, extractedSkills)
+	// One pass of the skill dictionary over the text
+	d.extractSkillsWithMatcher(text, extractedSkills)
 
 	// Remove duplicates and return result
 	d.deduplicateSkills(extractedSkills)
 
+	// Look around each skill for "expert in", "conocimientos básicos de", ...
+	d.inferProficiency(text, extractedSkills)
+
 	totalSkills := d.countTotalSkills(extractedSkills)
 	extractedSkills.Summary = fmt.Sprintf("Extracted %d skills using database categories", totalSkills)
 	extractedSkills.ConfidenceScore = d.calculateConfidenceScore(extractedSkills)
 
 	return &SkillExtractionResult{
 		Skills:           *extractedSkills,
+		Evidence:         d.buildEvidence(text, extractedSkills),
 		TotalSkillsFound: totalSkills,
-		ExtractionMethod: "database_ner",
+		ExtractionMethod: "database_dictionary",
 		AIConfidence:     fmt.Sprintf("%.2f", extractedSkills.ConfidenceScore),
 		RawText:          text,
 		ProcessingTime:   time.Now().Format(time.RFC3339),
 	}, nil
 }
 
-// extractSkillsFromTextParts extracts skills from individual text parts
-func (d *DatabaseSkillExtractor) extractSkillsFromTextParts(textParts []string, skills *ExtractedSkills) {
+// extractSkillsWithMatcher finds every skill name and synonym in the text
+func (d *DatabaseSkillExtractor) extractSkillsWithMatcher(text string, skills *ExtractedSkills) {
 	d.cacheMutex.RLock()
 	defer d.cacheMutex.RUnlock()
 
-	for _, part := range textParts {
-		normalized := normalizeSkillName(part)
-
-		if skillInfo, exists := d.skillsCache[normalized]; exists {
+	for _, match := range d.matcher.FindAll(text) {
+		if skillInfo, exists := d.skillsCache[match.Value]; exists {
 			d.categorizeSkill(skillInfo, skills)
+			recordHit(skills, skillInfo, match)
 		}
 	}
 }
 
-// extractSkillsWithRegex searches for skills using regex patterns
-func (d *DatabaseSkillExtractor) extractSkillsWithRegex(text string, skills *ExtractedSkills) {
-	d.cacheMutex.RLock()
-	defer d.cacheMutex.RUnlock()
-
-	// Create regex patterns for common skill formats
-	patterns := []string{
-		`\b[A-Z][a-z]+(?:\.[A-Z][a-z]+)*\b`, // Capitalized words (React.js, Node.js)
-		`\b[a-z]+(?:\.[a-z]+)*\b`,           // Lowercase words (mysql, redis)
-		`\b[A-Z]{2,}\b`,                     // Acronyms (AWS, API, SQL)
-		`\b[A-Za-z]+[#+]\b`,                 // Languages with special chars (C#, C++, F#)
-		`\b[A-Za-z]+\.NET\b`,                // .NET technologies (VB.NET, C#.NET)
-	}
-
-	for _, pattern := range patterns {
-		regex := regexp.MustCompile(pattern)
-		matches := regex.FindAllString(text, -1)
+// buildSkillMatcher compiles the names and synonyms of cached skills. Names of one or two
+// letters match case-sensitively so "Go" and "R" are not found in running text.
+func buildSkillMatcher(skillsCache map[string]SkillInfo) *skillmatch.Matcher {
+	var patterns []skillmatch.Pattern
+	for key, skillInfo := range skillsCache {
+		// The cache holds some skills under two keys; compile each skill once
+		if key != normalizeSkillName(skillInfo.Name) {
+			continue
+		}
 
-		for _, match := range matches {
-			normalized := normalizeSkillName(match)
-			if skillInfo, exists := d.skillsCache[normalized]; exists {
-				d.categorizeSkill(skillInfo, skills)
-			}
+		terms := append([]string{skillInfo.Name}, skillInfo.Synonyms...)
+		short := len([]rune(skillInfo.Name)) <= 2
+		if short {
+			terms = []string{skillInfo.Name, strings.ToUpper(skillInfo.Name)}
+		}
+		for _, term := range terms {
+			patterns = append(patterns, skillmatch.Pattern{Term: term, Value: key, CaseSensitive: short})
 		}
 	}
+	return skillmatch.New(patterns)
 }
 
 // categorizeSkill categorizes a skill into the appropriate category using pure dynamic categories
@@ -311,23 +348,6 @@ func generateSynonyms(name string) []string {
 	return synonyms
 }
 
-func (d *DatabaseSkillExtractor) isLikelySkill(text string) bool {
-	// Simple heuristic to identify potential skills
-	if len(text) < 2 || len(text) > 50 {
-		return false
-	}
-
-	// Check if it's mostly alphanumeric (including common programming language characters)
-	alphanumeric := regexp.MustCompile(`^[a-zA-Z0-9\s\-_.#+]+$`)
-	if !alphanumeric.MatchString(text) {
-		return false
-	}
-
-	// Check if it contains at least one letter
-	hasLetter := regexp.MustCompile(`[a-zA-Z]`)
-	return hasLetter.MatchString(text)
-}
-
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
 		if s == item {
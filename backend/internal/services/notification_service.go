@@ -24,6 +24,16 @@ func NewNotificationService(aiAgentRepo repositories.AIAgentRepository) Notifica
 }
 
 func (s *notificationService) SendTeamsMessage(channelID string, message string) error {
+	return s.postTeamsCard("AI Agent Response", "Employee Matching Results", message)
+}
+
+// SendTeamsNotification posts a message to the Teams channel under a title of its own
+func (s *notificationService) SendTeamsNotification(title string, message string) error {
+	return s.postTeamsCard(title, "", message)
+}
+
+// postTeamsCard posts a message card to the TEAMS_WEBHOOK_URL channel
+func (s *notificationService) postTeamsCard(title, subtitle, message string) error {
 	webhookURL := os.Getenv(constants.EnvTeamsWebhookURL)
 	if webhookURL == "" {
 		return fmt.Errorf("TEAMS_WEBHOOK_URL not set")
@@ -33,12 +43,12 @@ func (s *notificationService) SendTeamsMessage(channelID string, message string)
 	payload := map[string]interface{}{
 		"@type":      "MessageCard",
 		"@context":   "http://schema.org/extensions",
-		"summary":    "AI Agent Response",
+		"summary":    title,
 		"themeColor": "0078D4",
 		"sections": []map[string]interface{}{
 			{
-				"activityTitle":    "AI Agent Response",
-				"activitySubtitle": "Employee Matching Results",
+				"activityTitle":    title,
+				"activitySubtitle": subtitle,
 				"text":             message,
 				"markdown":         true,
 			},
@@ -0,0 +1,283 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"stafind-backend/internal/constants"
+	"stafind-backend/internal/models"
+	"stafind-backend/internal/repositories"
+	"sync"
+	"time"
+)
+
+// reextractionSource marks the CV extracts that track re-extraction runs
+const reextractionSource = "reextraction"
+
+// reextractionMetadata is stored as the metadata of a run's CV extract, so an interrupted run
+// can be resumed with the same request
+type reextractionMetadata struct {
+	Source           string `json:"source"`
+	ExtractorVersion int    `json:"extractor_version"` // The version the run extracts with
+	models.ReextractionRequest
+}
+
+// ReextractionService reprocesses the stored resume text of employees selected by a filter,
+// so improvements to extraction reach existing employees. Runs are tracked as CV extracts,
+// one file per employee, and skip the employees they already processed when resumed.
+type ReextractionService struct {
+	reextractionRepo repositories.ReextractionRepository
+	employeeRepo     repositories.EmployeeRepository
+	historyService   *ExtractionHistoryService
+	cvExtractService CVExtractService
+	importer         *ResumeImporter
+}
+
+// NewReextractionService creates a new re-extraction service
+func NewReextractionService(
+	reextractionRepo repositories.ReextractionRepository,
+	employeeRepo repositories.EmployeeRepository,
+	historyService *ExtractionHistoryService,
+	cvExtractService CVExtractService,
+	importer *ResumeImporter,
+) *ReextractionService {
+	return &ReextractionService{
+		reextractionRepo: reextractionRepo,
+		employeeRepo:     employeeRepo,
+		historyService:   historyService,
+		cvExtractService: cvExtractService,
+		importer:         importer,
+	}
+}
+
+// Start creates a run for the employees matching the request's filter and returns it with
+// their IDs, for Run
+func (s *ReextractionService) Start(req *models.ReextractionRequest) (*models.ReextractionRun, []int, error) {
+	if req.Concurrency <= 0 {
+		req.Concurrency = constants.DefaultReextractionConcurrency
+	}
+	if req.Concurrency > constants.MaxReextractionConcurrency {
+		return nil, nil, NewValidationError(fmt.Sprintf("concurrency must be at most %d", constants.MaxReextractionConcurrency))
+	}
+	if req.Filter.ExtractorVersionBelow < 0 {
+		return nil, nil, NewValidationError("extractor_version_below must not be negative")
+	}
+	if req.Filter.ExtractedAfter != nil && req.Filter.ExtractedBefore != nil && !req.Filter.ExtractedAfter.Before(*req.Filter.ExtractedBefore) {
+		return nil, nil, NewValidationError("extracted_after must be before extracted_before")
+	}
+
+	employeeIDs, err := s.reextractionRepo.ListCandidates(req.Filter, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	metadata, err := json.Marshal(reextractionMetadata{
+		Source:              reextractionSource,
+		ExtractorVersion:    constants.ExtractorVersion,
+		ReextractionRequest: *req,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode re-extraction request: %w", err)
+	}
+	metadataStr := string(metadata)
+
+	requestID := fmt.Sprintf("reextract-%d", time.Now().UnixNano())
+	progress, err := s.cvExtractService.CreateOrUpdateExtract(requestID, models.CVExtractStatusProcessing, len(employeeIDs), 0, &metadataStr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &models.ReextractionRun{
+		RequestID: requestID,
+		Request:   *req,
+		Progress:  progress,
+	}, employeeIDs, nil
+}
+
+// Resume returns an unfinished run with the IDs of the employees it has not processed yet,
+// for Run
+func (s *ReextractionService) Resume(requestID string) (*models.ReextractionRun, []int, error) {
+	run, err := s.run(requestID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if run.Progress.Status == models.CVExtractStatusCompleted {
+		return nil, nil, NewConflictError(fmt.Sprintf("re-extraction run %s is already completed", requestID))
+	}
+
+	employeeIDs, err := s.reextractionRepo.ListCandidates(run.Request.Filter, run.Progress.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	progress, err := s.cvExtractService.UpdateExtractStatus(requestID, models.CVExtractStatusProcessing)
+	if err != nil {
+		return nil, nil, err
+	}
+	run.Progress = progress
+
+	return run, employeeIDs, nil
+}
+
+// Get returns a run with its progress and the outcome for each employee processed so far
+func (s *ReextractionService) Get(requestID string) (*models.ReextractionRun, error) {
+	run, err := s.run(requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	run.Results, err = s.reextractionRepo.ListResults(run.Progress.ID)
+	if err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// Run re-extracts the given employees of a run, Concurrency at a time, reporting each outcome
+// to onResult if set. When ctx is cancelled the employees in progress finish and the run stays
+// processing, to be resumed later.
+func (s *ReextractionService) Run(ctx context.Context, run *models.ReextractionRun, employeeIDs []int, onResult func(models.ReextractionResult)) error {
+	startTime := time.Now()
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	for i := 0; i < run.Request.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for employeeID := range jobs {
+				result := s.reextractEmployee(employeeID, run.Request.DryRun)
+				s.record(run, result)
+				if onResult != nil {
+					mu.Lock()
+					onResult(result)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+feed:
+	for _, employeeID := range employeeIDs {
+		select {
+		case <-ctx.Done():
+			break feed
+		case jobs <- employeeID:
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		log.Printf("Re-extraction run %s interrupted; resume it to process the remaining employees", run.RequestID)
+		return err
+	}
+
+	if _, err := s.cvExtractService.MarkExtractSuccess(run.RequestID, time.Since(startTime).Milliseconds()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// RunInBackground runs a run's employees without waiting for them. A run cut short by a restart
+// stays processing and can be resumed.
+func (s *ReextractionService) RunInBackground(run *models.ReextractionRun, employeeIDs []int) {
+	go func() {
+		if err := s.Run(context.Background(), run, employeeIDs, nil); err != nil {
+			log.Printf("Re-extraction run %s failed: %v", run.RequestID, err)
+		}
+	}()
+}
+
+// reextractEmployee extracts an employee's stored resume text again and compares the result
+// with the employee's latest extraction. A dry run stores nothing.
+func (s *ReextractionService) reextractEmployee(employeeID int, dryRun bool) models.ReextractionResult {
+	result := models.ReextractionResult{EmployeeID: employeeID, CreatedAt: time.Now()}
+	failed := func(err error) models.ReextractionResult {
+		result.Status = models.ReextractionStatusFailed
+		result.ErrorMessage = err.Error()
+		return result
+	}
+
+	employee, err := s.employeeRepo.GetByID(employeeID)
+	if err != nil {
+		return failed(err)
+	}
+	result.EmployeeName = employee.Name
+
+	// The latest extraction keeps the stored resume text with its source
+	before, err := s.historyService.Latest(employeeID)
+	if err != nil {
+		return failed(err)
+	}
+	if before == nil || before.OriginalText == "" {
+		return failed(fmt.Errorf("employee %d has no stored resume text", employeeID))
+	}
+	text, source, resumeURL := before.OriginalText, before.Source, before.ResumeURL
+
+	var after *models.EmployeeExtraction
+	if dryRun {
+		resume, err := s.importer.Extract(text, source, resumeURL)
+		if err != nil {
+			return failed(err)
+		}
+		after = newExtractionRun(employeeID, text, resume, source, resumeURL)
+		after.Version = before.Version + 1
+	} else {
+		if _, err := s.importer.Reextract(employeeID, text, source, resumeURL); err != nil {
+			return failed(err)
+		}
+		if after, err = s.historyService.Latest(employeeID); err != nil || after == nil {
+			return failed(fmt.Errorf("failed to get the new extraction: %v", err))
+		}
+	}
+
+	result.Changes = DiffExtractions(before, after)
+	result.Status = models.ReextractionStatusUnchanged
+	if result.Changes.HasChanges() {
+		result.Status = models.ReextractionStatusChanged
+	}
+	return result
+}
+
+// record stores an employee's outcome and counts it in the run's progress. Failing to record
+// it only means the employee is processed again if the run is resumed.
+func (s *ReextractionService) record(run *models.ReextractionRun, result models.ReextractionResult) {
+	if err := s.reextractionRepo.SaveResult(run.Progress.ID, &result); err != nil {
+		log.Printf("Failed to save re-extraction result of employee %d: %v", result.EmployeeID, err)
+	}
+
+	fileStatus := models.CVExtractFileStatusProcessed
+	if result.Status == models.ReextractionStatusFailed {
+		fileStatus = models.CVExtractFileStatusFailed
+	}
+	if _, err := s.cvExtractService.UpdateFileProgress(run.RequestID, result.EmployeeID, fileStatus); err != nil {
+		log.Printf("Warning: Failed to update CV extract progress: %v", err)
+	}
+}
+
+// run loads a run and its request from its CV extract
+func (s *ReextractionService) run(requestID string) (*models.ReextractionRun, error) {
+	progress, err := s.cvExtractService.GetExtractByRequestID(requestID)
+	if err != nil {
+		return nil, NewNotFoundError(fmt.Sprintf("re-extraction run %s not found", requestID))
+	}
+
+	var metadata reextractionMetadata
+	if progress.Metadata != nil {
+		if err := json.Unmarshal([]byte(*progress.Metadata), &metadata); err != nil {
+			return nil, fmt.Errorf("failed to decode re-extraction request: %w", err)
+		}
+	}
+	if metadata.Source != reextractionSource {
+		return nil, NewNotFoundError(fmt.Sprintf("re-extraction run %s not found", requestID))
+	}
+
+	return &models.ReextractionRun{
+		RequestID: requestID,
+		Request:   metadata.ReextractionRequest,
+		Progress:  progress,
+	}, nil
+}
@@ -0,0 +1,197 @@
+package services
+
+import (
+	"encoding/json"
+	"sort"
+	"stafind-backend/internal/models"
+	"stafind-backend/internal/resumeparser"
+	"strconv"
+	"strings"
+)
+
+// MergeResumeSkills adds skills grouped by taxonomy category to resume data. Each skill is
+// recorded under its category and in the matching ResumeSkills bucket; duplicates are skipped.
+func MergeResumeSkills(resume *models.ProcessedResumeData, categories map[string][]string) {
+	if resume.SkillCategories == nil {
+		resume.SkillCategories = make(map[string][]string)
+	}
+
+	// Sorted so the buckets come out in the same order for the same input
+	names := make([]string, 0, len(categories))
+	for category := range categories {
+		names = append(names, category)
+	}
+	sort.Strings(names)
+
+	for _, category := range names {
+		for _, skill := range categories[category] {
+			if !contains(resume.SkillCategories[category], skill) {
+				resume.SkillCategories[category] = append(resume.SkillCategories[category], skill)
+			}
+
+			bucket := resumeSkillBucket(&resume.Skills, category)
+			if !contains(*bucket, skill) {
+				*bucket = append(*bucket, skill)
+			}
+		}
+	}
+}
+
+// CopyResumeData returns a copy of resume data whose skills can be merged into without
+// changing the original
+func CopyResumeData(resume *models.ProcessedResumeData) *models.ProcessedResumeData {
+	copied := *resume
+
+	copied.SkillCategories = make(map[string][]string, len(resume.SkillCategories))
+	for category, skills := range resume.SkillCategories {
+		copied.SkillCategories[category] = append([]string(nil), skills...)
+	}
+	copied.SkillProficiency = make(map[string]models.SkillProficiency, len(resume.SkillProficiency))
+	for skill, proficiency := range resume.SkillProficiency {
+		copied.SkillProficiency[skill] = proficiency
+	}
+	copied.SkillEvidence = make([]models.SkillEvidence, len(resume.SkillEvidence))
+	for i, evidence := range resume.SkillEvidence {
+		evidence.Categories = append([]string(nil), evidence.Categories...)
+		evidence.Sources = append([]string(nil), evidence.Sources...)
+		evidence.Mentions = append([]models.SkillMention(nil), evidence.Mentions...)
+		copied.SkillEvidence[i] = evidence
+	}
+	copied.Skills = models.ResumeSkills{
+		Technical:  append([]string{}, resume.Skills.Technical...),
+		Soft:       append([]string{}, resume.Skills.Soft...),
+		Languages:  append([]string{}, resume.Skills.Languages...),
+		Tools:      append([]string{}, resume.Skills.Tools...),
+		Frameworks: append([]string{}, resume.Skills.Frameworks...),
+	}
+	copied.UnknownSkills = append([]models.UnknownSkillTerm(nil), resume.UnknownSkills...)
+	copied.SpokenLanguages = append([]models.SpokenLanguage(nil), resume.SpokenLanguages...)
+	copied.CertificationDetails = append([]models.ResumeCertification(nil), resume.CertificationDetails...)
+
+	return &copied
+}
+
+// resumeSkillBucket returns the ResumeSkills list that skills of a taxonomy category belong to
+func resumeSkillBucket(skills *models.ResumeSkills, category string) *[]string {
+	category = strings.ToLower(category)
+
+	switch {
+	case strings.Contains(category, "soft"):
+		return &skills.Soft
+	case strings.Contains(category, "language"):
+		return &skills.Languages
+	case strings.Contains(category, "framework") || strings.Contains(category, "library"):
+		return &skills.Frameworks
+	case strings.Contains(category, "tool") || strings.Contains(category, "version control") ||
+		strings.Contains(category, "devops"):
+		return &skills.Tools
+	default:
+		return &skills.Technical
+	}
+}
+
+// resumeSkillNames returns every skill in resume data once, sorted by name
+func resumeSkillNames(resume *models.ProcessedResumeData) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, skills := range resume.SkillCategories {
+		for _, skill := range skills {
+			if !seen[strings.ToLower(skill)] {
+				seen[strings.ToLower(skill)] = true
+				names = append(names, skill)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// resumeBackground turns the work history, education and projects of a resume into an
+// employee's. Positions are given the catalog skills their title or description mention. Lists
+// the resume has no entries for are nil, so the stored ones are kept.
+func resumeBackground(resume *models.ProcessedResumeData) *models.EmployeeBackground {
+	background := &models.EmployeeBackground{}
+	skills := resumeSkillNames(resume)
+
+	for _, experience := range resume.Experience {
+		if experience.Company == "" && experience.Role == "" {
+			continue
+		}
+		position := models.EmployeePosition{
+			Company:     experience.Company,
+			Title:       experience.Role,
+			StartDate:   resumeDate(experience.StartDate, false),
+			Current:     experience.Current,
+			DatesByYear: len(experience.StartDate) == len("2006"),
+			Description: experience.Description,
+			Skills:      []string{},
+		}
+		if !experience.Current {
+			position.EndDate = resumeDate(experience.EndDate, true)
+		}
+		for _, skill := range skills {
+			if resumeparser.MentionsSkill(experience.Role+"\n"+experience.Description, skill) {
+				position.Skills = append(position.Skills, skill)
+			}
+		}
+		background.Positions = append(background.Positions, position)
+	}
+
+	for _, education := range resume.Education {
+		if education.Institution == "" && education.Degree == "" {
+			continue
+		}
+		background.Education = append(background.Education, models.EmployeeEducation{
+			Institution: education.Institution,
+			Degree:      education.Degree,
+			Field:       education.Field,
+			StartYear:   parseYear(education.StartYear),
+			EndYear:     parseYear(education.Year),
+		})
+	}
+
+	for _, project := range resume.Projects {
+		if project.Name == "" {
+			continue
+		}
+		background.Projects = append(background.Projects, models.EmployeeProject{
+			Name:         project.Name,
+			Role:         project.Role,
+			Description:  project.Description,
+			Technologies: append([]string{}, project.Technologies...),
+		})
+	}
+
+	return background
+}
+
+// parseYear returns a year written as digits, nil for anything else
+func parseYear(text string) *int {
+	year, err := strconv.Atoi(strings.TrimSpace(text))
+	if err != nil || year < 1900 || year > 2100 {
+		return nil
+	}
+	return &year
+}
+
+// resumeDataToMap converts resume data to the generic form stored in employees.extracted_data
+func resumeDataToMap(resume *models.ProcessedResumeData) (map[string]interface{}, error) {
+	data, err := json.Marshal(resume)
+	if err != nil {
+		return nil, err
+	}
+
+	var extractedData map[string]interface{}
+	if err := json.Unmarshal(data, &extractedData); err != nil {
+		return nil, err
+	}
+	return extractedData, nil
+}
+
+// emptyIfNil returns an empty slice for nil so lists encode as [] rather than null
+func emptyIfNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
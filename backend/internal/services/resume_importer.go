@@ -0,0 +1,83 @@
+package services
+
+import (
+	"stafind-backend/internal/models"
+)
+
+// ResumeImporter runs resume text through candidate extraction and stores the candidate.
+// It is shared by the ingestion paths that do not go through the extract endpoints.
+type ResumeImporter struct {
+	extractionService       *CandidateExtractService
+	candidateStorageService *CandidateStorageService
+}
+
+// ResumeImportResult holds the stored candidate and the data extracted from the resume
+type ResumeImportResult struct {
+	Candidate *models.CandidateExtractionResult
+	Resume    *models.ProcessedResumeData
+}
+
+// NewResumeImporter creates a new resume importer
+func NewResumeImporter(extractionService *CandidateExtractService, candidateStorageService *CandidateStorageService) *ResumeImporter {
+	return &ResumeImporter{
+		extractionService:       extractionService,
+		candidateStorageService: candidateStorageService,
+	}
+}
+
+// Import extracts candidate information from resume text and creates or updates the employee
+func (i *ResumeImporter) Import(text, extractionSource, resumeURL string, metadata map[string]interface{}) (*ResumeImportResult, error) {
+	extraction, err := i.extractionService.ProcessText(&models.ExtractProcessRequest{
+		Text:             text,
+		ResumeURL:        resumeURL,
+		ExtractionSource: extractionSource,
+		ProcessingType:   "candidate_extraction",
+		Metadata:         metadata,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	candidate, err := i.candidateStorageService.ProcessCandidateExtraction(text, extraction.Resume, extractionSource, resumeURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ResumeImportResult{
+		Candidate: candidate,
+		Resume:    extraction.Resume,
+	}, nil
+}
+
+// Extract runs resume text through candidate extraction without storing anything
+func (i *ResumeImporter) Extract(text, extractionSource, resumeURL string) (*models.ProcessedResumeData, error) {
+	extraction, err := i.extractionService.ProcessText(&models.ExtractProcessRequest{
+		Text:             text,
+		ResumeURL:        resumeURL,
+		ExtractionSource: extractionSource,
+		ProcessingType:   "candidate_extraction",
+	})
+	if err != nil {
+		return nil, err
+	}
+	return extraction.Resume, nil
+}
+
+// Reextract runs an employee's stored resume text through candidate extraction again and
+// updates that employee, whoever the text would otherwise be matched to
+func (i *ResumeImporter) Reextract(employeeID int, text, extractionSource, resumeURL string) (*ResumeImportResult, error) {
+	resume, err := i.Extract(text, extractionSource, resumeURL)
+	if err != nil {
+		return nil, err
+	}
+
+	candidate, err := i.candidateStorageService.ReextractEmployee(employeeID, text, resume, extractionSource, resumeURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ResumeImportResult{
+		Candidate: candidate,
+		Resume:    resume,
+	}, nil
+}
@@ -4,32 +4,69 @@ import (
 	"stafind-backend/internal/matching"
 	"stafind-backend/internal/models"
 	"stafind-backend/internal/repositories"
+	"stafind-backend/internal/spokenlang"
 )
 
 type searchService struct {
-	employeeRepo repositories.EmployeeRepository
-	matchEngine  *matching.MatchEngine
+	employeeRepo   repositories.EmployeeRepository
+	backgroundRepo repositories.EmployeeBackgroundRepository
+	matchEngine    *matching.MatchEngine
 }
 
 // NewSearchService creates a new search service
 func NewSearchService(
 	employeeRepo repositories.EmployeeRepository,
+	backgroundRepo repositories.EmployeeBackgroundRepository,
 ) SearchService {
 	return &searchService{
-		employeeRepo: employeeRepo,
-		matchEngine:  matching.NewMatchEngine(),
+		employeeRepo:   employeeRepo,
+		backgroundRepo: backgroundRepo,
+		matchEngine:    matching.NewMatchEngine(),
 	}
 }
 
 func (s *searchService) SearchEmployees(searchReq *models.SearchRequest) ([]models.Match, error) {
+	for _, requirement := range searchReq.Languages {
+		if spokenlang.Code(requirement.Language) == "" {
+			return nil, NewValidationError("unknown language: " + requirement.Language)
+		}
+		if requirement.MinLevel != "" && spokenlang.ParseLevel(requirement.MinLevel) == "" {
+			return nil, NewValidationError("unknown language level: " + requirement.MinLevel)
+		}
+	}
+
 	// Get all employees
 	employees, err := s.employeeRepo.GetAll()
 	if err != nil {
 		return nil, err
 	}
 
+	if err := loadSearchBackgrounds(s.backgroundRepo, searchReq, employees); err != nil {
+		return nil, err
+	}
+
 	// Use the matching engine to find matches
 	matches := s.matchEngine.SearchEmployees(searchReq, employees)
 
 	return matches, nil
 }
+
+// loadSearchBackgrounds adds work history and education to employees, but only for the
+// searches that filter by them: employers, degrees or years of experience
+func loadSearchBackgrounds(backgroundRepo repositories.EmployeeBackgroundRepository, searchReq *models.SearchRequest, employees []models.Employee) error {
+	if len(searchReq.Employers) == 0 && len(searchReq.Degrees) == 0 && searchReq.MinYearsExperience <= 0 {
+		return nil
+	}
+
+	backgrounds, err := backgroundRepo.GetAllSearchable()
+	if err != nil {
+		return err
+	}
+	for i := range employees {
+		if background := backgrounds[employees[i].ID]; background != nil {
+			employees[i].Positions = background.Positions
+			employees[i].Education = background.Education
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package services
+
+import "sync"
+
+// Skill catalog event actions
+const (
+	SkillCatalogCreated       = "created"
+	SkillCatalogUpdated       = "updated"
+	SkillCatalogDeleted       = "deleted"
+	SkillCatalogCategorized   = "categorized"   // A skill was added to a category
+	SkillCatalogUncategorized = "uncategorized" // A skill was removed from a category
+	SkillCatalogRemoteChange  = "remote"        // Another instance or a direct database change
+)
+
+// Skill catalog event resources
+const (
+	SkillCatalogSkill    = "skill"
+	SkillCatalogCategory = "category"
+)
+
+// SkillCatalogEvent describes a change to skills, categories or the links between them
+type SkillCatalogEvent struct {
+	Action   string
+	Resource string // Empty for remote changes, whose details are unknown
+	IDs      []int
+}
+
+// SkillCatalogEvents delivers skill catalog changes to the caches built from the catalog,
+// such as the skill extractor's dictionary. Listeners run synchronously in the publishing
+// goroutine and must be quick.
+type SkillCatalogEvents struct {
+	mutex     sync.RWMutex
+	listeners []func(SkillCatalogEvent)
+}
+
+// NewSkillCatalogEvents creates an event hub without listeners
+func NewSkillCatalogEvents() *SkillCatalogEvents {
+	return &SkillCatalogEvents{}
+}
+
+// Subscribe registers a listener for every later event
+func (e *SkillCatalogEvents) Subscribe(listener func(SkillCatalogEvent)) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.listeners = append(e.listeners, listener)
+}
+
+// Publish delivers an event to all listeners. Publishing on a nil hub does nothing, so
+// services can be used without one.
+func (e *SkillCatalogEvents) Publish(event SkillCatalogEvent) {
+	if e == nil {
+		return
+	}
+
+	e.mutex.RLock()
+	listeners := make([]func(SkillCatalogEvent), len(e.listeners))
+	copy(listeners, e.listeners)
+	e.mutex.RUnlock()
+
+	for _, listener := range listeners {
+		listener(event)
+	}
+}
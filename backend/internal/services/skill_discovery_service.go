@@ -0,0 +1,352 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"stafind-backend/internal/constants"
+	"stafind-backend/internal/models"
+	"stafind-backend/internal/repositories"
+)
+
+// SkillDiscoveryService collects terms that look like skills but are not in the skill catalog
+// and lets admins add them as skills, map them to an existing skill as an alias, or ignore
+// them. Resolving a term into the catalog re-extracts the employees whose resumes mention it.
+type SkillDiscoveryService struct {
+	discoveryRepo  repositories.SkillDiscoveryRepository
+	skillRepo      repositories.SkillRepository
+	categoryRepo   repositories.CategoryRepository
+	historyService *ExtractionHistoryService
+	skillService   SkillService
+	importer       *ResumeImporter
+}
+
+// NewSkillDiscoveryService creates a new skill discovery service
+func NewSkillDiscoveryService(
+	discoveryRepo repositories.SkillDiscoveryRepository,
+	skillRepo repositories.SkillRepository,
+	categoryRepo repositories.CategoryRepository,
+	historyService *ExtractionHistoryService,
+	skillService SkillService,
+	importer *ResumeImporter,
+) *SkillDiscoveryService {
+	return &SkillDiscoveryService{
+		discoveryRepo:  discoveryRepo,
+		skillRepo:      skillRepo,
+		categoryRepo:   categoryRepo,
+		historyService: historyService,
+		skillService:   skillService,
+		importer:       importer,
+	}
+}
+
+// List returns discovered terms by status, pending unless another is asked for, most frequent
+// first. Pending terms come with the categories suggested for them.
+func (s *SkillDiscoveryService) List(status string, limit int) ([]models.DiscoveredSkillTerm, error) {
+	switch status {
+	case "":
+		status = models.SkillTermStatusPending
+	case models.SkillTermStatusPending, models.SkillTermStatusApproved, models.SkillTermStatusAliased, models.SkillTermStatusIgnored:
+	default:
+		return nil, NewValidationError("status must be pending, approved, aliased or ignored")
+	}
+	if limit <= 0 || limit > constants.MaxSkillTermsLimit {
+		limit = constants.DefaultSkillTermsLimit
+	}
+
+	terms, err := s.discoveryRepo.ListTerms(status, limit)
+	if err != nil {
+		return nil, err
+	}
+	if status == models.SkillTermStatusPending {
+		for i := range terms {
+			terms[i].SuggestedCategories = s.suggestCategories(terms[i].Term)
+		}
+	}
+	return terms, nil
+}
+
+// Get returns a discovered term with the categories suggested for it
+func (s *SkillDiscoveryService) Get(id int) (*models.DiscoveredSkillTerm, error) {
+	term, err := s.discoveryRepo.GetTerm(id)
+	if err != nil {
+		return nil, NewNotFoundError("skill term not found")
+	}
+	term.SuggestedCategories = s.suggestCategories(term.Term)
+	return term, nil
+}
+
+// Approve adds a term to the skill catalog, under the categories asked for or else the ones
+// suggested, and re-extracts the employees whose resumes mention it. A skill of the same name
+// created meanwhile is reused.
+func (s *SkillDiscoveryService) Approve(id int, req *models.ApproveSkillTermRequest, resolvedBy string) (*models.SkillTermResolution, error) {
+	term, err := s.resolvableTerm(id)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		name = term.Term
+	}
+	categories := req.Categories
+	if len(categories) == 0 {
+		categories = s.suggestedCategoryIDs(name)
+	}
+
+	skill, err := s.skillService.CreateSkillWithCategories(&models.CreateSkillRequest{Name: name, Categories: categories})
+	if _, exists := err.(*ConflictError); exists {
+		skill, err = s.skillRepo.GetByName(name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.resolve(term, models.SkillTermStatusApproved, skill, resolvedBy)
+}
+
+// Alias maps a term to an existing skill, so the skill extractor finds the skill wherever the
+// term is written, and re-extracts the employees whose resumes mention it
+func (s *SkillDiscoveryService) Alias(id int, skillID int, resolvedBy string) (*models.SkillTermResolution, error) {
+	term, err := s.resolvableTerm(id)
+	if err != nil {
+		return nil, err
+	}
+
+	skill, err := s.skillService.GetSkillByID(skillID)
+	if err != nil {
+		return nil, NewNotFoundError("skill not found")
+	}
+	if err := s.skillService.AddSkillAlias(skill.ID, term.Term); err != nil {
+		return nil, err
+	}
+
+	return s.resolve(term, models.SkillTermStatusAliased, skill, resolvedBy)
+}
+
+// Ignore marks a term as not a skill. It keeps being counted and can still be approved or
+// aliased later.
+func (s *SkillDiscoveryService) Ignore(id int, resolvedBy string) (*models.SkillTermResolution, error) {
+	term, err := s.resolvableTerm(id)
+	if err != nil {
+		return nil, err
+	}
+	if term.Status == models.SkillTermStatusIgnored {
+		return nil, NewConflictError("skill term was already ignored")
+	}
+
+	return s.resolve(term, models.SkillTermStatusIgnored, nil, resolvedBy)
+}
+
+// resolvableTerm returns a term that is pending or ignored
+func (s *SkillDiscoveryService) resolvableTerm(id int) (*models.DiscoveredSkillTerm, error) {
+	term, err := s.discoveryRepo.GetTerm(id)
+	if err != nil {
+		return nil, NewNotFoundError("skill term not found")
+	}
+	if term.Status != models.SkillTermStatusPending && term.Status != models.SkillTermStatusIgnored {
+		return nil, NewConflictError(fmt.Sprintf("skill term was already %s", term.Status))
+	}
+	return term, nil
+}
+
+// resolve records the decision on a term and, when the term became part of the catalog,
+// starts re-extracting the employees whose resumes mention it
+func (s *SkillDiscoveryService) resolve(term *models.DiscoveredSkillTerm, status string, skill *models.Skill, resolvedBy string) (*models.SkillTermResolution, error) {
+	var skillID *int
+	if skill != nil {
+		skillID = &skill.ID
+	}
+
+	resolved, err := s.discoveryRepo.ResolveTerm(term.ID, status, skillID, resolvedBy)
+	if err != nil {
+		return nil, err
+	}
+	if !resolved {
+		return nil, NewConflictError("skill term was resolved by someone else")
+	}
+
+	resolution := &models.SkillTermResolution{Skill: skill}
+	if skill != nil {
+		employeeIDs, err := s.discoveryRepo.ListTermEmployees(term.ID)
+		if err != nil {
+			log.Printf("Failed to get employees mentioning skill term %q: %v", term.Term, err)
+		}
+		resolution.EmployeesReextracted = len(employeeIDs)
+		go s.reextract(term.Term, employeeIDs)
+	}
+
+	if resolution.Term, err = s.discoveryRepo.GetTerm(term.ID); err != nil {
+		return nil, err
+	}
+	return resolution, nil
+}
+
+// reextract runs the stored resumes of employees through extraction again, so a skill added to
+// the catalog is found in them
+func (s *SkillDiscoveryService) reextract(term string, employeeIDs []int) {
+	updated := 0
+	for _, employeeID := range employeeIDs {
+		// The latest extraction keeps the stored resume text with its source
+		latest, err := s.historyService.Latest(employeeID)
+		if err != nil {
+			log.Printf("Skipping re-extraction of employee %d for skill term %q: %v", employeeID, term, err)
+			continue
+		}
+		if latest == nil || latest.OriginalText == "" {
+			continue
+		}
+
+		if _, err := s.importer.Reextract(employeeID, latest.OriginalText, latest.Source, latest.ResumeURL); err != nil {
+			log.Printf("Failed to re-extract employee %d for skill term %q: %v", employeeID, term, err)
+			continue
+		}
+		updated++
+	}
+	log.Printf("Re-extracted %d of %d employees mentioning skill term %q", updated, len(employeeIDs), term)
+}
+
+// suggestCategories returns the category names suggested for a term
+func (s *SkillDiscoveryService) suggestCategories(term string) []string {
+	suggestions, err := s.skillService.SuggestSkillCategories(term)
+	if err != nil {
+		return nil
+	}
+	return suggestions
+}
+
+// suggestedCategoryIDs returns the IDs of the suggested categories that exist
+func (s *SkillDiscoveryService) suggestedCategoryIDs(term string) []int {
+	var ids []int
+	for _, name := range s.suggestCategories(term) {
+		if category, err := s.categoryRepo.GetByName(name); err == nil {
+			ids = append(ids, category.ID)
+		}
+	}
+	return ids
+}
+
+// notSkillWords are words that make a listed term a level, a spoken language or a description
+// rather than a skill, in English and Spanish
+var notSkillWords = map[string]bool{
+	"and": true, "or": true, "with": true, "of": true, "in": true, "the": true, "etc": true,
+	"y": true, "o": true, "con": true, "de": true, "en": true, "el": true, "la": true, "los": true, "las": true,
+	"basic": true, "intermediate": true, "advanced": true, "expert": true, "fluent": true, "native": true,
+	"básico": true, "basico": true, "intermedio": true, "avanzado": true, "experto": true, "fluido": true, "nativo": true,
+	"years": true, "year": true, "experience": true, "knowledge": true, "skills": true, "tools": true,
+	"años": true, "experiencia": true, "conocimientos": true, "habilidades": true, "herramientas": true,
+	"other": true, "others": true, "various": true, "otros": true, "otras": true, "varios": true,
+	"english": true, "spanish": true, "french": true, "german": true, "portuguese": true, "italian": true,
+	"inglés": true, "ingles": true, "español": true, "espanol": true, "francés": true, "alemán": true,
+	"portugués": true, "italiano": true,
+}
+
+// parentheticals matches "(advanced)" and "[3 years]" after a term
+var parentheticals = regexp.MustCompile(`\s*[(\[][^)\]]*[)\]]`)
+
+// maxSkillTermLength and maxSkillTermWords bound what can be a skill name
+const (
+	maxSkillTermLength  = 40
+	maxSkillTermWords   = 4
+	maxSkillTermContext = 160
+)
+
+// isLikelySkill reports whether a term listed in a resume looks like a skill name: short, not
+// a sentence, not a URL or email, with letters, and not made only of levels, spoken
+// languages and filler words
+func isLikelySkill(term string) bool {
+	term = strings.TrimSpace(term)
+	length := utf8.RuneCountInString(term)
+	if length < 2 || length > maxSkillTermLength {
+		return false
+	}
+
+	words := strings.Fields(term)
+	if len(words) > maxSkillTermWords || (len(words) > 1 && strings.HasSuffix(term, ".")) {
+		return false
+	}
+	lower := strings.ToLower(term)
+	if strings.ContainsAny(term, ":@?!=") || strings.Contains(lower, "http") || strings.Contains(lower, "www.") {
+		return false
+	}
+	if strings.IndexFunc(term, unicode.IsLetter) < 0 {
+		return false
+	}
+
+	if notSkillWords[strings.ToLower(words[0])] && len(words) > 1 {
+		return false
+	}
+	for _, word := range words {
+		if !notSkillWords[strings.Trim(strings.ToLower(word), ".,;")] {
+			return true
+		}
+	}
+	return false
+}
+
+// cleanSkillTerm drops levels and years in parentheses and surrounding punctuation
+func cleanSkillTerm(term string) string {
+	term = parentheticals.ReplaceAllString(term, "")
+	return strings.Trim(strings.TrimSpace(term), ".,;-–")
+}
+
+// skillTermContext returns the line of text that mentions a term, shortened around it
+func skillTermContext(text, term string) string {
+	lowerTerm := strings.ToLower(term)
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		index := strings.Index(strings.ToLower(line), lowerTerm)
+		if index < 0 {
+			continue
+		}
+
+		runes := []rune(line)
+		if len(runes) <= maxSkillTermContext {
+			return line
+		}
+		start := utf8.RuneCountInString(line[:min(index, len(line))]) - maxSkillTermContext/2
+		start = max(0, min(start, len(runes)-maxSkillTermContext))
+		return strings.TrimSpace(string(runes[start : start+maxSkillTermContext]))
+	}
+	return ""
+}
+
+// UnknownSkillTerms returns the terms that look like skills but that known does not recognise,
+// once each, with the text around them
+func UnknownSkillTerms(text string, terms []string, source string, known func(string) bool) []models.UnknownSkillTerm {
+	seen := make(map[string]bool)
+	var unknown []models.UnknownSkillTerm
+	for _, term := range terms {
+		term = cleanSkillTerm(term)
+		key := normalizeSkillName(term)
+		if seen[key] || !isLikelySkill(term) || known(term) {
+			continue
+		}
+		seen[key] = true
+		unknown = append(unknown, models.UnknownSkillTerm{
+			Term:    term,
+			Source:  source,
+			Context: skillTermContext(text, term),
+		})
+	}
+	return unknown
+}
+
+// mergeUnknownSkillTerms adds terms not already present, by normalized name
+func mergeUnknownSkillTerms(terms []models.UnknownSkillTerm, more ...models.UnknownSkillTerm) []models.UnknownSkillTerm {
+	seen := make(map[string]bool, len(terms))
+	for _, term := range terms {
+		seen[normalizeSkillName(term.Term)] = true
+	}
+	for _, term := range more {
+		if key := normalizeSkillName(term.Term); !seen[key] {
+			seen[key] = true
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
@@ -0,0 +1,324 @@
+package services
+
+import (
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"stafind-backend/internal/constants"
+	"stafind-backend/internal/models"
+)
+
+// defaultDictionaryConfidence is used for dictionary skills without evidence
+const defaultDictionaryConfidence = 0.7
+
+// SkillEnsemble merges the skills of the dictionary and Hugging Face extractors into one
+// ranked list. Both outputs are mapped to catalog skills first, so "ReactJS" from the model
+// and "React" from the dictionary are one skill.
+//
+// Each extractor votes with its confidence times its weight; votes combine as independent
+// evidence, score = 1 - Π(1 - weight·confidence), and a skill found by more than one
+// extractor has part of its remaining doubt removed by the agreement boost.
+type SkillEnsemble struct {
+	nerService        *NERService
+	weights           map[string]float64
+	nonCanonicalScale float64
+	agreementBoost    float64
+	minScore          float64
+}
+
+// NewSkillEnsemble creates an ensemble. Weights, agreement boost and minimum score come from
+// ENSEMBLE_WEIGHTS, ENSEMBLE_AGREEMENT_BOOST and ENSEMBLE_MIN_SCORE when set.
+func NewSkillEnsemble(nerService *NERService) *SkillEnsemble {
+	ensemble := &SkillEnsemble{
+		nerService: nerService,
+		weights: map[string]float64{
+			sourceDictionary:  constants.DefaultEnsembleDictionaryWeight,
+			sourceHuggingFace: constants.DefaultEnsembleHuggingFaceWeight,
+		},
+		nonCanonicalScale: constants.DefaultEnsembleNonCanonicalScale,
+		agreementBoost:    constants.DefaultEnsembleAgreementBoost,
+		minScore:          constants.DefaultEnsembleMinScore,
+	}
+
+	// "dictionary=1,huggingface=0.6"
+	for _, pair := range strings.Split(os.Getenv(constants.EnvEnsembleWeights), ",") {
+		method, value, found := strings.Cut(pair, "=")
+		if weight, err := strconv.ParseFloat(strings.TrimSpace(value), 64); found && err == nil && weight >= 0 && weight <= 1 {
+			ensemble.weights[strings.TrimSpace(method)] = weight
+		}
+	}
+	if value, err := strconv.ParseFloat(os.Getenv(constants.EnvEnsembleAgreementBoost), 64); err == nil && value >= 0 && value <= 1 {
+		ensemble.agreementBoost = value
+	}
+	if value, err := strconv.ParseFloat(os.Getenv(constants.EnvEnsembleMinScore), 64); err == nil && value >= 0 && value <= 1 {
+		ensemble.minScore = value
+	}
+
+	return ensemble
+}
+
+// ensembleSkill collects the votes for one skill while merging
+type ensembleSkill struct {
+	ranked   models.RankedSkill
+	evidence []models.SkillEvidence
+}
+
+// Merge combines dictionary resume data and Hugging Face skills into resume data whose skills
+// are the accepted skills of the ensemble, with the ranked list of every skill considered.
+// Either input may be nil.
+func (e *SkillEnsemble) Merge(nerData *models.ProcessedResumeData, huggingFaceResult *models.HuggingFaceSkillExtractionResponse) *models.ProcessedResumeData {
+	combined := &models.ProcessedResumeData{}
+	var methods []string
+	if nerData != nil {
+		combined = CopyResumeData(nerData)
+		methods = append(methods, "ner")
+	}
+	if huggingFaceResult != nil && huggingFaceResult.Success {
+		methods = append(methods, "huggingface")
+	}
+
+	ranked, evidence := e.rank(nerData, huggingFaceResult)
+
+	// The ensemble decides the stored skills: rebuild them from the accepted ones
+	accepted := make(map[string][]string)
+	var scoreSum float64
+	scores := make(map[string]float64)
+	for _, skill := range ranked {
+		if !skill.Accepted {
+			continue
+		}
+		for _, category := range skill.Categories {
+			accepted[category] = append(accepted[category], skill.Skill)
+		}
+		scores[skill.Skill] = skill.Score
+		scoreSum += skill.Score
+	}
+
+	combined.SkillCategories = nil
+	combined.Skills = models.ResumeSkills{}
+	combined.SkillEvidence = nil
+	MergeResumeSkills(combined, accepted)
+	MergeSkillEvidence(combined, evidence)
+
+	kept := combined.SkillEvidence[:0]
+	for _, skillEvidence := range combined.SkillEvidence {
+		if score, exists := scores[skillEvidence.Skill]; exists {
+			skillEvidence.Confidence = score
+			kept = append(kept, skillEvidence)
+		}
+	}
+	combined.SkillEvidence = kept
+
+	combined.RankedSkills = ranked
+	combined.UnknownSkills = mergeUnknownSkillTerms(combined.UnknownSkills, e.unknownSkills(huggingFaceResult, scores)...)
+	if len(scores) > 0 {
+		combined.ConfidenceScore = math.Round(scoreSum/float64(len(scores))*100) / 100
+	}
+	combined.ExtractionMethod = strings.Join(methods, "+")
+	return combined
+}
+
+// rank scores every skill found by either extractor, best first, and returns the evidence of
+// both under catalog names
+func (e *SkillEnsemble) rank(nerData *models.ProcessedResumeData, huggingFaceResult *models.HuggingFaceSkillExtractionResponse) ([]models.RankedSkill, []models.SkillEvidence) {
+	skills := make(map[string]*ensembleSkill)
+	var order []string
+
+	vote := func(name string, categories []string, canonical bool, method, surface string, confidence float64) *ensembleSkill {
+		key := normalizeSkillName(name)
+		skill, exists := skills[key]
+		if !exists {
+			skill = &ensembleSkill{ranked: models.RankedSkill{Skill: name, Canonical: canonical}}
+			skills[key] = skill
+			order = append(order, key)
+		}
+		for _, category := range categories {
+			if !contains(skill.ranked.Categories, category) {
+				skill.ranked.Categories = append(skill.ranked.Categories, category)
+			}
+		}
+
+		weight := e.weights[method]
+		if !canonical {
+			weight *= e.nonCanonicalScale
+		}
+		for i := range skill.ranked.Votes {
+			// One vote per method; a repeated finding keeps the more confident one
+			if skill.ranked.Votes[i].Method == method {
+				if confidence > skill.ranked.Votes[i].Confidence {
+					skill.ranked.Votes[i].Confidence = confidence
+					skill.ranked.Votes[i].Name = surface
+				}
+				return skill
+			}
+		}
+		skill.ranked.Votes = append(skill.ranked.Votes, models.SkillVote{
+			Method:     method,
+			Name:       surface,
+			Confidence: confidence,
+			Weight:     weight,
+		})
+		return skill
+	}
+
+	if nerData != nil {
+		confidences := make(map[string]float64, len(nerData.SkillEvidence))
+		for _, skillEvidence := range nerData.SkillEvidence {
+			confidences[skillEvidence.Skill] = skillEvidence.Confidence
+			skill := vote(skillEvidence.Skill, skillEvidence.Categories, true, sourceDictionary, skillEvidence.Skill, skillEvidence.Confidence)
+			skill.evidence = append(skill.evidence, skillEvidence)
+		}
+		for category, skillList := range nerData.SkillCategories {
+			for _, skillName := range skillList {
+				if _, exists := confidences[skillName]; !exists {
+					vote(skillName, []string{category}, true, sourceDictionary, skillName, defaultDictionaryConfidence)
+				}
+			}
+		}
+	}
+
+	if huggingFaceResult != nil && huggingFaceResult.Success {
+		evidenceBySkill := make(map[string]models.SkillEvidence, len(huggingFaceResult.Evidence))
+		for _, skillEvidence := range huggingFaceResult.Evidence {
+			evidenceBySkill[skillEvidence.Skill] = skillEvidence
+		}
+
+		for _, hfSkill := range huggingFaceResult.Skills {
+			name, categories, canonical := hfSkill.Name, []string{hfSkill.Category}, false
+			if skillInfo, found := e.canonicalSkill(hfSkill.Name); found {
+				name, categories, canonical = skillInfo.Name, skillInfo.Categories, true
+			}
+
+			skill := vote(name, categories, canonical, sourceHuggingFace, hfSkill.Name, hfSkill.Confidence)
+			if skillEvidence, exists := evidenceBySkill[hfSkill.Name]; exists {
+				skillEvidence.Skill = name
+				skillEvidence.Categories = categories
+				skill.evidence = append(skill.evidence, skillEvidence)
+			}
+		}
+	}
+
+	ranked := make([]models.RankedSkill, 0, len(order))
+	var evidence []models.SkillEvidence
+	for _, key := range order {
+		skill := skills[key]
+		skill.ranked.Score = e.score(skill.ranked.Votes)
+		skill.ranked.Accepted = skill.ranked.Score >= e.minScore
+		sort.Strings(skill.ranked.Categories)
+		ranked = append(ranked, skill.ranked)
+		evidence = append(evidence, skill.evidence...)
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].Skill < ranked[j].Skill
+	})
+	return ranked, evidence
+}
+
+// score combines the votes for a skill
+func (e *SkillEnsemble) score(votes []models.SkillVote) float64 {
+	doubt := 1.0
+	for _, vote := range votes {
+		doubt *= 1 - math.Min(math.Max(vote.Weight*vote.Confidence, 0), 1)
+	}
+	score := 1 - doubt
+
+	if len(votes) > 1 {
+		score += e.agreementBoost * (1 - score)
+	}
+	return math.Round(score*100) / 100
+}
+
+// unknownSkills returns the skills Hugging Face found that are not in the catalog and that the
+// ensemble did not accept, for skill discovery
+func (e *SkillEnsemble) unknownSkills(huggingFaceResult *models.HuggingFaceSkillExtractionResponse, accepted map[string]float64) []models.UnknownSkillTerm {
+	if huggingFaceResult == nil || !huggingFaceResult.Success {
+		return nil
+	}
+
+	var unknown []models.UnknownSkillTerm
+	for _, hfSkill := range huggingFaceResult.Skills {
+		term := cleanSkillTerm(hfSkill.Name)
+		if _, exists := accepted[hfSkill.Name]; exists || !isLikelySkill(term) {
+			continue
+		}
+		if _, found := e.canonicalSkill(term); found {
+			continue
+		}
+		unknown = append(unknown, models.UnknownSkillTerm{
+			Term:    term,
+			Source:  models.SkillTermSourceHuggingFace,
+			Context: hfSkill.Context,
+		})
+	}
+	return unknown
+}
+
+// canonicalSkill maps a name to the skill catalog when a NER service is available
+func (e *SkillEnsemble) canonicalSkill(name string) (SkillInfo, bool) {
+	if e.nerService == nil {
+		return SkillInfo{}, false
+	}
+	return e.nerService.CanonicalSkill(name)
+}
+
+// Agreement compares the skills the two extractors found, after mapping both to catalog
+// skills
+func (e *SkillEnsemble) Agreement(nerData *models.ProcessedResumeData, huggingFaceResult *models.HuggingFaceSkillExtractionResponse) models.ExtractionAgreement {
+	agreement := models.ExtractionAgreement{
+		SharedSkills:    []string{},
+		OnlyDictionary:  []string{},
+		OnlyHuggingFace: []string{},
+	}
+
+	ranked, _ := e.rank(nerData, huggingFaceResult)
+
+	canonical := 0
+	for _, skill := range ranked {
+		var byDictionary, byHuggingFace bool
+		for _, vote := range skill.Votes {
+			byDictionary = byDictionary || vote.Method == sourceDictionary
+			byHuggingFace = byHuggingFace || vote.Method == sourceHuggingFace
+		}
+
+		switch {
+		case byDictionary && byHuggingFace:
+			agreement.SharedSkills = append(agreement.SharedSkills, skill.Skill)
+		case byDictionary:
+			agreement.OnlyDictionary = append(agreement.OnlyDictionary, skill.Skill)
+		case byHuggingFace:
+			agreement.OnlyHuggingFace = append(agreement.OnlyHuggingFace, skill.Skill)
+		}
+		if byDictionary {
+			agreement.DictionarySkills++
+		}
+		if byHuggingFace {
+			agreement.HuggingFaceSkills++
+			if skill.Canonical {
+				canonical++
+			}
+		}
+	}
+
+	sort.Strings(agreement.SharedSkills)
+	sort.Strings(agreement.OnlyDictionary)
+	sort.Strings(agreement.OnlyHuggingFace)
+
+	shared := float64(len(agreement.SharedSkills))
+	if union := len(ranked); union > 0 {
+		agreement.Jaccard = math.Round(shared/float64(union)*100) / 100
+	}
+	if smaller := min(agreement.DictionarySkills, agreement.HuggingFaceSkills); smaller > 0 {
+		agreement.OverlapCoefficient = math.Round(shared/float64(smaller)*100) / 100
+	}
+	if agreement.HuggingFaceSkills > 0 {
+		agreement.CanonicalRate = math.Round(float64(canonical)/float64(agreement.HuggingFaceSkills)*100) / 100
+	}
+	return agreement
+}
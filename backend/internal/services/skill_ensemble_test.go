@@ -0,0 +1,336 @@
+package services
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"stafind-backend/internal/constants"
+	"stafind-backend/internal/models"
+	"stafind-backend/internal/repositories"
+)
+
+// fakeCatalogRepo serves a small skill catalog
+type fakeCatalogRepo struct {
+	repositories.SkillRepository
+	skills  []models.Skill
+	aliases map[int][]string
+}
+
+func (r *fakeCatalogRepo) GetSkillsWithCategories() ([]models.Skill, error) {
+	return r.skills, nil
+}
+
+func (r *fakeCatalogRepo) GetSkillAliases() (map[int][]string, error) {
+	return r.aliases, nil
+}
+
+// fakeCategoryRepo has no categories; the catalog skills carry their own
+type fakeCategoryRepo struct {
+	repositories.CategoryRepository
+}
+
+func (fakeCategoryRepo) GetAll() ([]models.Category, error) {
+	return nil, nil
+}
+
+// newTestEnsemble creates an ensemble with the default weights over a catalog of Go,
+// PostgreSQL and React, which Hugging Face may call ReactJS
+func newTestEnsemble(t *testing.T) *SkillEnsemble {
+	t.Setenv(constants.EnvEnsembleWeights, "")
+	t.Setenv(constants.EnvEnsembleAgreementBoost, "")
+	t.Setenv(constants.EnvEnsembleMinScore, "")
+
+	catalog := &fakeCatalogRepo{
+		skills: []models.Skill{
+			{ID: 1, Name: "Go", Categories: []models.Category{{Name: "Programming Languages"}}},
+			{ID: 2, Name: "PostgreSQL", Categories: []models.Category{{Name: "Databases"}}},
+			{ID: 3, Name: "React", Categories: []models.Category{{Name: "Frontend"}}},
+		},
+		aliases: map[int][]string{3: {"ReactJS"}},
+	}
+	return NewSkillEnsemble(NewNERService(catalog, fakeCategoryRepo{}))
+}
+
+// dictionaryResult found Go with evidence and PostgreSQL without
+func dictionaryResult() *models.ProcessedResumeData {
+	return &models.ProcessedResumeData{
+		SkillCategories: map[string][]string{
+			"Programming Languages": {"Go"},
+			"Databases":             {"PostgreSQL"},
+		},
+		SkillEvidence: []models.SkillEvidence{
+			{Skill: "Go", Categories: []string{"Programming Languages"}, Sources: []string{"dictionary"}, Confidence: 0.9},
+		},
+	}
+}
+
+// huggingFaceResult found Go, React under another name and a term outside the catalog
+func huggingFaceResult() *models.HuggingFaceSkillExtractionResponse {
+	return &models.HuggingFaceSkillExtractionResponse{
+		Success: true,
+		Skills: []models.HuggingFaceSkill{
+			{Name: "Go", Category: "Programming Languages", Confidence: 0.8},
+			{Name: "ReactJS", Category: "Frontend", Confidence: 0.9},
+			{Name: "Kubernetes operators", Category: "DevOps", Confidence: 0.5},
+		},
+	}
+}
+
+// rankedScores lists the ranked skills as name, score and acceptance, best first
+func rankedScores(ranked []models.RankedSkill) []string {
+	var scores []string
+	for _, skill := range ranked {
+		accepted := "rejected"
+		if skill.Accepted {
+			accepted = "accepted"
+		}
+		scores = append(scores, skill.Skill+" "+formatScore(skill.Score)+" "+accepted)
+	}
+	return scores
+}
+
+func formatScore(score float64) string {
+	return strconv.FormatFloat(score, 'f', 2, 64)
+}
+
+func TestSkillEnsembleScore(t *testing.T) {
+	ensemble := &SkillEnsemble{agreementBoost: 0.3}
+
+	tests := []struct {
+		name  string
+		votes []models.SkillVote
+		want  float64
+	}{
+		{"no votes", nil, 0},
+		{"dictionary only", []models.SkillVote{{Method: sourceDictionary, Confidence: 0.9, Weight: 1}}, 0.9},
+		{"hugging face only", []models.SkillVote{{Method: sourceHuggingFace, Confidence: 0.8, Weight: 0.6}}, 0.48},
+		// 1 - 0.3·0.52 = 0.844, plus 0.3 of the remaining 0.156
+		{"agreement", []models.SkillVote{
+			{Method: sourceDictionary, Confidence: 0.7, Weight: 1},
+			{Method: sourceHuggingFace, Confidence: 0.8, Weight: 0.6},
+		}, 0.89},
+		// Agreement lifts two weak votes to the default minimum: 0.145 plus 0.3 of 0.855
+		{"weak agreement", []models.SkillVote{
+			{Method: sourceDictionary, Confidence: 0.1, Weight: 1},
+			{Method: sourceHuggingFace, Confidence: 0.1, Weight: 0.5},
+		}, 0.4},
+		{"confidence above one", []models.SkillVote{{Method: sourceDictionary, Confidence: 1.5, Weight: 1}}, 1},
+		{"negative confidence", []models.SkillVote{{Method: sourceDictionary, Confidence: -0.5, Weight: 1}}, 0},
+		{"zero weight", []models.SkillVote{{Method: sourceHuggingFace, Confidence: 0.9, Weight: 0}}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ensemble.score(tt.votes); got != tt.want {
+				t.Errorf("score() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSkillEnsembleMerge(t *testing.T) {
+	tests := []struct {
+		name        string
+		dictionary  *models.ProcessedResumeData
+		huggingFace *models.HuggingFaceSkillExtractionResponse
+		wantRanked  []string
+		wantSkills  map[string][]string
+		wantMethod  string
+		wantScore   float64
+	}{
+		{
+			name:        "both extractors",
+			dictionary:  dictionaryResult(),
+			huggingFace: huggingFaceResult(),
+			wantRanked: []string{
+				// 1 - 0.1·0.52 = 0.948, plus 0.3 of the remaining 0.052
+				"Go 0.96 accepted",
+				// Dictionary skill without evidence
+				"PostgreSQL 0.70 accepted",
+				// ReactJS mapped to the catalog: 0.9·0.6
+				"React 0.54 accepted",
+				// Outside the catalog the weight is halved: 0.5·0.3
+				"Kubernetes operators 0.15 rejected",
+			},
+			wantSkills: map[string][]string{
+				"Programming Languages": {"Go"},
+				"Databases":             {"PostgreSQL"},
+				"Frontend":              {"React"},
+			},
+			wantMethod: "ner+huggingface",
+			wantScore:  0.73,
+		},
+		{
+			name:       "dictionary only",
+			dictionary: dictionaryResult(),
+			wantRanked: []string{"Go 0.90 accepted", "PostgreSQL 0.70 accepted"},
+			wantSkills: map[string][]string{
+				"Programming Languages": {"Go"},
+				"Databases":             {"PostgreSQL"},
+			},
+			wantMethod: "ner",
+			wantScore:  0.8,
+		},
+		{
+			name:        "hugging face only",
+			huggingFace: huggingFaceResult(),
+			wantRanked: []string{
+				"React 0.54 accepted",
+				"Go 0.48 accepted",
+				"Kubernetes operators 0.15 rejected",
+			},
+			wantSkills: map[string][]string{
+				"Programming Languages": {"Go"},
+				"Frontend":              {"React"},
+			},
+			wantMethod: "huggingface",
+			wantScore:  0.51,
+		},
+		{
+			name: "below the minimum score",
+			dictionary: &models.ProcessedResumeData{
+				SkillCategories: map[string][]string{"Programming Languages": {"Go"}},
+				SkillEvidence: []models.SkillEvidence{
+					{Skill: "Go", Categories: []string{"Programming Languages"}, Confidence: 0.3},
+				},
+			},
+			huggingFace: &models.HuggingFaceSkillExtractionResponse{
+				Success: true,
+				Skills:  []models.HuggingFaceSkill{{Name: "PostgreSQL", Category: "Databases", Confidence: 0.6}},
+			},
+			wantRanked: []string{"PostgreSQL 0.36 rejected", "Go 0.30 rejected"},
+			wantSkills: map[string][]string{},
+			wantMethod: "ner+huggingface",
+		},
+		{
+			name:       "failed hugging face result",
+			dictionary: dictionaryResult(),
+			huggingFace: &models.HuggingFaceSkillExtractionResponse{
+				Success: false,
+				Skills:  []models.HuggingFaceSkill{{Name: "React", Confidence: 0.9}},
+			},
+			wantRanked: []string{"Go 0.90 accepted", "PostgreSQL 0.70 accepted"},
+			wantSkills: map[string][]string{
+				"Programming Languages": {"Go"},
+				"Databases":             {"PostgreSQL"},
+			},
+			wantMethod: "ner",
+			wantScore:  0.8,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged := newTestEnsemble(t).Merge(tt.dictionary, tt.huggingFace)
+
+			if got := rankedScores(merged.RankedSkills); !reflect.DeepEqual(got, tt.wantRanked) {
+				t.Errorf("ranked = %q, want %q", got, tt.wantRanked)
+			}
+			skills := merged.SkillCategories
+			if skills == nil {
+				skills = map[string][]string{}
+			}
+			if !reflect.DeepEqual(skills, tt.wantSkills) {
+				t.Errorf("skills = %v, want %v", skills, tt.wantSkills)
+			}
+			if merged.ExtractionMethod != tt.wantMethod {
+				t.Errorf("method = %q, want %q", merged.ExtractionMethod, tt.wantMethod)
+			}
+			if merged.ConfidenceScore != tt.wantScore {
+				t.Errorf("confidence = %v, want %v", merged.ConfidenceScore, tt.wantScore)
+			}
+			// Evidence is kept for accepted skills only, at the ensemble score
+			for _, evidence := range merged.SkillEvidence {
+				if _, accepted := tt.wantSkills[evidence.Categories[0]]; !accepted {
+					t.Errorf("evidence kept for rejected skill %s", evidence.Skill)
+				}
+			}
+		})
+	}
+}
+
+func TestSkillEnsembleMergeReportsUnknownSkills(t *testing.T) {
+	merged := newTestEnsemble(t).Merge(dictionaryResult(), huggingFaceResult())
+
+	var terms []string
+	for _, unknown := range merged.UnknownSkills {
+		terms = append(terms, unknown.Term)
+	}
+	if want := []string{"Kubernetes operators"}; !reflect.DeepEqual(terms, want) {
+		t.Errorf("unknown skills = %q, want %q", terms, want)
+	}
+}
+
+func TestSkillEnsembleAgreement(t *testing.T) {
+	tests := []struct {
+		name        string
+		dictionary  *models.ProcessedResumeData
+		huggingFace *models.HuggingFaceSkillExtractionResponse
+		want        models.ExtractionAgreement
+	}{
+		{
+			name:        "partial agreement",
+			dictionary:  dictionaryResult(),
+			huggingFace: huggingFaceResult(),
+			want: models.ExtractionAgreement{
+				DictionarySkills:  2,
+				HuggingFaceSkills: 3,
+				SharedSkills:      []string{"Go"},
+				OnlyDictionary:    []string{"PostgreSQL"},
+				// ReactJS is counted as the catalog's React
+				OnlyHuggingFace:    []string{"Kubernetes operators", "React"},
+				Jaccard:            0.25,
+				OverlapCoefficient: 0.5,
+				CanonicalRate:      0.67,
+			},
+		},
+		{
+			name:       "dictionary only",
+			dictionary: dictionaryResult(),
+			want: models.ExtractionAgreement{
+				DictionarySkills: 2,
+				SharedSkills:     []string{},
+				OnlyDictionary:   []string{"Go", "PostgreSQL"},
+				OnlyHuggingFace:  []string{},
+			},
+		},
+		{
+			name: "full agreement",
+			dictionary: &models.ProcessedResumeData{
+				SkillCategories: map[string][]string{"Frontend": {"React"}},
+			},
+			huggingFace: &models.HuggingFaceSkillExtractionResponse{
+				Success: true,
+				Skills:  []models.HuggingFaceSkill{{Name: "reactjs", Confidence: 0.4}},
+			},
+			want: models.ExtractionAgreement{
+				DictionarySkills:   1,
+				HuggingFaceSkills:  1,
+				SharedSkills:       []string{"React"},
+				OnlyDictionary:     []string{},
+				OnlyHuggingFace:    []string{},
+				Jaccard:            1,
+				OverlapCoefficient: 1,
+				CanonicalRate:      1,
+			},
+		},
+		{
+			name: "nothing found",
+			want: models.ExtractionAgreement{
+				SharedSkills:    []string{},
+				OnlyDictionary:  []string{},
+				OnlyHuggingFace: []string{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newTestEnsemble(t).Agreement(tt.dictionary, tt.huggingFace)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Agreement() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package services
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"stafind-backend/internal/models"
+	"stafind-backend/internal/skillmatch"
+)
+
+// Extraction stages recorded in skill evidence
+const (
+	sourceDictionary  = "dictionary"
+	sourceAlias       = "alias" // Written differently from the skill name, e.g. "NodeJS" for "Node.js"
+	sourceHuggingFace = "huggingface"
+)
+
+// skillHits records where the skill dictionary matched one skill
+type skillHits struct {
+	sources  []string
+	mentions []skillmatch.Match
+}
+
+// recordHit notes a dictionary match of a skill
+func recordHit(skills *ExtractedSkills, skillInfo SkillInfo, match skillmatch.Match) {
+	hits, exists := skills.hits[skillInfo.Name]
+	if !exists {
+		hits = &skillHits{sources: []string{sourceDictionary}}
+		skills.hits[skillInfo.Name] = hits
+	}
+
+	if !strings.EqualFold(match.Text, skillInfo.Name) && !contains(hits.sources, sourceAlias) {
+		hits.sources = append(hits.sources, sourceAlias)
+	}
+	hits.mentions = append(hits.mentions, match)
+}
+
+// buildEvidence returns the evidence of every extracted skill, ordered by first mention
+func (d *DatabaseSkillExtractor) buildEvidence(text string, skills *ExtractedSkills) []models.SkillEvidence {
+	categories := make(map[string][]string)
+	for category, skillList := range skills.Categories {
+		for _, skillName := range skillList {
+			categories[skillName] = append(categories[skillName], category)
+		}
+	}
+
+	evidence := make([]models.SkillEvidence, 0, len(categories))
+	for skillName, skillCategories := range categories {
+		sort.Strings(skillCategories)
+
+		var (
+			sources  []string
+			mentions = []models.SkillMention{}
+			exact    bool
+		)
+		if hits, exists := skills.hits[skillName]; exists {
+			sources = hits.sources
+			for _, match := range hits.mentions {
+				mentions = append(mentions, characterMention(text, match))
+				exact = exact || strings.EqualFold(match.Text, skillName)
+			}
+		}
+
+		_, stated := skills.Proficiency[skillName]
+
+		evidence = append(evidence, models.SkillEvidence{
+			Skill:      skillName,
+			Categories: skillCategories,
+			Sources:    emptyIfNil(sources),
+			Mentions:   mentions,
+			Confidence: skillConfidence(skillName, exact, len(mentions), stated),
+		})
+	}
+
+	sortEvidence(evidence)
+	return evidence
+}
+
+// characterMention converts the byte offsets of a match to character offsets
+func characterMention(text string, match skillmatch.Match) models.SkillMention {
+	start := utf8.RuneCountInString(text[:match.Start])
+	return models.SkillMention{
+		Start: start,
+		End:   start + utf8.RuneCountInString(match.Text),
+		Text:  match.Text,
+	}
+}
+
+// skillConfidence scores one skill: higher when written as its name than only through an
+// alias, raised by repeated mentions and a stated proficiency, lowered for one- and
+// two-letter names that are easily matched by accident ("R", "C")
+func skillConfidence(skillName string, exact bool, mentionCount int, statedProficiency bool) float64 {
+	confidence := 0.65
+	if exact {
+		confidence = 0.75
+	}
+
+	if mentionCount > 1 {
+		confidence += math.Min(float64(mentionCount-1)*0.05, 0.15)
+	}
+	if statedProficiency {
+		confidence += 0.1
+	}
+	if utf8.RuneCountInString(skillName) <= 2 {
+		confidence -= 0.2
+	}
+
+	confidence = math.Min(math.Max(confidence, 0.1), 0.99)
+	return math.Round(confidence*100) / 100
+}
+
+// huggingFaceEvidence maps Hugging Face skills, whose offsets are already in characters,
+// to skill evidence
+func huggingFaceEvidence(skills []models.HuggingFaceSkill) []models.SkillEvidence {
+	evidence := make([]models.SkillEvidence, 0, len(skills))
+	for _, skill := range skills {
+		mentions := skill.Mentions
+		if len(mentions) == 0 {
+			mentions = []models.SkillMention{{Start: skill.StartPosition, End: skill.EndPosition, Text: skill.Name}}
+		}
+
+		evidence = append(evidence, models.SkillEvidence{
+			Skill:      skill.Name,
+			Categories: []string{skill.Category},
+			Sources:    []string{sourceHuggingFace},
+			Mentions:   mentions,
+			Confidence: math.Round(skill.Confidence*100) / 100,
+		})
+	}
+
+	sortEvidence(evidence)
+	return evidence
+}
+
+// MergeSkillEvidence adds evidence from another extractor to resume data. Evidence for a
+// skill already present is combined: sources, categories and mentions are joined and the
+// higher confidence kept.
+func MergeSkillEvidence(resume *models.ProcessedResumeData, evidence []models.SkillEvidence) {
+	index := make(map[string]int, len(resume.SkillEvidence))
+	for i, existing := range resume.SkillEvidence {
+		index[strings.ToLower(existing.Skill)] = i
+	}
+
+	for _, incoming := range evidence {
+		i, exists := index[strings.ToLower(incoming.Skill)]
+		if !exists {
+			index[strings.ToLower(incoming.Skill)] = len(resume.SkillEvidence)
+			resume.SkillEvidence = append(resume.SkillEvidence, incoming)
+			continue
+		}
+
+		existing := &resume.SkillEvidence[i]
+		for _, source := range incoming.Sources {
+			if !contains(existing.Sources, source) {
+				existing.Sources = append(existing.Sources, source)
+			}
+		}
+		for _, category := range incoming.Categories {
+			if !contains(existing.Categories, category) {
+				existing.Categories = append(existing.Categories, category)
+			}
+		}
+		for _, mention := range incoming.Mentions {
+			if !containsMention(existing.Mentions, mention) {
+				existing.Mentions = append(existing.Mentions, mention)
+			}
+		}
+		sort.Slice(existing.Mentions, func(a, b int) bool {
+			return existing.Mentions[a].Start < existing.Mentions[b].Start
+		})
+		existing.Confidence = math.Max(existing.Confidence, incoming.Confidence)
+	}
+
+	sortEvidence(resume.SkillEvidence)
+}
+
+// sortEvidence orders evidence by first mention, unmentioned skills last by name
+func sortEvidence(evidence []models.SkillEvidence) {
+	sort.SliceStable(evidence, func(i, j int) bool {
+		a, b := evidence[i], evidence[j]
+		switch {
+		case len(a.Mentions) == 0 || len(b.Mentions) == 0:
+			if len(a.Mentions) != len(b.Mentions) {
+				return len(a.Mentions) > 0
+			}
+			return a.Skill < b.Skill
+		case a.Mentions[0].Start != b.Mentions[0].Start:
+			return a.Mentions[0].Start < b.Mentions[0].Start
+		default:
+			return a.Skill < b.Skill
+		}
+	})
+}
+
+func containsMention(mentions []models.SkillMention, mention models.SkillMention) bool {
+	for _, m := range mentions {
+		if m.Start == mention.Start && m.End == mention.End {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,156 @@
+package services
+
+import (
+	"regexp"
+	"strings"
+
+	"stafind-backend/internal/models"
+	"stafind-backend/internal/skillmatch"
+)
+
+// proficiencyCue is a phrase stating how well a candidate knows the skill next to it
+type proficiencyCue struct {
+	pattern *regexp.Regexp
+	level   int
+}
+
+// proficiencyCues in English and Spanish, on the 1-5 scale of employee_skills
+var proficiencyCues = []proficiencyCue{
+	{regexp.MustCompile(`(?i)\b(?:expert(?:ise)?|mastery|deep knowledge|guru|experto|experta|dominio|especialista)\b`), 5},
+	{regexp.MustCompile(`(?i)\b(?:advanced|proficient|proficiency|strong (?:knowledge|experience|background|skills?)|extensive experience|certified|certification|avanzad[oa]|amplia experiencia|s[oó]lidos conocimientos|certificad[oa]|certificaci[oó]n)\b`), 4},
+	{regexp.MustCompile(`(?i)\b(?:intermediate|working knowledge|hands-on experience|intermedi[oa]|buen nivel|conocimientos medios)\b`), 3},
+	{regexp.MustCompile(`(?i)\b(?:familiar|familiarity|exposure to|some experience|familiarizad[oa]|nociones|conocimientos generales)\b`), 2},
+	{regexp.MustCompile(`(?i)\b(?:basic|beginner|(?:currently|still|now) learning|b[aá]sic[oa]s?|principiante|aprendiendo|en formaci[oó]n)\b|\(\s*learning\s*\)`), 1},
+}
+
+// learningBefore is "learning" right before a mention, as in "learning Rust", with the word
+// before it. On its own "learning" is too common a cue: "machine learning with Python" says
+// nothing about Python.
+var learningBefore = regexp.MustCompile(`(?i)(?:^|[^\w-])(?:([\w-]+)\s+)?(learning)\s+$`)
+
+// learningCompounds are words that make "learning" part of a term rather than a cue
+var learningCompounds = map[string]bool{
+	"machine": true, "deep": true, "reinforcement": true, "transfer": true, "supervised": true,
+	"unsupervised": true, "self-supervised": true, "semi-supervised": true, "federated": true,
+	"statistical": true, "active": true, "ensemble": true, "representation": true, "meta": true,
+	"online": true, "e": true, "continuous": true, "lifelong": true,
+	"distance": true, "blended": true,
+}
+
+// learningCue returns where "learning" ends a window right before a mention and is not part of
+// a term such as "deep learning"
+func learningCue(window string) ([]int, bool) {
+	match := learningBefore.FindStringSubmatchIndex(window)
+	if match == nil {
+		return nil, false
+	}
+	if match[2] >= 0 && learningCompounds[strings.ToLower(window[match[2]:match[3]])] {
+		return nil, false
+	}
+	return []int{match[4], match[5]}, true
+}
+
+const (
+	// maxCueDistanceBefore bounds how far before a mention a cue may start: "expert in Go,
+	// Python and Rust" applies to all three
+	maxCueDistanceBefore = 60
+	// maxCueDistanceAfter bounds cues written after the skill: "Python (advanced)"
+	maxCueDistanceAfter = 25
+)
+
+// clauseBreaks end the stretch of text a cue applies to
+var clauseBreaks = regexp.MustCompile(`[\n;|•]|\.\s`)
+
+// inferProficiency records the proficiency stated next to each extracted skill. A cue right
+// after a mention wins over one before it; across mentions the highest level is kept.
+func (d *DatabaseSkillExtractor) inferProficiency(text string, skills *ExtractedSkills) {
+	for skillName, hits := range skills.hits {
+		var (
+			best  models.SkillProficiency
+			found bool
+		)
+		for _, match := range hits.mentions {
+			proficiency, ok := proficiencyAtMention(text, match)
+			if ok && (!found || proficiency.Level > best.Level) {
+				best, found = proficiency, true
+			}
+		}
+		if found {
+			skills.Proficiency[skillName] = best
+		}
+	}
+}
+
+// proficiencyAtMention looks for a cue in the clause around one mention
+func proficiencyAtMention(text string, mention skillmatch.Match) (models.SkillProficiency, bool) {
+	// After the mention, up to the end of the list item: "Go (expert), Python"
+	after := text[mention.End:min(len(text), mention.End+maxCueDistanceAfter)]
+	if loc := clauseBreaks.FindStringIndex(after); loc != nil {
+		after = after[:loc[0]]
+	}
+	if idx := strings.Index(after, ","); idx >= 0 {
+		after = after[:idx]
+	}
+	if level, loc, ok := findCue(after, false); ok {
+		end := mention.End + loc[1]
+		if strings.HasPrefix(text[end:], ")") {
+			end++
+		}
+		return models.SkillProficiency{
+			Level:    level,
+			Evidence: cleanEvidence(text[mention.Start:end]),
+		}, true
+	}
+
+	// Before the mention, back to the start of the clause: "familiar with Go"
+	windowStart := max(0, mention.Start-maxCueDistanceBefore)
+	before := text[windowStart:mention.Start]
+	if locs := clauseBreaks.FindAllStringIndex(before, -1); len(locs) > 0 {
+		windowStart += locs[len(locs)-1][1]
+		before = text[windowStart:mention.Start]
+	}
+	// A cue followed by a comma describes the previous item: "React (intermediate), Docker"
+	level, loc, ok := findCue(before, true)
+	if ok && !strings.HasPrefix(strings.TrimLeft(before[loc[1]:], " )"), ",") {
+		return models.SkillProficiency{
+			Level:    level,
+			Evidence: cleanEvidence(text[windowStart+loc[0] : mention.End]),
+		}, true
+	}
+
+	return models.SkillProficiency{}, false
+}
+
+// findCue returns the cue closest to the mention: the last one in text before it, or the
+// first one after it
+func findCue(window string, last bool) (int, []int, bool) {
+	var (
+		level int
+		best  []int
+	)
+
+	for _, cue := range proficiencyCues {
+		for _, loc := range cue.pattern.FindAllStringIndex(window, -1) {
+			closer := best == nil ||
+				(last && loc[1] > best[1]) ||
+				(!last && loc[0] < best[0])
+			if closer {
+				level, best = cue.level, loc
+			}
+		}
+	}
+
+	// "learning Rust" is the cue closest to the mention
+	if last {
+		if loc, ok := learningCue(window); ok && (best == nil || loc[1] > best[1]) {
+			level, best = 1, loc
+		}
+	}
+
+	return level, best, best != nil
+}
+
+// cleanEvidence collapses the whitespace of an evidence phrase
+func cleanEvidence(phrase string) string {
+	return strings.Join(strings.Fields(phrase), " ")
+}
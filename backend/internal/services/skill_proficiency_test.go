@@ -0,0 +1,49 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"stafind-backend/internal/skillmatch"
+)
+
+func TestProficiencyAtMention(t *testing.T) {
+	tests := []struct {
+		text     string
+		skill    string
+		level    int // 0 when no level is stated
+		evidence string
+	}{
+		{"Expert in Go, Python and Rust", "Python", 5, "Expert in Go, Python"},
+		{"Skills: Python (advanced), Docker", "Python", 4, "Python (advanced)"},
+		{"Skills: React (intermediate), Docker", "Docker", 0, ""},
+		{"Familiar with Kubernetes", "Kubernetes", 2, "Familiar with Kubernetes"},
+		{"Currently learning Rust", "Rust", 1, "Currently learning Rust"},
+		{"Spent 2023 learning Rust", "Rust", 1, "learning Rust"},
+		{"Languages: Go, Rust (learning)", "Rust", 1, "Rust (learning)"},
+		{"Conocimientos básicos de Docker", "Docker", 1, "básicos de Docker"},
+		// "learning" in a term is not a cue
+		{"Machine learning with Python for 6 years", "Python", 0, ""},
+		{"Built deep learning models in PyTorch", "PyTorch", 0, ""},
+		{"Python for machine learning", "Python", 0, ""},
+		{"Deep learning TensorFlow pipelines", "TensorFlow", 0, ""},
+		{"Reinforcement learning research; Python", "Python", 0, ""},
+	}
+
+	for _, tt := range tests {
+		start := strings.Index(tt.text, tt.skill)
+		mention := skillmatch.Match{Start: start, End: start + len(tt.skill), Text: tt.skill}
+
+		proficiency, found := proficiencyAtMention(tt.text, mention)
+		if tt.level == 0 {
+			if found {
+				t.Errorf("%q: %s got level %d from %q, want none", tt.text, tt.skill, proficiency.Level, proficiency.Evidence)
+			}
+			continue
+		}
+		if !found || proficiency.Level != tt.level || proficiency.Evidence != tt.evidence {
+			t.Errorf("%q: %s = level %d from %q, want level %d from %q",
+				tt.text, tt.skill, proficiency.Level, proficiency.Evidence, tt.level, tt.evidence)
+		}
+	}
+}
@@ -8,21 +8,31 @@ import (
 	"stafind-backend/internal/models"
 	"stafind-backend/internal/repositories"
 	"strings"
+
+	"github.com/lib/pq"
 )
 
 type skillService struct {
 	skillRepo    repositories.SkillRepository
 	employeeRepo repositories.EmployeeRepository
+	events       *SkillCatalogEvents
 }
 
-// NewSkillService creates a new skill service
-func NewSkillService(skillRepo repositories.SkillRepository, employeeRepo repositories.EmployeeRepository) SkillService {
+// NewSkillService creates a new skill service. Changes to skills and their categories are
+// published to events, which may be nil.
+func NewSkillService(skillRepo repositories.SkillRepository, employeeRepo repositories.EmployeeRepository, events *SkillCatalogEvents) SkillService {
 	return &skillService{
 		skillRepo:    skillRepo,
 		employeeRepo: employeeRepo,
+		events:       events,
 	}
 }
 
+// publish announces a change to skills
+func (s *skillService) publish(action string, ids ...int) {
+	s.events.Publish(SkillCatalogEvent{Action: action, Resource: SkillCatalogSkill, IDs: ids})
+}
+
 func (s *skillService) GetAllSkills() ([]models.Skill, error) {
 	return s.skillRepo.GetAll()
 }
@@ -100,7 +110,12 @@ func (s *skillService) CreateSkill(skill *models.Skill) (*models.Skill, error) {
 		return nil, &ConflictError{Resource: "skill", Message: "Skill already exists"}
 	}
 
-	return s.skillRepo.Create(skill)
+	created, err := s.skillRepo.Create(skill)
+	if err != nil {
+		return nil, err
+	}
+	s.publish(SkillCatalogCreated, created.ID)
+	return created, nil
 }
 
 func (s *skillService) CreateSkillWithCategories(req *models.CreateSkillRequest) (*models.Skill, error) {
@@ -128,6 +143,7 @@ func (s *skillService) CreateSkillWithCategories(req *models.CreateSkillRequest)
 	if err != nil {
 		return nil, err
 	}
+	defer s.publish(SkillCatalogCreated, createdSkill.ID)
 
 	// If categories are provided, associate them with the skill
 	if len(req.Categories) > 0 {
@@ -172,7 +188,16 @@ func (s *skillService) CreateSkillsBatch(skills []models.Skill) ([]models.Skill,
 		}
 	}
 
-	return s.skillRepo.CreateBatch(skills)
+	created, err := s.skillRepo.CreateBatch(skills)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int, 0, len(created))
+	for _, skill := range created {
+		ids = append(ids, skill.ID)
+	}
+	s.publish(SkillCatalogCreated, ids...)
+	return created, nil
 }
 
 func (s *skillService) UpdateSkill(id int, skill *models.Skill) (*models.Skill, error) {
@@ -196,7 +221,12 @@ func (s *skillService) UpdateSkill(id int, skill *models.Skill) (*models.Skill,
 		return nil, &ConflictError{Resource: "skill", Message: "Another skill with this name already exists"}
 	}
 
-	return s.skillRepo.Update(id, skill)
+	updated, err := s.skillRepo.Update(id, skill)
+	if err != nil {
+		return nil, err
+	}
+	s.publish(SkillCatalogUpdated, id)
+	return updated, nil
 }
 
 func (s *skillService) UpdateSkillWithCategories(id int, req *models.CreateSkillRequest) (*models.Skill, error) {
@@ -233,6 +263,7 @@ func (s *skillService) UpdateSkillWithCategories(id int, req *models.CreateSkill
 	if err != nil {
 		return nil, err
 	}
+	defer s.publish(SkillCatalogUpdated, id)
 
 	// Update categories
 	err = s.skillRepo.AssociateCategories(id, req.Categories)
@@ -279,7 +310,15 @@ func (s *skillService) UpdateSkillsBatch(updates []models.SkillUpdate) error {
 		}
 	}
 
-	return s.skillRepo.UpdateBatch(updates)
+	if err := s.skillRepo.UpdateBatch(updates); err != nil {
+		return err
+	}
+	ids := make([]int, 0, len(updates))
+	for _, update := range updates {
+		ids = append(ids, update.ID)
+	}
+	s.publish(SkillCatalogUpdated, ids...)
+	return nil
 }
 
 func (s *skillService) DeleteSkill(id int) error {
@@ -292,7 +331,11 @@ func (s *skillService) DeleteSkill(id int) error {
 		return err
 	}
 
-	return s.skillRepo.Delete(id)
+	if err := s.skillRepo.Delete(id); err != nil {
+		return err
+	}
+	s.publish(SkillCatalogDeleted, id)
+	return nil
 }
 
 func (s *skillService) DeleteSkillsBatch(ids []int) error {
@@ -314,7 +357,11 @@ func (s *skillService) DeleteSkillsBatch(ids []int) error {
 		}
 	}
 
-	return s.skillRepo.DeleteBatch(validIDs)
+	if err := s.skillRepo.DeleteBatch(validIDs); err != nil {
+		return err
+	}
+	s.publish(SkillCatalogDeleted, validIDs...)
+	return nil
 }
 
 func (s *skillService) GetSkillStats() (*models.SkillStats, error) {
@@ -731,7 +778,11 @@ func (s *skillService) AddSkillToCategory(skillID, categoryID int) error {
 		return err
 	}
 
-	return s.skillRepo.AddSkillToCategory(skillID, categoryID)
+	if err := s.skillRepo.AddSkillToCategory(skillID, categoryID); err != nil {
+		return err
+	}
+	s.publish(SkillCatalogCategorized, skillID)
+	return nil
 }
 
 func (s *skillService) RemoveSkillFromCategory(skillID, categoryID int) error {
@@ -751,7 +802,11 @@ func (s *skillService) RemoveSkillFromCategory(skillID, categoryID int) error {
 		return err
 	}
 
-	return s.skillRepo.RemoveSkillFromCategory(skillID, categoryID)
+	if err := s.skillRepo.RemoveSkillFromCategory(skillID, categoryID); err != nil {
+		return err
+	}
+	s.publish(SkillCatalogUncategorized, skillID)
+	return nil
 }
 
 func (s *skillService) GetSkillCategories(skillID int) ([]models.Category, error) {
@@ -770,3 +825,39 @@ func (s *skillService) GetSkillCategories(skillID int) ([]models.Category, error
 
 	return s.skillRepo.GetSkillCategories(skillID)
 }
+
+// AddSkillAlias adds another name the skill extractor recognises a skill by
+func (s *skillService) AddSkillAlias(skillID int, alias string) error {
+	alias = strings.TrimSpace(alias)
+	if skillID <= 0 {
+		return &ValidationError{Field: "skill_id", Message: "Valid skill ID is required"}
+	}
+	if alias == "" {
+		return &ValidationError{Field: "alias", Message: "Alias is required"}
+	}
+	if len(alias) > 100 {
+		return &ValidationError{Field: "alias", Message: "Alias cannot exceed 100 characters"}
+	}
+
+	// Check if skill exists
+	_, err := s.skillRepo.GetByID(skillID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return &NotFoundError{Resource: "skill", ID: skillID}
+		}
+		return err
+	}
+
+	if existingSkill, err := s.skillRepo.GetByName(alias); err == nil && existingSkill != nil {
+		return &ConflictError{Resource: "skill", Message: "A skill with this name already exists"}
+	}
+
+	if err := s.skillRepo.AddSkillAlias(skillID, alias); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return &ConflictError{Resource: "skill alias", Message: "Alias already exists"}
+		}
+		return err
+	}
+	s.publish(SkillCatalogUpdated, skillID)
+	return nil
+}
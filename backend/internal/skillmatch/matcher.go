@@ -0,0 +1,284 @@
+// Package skillmatch finds dictionary terms such as skill names in text with an Aho-Corasick
+// automaton. Matching ignores case and diacritics, treats any run of whitespace as one space,
+// respects word boundaries and prefers the longest of overlapping matches, so "Spring Boot"
+// wins over "Spring".
+package skillmatch
+
+import (
+	"sort"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Pattern is a term to look for and the value reported when it matches
+type Pattern struct {
+	Term          string
+	Value         string
+	CaseSensitive bool // For short terms that are common words in any other case, like "Go"
+}
+
+// Match is one occurrence of a pattern in a text
+type Match struct {
+	Start int    // Byte offset of the match in the original text
+	End   int    // Byte offset just past the match
+	Text  string // The matched text as written
+	Value string // Value of the matched pattern
+}
+
+// Matcher is a compiled set of patterns. It is safe for concurrent use.
+type Matcher struct {
+	nodes    []node
+	patterns []compiledPattern
+}
+
+type node struct {
+	next   map[rune]int32
+	fail   int32
+	output []int32 // Patterns ending at this node, including through fail links
+}
+
+type compiledPattern struct {
+	Pattern
+	length     int  // Length in normalized runes
+	wordStart  bool // The term starts with a letter or digit, so needs a boundary before it
+	wordEnd    bool // The term ends with a letter or digit, so needs a boundary after it
+	exactRunes []rune
+}
+
+// New compiles patterns into a matcher. Patterns that normalize to nothing are skipped.
+func New(patterns []Pattern) *Matcher {
+	m := &Matcher{nodes: []node{{next: make(map[rune]int32)}}}
+
+	for _, p := range patterns {
+		runes := normalizeRunes(p.Term)
+		if len(runes) == 0 {
+			continue
+		}
+
+		state := int32(0)
+		for _, r := range runes {
+			child, exists := m.nodes[state].next[r]
+			if !exists {
+				child = int32(len(m.nodes))
+				m.nodes = append(m.nodes, node{next: make(map[rune]int32)})
+				m.nodes[state].next[r] = child
+			}
+			state = child
+		}
+
+		index := int32(len(m.patterns))
+		m.nodes[state].output = append(m.nodes[state].output, index)
+		m.patterns = append(m.patterns, compiledPattern{
+			Pattern:    p,
+			length:     len(runes),
+			wordStart:  isWordRune(runes[0]),
+			wordEnd:    isWordRune(runes[len(runes)-1]),
+			exactRunes: collapseSpaces([]rune(p.Term)),
+		})
+	}
+
+	m.buildFailLinks()
+	return m
+}
+
+// buildFailLinks links every node to the longest proper suffix that is also a prefix in the
+// trie, breadth first so that suffixes are linked before the nodes that need them
+func (m *Matcher) buildFailLinks() {
+	queue := make([]int32, 0, len(m.nodes))
+	for _, child := range m.nodes[0].next {
+		queue = append(queue, child)
+	}
+
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+
+		for r, child := range m.nodes[state].next {
+			fail := m.nodes[state].fail
+			for fail != 0 {
+				if _, exists := m.nodes[fail].next[r]; exists {
+					break
+				}
+				fail = m.nodes[fail].fail
+			}
+			if target, exists := m.nodes[fail].next[r]; exists && target != child {
+				m.nodes[child].fail = target
+			}
+
+			target := m.nodes[child].fail
+			m.nodes[child].output = append(m.nodes[child].output, m.nodes[target].output...)
+			queue = append(queue, child)
+		}
+	}
+}
+
+// Len returns the number of compiled patterns
+func (m *Matcher) Len() int {
+	return len(m.patterns)
+}
+
+// FindAll returns the non-overlapping matches in text in order. Of overlapping matches the
+// one starting first wins, then the longest.
+func (m *Matcher) FindAll(text string) []Match {
+	runes, starts, ends := normalizeText(text)
+
+	type candidate struct {
+		start, end int // In normalized runes
+		pattern    int32
+	}
+	var candidates []candidate
+
+	state := int32(0)
+	for i, r := range runes {
+		for state != 0 {
+			if _, exists := m.nodes[state].next[r]; exists {
+				break
+			}
+			state = m.nodes[state].fail
+		}
+		if next, exists := m.nodes[state].next[r]; exists {
+			state = next
+		}
+
+		for _, index := range m.nodes[state].output {
+			p := &m.patterns[index]
+			start, end := i+1-p.length, i+1
+			if p.wordStart && start > 0 && isWordRune(runes[start-1]) {
+				continue
+			}
+			if p.wordEnd && end < len(runes) && isWordRune(runes[end]) {
+				continue
+			}
+			candidates = append(candidates, candidate{start: start, end: end, pattern: index})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].start != candidates[j].start {
+			return candidates[i].start < candidates[j].start
+		}
+		return candidates[i].end > candidates[j].end
+	})
+
+	var matches []Match
+	covered := 0
+	for _, c := range candidates {
+		if c.start < covered {
+			continue
+		}
+
+		p := &m.patterns[c.pattern]
+		matched := text[starts[c.start]:ends[c.end-1]]
+		if p.CaseSensitive && !equalRunes(collapseSpaces([]rune(matched)), p.exactRunes) {
+			continue
+		}
+
+		matches = append(matches, Match{
+			Start: starts[c.start],
+			End:   ends[c.end-1],
+			Text:  matched,
+			Value: p.Value,
+		})
+		covered = c.end
+	}
+
+	return matches
+}
+
+// normalizeText folds text for matching and returns, for every normalized rune, the byte
+// range of the original text it stands for
+func normalizeText(text string) ([]rune, []int, []int) {
+	runes := make([]rune, 0, len(text))
+	starts := make([]int, 0, len(text))
+	ends := make([]int, 0, len(text))
+
+	for i, r := range text {
+		size := utf8.RuneLen(r)
+		if size < 0 {
+			size = 1
+		}
+
+		if unicode.IsSpace(r) {
+			if n := len(runes); n > 0 && runes[n-1] == ' ' {
+				ends[n-1] = i + size
+				continue
+			}
+			r = ' '
+		} else {
+			r = foldRune(r)
+		}
+
+		runes = append(runes, r)
+		starts = append(starts, i)
+		ends = append(ends, i+size)
+	}
+
+	return runes, starts, ends
+}
+
+// normalizeRunes folds a pattern the same way as text, without surrounding whitespace
+func normalizeRunes(term string) []rune {
+	runes, _, _ := normalizeText(term)
+	for len(runes) > 0 && runes[0] == ' ' {
+		runes = runes[1:]
+	}
+	for len(runes) > 0 && runes[len(runes)-1] == ' ' {
+		runes = runes[:len(runes)-1]
+	}
+	return runes
+}
+
+// collapseSpaces turns whitespace runs into single spaces and trims them, for comparing
+// case-sensitive matches with their term
+func collapseSpaces(runes []rune) []rune {
+	out := make([]rune, 0, len(runes))
+	for _, r := range runes {
+		if unicode.IsSpace(r) {
+			if len(out) == 0 || out[len(out)-1] == ' ' {
+				continue
+			}
+			r = ' '
+		}
+		out = append(out, r)
+	}
+	if len(out) > 0 && out[len(out)-1] == ' ' {
+		out = out[:len(out)-1]
+	}
+	return out
+}
+
+func equalRunes(a, b []rune) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// diacritics maps accented Latin letters to their base letter
+var diacritics = map[rune]rune{
+	'á': 'a', 'à': 'a', 'â': 'a', 'ä': 'a', 'ã': 'a', 'å': 'a',
+	'é': 'e', 'è': 'e', 'ê': 'e', 'ë': 'e',
+	'í': 'i', 'ì': 'i', 'î': 'i', 'ï': 'i',
+	'ó': 'o', 'ò': 'o', 'ô': 'o', 'ö': 'o', 'õ': 'o',
+	'ú': 'u', 'ù': 'u', 'û': 'u', 'ü': 'u',
+	'ñ': 'n', 'ç': 'c', 'ý': 'y', 'ÿ': 'y',
+}
+
+// foldRune lowercases a rune and strips its diacritic. The result is always one rune, so
+// offsets into the original text stay aligned.
+func foldRune(r rune) rune {
+	r = unicode.ToLower(r)
+	if base, ok := diacritics[r]; ok {
+		return base
+	}
+	return r
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
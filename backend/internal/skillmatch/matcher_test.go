@@ -0,0 +1,205 @@
+package skillmatch
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// values returns the values of the matches of text
+func values(m *Matcher, text string) []string {
+	var found []string
+	for _, match := range m.FindAll(text) {
+		found = append(found, match.Value)
+	}
+	return found
+}
+
+func newTestMatcher(terms ...string) *Matcher {
+	patterns := make([]Pattern, 0, len(terms))
+	for _, term := range terms {
+		patterns = append(patterns, Pattern{Term: term, Value: term, CaseSensitive: len([]rune(term)) <= 2})
+	}
+	return New(patterns)
+}
+
+func TestFindAllLongestMatch(t *testing.T) {
+	m := newTestMatcher("Spring", "Spring Boot", "Boot", "Google Cloud", "Google Cloud Platform", "Cloud")
+
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"Java with Spring Boot and Spring", []string{"Spring Boot", "Spring"}},
+		{"Deployed on Google Cloud Platform", []string{"Google Cloud Platform"}},
+		// The longer term is cut short, so the shorter ones match
+		{"Google Cloud Plat", []string{"Google Cloud"}},
+		{"Spring  \n Boot", []string{"Spring Boot"}},
+		{"Cloud Spring", []string{"Cloud", "Spring"}},
+	}
+
+	for _, tt := range tests {
+		if got := values(m, tt.text); strings.Join(got, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("FindAll(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestFindAllWordBoundaries(t *testing.T) {
+	m := newTestMatcher("Java", "React", "C++", "C#", ".NET", "Node.js", "SQL")
+
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"Java, JavaScript and Javanese", []string{"Java"}},
+		{"React and ReactiveX", []string{"React"}},
+		{"Preact is not React.", []string{"React"}},
+		// Terms that end in symbols need no boundary after them
+		{"C++17, C#10 and .NET, not .NETCore", []string{"C++", "C#", ".NET"}},
+		{"Node.js/React", []string{"Node.js", "React"}},
+		{"MySQL and PostgreSQL, not SQL-92", []string{"SQL"}},
+		{"sql2019", nil},
+	}
+
+	for _, tt := range tests {
+		if got := values(m, tt.text); strings.Join(got, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("FindAll(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestFindAllCaseFolding(t *testing.T) {
+	m := newTestMatcher("PostgreSQL", "Go", "R", "Análisis de datos", "Node.js")
+
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"POSTGRESQL and postgresql", []string{"PostgreSQL", "PostgreSQL"}},
+		// Diacritics are ignored both ways
+		{"analisis de datos", []string{"Análisis de datos"}},
+		{"NODE.JS", []string{"Node.js"}},
+		// Short terms only match as written, since "go" and "r" are common words
+		{"Go and R; we go far", []string{"Go", "R"}},
+		{"GO r", nil},
+	}
+
+	for _, tt := range tests {
+		if got := values(m, tt.text); strings.Join(got, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("FindAll(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestFindAllOffsets(t *testing.T) {
+	m := newTestMatcher("Análisis de datos", "Kubernetes")
+	text := "Sé análisis  de datos y Kubernetes"
+
+	matches := m.FindAll(text)
+	if len(matches) != 2 {
+		t.Fatalf("FindAll(%q) = %+v, want 2 matches", text, matches)
+	}
+	for _, match := range matches {
+		if text[match.Start:match.End] != match.Text {
+			t.Errorf("match %+v does not cover its text", match)
+		}
+	}
+	if matches[0].Text != "análisis  de datos" || matches[1].Text != "Kubernetes" {
+		t.Errorf("texts = %q, %q", matches[0].Text, matches[1].Text)
+	}
+}
+
+func TestNewSkipsEmptyTerms(t *testing.T) {
+	m := New([]Pattern{{Term: "  ", Value: "blank"}, {Term: "Go", Value: "Go", CaseSensitive: true}})
+	if m.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", m.Len())
+	}
+	if got := values(New(nil), "Go"); got != nil {
+		t.Errorf("an empty matcher found %q", got)
+	}
+}
+
+// benchmarkSkills stands in for the skills table
+var benchmarkSkills = []string{
+	"Go", "Python", "Java", "JavaScript", "TypeScript", "C#", "C++", "Ruby", "PHP", "Rust",
+	"Kotlin", "Swift", "Scala", "R", "SQL", "Bash", "React", "Angular", "Vue.js", "Next.js",
+	"Node.js", "Express", "Django", "Flask", "FastAPI", "Spring", "Spring Boot", "Ruby on Rails",
+	".NET", "ASP.NET Core", "Laravel", "PostgreSQL", "MySQL", "MongoDB", "Redis", "Elasticsearch",
+	"Cassandra", "DynamoDB", "SQLite", "Oracle", "Kafka", "RabbitMQ", "AWS", "Azure",
+	"Google Cloud Platform", "GCP", "Docker", "Kubernetes", "Terraform", "Ansible", "Jenkins",
+	"GitHub Actions", "GitLab CI", "Git", "Linux", "GraphQL", "REST", "gRPC", "Microservices",
+	"Machine Learning", "TensorFlow", "PyTorch", "Pandas", "NumPy", "Spark", "Hadoop", "Airflow",
+	"Tableau", "Power BI", "Scrum", "Agile", "Kanban", "Jira", "Figma", "HTML", "CSS", "Sass",
+	"Tailwind CSS", "Webpack", "Jest", "Cypress", "Selenium", "Prometheus", "Grafana",
+}
+
+// benchmarkPage is one page of resume prose
+const benchmarkPage = `Senior Software Engineer at Acme Corp, Jan 2019 - Present.
+Designed microservices in Go and Java (Spring Boot) deployed on Kubernetes in Google Cloud Platform.
+Built data pipelines with Apache Kafka, Airflow and Spark; reporting in Power BI and Tableau.
+Led the migration from MySQL to PostgreSQL and introduced Redis caching, cutting latency by 40%.
+Mentored five developers, ran Scrum ceremonies and owned the GitHub Actions CI pipelines.
+Desarrollador en Globant, marzo 2015 - diciembre 2018: aplicaciones con React, Node.js y MongoDB,
+despliegue con Docker y Terraform sobre AWS, pruebas con Jest y Cypress.
+`
+
+// benchmarkResume repeats the page to about ten dense resume pages of 500 words
+func benchmarkResume() string {
+	pageWords := len(strings.Fields(benchmarkPage))
+	var text strings.Builder
+	for words := 0; words < 10*500; words += pageWords {
+		text.WriteString(benchmarkPage)
+	}
+	return text.String()
+}
+
+func BenchmarkFindAll(b *testing.B) {
+	m := newTestMatcher(benchmarkSkills...)
+	text := benchmarkResume()
+
+	b.SetBytes(int64(len(text)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		m.FindAll(text)
+	}
+}
+
+// BenchmarkFindAllRegexScan is the dictionary lookup the skill extractor used before the
+// matcher, as a baseline: five broad patterns over the whole text, each match looked up on its
+// own. It cannot see multi-word skills.
+func BenchmarkFindAllRegexScan(b *testing.B) {
+	lookup := make(map[string]bool, len(benchmarkSkills))
+	for _, skill := range benchmarkSkills {
+		lookup[strings.ToLower(skill)] = true
+	}
+	text := benchmarkResume()
+	patterns := []string{
+		`\b[A-Z][a-z]+(?:\.[A-Z][a-z]+)*\b`,
+		`\b[a-z]+(?:\.[a-z]+)*\b`,
+		`\b[A-Z]{2,}\b`,
+		`\b[A-Za-z]+[#+]\b`,
+		`\b[A-Za-z]+\.NET\b`,
+	}
+
+	b.SetBytes(int64(len(text)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		found := 0
+		for _, pattern := range patterns {
+			regex := regexp.MustCompile(pattern)
+			for _, match := range regex.FindAllString(text, -1) {
+				if lookup[strings.ToLower(match)] {
+					found++
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkNew(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		newTestMatcher(benchmarkSkills...)
+	}
+}
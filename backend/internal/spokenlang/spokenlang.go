@@ -0,0 +1,272 @@
+// Package spokenlang recognises the human languages a resume says its candidate speaks and how
+// well: native, a CEFR level (A1 to C2), or words such as "fluent" or "intermedio" mapped to
+// one. Language and level names are recognised in English and Spanish.
+package spokenlang
+
+import (
+	"strings"
+
+	"stafind-backend/internal/identity"
+	"stafind-backend/internal/models"
+)
+
+// language is a language with the names resumes use for it
+type language struct {
+	code    string
+	name    string
+	aliases []string
+}
+
+var languages = []language{
+	{"en", "English", []string{"english", "ingles"}},
+	{"es", "Spanish", []string{"spanish", "espanol", "castellano", "castilian"}},
+	{"pt", "Portuguese", []string{"portuguese", "portugues"}},
+	{"fr", "French", []string{"french", "frances"}},
+	{"de", "German", []string{"german", "aleman"}},
+	{"it", "Italian", []string{"italian", "italiano"}},
+	{"ca", "Catalan", []string{"catalan", "catala"}},
+	{"eu", "Basque", []string{"basque", "euskera", "euskara"}},
+	{"gl", "Galician", []string{"galician", "gallego"}},
+	{"nl", "Dutch", []string{"dutch", "neerlandes", "holandes"}},
+	{"sv", "Swedish", []string{"swedish", "sueco"}},
+	{"pl", "Polish", []string{"polish", "polaco"}},
+	{"ro", "Romanian", []string{"romanian", "rumano"}},
+	{"ru", "Russian", []string{"russian", "ruso"}},
+	{"ar", "Arabic", []string{"arabic", "arabe"}},
+	{"zh", "Chinese", []string{"chinese", "mandarin", "chino"}},
+	{"ja", "Japanese", []string{"japanese", "japones"}},
+	{"ko", "Korean", []string{"korean", "coreano"}},
+	{"hi", "Hindi", []string{"hindi"}},
+}
+
+// levelRanks orders the levels from lowest to highest
+var levelRanks = map[string]int{
+	"A1": 1, "A2": 2, "B1": 3, "B2": 4, "C1": 5, "C2": 6, models.LanguageLevelNative: 7,
+}
+
+// levelTerms maps the words resumes describe a level with, folded, to the level. LinkedIn's
+// scale (elementary, limited working, professional working, full professional, native or
+// bilingual) and the Cambridge exams are included.
+var levelTerms = map[string]string{
+	"a1": "A1", "a2": "A2", "b1": "B1", "b2": "B2", "c1": "C1", "c2": "C2",
+
+	"native": models.LanguageLevelNative, "native speaker": models.LanguageLevelNative,
+	"mother tongue": models.LanguageLevelNative, "bilingual": models.LanguageLevelNative,
+	"nativo": models.LanguageLevelNative, "nativa": models.LanguageLevelNative,
+	"lengua materna": models.LanguageLevelNative, "bilingue": models.LanguageLevelNative,
+
+	"cpe":    "C2",
+	"fluent": "C1", "fluently": "C1", "fluency": "C1", "advanced": "C1", "full professional": "C1", "cae": "C1",
+	"fluido": "C1", "fluida": "C1", "fluidez": "C1", "avanzado": "C1", "avanzada": "C1",
+	"upper intermediate": "B2", "professional working": "B2", "first certificate": "B2", "fce": "B2",
+	"intermedio alto": "B2", "intermedia alta": "B2",
+	"intermediate": "B1", "conversational": "B1", "limited working": "B1",
+	"intermedio": "B1", "intermedia": "B1", "conversacional": "B1",
+	"pre intermediate": "A2", "elementary": "A2", "basic": "A2",
+	"elemental": "A2", "basico": "A2", "basica": "A2",
+	"beginner": "A1", "principiante": "A1", "nociones": "A1",
+}
+
+// connectors may stand between a level and its language, as in "fluent in English" or
+// "nivel avanzado de inglés"
+var connectors = map[string]bool{"in": true, "en": true, "de": true, "level": true, "nivel": true}
+
+// maxTermWords is the most words of a language or level name
+const maxTermWords = 2
+
+// codes maps the folded names and codes of every language to its code
+var codes = func() map[string]string {
+	codes := make(map[string]string)
+	for _, l := range languages {
+		codes[l.code] = l.code
+		codes[identity.NormalizeName(l.name)] = l.code
+		for _, alias := range l.aliases {
+			codes[alias] = l.code
+		}
+	}
+	return codes
+}()
+
+// names maps the folded names of every language, without codes, to its code, for reading
+// resume text, where "en" or "de" are words rather than codes
+var names = func() map[string]string {
+	names := make(map[string]string)
+	for _, l := range languages {
+		for _, alias := range l.aliases {
+			names[alias] = l.code
+		}
+	}
+	return names
+}()
+
+// Code returns the ISO 639-1 code of a language given by name, in English or Spanish, or by
+// code; "" when the language is unknown
+func Code(name string) string {
+	return codes[identity.NormalizeName(name)]
+}
+
+// Name returns the English name of a language code
+func Name(code string) string {
+	for _, l := range languages {
+		if strings.EqualFold(l.code, code) {
+			return l.name
+		}
+	}
+	return ""
+}
+
+// ParseLevel returns the level a phrase such as "B2", "fluent" or "nativo" describes, "" when
+// it describes none
+func ParseLevel(text string) string {
+	if found := mentions(strings.Fields(identity.NormalizeName(text)), levelTerms); len(found) > 0 {
+		return found[0].value
+	}
+	return ""
+}
+
+// AtLeast reports whether level is min or higher; a level that was not stated meets no minimum
+func AtLeast(level, min string) bool {
+	if min == "" {
+		return true
+	}
+	return levelRanks[level] > 0 && levelRanks[level] >= levelRanks[min]
+}
+
+// Parse reads the items of a resume's languages section, such as "English (C1)", "Inglés:
+// nativo" or "Fluent in English and French". A level applies to the language it follows or, in
+// an item with a single level, to every language of the item.
+func Parse(items []string) []models.SpokenLanguage {
+	var found []models.SpokenLanguage
+	for _, item := range items {
+		words := strings.Fields(identity.NormalizeName(item))
+		langs := mentions(words, names)
+		levels := mentions(words, levelTerms)
+
+		for i, lang := range langs {
+			next := len(words)
+			if i+1 < len(langs) {
+				next = langs[i+1].start
+			}
+			level := ""
+			for _, l := range levels {
+				if l.start >= lang.end && l.start < next {
+					level = l.value
+					break
+				}
+			}
+			// "Fluent English", or one level for the whole item
+			if level == "" {
+				previous := 0
+				if i > 0 {
+					previous = langs[i-1].end
+				}
+				for _, l := range levels {
+					if l.end <= lang.start && l.start >= previous {
+						level = l.value
+					}
+				}
+			}
+			if level == "" && len(levels) == 1 {
+				level = levels[0].value
+			}
+			found = append(found, spoken(lang.value, level))
+		}
+	}
+	return merge(found)
+}
+
+// Find finds languages stated with a level anywhere in text, as in "Native Spanish speaker" or
+// "English: B2". Languages named without a level next to them are not taken, as most mentions
+// of a language outside a languages section are not about the candidate.
+func Find(text string) []models.SpokenLanguage {
+	var found []models.SpokenLanguage
+	for _, line := range strings.Split(text, "\n") {
+		words := strings.Fields(identity.NormalizeName(line))
+		levels := mentions(words, levelTerms)
+		if len(levels) == 0 {
+			continue
+		}
+		for _, lang := range mentions(words, names) {
+			for _, level := range levels {
+				if adjacent(words, level.end, lang.start) || adjacent(words, lang.end, level.start) {
+					found = append(found, spoken(lang.value, level.value))
+					break
+				}
+			}
+		}
+	}
+	return merge(found)
+}
+
+// Extract combines the languages of a resume's languages section with those stated with a
+// level elsewhere in its text
+func Extract(sectionItems []string, text string) []models.SpokenLanguage {
+	return merge(append(Parse(sectionItems), Find(text)...))
+}
+
+// mention is a language or level name found in a run of words, with the word positions it spans
+type mention struct {
+	value      string
+	start, end int
+}
+
+// mentions finds the terms of a dictionary in words, longest first, as in "upper intermediate"
+// rather than "intermediate"
+func mentions(words []string, terms map[string]string) []mention {
+	var found []mention
+	for start := 0; start < len(words); {
+		matched := false
+		for length := maxTermWords; length >= 1; length-- {
+			if start+length > len(words) {
+				continue
+			}
+			if value, exists := terms[strings.Join(words[start:start+length], " ")]; exists {
+				found = append(found, mention{value: value, start: start, end: start + length})
+				start += length
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			start++
+		}
+	}
+	return found
+}
+
+// adjacent reports whether only connectors stand between the words ending at end and those
+// starting at start
+func adjacent(words []string, end, start int) bool {
+	if start < end || start-end > maxTermWords {
+		return false
+	}
+	for _, word := range words[end:start] {
+		if !connectors[word] {
+			return false
+		}
+	}
+	return true
+}
+
+// spoken describes a language by code
+func spoken(code, level string) models.SpokenLanguage {
+	return models.SpokenLanguage{Code: code, Name: Name(code), Level: level}
+}
+
+// merge keeps each language once, at the highest level stated for it, in the order first found
+func merge(found []models.SpokenLanguage) []models.SpokenLanguage {
+	var merged []models.SpokenLanguage
+	index := make(map[string]int)
+	for _, language := range found {
+		i, seen := index[language.Code]
+		if !seen {
+			index[language.Code] = len(merged)
+			merged = append(merged, language)
+			continue
+		}
+		if levelRanks[language.Level] > levelRanks[merged[i].Level] {
+			merged[i].Level = language.Level
+		}
+	}
+	return merged
+}
@@ -0,0 +1,117 @@
+package spokenlang
+
+import (
+	"strings"
+	"testing"
+
+	"stafind-backend/internal/models"
+)
+
+// describe lists languages as "en C1, fr" for comparison
+func describe(languages []models.SpokenLanguage) string {
+	var parts []string
+	for _, language := range languages {
+		parts = append(parts, strings.TrimSpace(language.Code+" "+language.Level))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		items []string
+		want  string
+	}{
+		{[]string{"English (C1)"}, "en C1"},
+		{[]string{"Inglés: nativo"}, "en native"},
+		{[]string{"Inglés avanzado", "Francés intermedio alto"}, "en C1, fr B2"},
+		{[]string{"English C1, French B1"}, "en C1, fr B1"},
+		// One level for the whole item
+		{[]string{"Fluent in English and French"}, "en C1, fr C1"},
+		{[]string{"Catalán y castellano: nativo"}, "ca native, es native"},
+		// The longest level name wins
+		{[]string{"Upper intermediate English"}, "en B2"},
+		// A level after a language belongs to it, not to the next one
+		{[]string{"German B1 - Italian A2"}, "de B1, it A2"},
+		// A language listed twice keeps its highest level
+		{[]string{"English", "English (B2)", "English: basic"}, "en B2"},
+		{[]string{"Portugués"}, "pt"},
+		{[]string{"Klingon (C2)"}, ""},
+		{nil, ""},
+	}
+
+	for _, tt := range tests {
+		if got := describe(Parse(tt.items)); got != tt.want {
+			t.Errorf("Parse(%q) = %q, want %q", tt.items, got, tt.want)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Native Spanish speaker", "es native"},
+		{"English: B2", "en B2"},
+		{"Nivel avanzado de inglés", "en C1"},
+		{"B2 level in English", "en B2"},
+		// Only a line with a level next to the language counts
+		{"Worked with the English team\nFluent in German", "de C1"},
+		{"Managed the Spanish office, advanced Python", ""},
+		{"Spanish", ""},
+		// "en" and "de" are words in text, not codes
+		{"Experiencia en proyectos de nivel avanzado", ""},
+	}
+
+	for _, tt := range tests {
+		if got := describe(Find(tt.text)); got != tt.want {
+			t.Errorf("Find(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	tests := map[string]string{
+		"B2":                 "B2",
+		"c1":                 "C1",
+		"fluent":             "C1",
+		"Nativo":             models.LanguageLevelNative,
+		"Bilingüe":           models.LanguageLevelNative,
+		"Upper Intermediate": "B2",
+		"intermedio alto":    "B2",
+		"Intermediate":       "B1",
+		"Cambridge FCE":      "B2",
+		"good":               "",
+		"":                   "",
+	}
+	for text, want := range tests {
+		if got := ParseLevel(text); got != want {
+			t.Errorf("ParseLevel(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
+
+func TestAtLeast(t *testing.T) {
+	tests := []struct {
+		level, min string
+		want       bool
+	}{
+		{"C1", "B2", true},
+		{"B2", "B2", true},
+		{"B1", "B2", false},
+		{models.LanguageLevelNative, "C2", true},
+		{"C2", models.LanguageLevelNative, false},
+		// A level that was not stated meets no minimum...
+		{"", "A1", false},
+		{"fluent", "A1", false},
+		// ...but any level meets none
+		{"", "", true},
+		{"A1", "", true},
+	}
+
+	for _, tt := range tests {
+		if got := AtLeast(tt.level, tt.min); got != tt.want {
+			t.Errorf("AtLeast(%q, %q) = %v, want %v", tt.level, tt.min, got, tt.want)
+		}
+	}
+}
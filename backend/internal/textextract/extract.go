@@ -0,0 +1,118 @@
+// Package textextract turns downloaded resume files into plain text for the extraction pipeline
+package textextract
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// Supported MIME types
+const (
+	MimeTypePDF      = "application/pdf"
+	MimeTypeDOCX     = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	MimeTypePlain    = "text/plain"
+	MimeTypeMarkdown = "text/markdown"
+)
+
+var (
+	// ErrUnsupportedType is returned for files whose format cannot be read
+	ErrUnsupportedType = errors.New("unsupported file type")
+	// ErrNoText is returned when a file contains no extractable text, e.g. a scanned PDF
+	ErrNoText = errors.New("no extractable text found")
+)
+
+// Extract returns the text content of a file. The MIME type decides the format; the file
+// name extension is used when the MIME type is missing or generic.
+func Extract(data []byte, mimeType, fileName string) (string, error) {
+	var (
+		text string
+		err  error
+	)
+
+	switch detectFormat(mimeType, fileName) {
+	case MimeTypePDF:
+		text, err = extractPDF(data)
+	case MimeTypeDOCX:
+		text, err = extractDOCX(data)
+	case MimeTypePlain:
+		text, err = extractPlain(data)
+	default:
+		return "", ErrUnsupportedType
+	}
+	if err != nil {
+		return "", err
+	}
+
+	text = normalizeWhitespace(text)
+	if text == "" {
+		return "", ErrNoText
+	}
+	return text, nil
+}
+
+// IsSupported reports whether Extract can read files of the given type
+func IsSupported(mimeType, fileName string) bool {
+	return detectFormat(mimeType, fileName) != ""
+}
+
+func detectFormat(mimeType, fileName string) string {
+	mimeType = strings.ToLower(strings.TrimSpace(strings.SplitN(mimeType, ";", 2)[0]))
+	switch {
+	case mimeType == MimeTypePDF:
+		return MimeTypePDF
+	case mimeType == MimeTypeDOCX:
+		return MimeTypeDOCX
+	case strings.HasPrefix(mimeType, "text/"):
+		return MimeTypePlain
+	}
+
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".pdf":
+		return MimeTypePDF
+	case ".docx":
+		return MimeTypeDOCX
+	case ".txt", ".md":
+		return MimeTypePlain
+	}
+	return ""
+}
+
+func extractPlain(data []byte) (string, error) {
+	// Strip a UTF-8 byte order mark
+	data = []byte(strings.TrimPrefix(string(data), "\ufeff"))
+	if !utf8.Valid(data) {
+		// Assume Latin-1, the usual encoding of legacy exports
+		runes := make([]rune, len(data))
+		for i, b := range data {
+			runes[i] = rune(b)
+		}
+		return string(runes), nil
+	}
+	return string(data), nil
+}
+
+// normalizeWhitespace trims trailing spaces and collapses runs of blank lines
+func normalizeWhitespace(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+
+	lines := strings.Split(text, "\n")
+	result := make([]string, 0, len(lines))
+	blank := false
+	for _, line := range lines {
+		line = strings.Join(strings.Fields(line), " ")
+		if line == "" {
+			if !blank && len(result) > 0 {
+				result = append(result, "")
+			}
+			blank = true
+			continue
+		}
+		result = append(result, line)
+		blank = false
+	}
+
+	return strings.TrimSpace(strings.Join(result, "\n"))
+}
@@ -0,0 +1,468 @@
+package textextract
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+
+	"github.com/ledongthuc/pdf"
+)
+
+// maxPDFStreamSize caps the decompressed size of a single content stream
+const maxPDFStreamSize = 50 << 20
+
+// streamDictionaryWindow is how far back from a "stream" keyword the stream dictionary is searched
+const streamDictionaryWindow = 2048
+
+// Streams that never hold page text
+var pdfSkippedStreamMarkers = [][]byte{
+	[]byte("/Image"),
+	[]byte("/Length1"),
+	[]byte("/Length2"),
+	[]byte("/Length3"),
+	[]byte("/ObjStm"),
+	[]byte("/XRef"),
+	[]byte("/Metadata"),
+	[]byte("/EmbeddedFile"),
+	[]byte("/ICCBased"),
+	[]byte("/FontFile"),
+}
+
+// extractPDF reads the text of a PDF. Pages are read with a PDF parser that decodes fonts
+// through their ToUnicode CMaps, including the Identity-H fonts of most PDF exporters; files
+// it cannot parse, such as ones with a broken cross-reference table, fall back to scanning the
+// content streams. Scanned PDFs yield ErrNoText.
+func extractPDF(data []byte) (string, error) {
+	if !bytes.HasPrefix(bytes.TrimSpace(data[:min(len(data), 1024)]), []byte("%PDF")) {
+		return "", fmt.Errorf("invalid PDF file: missing header")
+	}
+
+	if text, err := readPDFPages(data); err == nil && looksLikeText(text) {
+		return text, nil
+	}
+	return scanPDFStreams(data)
+}
+
+// readPDFPages lays out the glyphs of each page in lines
+func readPDFPages(data []byte) (text string, err error) {
+	// The parser panics on malformed objects
+	defer func() {
+		if r := recover(); r != nil {
+			text, err = "", fmt.Errorf("failed to parse PDF: %v", r)
+		}
+	}()
+
+	reader, err := pdf.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", err
+	}
+
+	var out strings.Builder
+	for i := 1; i <= reader.NumPage(); i++ {
+		page := reader.Page(i)
+		if page.V.IsNull() {
+			continue
+		}
+		out.WriteString(layoutGlyphs(page.Content().Text))
+		out.WriteString("\n")
+	}
+	return out.String(), nil
+}
+
+// layoutGlyphs joins glyphs in the order they are drawn, starting a line when the baseline
+// moves and a word when a glyph starts clearly after the previous one ends
+func layoutGlyphs(glyphs []pdf.Text) string {
+	var (
+		out  strings.Builder
+		prev *pdf.Text
+	)
+
+	for i := range glyphs {
+		glyph := &glyphs[i]
+		if glyph.S == "" || glyph.S == "\n" || glyph.S == "\uFFFD" {
+			continue
+		}
+
+		if prev != nil {
+			size := math.Max(glyph.FontSize, 1)
+			last := out.String()[out.Len()-1]
+			switch {
+			case math.Abs(glyph.Y-prev.Y) > size/2:
+				if last != '\n' {
+					out.WriteString("\n")
+				}
+			case glyph.X-(prev.X+prev.W) > size/4 && last != ' ' && glyph.S != " ":
+				out.WriteString(" ")
+			}
+		}
+
+		out.WriteString(glyph.S)
+		prev = glyph
+	}
+
+	return out.String()
+}
+
+// scanPDFStreams is a best-effort reader for the text operators of PDF content streams. It
+// handles uncompressed and Flate-compressed streams with standard font encodings.
+func scanPDFStreams(data []byte) (string, error) {
+	var text strings.Builder
+	pos := 0
+	for {
+		idx := bytes.Index(data[pos:], []byte("stream"))
+		if idx < 0 {
+			break
+		}
+		keyword := pos + idx
+		pos = keyword + len("stream")
+
+		// "endstream" also contains the keyword
+		if keyword >= 3 && string(data[keyword-3:keyword]) == "end" {
+			continue
+		}
+
+		start := pos
+		if start < len(data) && data[start] == '\r' {
+			start++
+		}
+		if start < len(data) && data[start] == '\n' {
+			start++
+		}
+		end := bytes.Index(data[start:], []byte("endstream"))
+		if end < 0 {
+			break
+		}
+		raw := data[start : start+end]
+		pos = start + end + len("endstream")
+
+		dictionary := data[max(0, keyword-streamDictionaryWindow):keyword]
+		if objStart := bytes.LastIndex(dictionary, []byte(" obj")); objStart >= 0 {
+			dictionary = dictionary[objStart:]
+		}
+		if skipPDFStream(dictionary) {
+			continue
+		}
+
+		content := raw
+		if bytes.Contains(dictionary, []byte("/FlateDecode")) {
+			inflated, err := inflate(raw)
+			if err != nil {
+				continue
+			}
+			content = inflated
+		} else if bytes.Contains(dictionary, []byte("/Filter")) {
+			// Other filters (DCT, JBIG2, LZW, ...) are not text
+			continue
+		}
+
+		text.WriteString(parseContentStream(content))
+		text.WriteString("\n")
+	}
+
+	result := text.String()
+	if !looksLikeText(result) {
+		return "", ErrNoText
+	}
+	return result, nil
+}
+
+func skipPDFStream(dictionary []byte) bool {
+	for _, marker := range pdfSkippedStreamMarkers {
+		if bytes.Contains(dictionary, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+func inflate(raw []byte) ([]byte, error) {
+	reader, err := zlib.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(io.LimitReader(reader, maxPDFStreamSize))
+	// Streams are often padded after the compressed data; keep what was decoded
+	if err != nil && len(content) == 0 {
+		return nil, err
+	}
+	return content, nil
+}
+
+// parseContentStream collects the strings shown by Tj, TJ, ' and " operators
+func parseContentStream(content []byte) string {
+	var (
+		out      strings.Builder
+		strs     []string
+		nums     []float64
+		array    []string
+		inArray  bool
+		lastY    float64
+		hasLastY bool
+	)
+
+	newline := func() {
+		if out.Len() > 0 && !strings.HasSuffix(out.String(), "\n") {
+			out.WriteString("\n")
+		}
+	}
+	space := func() {
+		if out.Len() > 0 {
+			last := out.String()[out.Len()-1]
+			if last != ' ' && last != '\n' {
+				out.WriteString(" ")
+			}
+		}
+	}
+
+	i := 0
+	for i < len(content) {
+		ch := content[i]
+		switch {
+		case isPDFWhitespace(ch):
+			i++
+		case ch == '%':
+			for i < len(content) && content[i] != '\n' && content[i] != '\r' {
+				i++
+			}
+		case ch == '(':
+			value, next := readLiteralString(content, i)
+			i = next
+			if inArray {
+				array = append(array, value)
+			} else {
+				strs = append(strs, value)
+			}
+		case ch == '<' && i+1 < len(content) && content[i+1] == '<':
+			i += 2
+		case ch == '>' && i+1 < len(content) && content[i+1] == '>':
+			i += 2
+		case ch == '<':
+			value, next := readHexString(content, i)
+			i = next
+			if inArray {
+				array = append(array, value)
+			} else {
+				strs = append(strs, value)
+			}
+		case ch == '[':
+			inArray = true
+			array = array[:0]
+			i++
+		case ch == ']':
+			inArray = false
+			i++
+		case ch == '/':
+			i++
+			for i < len(content) && !isPDFDelimiter(content[i]) && !isPDFWhitespace(content[i]) {
+				i++
+			}
+		case ch == '-' || ch == '+' || ch == '.' || (ch >= '0' && ch <= '9'):
+			start := i
+			i++
+			for i < len(content) && (content[i] == '.' || (content[i] >= '0' && content[i] <= '9')) {
+				i++
+			}
+			value, err := strconv.ParseFloat(string(content[start:i]), 64)
+			if err != nil {
+				continue
+			}
+			if inArray {
+				// Large negative kerning inside TJ arrays separates words
+				if value < -200 {
+					array = append(array, " ")
+				}
+			} else {
+				nums = append(nums, value)
+			}
+		default:
+			start := i
+			for i < len(content) && !isPDFDelimiter(content[i]) && !isPDFWhitespace(content[i]) {
+				i++
+			}
+			if i == start {
+				i++
+				continue
+			}
+
+			switch string(content[start:i]) {
+			case "Tj":
+				if len(strs) > 0 {
+					out.WriteString(strs[len(strs)-1])
+				}
+			case "'", "\"":
+				newline()
+				if len(strs) > 0 {
+					out.WriteString(strs[len(strs)-1])
+				}
+			case "TJ":
+				out.WriteString(strings.Join(array, ""))
+				array = array[:0]
+			case "T*":
+				newline()
+			case "Td", "TD":
+				if len(nums) >= 2 && nums[len(nums)-1] != 0 {
+					newline()
+				} else {
+					space()
+				}
+			case "Tm":
+				if len(nums) >= 6 {
+					y := nums[len(nums)-1]
+					if hasLastY && y != lastY {
+						newline()
+					} else {
+						space()
+					}
+					lastY, hasLastY = y, true
+				}
+			case "ET":
+				space()
+			case "ID":
+				// Skip inline image data up to the EI operator
+				if end := bytes.Index(content[i:], []byte("EI")); end >= 0 {
+					i += end + 2
+				} else {
+					i = len(content)
+				}
+			}
+			strs = strs[:0]
+			nums = nums[:0]
+		}
+	}
+
+	return out.String()
+}
+
+func readLiteralString(content []byte, start int) (string, int) {
+	var value []byte
+	depth := 0
+	i := start
+	for i < len(content) {
+		ch := content[i]
+		switch {
+		case ch == '\\' && i+1 < len(content):
+			i++
+			escaped := content[i]
+			switch escaped {
+			case 'n':
+				value = append(value, '\n')
+			case 'r':
+				value = append(value, '\r')
+			case 't':
+				value = append(value, '\t')
+			case 'b', 'f':
+			case '\r':
+				if i+1 < len(content) && content[i+1] == '\n' {
+					i++
+				}
+			case '\n':
+			default:
+				if escaped >= '0' && escaped <= '7' {
+					code := 0
+					digits := 0
+					for digits < 3 && i < len(content) && content[i] >= '0' && content[i] <= '7' {
+						code = code*8 + int(content[i]-'0')
+						i++
+						digits++
+					}
+					value = append(value, byte(code))
+					continue
+				}
+				value = append(value, escaped)
+			}
+			i++
+		case ch == '(':
+			depth++
+			if depth > 1 {
+				value = append(value, ch)
+			}
+			i++
+		case ch == ')':
+			depth--
+			i++
+			if depth == 0 {
+				return decodePDFString(value), i
+			}
+			value = append(value, ch)
+		default:
+			value = append(value, ch)
+			i++
+		}
+	}
+	return decodePDFString(value), i
+}
+
+func readHexString(content []byte, start int) (string, int) {
+	var digits []byte
+	i := start + 1
+	for i < len(content) && content[i] != '>' {
+		if !isPDFWhitespace(content[i]) {
+			digits = append(digits, content[i])
+		}
+		i++
+	}
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+
+	value := make([]byte, 0, len(digits)/2)
+	for j := 0; j+1 < len(digits); j += 2 {
+		b, err := strconv.ParseUint(string(digits[j:j+2]), 16, 8)
+		if err != nil {
+			return "", i + 1
+		}
+		value = append(value, byte(b))
+	}
+	return decodePDFString(value), i + 1
+}
+
+// decodePDFString decodes UTF-16BE strings with a byte order mark and treats everything else as Latin-1
+func decodePDFString(value []byte) string {
+	if len(value) >= 2 && value[0] == 0xFE && value[1] == 0xFF {
+		units := make([]uint16, 0, (len(value)-2)/2)
+		for j := 2; j+1 < len(value); j += 2 {
+			units = append(units, uint16(value[j])<<8|uint16(value[j+1]))
+		}
+		return string(utf16.Decode(units))
+	}
+
+	var out strings.Builder
+	for _, b := range value {
+		r := rune(b)
+		if r == '\n' || r == '\t' || !unicode.IsControl(r) {
+			out.WriteRune(r)
+		}
+	}
+	return out.String()
+}
+
+// looksLikeText rejects output made mostly of glyph IDs from fonts with custom encodings
+func looksLikeText(text string) bool {
+	total, readable := 0, 0
+	for _, r := range text {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		total++
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune(".,;:-()@/+&'\"%#", r) {
+			readable++
+		}
+	}
+	return total > 0 && float64(readable)/float64(total) >= 0.7
+}
+
+func isPDFWhitespace(ch byte) bool {
+	return ch == ' ' || ch == '\n' || ch == '\r' || ch == '\t' || ch == '\f' || ch == 0
+}
+
+func isPDFDelimiter(ch byte) bool {
+	return strings.IndexByte("()<>[]{}/%", ch) >= 0
+}
@@ -0,0 +1,142 @@
+package textextract
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// buildPDF writes a PDF with the objects given, numbered from 1, and a cross-reference table.
+// Object 1 must be the catalog.
+func buildPDF(objects ...string) []byte {
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n")
+
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return out.Bytes()
+}
+
+// stream wraps content in a stream object
+func stream(dictionary, content string) string {
+	return fmt.Sprintf("<< %s /Length %d >>\nstream\n%s\nendstream", dictionary, len(content), content)
+}
+
+// flateStream wraps content in a Flate-compressed stream object
+func flateStream(content string) string {
+	var compressed bytes.Buffer
+	writer := zlib.NewWriter(&compressed)
+	writer.Write([]byte(content))
+	writer.Close()
+	return fmt.Sprintf("<< /Filter /FlateDecode /Length %d >>\nstream\n%s\nendstream", compressed.Len(), compressed.String())
+}
+
+// onePagePDF builds a one-page PDF with a font and a content stream
+func onePagePDF(font, content string, extra ...string) []byte {
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>",
+		font,
+		content,
+	}
+	return buildPDF(append(objects, extra...)...)
+}
+
+const resumeLines = "Ana Garcia\nSenior Backend Engineer\nSkills: Go, PostgreSQL, Kubernetes"
+
+func TestExtractPDFStandardFont(t *testing.T) {
+	content := `BT /F1 12 Tf 72 720 Td (Ana Garcia) Tj 0 -16 Td (Senior Backend Engineer) Tj 0 -16 Td [(Skills: Go, )-20(PostgreSQL, Kubernetes)] TJ ET`
+	data := onePagePDF("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>", flateStream(content))
+
+	text, err := extractPDF(data)
+	if err != nil {
+		t.Fatalf("extractPDF() error = %v", err)
+	}
+	if got := strings.TrimSpace(text); got != resumeLines {
+		t.Errorf("extractPDF() = %q, want %q", got, resumeLines)
+	}
+}
+
+// Exporters write text as glyph IDs of an Identity-H font and map them back to Unicode with a
+// ToUnicode CMap
+func TestExtractPDFIdentityHFont(t *testing.T) {
+	// Glyph IDs are assigned in order of first use
+	var glyphs []rune
+	ids := make(map[rune]int)
+	encode := func(line string) string {
+		var hex strings.Builder
+		for _, r := range line {
+			if _, exists := ids[r]; !exists {
+				glyphs = append(glyphs, r)
+				ids[r] = len(glyphs)
+			}
+			fmt.Fprintf(&hex, "%04X", ids[r])
+		}
+		return "<" + hex.String() + ">"
+	}
+
+	var content strings.Builder
+	content.WriteString("BT /F1 11 Tf\n")
+	for i, line := range strings.Split(resumeLines, "\n") {
+		fmt.Fprintf(&content, "1 0 0 1 72 %d Tm %s Tj\n", 720-16*i, encode(line))
+	}
+	content.WriteString("ET")
+
+	var cmap strings.Builder
+	cmap.WriteString("/CIDInit /ProcSet findresource begin 12 dict begin begincmap\n")
+	cmap.WriteString("1 begincodespacerange <0000> <FFFF> endcodespacerange\n")
+	fmt.Fprintf(&cmap, "%d beginbfchar\n", len(glyphs))
+	for i, r := range glyphs {
+		fmt.Fprintf(&cmap, "<%04X> <%04X>\n", i+1, r)
+	}
+	cmap.WriteString("endbfchar\nendcmap CMapName currentdict /CMap defineresource pop end end")
+
+	font := "<< /Type /Font /Subtype /Type0 /BaseFont /ABCDEF+Calibri /Encoding /Identity-H /DescendantFonts [7 0 R] /ToUnicode 6 0 R >>"
+	descendant := "<< /Type /Font /Subtype /CIDFontType2 /BaseFont /ABCDEF+Calibri /CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> >>"
+	data := onePagePDF(font, flateStream(content.String()), stream("", cmap.String()), descendant)
+
+	// The glyph IDs alone are not text
+	if _, err := scanPDFStreams(data); err != ErrNoText {
+		t.Fatalf("scanning the content streams should find no text, got error %v", err)
+	}
+
+	text, err := extractPDF(data)
+	if err != nil {
+		t.Fatalf("extractPDF() error = %v", err)
+	}
+	if got := strings.TrimSpace(text); got != resumeLines {
+		t.Errorf("extractPDF() = %q, want %q", got, resumeLines)
+	}
+}
+
+func TestExtractPDFBrokenCrossReference(t *testing.T) {
+	// Without a cross-reference table the parser gives up and the content streams are scanned
+	data := []byte("%PDF-1.4\n1 0 obj\n" + flateStream(`BT /F1 12 Tf 72 720 Td (Ana Garcia, Senior Backend Engineer) Tj ET`) + "\nendobj\n%%EOF\n")
+
+	text, err := extractPDF(data)
+	if err != nil {
+		t.Fatalf("extractPDF() error = %v", err)
+	}
+	if !strings.Contains(text, "Ana Garcia, Senior Backend Engineer") {
+		t.Errorf("extractPDF() = %q, want the shown string", text)
+	}
+}
+
+func TestExtractPDFRejectsOtherFiles(t *testing.T) {
+	if _, err := extractPDF([]byte("PK\x03\x04 not a pdf")); err == nil {
+		t.Error("extractPDF() of a non-PDF should fail")
+	}
+}
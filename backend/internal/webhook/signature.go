@@ -0,0 +1,60 @@
+// Package webhook signs and verifies inbound integration requests.
+//
+// A signed request carries three headers:
+//
+//	X-Signature-Key-Id:    ID of the API key the integration was issued
+//	X-Signature-Timestamp: Unix time in seconds when the request was signed
+//	X-Signature:           v1=<hex HMAC-SHA256 of "<timestamp>.<raw body>" keyed with the signing secret>
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"stafind-backend/internal/constants"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignatureVersion is the scheme prefix of the signature header value
+const SignatureVersion = "v1"
+
+// ComputeSignature returns the signature header value for a timestamp and raw body
+func ComputeSignature(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return SignatureVersion + "=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature reports whether signature matches the timestamp and body. The header may list
+// several comma-separated signatures (e.g. while a secret is being rotated); any match is accepted.
+func VerifySignature(secret string, timestamp int64, body []byte, signature string) bool {
+	expected := []byte(ComputeSignature(secret, timestamp, body))
+	for _, candidate := range strings.Split(signature, ",") {
+		if hmac.Equal(expected, []byte(strings.TrimSpace(candidate))) {
+			return true
+		}
+	}
+	return false
+}
+
+// WithinTolerance reports whether timestamp is no further than tolerance from now
+func WithinTolerance(timestamp int64, now time.Time, tolerance time.Duration) bool {
+	skew := now.Sub(time.Unix(timestamp, 0))
+	if skew < 0 {
+		skew = -skew
+	}
+	return skew <= tolerance
+}
+
+// SignRequest signs an outgoing request with the current time. body must be the exact bytes sent.
+func SignRequest(req *http.Request, keyID int, secret string, body []byte) {
+	timestamp := time.Now().Unix()
+	req.Header.Set(constants.HeaderSignatureKeyID, strconv.Itoa(keyID))
+	req.Header.Set(constants.HeaderSignatureTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(constants.HeaderSignature, ComputeSignature(secret, timestamp, body))
+}
@@ -0,0 +1,121 @@
+package webhook
+
+import (
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"stafind-backend/internal/constants"
+)
+
+func TestVerifySignature(t *testing.T) {
+	const secret = "whsec_test"
+	const timestamp = int64(1700000000)
+	body := []byte(`{"text":"resume"}`)
+	valid := ComputeSignature(secret, timestamp, body)
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp int64
+		body      []byte
+		signature string
+		want      bool
+	}{
+		{"valid", secret, timestamp, body, valid, true},
+		{"surrounding spaces", secret, timestamp, body, "  " + valid + " ", true},
+		{"second of two candidates", secret, timestamp, body, "v1=00," + valid, true},
+		{"first of two candidates", secret, timestamp, body, valid + ", v1=00", true},
+		{"empty header", secret, timestamp, body, "", false},
+		{"only separators", secret, timestamp, body, ",,", false},
+		{"missing version prefix", secret, timestamp, body, strings.TrimPrefix(valid, "v1="), false},
+		{"other version", secret, timestamp, body, "v2=" + strings.TrimPrefix(valid, "v1="), false},
+		{"upper-case hex", secret, timestamp, body, "v1=" + strings.ToUpper(strings.TrimPrefix(valid, "v1=")), false},
+		{"truncated", secret, timestamp, body, valid[:len(valid)-1], false},
+		{"one digit changed", secret, timestamp, body, valid[:len(valid)-1] + flipHex(valid[len(valid)-1]), false},
+		{"other secret", "whsec_other", timestamp, body, valid, false},
+		{"other timestamp", secret, timestamp + 1, body, valid, false},
+		{"other body", secret, timestamp, []byte(`{"text":"resume" }`), valid, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VerifySignature(tt.secret, tt.timestamp, tt.body, tt.signature); got != tt.want {
+				t.Errorf("VerifySignature(%q) = %v, want %v", tt.signature, got, tt.want)
+			}
+		})
+	}
+}
+
+// flipHex returns a hex digit different from c
+func flipHex(c byte) string {
+	if c == '0' {
+		return "1"
+	}
+	return "0"
+}
+
+func TestComputeSignatureFormat(t *testing.T) {
+	signature := ComputeSignature("whsec_test", 1700000000, []byte("{}"))
+	digest, ok := strings.CutPrefix(signature, SignatureVersion+"=")
+	if !ok {
+		t.Fatalf("ComputeSignature() = %q, want the %s= prefix", signature, SignatureVersion)
+	}
+	if len(digest) != 64 || strings.ToLower(digest) != digest {
+		t.Errorf("digest = %q, want 64 lower-case hex digits", digest)
+	}
+	if again := ComputeSignature("whsec_test", 1700000000, []byte("{}")); again != signature {
+		t.Errorf("ComputeSignature() is not deterministic: %q then %q", signature, again)
+	}
+}
+
+func TestWithinTolerance(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	tolerance := 300 * time.Second
+
+	tests := []struct {
+		name      string
+		timestamp int64
+		want      bool
+	}{
+		{"now", now.Unix(), true},
+		{"in the past within tolerance", now.Unix() - 299, true},
+		{"in the future within tolerance", now.Unix() + 299, true},
+		{"exactly the tolerance ago", now.Unix() - 300, true},
+		{"exactly the tolerance ahead", now.Unix() + 300, true},
+		{"one second too old", now.Unix() - 301, false},
+		{"one second too far ahead", now.Unix() + 301, false},
+		{"zero", 0, false},
+		{"negative", -1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WithinTolerance(tt.timestamp, now, tolerance); got != tt.want {
+				t.Errorf("WithinTolerance(%d) = %v, want %v", tt.timestamp, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSignRequest(t *testing.T) {
+	body := []byte(`{"text":"resume"}`)
+	req := httptest.NewRequest("POST", "/ai-agent/process", nil)
+	SignRequest(req, 7, "whsec_test", body)
+
+	if got := req.Header.Get(constants.HeaderSignatureKeyID); got != "7" {
+		t.Errorf("key ID header = %q, want 7", got)
+	}
+	timestamp, err := strconv.ParseInt(req.Header.Get(constants.HeaderSignatureTimestamp), 10, 64)
+	if err != nil {
+		t.Fatalf("timestamp header: %v", err)
+	}
+	if !WithinTolerance(timestamp, time.Now(), time.Minute) {
+		t.Errorf("timestamp %d is not the current time", timestamp)
+	}
+	if !VerifySignature("whsec_test", timestamp, body, req.Header.Get(constants.HeaderSignature)) {
+		t.Error("signature of a signed request does not verify")
+	}
+}